@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/auth"
+)
+
+// devtoken prints an admin token signed by the dev issuer, for servers running with
+// AUTH_KEY_SOURCE=dev and the same key file.
+func main() {
+	keyFile := flag.String("key", envOr("AUTH_DEV_KEY_FILE", auth.DefaultDevKeyFile), "dev issuer key file, created when missing")
+	subject := flag.String("sub", "", "admin subject, e.g. auth0|123")
+	roles := flag.String("roles", "admin_local", "comma-separated roles")
+	ttl := flag.Duration("ttl", time.Hour, "token lifetime")
+	aud := flag.String("aud", os.Getenv("AUTH0_AUDIENCE"), "token audience")
+	rolesClaim := flag.String("roles-claim", envOr("AUTH_ROLES_CLAIM", auth.DefaultRolesClaim), "claim holding the roles")
+	jwksFile := flag.String("jwks", "", "also write the public key as a JWKS file, for AUTH_KEY_SOURCE=file")
+	flag.Parse()
+
+	if *subject == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	issuer, err := auth.LoadOrCreateDevIssuer(*keyFile, *aud)
+	if err != nil {
+		log.Fatalf("Failed to load the dev issuer key: %v", err)
+	}
+	issuer.RolesClaim = *rolesClaim
+
+	if *jwksFile != "" {
+		if err := issuer.WriteJWKS(*jwksFile); err != nil {
+			log.Fatalf("Failed to write the JWKS file: %v", err)
+		}
+	}
+
+	var roleList []string
+	for _, role := range strings.Split(*roles, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roleList = append(roleList, role)
+		}
+	}
+
+	token, _, err := issuer.Mint(*subject, roleList, *ttl)
+	if err != nil {
+		log.Fatalf("Failed to mint token: %v", err)
+	}
+	fmt.Println(token)
+}
+
+func envOr(variable, fallback string) string {
+	if value := os.Getenv(variable); value != "" {
+		return value
+	}
+	return fallback
+}
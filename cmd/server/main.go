@@ -16,10 +16,22 @@ func main() {
 
 	db.ConnectDatabase()
 
-	err := db.DB.AutoMigrate(&domain.User{}, &domain.ParkingLot{}, &domain.Sensor{}, &domain.Esp32Device{}, &domain.Admin{})
+	err := db.DB.AutoMigrate(&domain.User{}, &domain.ParkingLot{}, &domain.Sensor{}, &domain.Esp32Device{}, &domain.Admin{}, &domain.OccupancySample{}, &domain.SensorEvent{}, &domain.LotSnapshot{}, &domain.RefreshToken{}, &domain.FavoriteParkingLot{}, &domain.AvailabilityAlert{}, &domain.Reservation{}, &domain.ParkingSession{}, &domain.Payment{}, &domain.PaymentEvent{}, &domain.Vehicle{}, &domain.Review{}, &domain.ReviewReport{}, &domain.CrowdReport{}, &domain.ReporterReputation{}, &domain.UserToken{}, &domain.RolePermission{}, &domain.APIKey{}, &domain.APIKeyUsage{}, &domain.Organization{}, &domain.OrganizationMembership{}, &domain.OrganizationMembershipLot{}, &domain.AdminInvitation{}, &domain.AdminInvitationEvent{}, &domain.AdminStatusEvent{}, &domain.OperatorDocument{}, &domain.AdminVerificationEvent{}, &domain.ImpersonationSession{}, &domain.ImpersonationAuditEntry{})
 	if err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
+	if err := db.MigrateRolePermissions(db.DB); err != nil {
+		log.Fatal("Failed to store the default role permissions:", err)
+	}
+	if err := db.MigrateOrganizations(db.DB); err != nil {
+		log.Fatal("Failed to move parking lots into organizations:", err)
+	}
+	if err := db.MigrateOperatorVerification(db.DB); err != nil {
+		log.Fatal("Failed to verify existing operators:", err)
+	}
+	if err := db.MigrateDeviceParkingLots(db.DB); err != nil {
+		log.Fatal("Failed to assign devices to parking lots:", err)
+	}
 	fmt.Println("Database connected and migrated successfully")
 
 	gin.SetMode(gin.ReleaseMode)
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+// AccountHandler handles e-mail verification and password recovery of drivers
+type AccountHandler struct {
+	AccountUseCase usecase.IAccountUseCase
+}
+
+// NewAccountHandler creates a new instance of AccountHandler
+func NewAccountHandler(accountUseCase usecase.IAccountUseCase) *AccountHandler {
+	return &AccountHandler{AccountUseCase: accountUseCase}
+}
+
+type TokenInput struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ForgotPasswordInput struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordInput struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// SendVerification e-mails the authenticated driver a new verification link
+func (h *AccountHandler) SendVerification(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.AccountUseCase.SendVerification(userID); err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrEmailAlreadyVerified):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrAccountEmailLimited):
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send verification e-mail"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"status": "Verification e-mail sent"})
+}
+
+// VerifyEmail redeems the token of a verification link
+func (h *AccountHandler) VerifyEmail(c *gin.Context) {
+	var input TokenInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.AccountUseCase.VerifyEmail(input.Token); err != nil {
+		if errors.Is(err, usecase.ErrInvalidToken) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify e-mail"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "E-mail verified"})
+}
+
+// ForgotPassword e-mails a reset link. It answers the same whether or not the address is registered.
+func (h *AccountHandler) ForgotPassword(c *gin.Context) {
+	var input ForgotPasswordInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.AccountUseCase.RequestPasswordReset(input.Email); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send password reset e-mail"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"status": "If the address is registered, a reset link was sent"})
+}
+
+// ResetPassword sets a new password with the token of a reset link
+func (h *AccountHandler) ResetPassword(c *gin.Context) {
+	var input ResetPasswordInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.AccountUseCase.ResetPassword(input.Token, input.Password); err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidToken), errors.Is(err, usecase.ErrWeakPassword):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset password"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "Password updated, please log in again"})
+}
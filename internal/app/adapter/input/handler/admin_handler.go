@@ -1,8 +1,8 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
-	"strings"
 
 	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
 	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
@@ -20,40 +20,8 @@ func NewAdminHandler(adminUseCase usecase.IAdminUseCase) *AdminHandler {
 	}
 }
 
-func (h *AdminHandler) RegisterAdmin(c *gin.Context) {
-	// Define a struct to parse the request body
-	type RegisterAdminPayload struct {
-		User string `json:"user_id"`
-	}
-
-	var payload RegisterAdminPayload
-
-	// Bind JSON payload to the struct
-	if err := c.ShouldBindJSON(&payload); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
-		return
-	}
-
-	// Extract adminID from the payload
-	adminID := payload.User
-
-	if strings.EqualFold(adminID, "") {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "missing admin ID"})
-		return
-	}
-
-	// Call the use case to register the admin
-	err := h.AdminUseCase.RegisterAdmin(adminID)
-	if err != nil {
-		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-		return
-	}
-
-	c.JSON(http.StatusCreated, gin.H{"status": "admin registered successfully"})
-}
-
 func (h *AdminHandler) CompleteAdminProfile(c *gin.Context) {
-	adminID, _ := helpers.ExtractAdminIDAndRole(c)
+	adminID := helpers.ExtractAdminID(c)
 
 	var profileData domain.AdminProfileData
 	if err := c.ShouldBindJSON(&profileData); err != nil {
@@ -62,6 +30,14 @@ func (h *AdminHandler) CompleteAdminProfile(c *gin.Context) {
 	}
 
 	if err := h.AdminUseCase.CompleteAdminProfile(adminID, profileData); err != nil {
+		if errors.Is(err, usecase.ErrInvalidNIT) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecase.ErrVerificationLocked) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to complete profile"})
 		return
 	}
@@ -70,7 +46,7 @@ func (h *AdminHandler) CompleteAdminProfile(c *gin.Context) {
 }
 
 func (h *AdminHandler) GetAdminProfile(c *gin.Context) {
-	adminID, _ := helpers.ExtractAdminIDAndRole(c)
+	adminID := helpers.ExtractAdminID(c)
 
 	profile, err := h.AdminUseCase.GetAdminProfile(adminID)
 	if err != nil {
@@ -82,7 +58,7 @@ func (h *AdminHandler) GetAdminProfile(c *gin.Context) {
 }
 
 func (h *AdminHandler) GetParkingLotsByAdmin(c *gin.Context) {
-	adminID, _ := helpers.ExtractAdminIDAndRole(c)
+	adminID := helpers.ExtractAdminID(c)
 
 	parkingLots, err := h.AdminUseCase.GetParkingLotsByAdmin(adminID)
 	if err != nil {
@@ -20,85 +20,16 @@ func setupAdminHandler(mockUseCase *mockgen.MockIAdminUseCase) *gin.Engine {
 
 	r := gin.Default()
 	admin := r.Group("/admin")
-	admin.Use(middlewares.AuthMiddleware("admin_local", "admin_global"))
+	admin.Use(middlewares.AuthMiddleware())
 	{
-		admin.POST("/register", adminHandler.RegisterAdmin)
-		admin.PUT("/complete-profile", adminHandler.CompleteAdminProfile)
-		admin.GET("/profile", adminHandler.GetAdminProfile)
-		admin.GET("/parking-lots", adminHandler.GetParkingLotsByAdmin)
+		admin.PUT("/complete-profile", middlewares.RequirePermission(domain.PermissionProfileManage), adminHandler.CompleteAdminProfile)
+		admin.GET("/profile", middlewares.RequirePermission(domain.PermissionProfileManage), adminHandler.GetAdminProfile)
+		admin.GET("/parking-lots", middlewares.RequirePermission(domain.PermissionLotRead), adminHandler.GetParkingLotsByAdmin)
 	}
 
 	return r
 }
 
-func TestRegisterAdmin(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	mockUseCase := mockgen.NewMockIAdminUseCase(ctrl)
-	r := setupAdminHandler(mockUseCase)
-
-	type RegisterAdminPayload struct {
-		User string `json:"user_id"`
-	}
-
-	payload := RegisterAdminPayload{
-		User: "auth0|6721363081b8547d3f95a976",
-	}
-
-	mockUseCase.EXPECT().RegisterAdmin("auth0|6721363081b8547d3f95a976").Return(nil)
-
-	w := httptest.NewRecorder()
-	body, _ := json.Marshal(payload)
-	req, _ := http.NewRequest("POST", "/admin/register", bytes.NewBuffer(body))
-	tokenString, _ := generateTestJWT()
-	req.Header.Set("Authorization", "Bearer "+tokenString)
-
-	r.ServeHTTP(w, req)
-
-	assert.Equal(t, http.StatusCreated, w.Code)
-	var response gin.H
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	if err != nil {
-		return
-	}
-	assert.Equal(t, "admin registered successfully", response["status"])
-}
-
-func TestRegisterAdmin_Error(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	mockUseCase := mockgen.NewMockIAdminUseCase(ctrl)
-	r := setupAdminHandler(mockUseCase)
-
-	type RegisterAdminPayload struct {
-		User string `json:"user_id"`
-	}
-
-	payload := RegisterAdminPayload{
-		User: "auth0|6721363081b8547d3f95a976",
-	}
-
-	mockUseCase.EXPECT().RegisterAdmin("auth0|6721363081b8547d3f95a976").Return(errors.New("registration failed"))
-
-	w := httptest.NewRecorder()
-	body, _ := json.Marshal(payload)
-	req, _ := http.NewRequest("POST", "/admin/register", bytes.NewBuffer(body))
-	tokenString, _ := generateTestJWT()
-	req.Header.Set("Authorization", "Bearer "+tokenString)
-
-	r.ServeHTTP(w, req)
-
-	assert.Equal(t, http.StatusConflict, w.Code)
-	var response gin.H
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	if err != nil {
-		return
-	}
-	assert.Equal(t, "registration failed", response["error"])
-}
-
 func TestCompleteAdminProfile(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+const invalidInvitationID = "invalid invitation id"
+
+// AdminInvitationHandler invites admins and lets invitees accept with their own token
+type AdminInvitationHandler struct {
+	AdminInvitationUseCase usecase.IAdminInvitationUseCase
+}
+
+// NewAdminInvitationHandler creates a new instance of AdminInvitationHandler
+func NewAdminInvitationHandler(invitationUseCase usecase.IAdminInvitationUseCase) *AdminInvitationHandler {
+	return &AdminInvitationHandler{AdminInvitationUseCase: invitationUseCase}
+}
+
+type AcceptInvitationInput struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// CreateInvitation e-mails an invitation; the token is only sent to the invitee
+func (h *AdminInvitationHandler) CreateInvitation(c *gin.Context) {
+	var req usecase.InvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidRequestBody})
+		return
+	}
+
+	invitation, err := h.AdminInvitationUseCase.Invite(helpers.ExtractAdminID(c), isGlobalAdmin(c), req)
+	if err != nil {
+		writeInvitationError(c, err, "Failed to create invitation")
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+// ListInvitations returns the invitations of `organization_id`, or every one for global admins
+func (h *AdminInvitationHandler) ListInvitations(c *gin.Context) {
+	var organizationID *uint
+	if value := c.Query("organization_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": invalidOrganizationID})
+			return
+		}
+		organization := uint(id)
+		organizationID = &organization
+	}
+
+	invitations, err := h.AdminInvitationUseCase.ListInvitations(helpers.ExtractAdminID(c), isGlobalAdmin(c), organizationID)
+	if err != nil {
+		writeInvitationError(c, err, "Failed to list invitations")
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// RevokeInvitation stops a pending invitation from being accepted
+func (h *AdminInvitationHandler) RevokeInvitation(c *gin.Context) {
+	invitationID, ok := parseInvitationID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.AdminInvitationUseCase.RevokeInvitation(helpers.ExtractAdminID(c), isGlobalAdmin(c), invitationID); err != nil {
+		writeInvitationError(c, err, "Failed to revoke invitation")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "Invitation revoked"})
+}
+
+// ListInvitationEvents returns the audit trail of an invitation
+func (h *AdminInvitationHandler) ListInvitationEvents(c *gin.Context) {
+	invitationID, ok := parseInvitationID(c)
+	if !ok {
+		return
+	}
+
+	events, err := h.AdminInvitationUseCase.ListInvitationEvents(helpers.ExtractAdminID(c), isGlobalAdmin(c), invitationID)
+	if err != nil {
+		writeInvitationError(c, err, "Failed to list invitation events")
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// AcceptInvitation registers the authenticated subject as the invited admin
+func (h *AdminInvitationHandler) AcceptInvitation(c *gin.Context) {
+	var input AcceptInvitationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidRequestBody})
+		return
+	}
+
+	admin, err := h.AdminInvitationUseCase.AcceptInvitation(helpers.ExtractAdminID(c), input.Token)
+	if err != nil {
+		writeInvitationError(c, err, "Failed to accept invitation")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "admin registered successfully", "admin_id": admin.ID})
+}
+
+// isGlobalAdmin reports whether the admin manages admins across every organization
+func isGlobalAdmin(c *gin.Context) bool {
+	return helpers.HasPermission(c, domain.PermissionAdminManage)
+}
+
+func parseInvitationID(c *gin.Context) (uint, bool) {
+	invitationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidInvitationID})
+		return 0, false
+	}
+	return uint(invitationID), true
+}
+
+func writeInvitationError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidInvitationEmail), errors.Is(err, usecase.ErrInvalidMembershipRole),
+		errors.Is(err, usecase.ErrInvalidInvitation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvitationForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvitationNotFound), errors.Is(err, usecase.ErrOrganizationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvitationClosed), errors.Is(err, usecase.ErrAlreadyMember):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvitationNotSent):
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
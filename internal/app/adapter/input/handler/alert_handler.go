@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// AlertHandler manages the availability alerts of the authenticated driver
+type AlertHandler struct {
+	AlertUseCase usecase.IAlertUseCase
+}
+
+// NewAlertHandler creates a new instance of AlertHandler
+func NewAlertHandler(alertUseCase usecase.IAlertUseCase) *AlertHandler {
+	return &AlertHandler{AlertUseCase: alertUseCase}
+}
+
+// ListAlerts returns the availability alerts of the driver
+func (h *AlertHandler) ListAlerts(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	alerts, err := h.AlertUseCase.ListAlerts(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list alerts"})
+		return
+	}
+
+	c.JSON(http.StatusOK, alerts)
+}
+
+// CreateAlert subscribes the driver to free spaces of a parking lot during a daily time window
+func (h *AlertHandler) CreateAlert(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input usecase.CreateAlertRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	alert, err := h.AlertUseCase.CreateAlert(userID, input)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidAlertWindow) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Parking lot not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create alert"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, alert)
+}
+
+// DeleteAlert removes an availability alert of the driver
+func (h *AlertHandler) DeleteAlert(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	alertID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid alert id"})
+		return
+	}
+
+	if err := h.AlertUseCase.DeleteAlert(userID, uint(alertID)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete alert"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+const invalidAPIKeyID = "invalid api key id"
+
+// APIKeyHandler lets global admins manage the API keys of integrators
+type APIKeyHandler struct {
+	APIKeyUseCase usecase.IAPIKeyUseCase
+}
+
+// NewAPIKeyHandler creates a new instance of APIKeyHandler
+func NewAPIKeyHandler(apiKeyUseCase usecase.IAPIKeyUseCase) *APIKeyHandler {
+	return &APIKeyHandler{APIKeyUseCase: apiKeyUseCase}
+}
+
+// CreateAPIKey issues a key; the response is the only time the key is shown
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req usecase.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidRequestBody})
+		return
+	}
+
+	created, err := h.APIKeyUseCase.CreateKey(helpers.ExtractAdminID(c), req)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidAPIKeyRequest) || errors.Is(err, usecase.ErrUnknownAPIScope) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create api key"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// ListAPIKeys returns every key without its secret
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	keys, err := h.APIKeyUseCase.ListKeys()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list api keys"})
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// RevokeAPIKey stops a key from being accepted
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidAPIKeyID})
+		return
+	}
+
+	if err := h.APIKeyUseCase.RevokeKey(uint(id)); err != nil {
+		if errors.Is(err, usecase.ErrAPIKeyNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke api key"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "API key revoked"})
+}
+
+// GetAPIKeyUsage returns the daily request counters of a key over the last `days` days
+func (h *APIKeyHandler) GetAPIKeyUsage(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidAPIKeyID})
+		return
+	}
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid days"})
+		return
+	}
+
+	usage, err := h.APIKeyUseCase.GetUsage(uint(id), days)
+	if err != nil {
+		if errors.Is(err, usecase.ErrAPIKeyNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get api key usage"})
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/CamiloLeonP/parking-radar/internal/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// AuthKeysHandler reports the state of the cache of admin token signing keys
+type AuthKeysHandler struct {
+	Cache *auth.RemoteJWKS
+}
+
+// NewAuthKeysHandler creates a new instance of AuthKeysHandler
+func NewAuthKeysHandler(cache *auth.RemoteJWKS) *AuthKeysHandler {
+	return &AuthKeysHandler{Cache: cache}
+}
+
+// GetMetrics returns the fetch, refresh and failure counters of the key cache
+func (h *AuthKeysHandler) GetMetrics(c *gin.Context) {
+	c.JSON(http.StatusOK, h.Cache.Metrics())
+}
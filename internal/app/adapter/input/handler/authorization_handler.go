@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// AuthorizationHandler manages the permissions granted to admin roles
+type AuthorizationHandler struct {
+	AuthorizationUseCase usecase.IAuthorizationUseCase
+}
+
+// NewAuthorizationHandler creates a new instance of AuthorizationHandler
+func NewAuthorizationHandler(authorizationUseCase usecase.IAuthorizationUseCase) *AuthorizationHandler {
+	return &AuthorizationHandler{AuthorizationUseCase: authorizationUseCase}
+}
+
+type RolePermissionsInput struct {
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+// ListRolePermissions returns the permissions of every role and the permissions that exist
+func (h *AuthorizationHandler) ListRolePermissions(c *gin.Context) {
+	roles, err := h.AuthorizationUseCase.ListRolePermissions()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list role permissions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"roles": roles, "permissions": domain.Permissions})
+}
+
+// SetRolePermissions replaces the permissions granted to a role
+func (h *AuthorizationHandler) SetRolePermissions(c *gin.Context) {
+	var input RolePermissionsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidRequestBody})
+		return
+	}
+
+	if err := h.AuthorizationUseCase.SetRolePermissions(c.Param("role"), input.Permissions); err != nil {
+		if errors.Is(err, usecase.ErrUnknownPermission) || errors.Is(err, usecase.ErrInvalidRole) ||
+			errors.Is(err, usecase.ErrGlobalAdminLocked) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update role permissions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "Role permissions updated"})
+}
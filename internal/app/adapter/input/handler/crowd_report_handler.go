@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CrowdReportHandler receives occupancy reports from drivers for lots without sensors
+type CrowdReportHandler struct {
+	CrowdReportUseCase usecase.ICrowdReportUseCase
+}
+
+// NewCrowdReportHandler creates a new instance of CrowdReportHandler
+func NewCrowdReportHandler(crowdReportUseCase usecase.ICrowdReportUseCase) *CrowdReportHandler {
+	return &CrowdReportHandler{CrowdReportUseCase: crowdReportUseCase}
+}
+
+// SubmitReport records a full / few / plenty report of the driver and returns the new estimate
+func (h *CrowdReportHandler) SubmitReport(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input usecase.CrowdReportRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.CrowdReportUseCase.SubmitReport(userID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCrowdLevel):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Parking lot not found"})
+		case errors.Is(err, usecase.ErrLotHasSensors):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrCrowdReportTooSoon):
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save report"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
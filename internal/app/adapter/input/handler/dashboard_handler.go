@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+// DashboardHandler serves the admin portal home page summary
+type DashboardHandler struct {
+	DashboardUseCase usecase.IDashboardUseCase
+}
+
+// NewDashboardHandler creates a new instance of DashboardHandler
+func NewDashboardHandler(dashboardUseCase usecase.IDashboardUseCase) *DashboardHandler {
+	return &DashboardHandler{DashboardUseCase: dashboardUseCase}
+}
+
+// GetDashboard returns the summary of the authenticated admin's parking lots
+func (h *DashboardHandler) GetDashboard(c *gin.Context) {
+	adminUUID := helpers.ExtractAdminID(c)
+
+	dashboard, err := h.DashboardUseCase.GetDashboard(adminUUID, helpers.HasPermission(c, domain.PermissionLotAll))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get dashboard"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dashboard)
+}
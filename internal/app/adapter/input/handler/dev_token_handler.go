@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/auth"
+	"github.com/gin-gonic/gin"
+)
+
+const defaultDevTokenTTL = time.Hour
+
+// DevTokenHandler mints admin tokens with the dev issuer. It is only routed in dev mode.
+type DevTokenHandler struct {
+	Issuer *auth.DevIssuer
+}
+
+// NewDevTokenHandler creates a new instance of DevTokenHandler
+func NewDevTokenHandler(issuer *auth.DevIssuer) *DevTokenHandler {
+	return &DevTokenHandler{Issuer: issuer}
+}
+
+type DevTokenInput struct {
+	Subject    string   `json:"subject" binding:"required"`
+	Roles      []string `json:"roles"`
+	TTLMinutes int      `json:"ttl_minutes" binding:"omitempty,min=1"`
+}
+
+// MintToken returns a signed admin token for the requested subject and roles
+func (h *DevTokenHandler) MintToken(c *gin.Context) {
+	var input DevTokenInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidRequestBody})
+		return
+	}
+
+	ttl := defaultDevTokenTTL
+	if input.TTLMinutes > 0 {
+		ttl = time.Duration(input.TTLMinutes) * time.Minute
+	}
+
+	token, expiresAt, err := h.Issuer.Mint(input.Subject, input.Roles, ttl)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mint token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"access_token": token,
+		"token_type":   "Bearer",
+		"expires_at":   expiresAt,
+	})
+}
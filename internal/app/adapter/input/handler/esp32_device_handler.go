@@ -6,7 +6,9 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
 	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
 	"github.com/gin-gonic/gin"
 )
 
@@ -16,12 +18,15 @@ const (
 
 type Esp32DeviceHandler struct {
 	Esp32DeviceUseCase usecase.IEsp32DeviceUseCase
+	ParkingLotUseCase  usecase.IParkingLotUseCase
 }
 
-func NewEsp32DeviceHandler(esp32DeviceUseCase usecase.IEsp32DeviceUseCase) *Esp32DeviceHandler {
-	return &Esp32DeviceHandler{Esp32DeviceUseCase: esp32DeviceUseCase}
+func NewEsp32DeviceHandler(esp32DeviceUseCase usecase.IEsp32DeviceUseCase, parkingLotUseCase usecase.IParkingLotUseCase) *Esp32DeviceHandler {
+	return &Esp32DeviceHandler{Esp32DeviceUseCase: esp32DeviceUseCase, ParkingLotUseCase: parkingLotUseCase}
 }
 
+// CreateEsp32Device registers a device on a parking lot of the admin. The token the device reports
+// with is only returned in this response.
 func (h *Esp32DeviceHandler) CreateEsp32Device(c *gin.Context) {
 	var req usecase.CreateEsp32DeviceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -29,6 +34,10 @@ func (h *Esp32DeviceHandler) CreateEsp32Device(c *gin.Context) {
 		return
 	}
 
+	if !authorizeParkingLotID(c, h.ParkingLotUseCase, req.ParkingLotID) {
+		return
+	}
+
 	existingDevice, err := h.Esp32DeviceUseCase.GetEsp32DeviceByIdentifier(req.DeviceIdentifier)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -40,25 +49,18 @@ func (h *Esp32DeviceHandler) CreateEsp32Device(c *gin.Context) {
 		return
 	}
 
-	err = h.Esp32DeviceUseCase.CreateEsp32Device(req)
+	device, err := h.Esp32DeviceUseCase.CreateEsp32Device(req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"status": "esp32 device created"})
+	c.JSON(http.StatusCreated, device)
 }
 
 func (h *Esp32DeviceHandler) GetEsp32Device(c *gin.Context) {
-	esp32DeviceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": invalidIDError})
-		return
-	}
-
-	esp32Device, err := h.Esp32DeviceUseCase.GetEsp32Device(esp32DeviceID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	esp32Device, ok := h.authorizeEsp32Device(c)
+	if !ok {
 		return
 	}
 	c.JSON(http.StatusOK, esp32Device)
@@ -71,13 +73,12 @@ func (h *Esp32DeviceHandler) UpdateEsp32Device(c *gin.Context) {
 		return
 	}
 
-	esp32DeviceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": invalidIDError})
+	esp32Device, ok := h.authorizeEsp32Device(c)
+	if !ok {
 		return
 	}
 
-	err = h.Esp32DeviceUseCase.UpdateEsp32Device(esp32DeviceID, req)
+	err := h.Esp32DeviceUseCase.UpdateEsp32Device(esp32Device.ID, req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -87,13 +88,12 @@ func (h *Esp32DeviceHandler) UpdateEsp32Device(c *gin.Context) {
 }
 
 func (h *Esp32DeviceHandler) DeleteEsp32Device(c *gin.Context) {
-	esp32DeviceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": invalidIDError})
+	esp32Device, ok := h.authorizeEsp32Device(c)
+	if !ok {
 		return
 	}
 
-	err = h.Esp32DeviceUseCase.DeleteEsp32Device(esp32DeviceID)
+	err := h.Esp32DeviceUseCase.DeleteEsp32Device(esp32Device.ID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -101,11 +101,55 @@ func (h *Esp32DeviceHandler) DeleteEsp32Device(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "esp32 device deleted"})
 }
 
+// RotateEsp32DeviceToken issues a new token for the device, for instance to provision a device
+// registered before tokens existed. The previous token stops working.
+func (h *Esp32DeviceHandler) RotateEsp32DeviceToken(c *gin.Context) {
+	esp32Device, ok := h.authorizeEsp32Device(c)
+	if !ok {
+		return
+	}
+
+	device, err := h.Esp32DeviceUseCase.RotateEsp32DeviceToken(esp32Device.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, device)
+}
+
+// ListEsp32Devices lists the devices of the parking lots of the admin, or every device for admins
+// with access to every lot.
 func (h *Esp32DeviceHandler) ListEsp32Devices(c *gin.Context) {
-	esp32Devices, err := h.Esp32DeviceUseCase.ListEsp32Devices()
+	adminUUID := helpers.ExtractAdminID(c)
+	esp32Devices, err := h.Esp32DeviceUseCase.ListEsp32Devices(adminUUID, helpers.HasPermission(c, domain.PermissionLotAll))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, esp32Devices)
 }
+
+// authorizeEsp32Device loads the device of the :id parameter and checks that the admin may act on
+// its parking lot. It writes the error response when they may not.
+func (h *Esp32DeviceHandler) authorizeEsp32Device(c *gin.Context) (*usecase.Esp32DeviceResponse, bool) {
+	esp32DeviceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidIDError})
+		return nil, false
+	}
+
+	esp32Device, err := h.Esp32DeviceUseCase.GetEsp32Device(esp32DeviceID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrDeviceNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	if !authorizeParkingLotID(c, h.ParkingLotUseCase, esp32Device.ParkingLotID) {
+		return nil, false
+	}
+	return esp32Device, true
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// FavoriteHandler manages the favorite parking lots of the authenticated driver
+type FavoriteHandler struct {
+	FavoriteUseCase usecase.IFavoriteUseCase
+}
+
+// NewFavoriteHandler creates a new instance of FavoriteHandler
+func NewFavoriteHandler(favoriteUseCase usecase.IFavoriteUseCase) *FavoriteHandler {
+	return &FavoriteHandler{FavoriteUseCase: favoriteUseCase}
+}
+
+type AddFavoriteInput struct {
+	ParkingLotID uint `json:"parking_lot_id" binding:"required"`
+}
+
+// ListFavorites returns the favorite parking lots of the driver with their available spaces
+func (h *FavoriteHandler) ListFavorites(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	favorites, err := h.FavoriteUseCase.ListFavorites(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list favorites"})
+		return
+	}
+
+	c.JSON(http.StatusOK, favorites)
+}
+
+// AddFavorite saves a parking lot as favorite of the driver
+func (h *FavoriteHandler) AddFavorite(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input AddFavoriteInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.FavoriteUseCase.AddFavorite(userID, input.ParkingLotID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Parking lot not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add favorite"})
+		return
+	}
+
+	c.Status(http.StatusCreated)
+}
+
+// RemoveFavorite removes a parking lot from the favorites of the driver
+func (h *FavoriteHandler) RemoveFavorite(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	parkingLotID, err := strconv.ParseUint(c.Param("parking_lot_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidParkingLotID})
+		return
+	}
+
+	if err := h.FavoriteUseCase.RemoveFavorite(userID, uint(parkingLotID)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove favorite"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
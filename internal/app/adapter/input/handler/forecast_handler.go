@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const invalidTimeParam = "invalid time, expected RFC3339"
+
+// ForecastHandler serves predicted availability for drivers
+type ForecastHandler struct {
+	ForecastUseCase usecase.IForecastUseCase
+	VehicleUseCase  usecase.IVehicleUseCase
+}
+
+// NewForecastHandler creates a new instance of ForecastHandler
+func NewForecastHandler(forecastUseCase usecase.IForecastUseCase, vehicleUseCase usecase.IVehicleUseCase) *ForecastHandler {
+	return &ForecastHandler{
+		ForecastUseCase: forecastUseCase,
+		VehicleUseCase:  vehicleUseCase,
+	}
+}
+
+// GetForecast returns the expected free spaces of a parking lot at the `at` query instant
+func (h *ForecastHandler) GetForecast(c *gin.Context) {
+	parkingLotID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidParkingLotID})
+		return
+	}
+
+	at, err := parseTimeQuery(c, "at")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidTimeParam})
+		return
+	}
+	if at == nil {
+		now := time.Now()
+		at = &now
+	}
+
+	forecast, err := h.ForecastUseCase.ForecastAvailability(uint(parkingLotID), *at)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "parking lot not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to forecast availability"})
+		return
+	}
+
+	c.JSON(http.StatusOK, forecast)
+}
+
+// ListNearby lists parking lots around the driver, ranked by predicted availability when `arrive_at` is given.
+// Signed-in drivers only see the spots their default vehicle can use.
+func (h *ForecastHandler) ListNearby(c *gin.Context) {
+	lat, errLat := strconv.ParseFloat(c.Query("lat"), 64)
+	lng, errLng := strconv.ParseFloat(c.Query("lng"), 64)
+	if errLat != nil || errLng != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid lat or lng"})
+		return
+	}
+
+	var radius float64
+	if radiusParam := c.Query("radius_km"); radiusParam != "" {
+		r, err := strconv.ParseFloat(radiusParam, 64)
+		if err != nil || r <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid radius_km"})
+			return
+		}
+		radius = r
+	}
+
+	arriveAt, err := parseTimeQuery(c, "arrive_at")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidTimeParam})
+		return
+	}
+
+	spots, err := driverSpotFilter(c, h.VehicleUseCase)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load default vehicle"})
+		return
+	}
+
+	parkingLots, err := h.ForecastUseCase.ListNearby(usecase.NearbySearchRequest{
+		Latitude:  lat,
+		Longitude: lng,
+		RadiusKm:  radius,
+		ArriveAt:  arriveAt,
+		Spots:     spots,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve parking lots"})
+		return
+	}
+
+	c.JSON(http.StatusOK, parkingLots)
+}
+
+// parseTimeQuery parses an optional RFC3339 query parameter, returning nil when it is absent
+func parseTimeQuery(c *gin.Context, name string) (*time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
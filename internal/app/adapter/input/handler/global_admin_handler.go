@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+const invalidAdminID = "invalid admin id"
+
+// GlobalAdminHandler is the console global admins use to oversee every operator and parking lot
+type GlobalAdminHandler struct {
+	GlobalAdminUseCase usecase.IGlobalAdminUseCase
+}
+
+// NewGlobalAdminHandler creates a new instance of GlobalAdminHandler
+func NewGlobalAdminHandler(globalAdminUseCase usecase.IGlobalAdminUseCase) *GlobalAdminHandler {
+	return &GlobalAdminHandler{GlobalAdminUseCase: globalAdminUseCase}
+}
+
+type AdminStatusInput struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// ListAdmins searches the admins by `q` and `status`, a `page` of `page_size` at a time
+func (h *GlobalAdminHandler) ListAdmins(c *gin.Context) {
+	page, ok := parsePage(c)
+	if !ok {
+		return
+	}
+
+	admins, err := h.GlobalAdminUseCase.ListAdmins(usecase.AdminQuery{Search: c.Query("q"), Status: c.Query("status"), Page: page})
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidAdminStatusFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list admins"})
+		return
+	}
+
+	c.JSON(http.StatusOK, admins)
+}
+
+// GetAdmin returns an admin with the history of their status
+func (h *GlobalAdminHandler) GetAdmin(c *gin.Context) {
+	adminID, ok := parseAdminID(c)
+	if !ok {
+		return
+	}
+
+	admin, err := h.GlobalAdminUseCase.GetAdmin(adminID)
+	if err != nil {
+		writeAdminStatusError(c, err, "Failed to get admin")
+		return
+	}
+
+	c.JSON(http.StatusOK, admin)
+}
+
+// ApproveAdmin activates a newly registered operator
+func (h *GlobalAdminHandler) ApproveAdmin(c *gin.Context) {
+	h.changeStatus(c, h.GlobalAdminUseCase.ApproveAdmin, "Failed to approve admin")
+}
+
+// SuspendAdmin suspends an operator, hiding their parking lots and rejecting their telemetry
+func (h *GlobalAdminHandler) SuspendAdmin(c *gin.Context) {
+	h.changeStatus(c, h.GlobalAdminUseCase.SuspendAdmin, "Failed to suspend admin")
+}
+
+// ReinstateAdmin activates a suspended operator again
+func (h *GlobalAdminHandler) ReinstateAdmin(c *gin.Context) {
+	h.changeStatus(c, h.GlobalAdminUseCase.ReinstateAdmin, "Failed to reinstate admin")
+}
+
+// ListParkingLots searches every parking lot by `q`, `admin_id` and `organization_id`
+func (h *GlobalAdminHandler) ListParkingLots(c *gin.Context) {
+	page, ok := parsePage(c)
+	if !ok {
+		return
+	}
+	query := usecase.GlobalParkingLotQuery{Search: c.Query("q"), Page: page}
+	if value := c.Query("admin_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": invalidAdminID})
+			return
+		}
+		query.AdminID = uint(id)
+	}
+	if value := c.Query("organization_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": invalidOrganizationID})
+			return
+		}
+		query.OrganizationID = uint(id)
+	}
+
+	parkingLots, err := h.GlobalAdminUseCase.ListParkingLots(query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list parking lots"})
+		return
+	}
+
+	c.JSON(http.StatusOK, parkingLots)
+}
+
+func (h *GlobalAdminHandler) changeStatus(c *gin.Context, change func(actorUUID string, adminID uint, reason string) (*domain.Admin, error), fallback string) {
+	adminID, ok := parseAdminID(c)
+	if !ok {
+		return
+	}
+	var input AdminStatusInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": usecase.ErrStatusReasonRequired.Error()})
+		return
+	}
+
+	admin, err := change(helpers.ExtractAdminID(c), adminID, input.Reason)
+	if err != nil {
+		writeAdminStatusError(c, err, fallback)
+		return
+	}
+
+	c.JSON(http.StatusOK, admin)
+}
+
+func parseAdminID(c *gin.Context) (uint, bool) {
+	adminID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidAdminID})
+		return 0, false
+	}
+	return uint(adminID), true
+}
+
+// parsePage reads the optional `page` and `page_size` query parameters
+func parsePage(c *gin.Context) (usecase.Page, bool) {
+	var page usecase.Page
+	if value := c.Query("page"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid page"})
+			return page, false
+		}
+		page.Page = parsed
+	}
+	if value := c.Query("page_size"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid page_size"})
+			return page, false
+		}
+		page.PageSize = parsed
+	}
+	return page, true
+}
+
+func writeAdminStatusError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, usecase.ErrStatusReasonRequired):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrAdminNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidStatusTransition):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+const invalidSessionID = "invalid session id"
+
+// ImpersonationHandler lets global admins act as an admin and review what they did meanwhile
+type ImpersonationHandler struct {
+	ImpersonationUseCase usecase.IImpersonationUseCase
+}
+
+// NewImpersonationHandler creates a new instance of ImpersonationHandler
+func NewImpersonationHandler(impersonationUseCase usecase.IImpersonationUseCase) *ImpersonationHandler {
+	return &ImpersonationHandler{ImpersonationUseCase: impersonationUseCase}
+}
+
+// StartImpersonation makes the following requests of the global admin run as the admin
+func (h *ImpersonationHandler) StartImpersonation(c *gin.Context) {
+	var req usecase.ImpersonationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidRequestBody})
+		return
+	}
+
+	session, err := h.ImpersonationUseCase.StartImpersonation(helpers.ExtractAdminID(c), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrStatusReasonRequired), errors.Is(err, usecase.ErrInvalidImpersonationDuration),
+			errors.Is(err, usecase.ErrImpersonateSelf):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrAdminNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start impersonation"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, session)
+}
+
+// StopImpersonation makes the requests of the global admin run as themselves again
+func (h *ImpersonationHandler) StopImpersonation(c *gin.Context) {
+	if err := h.ImpersonationUseCase.StopImpersonation(helpers.ExtractAdminID(c)); err != nil {
+		writeImpersonationError(c, err, "Failed to stop impersonation")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "Impersonation stopped"})
+}
+
+// GetImpersonation returns the active session of the global admin
+func (h *ImpersonationHandler) GetImpersonation(c *gin.Context) {
+	session, err := h.ImpersonationUseCase.CurrentImpersonation(helpers.ExtractAdminID(c))
+	if err != nil {
+		writeImpersonationError(c, err, "Failed to get impersonation")
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// ListSessions returns every impersonation session
+func (h *ImpersonationHandler) ListSessions(c *gin.Context) {
+	sessions, err := h.ImpersonationUseCase.ListSessions()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list impersonation sessions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// ListAuditEntries returns the mutating requests made in an impersonation session
+func (h *ImpersonationHandler) ListAuditEntries(c *gin.Context) {
+	sessionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidSessionID})
+		return
+	}
+
+	entries, err := h.ImpersonationUseCase.ListAuditEntries(uint(sessionID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list impersonation audit"})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+func writeImpersonationError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, usecase.ErrNoImpersonation) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// IntegrationHandler serves availability data to integrators authenticated with an API key
+type IntegrationHandler struct {
+	ParkingLotUseCase       usecase.IParkingLotUseCase
+	OccupancyHistoryUseCase usecase.IOccupancyHistoryUseCase
+}
+
+// NewIntegrationHandler creates a new instance of IntegrationHandler
+func NewIntegrationHandler(parkingLotUseCase usecase.IParkingLotUseCase, occupancyHistoryUseCase usecase.IOccupancyHistoryUseCase) *IntegrationHandler {
+	return &IntegrationHandler{
+		ParkingLotUseCase:       parkingLotUseCase,
+		OccupancyHistoryUseCase: occupancyHistoryUseCase,
+	}
+}
+
+// ListAvailability returns every parking lot with its current available spaces
+func (h *IntegrationHandler) ListAvailability(c *gin.Context) {
+	parkingLots, err := h.ParkingLotUseCase.ListParkingLots(usecase.ParkingLotQuery{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list parking lots"})
+		return
+	}
+
+	c.JSON(http.StatusOK, parkingLots)
+}
+
+// GetOccupancyHistory returns the occupancy samples of a parking lot between `from` and `to`.
+// API keys get the lots shown to drivers; admins the lots they may act on.
+func (h *IntegrationHandler) GetOccupancyHistory(c *gin.Context) {
+	parkingLotID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidParkingLotID})
+		return
+	}
+	_, withAPIKey := c.Get(helpers.APIKeyIDKey)
+	if !withAPIKey && !authorizeParkingLotID(c, h.ParkingLotUseCase, uint(parkingLotID)) {
+		return
+	}
+	from, errFrom := parseTimeQuery(c, "from")
+	to, errTo := parseTimeQuery(c, "to")
+	if errFrom != nil || errTo != nil || from == nil || to == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidTimeParam})
+		return
+	}
+
+	samples, err := h.OccupancyHistoryUseCase.GetHistory(uint(parkingLotID), *from, *to, withAPIKey)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidHistoryRange):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "parking lot not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get occupancy history"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, samples)
+}
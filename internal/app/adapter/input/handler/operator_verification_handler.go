@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+const invalidDocumentID = "invalid document id"
+
+// OperatorVerificationHandler lets operators submit their documents for verification and global
+// admins review them
+type OperatorVerificationHandler struct {
+	OperatorVerificationUseCase usecase.IOperatorVerificationUseCase
+}
+
+// NewOperatorVerificationHandler creates a new instance of OperatorVerificationHandler
+func NewOperatorVerificationHandler(verificationUseCase usecase.IOperatorVerificationUseCase) *OperatorVerificationHandler {
+	return &OperatorVerificationHandler{OperatorVerificationUseCase: verificationUseCase}
+}
+
+type VerificationReviewInput struct {
+	Reason string `json:"reason"`
+}
+
+// GetVerification returns where the authenticated operator stands in the verification
+func (h *OperatorVerificationHandler) GetVerification(c *gin.Context) {
+	verification, err := h.OperatorVerificationUseCase.GetVerification(helpers.ExtractAdminID(c))
+	if err != nil {
+		writeVerificationError(c, err, "Failed to get verification")
+		return
+	}
+
+	c.JSON(http.StatusOK, verification)
+}
+
+// UploadDocument stores the multipart `file` as a document of the `kind`
+func (h *OperatorVerificationHandler) UploadDocument(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, usecase.MaxDocumentSize+1<<20)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": usecase.ErrDocumentTooLarge.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	defer file.Close()
+
+	document, err := h.OperatorVerificationUseCase.UploadDocument(helpers.ExtractAdminID(c), usecase.DocumentUpload{
+		Kind:     c.PostForm("kind"),
+		FileName: header.Filename,
+		Size:     header.Size,
+		Content:  file,
+	})
+	if err != nil {
+		writeVerificationError(c, err, "Failed to upload document")
+		return
+	}
+
+	c.JSON(http.StatusCreated, document)
+}
+
+// SubmitForReview puts the authenticated operator under review
+func (h *OperatorVerificationHandler) SubmitForReview(c *gin.Context) {
+	verification, err := h.OperatorVerificationUseCase.SubmitForReview(helpers.ExtractAdminID(c))
+	if err != nil {
+		writeVerificationError(c, err, "Failed to submit for review")
+		return
+	}
+
+	c.JSON(http.StatusOK, verification)
+}
+
+// GetOperatorVerification returns where an operator stands in the verification
+func (h *OperatorVerificationHandler) GetOperatorVerification(c *gin.Context) {
+	adminID, ok := parseAdminID(c)
+	if !ok {
+		return
+	}
+
+	verification, err := h.OperatorVerificationUseCase.GetOperatorVerification(adminID)
+	if err != nil {
+		writeVerificationError(c, err, "Failed to get verification")
+		return
+	}
+
+	c.JSON(http.StatusOK, verification)
+}
+
+// DownloadDocument sends a document of an operator as an attachment
+func (h *OperatorVerificationHandler) DownloadDocument(c *gin.Context) {
+	adminID, ok := parseAdminID(c)
+	if !ok {
+		return
+	}
+	documentID, err := strconv.ParseUint(c.Param("document_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidDocumentID})
+		return
+	}
+
+	document, content, err := h.OperatorVerificationUseCase.OpenDocument(adminID, uint(documentID))
+	if err != nil {
+		writeVerificationError(c, err, "Failed to open document")
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, document.Size, document.ContentType, content, map[string]string{
+		"Content-Disposition": fmt.Sprintf("attachment; filename=%q", document.FileName),
+	})
+}
+
+// VerifyOperator verifies an operator under review
+func (h *OperatorVerificationHandler) VerifyOperator(c *gin.Context) {
+	h.review(c, h.OperatorVerificationUseCase.VerifyOperator, "Failed to verify operator")
+}
+
+// RejectOperator rejects an operator under review with the `reason` to fix
+func (h *OperatorVerificationHandler) RejectOperator(c *gin.Context) {
+	h.review(c, h.OperatorVerificationUseCase.RejectOperator, "Failed to reject operator")
+}
+
+func (h *OperatorVerificationHandler) review(c *gin.Context, review func(actorUUID string, adminID uint, reason string) (*usecase.VerificationDetail, error), fallback string) {
+	adminID, ok := parseAdminID(c)
+	if !ok {
+		return
+	}
+	var input VerificationReviewInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidRequestBody})
+		return
+	}
+
+	verification, err := review(helpers.ExtractAdminID(c), adminID, input.Reason)
+	if err != nil {
+		writeVerificationError(c, err, fallback)
+		return
+	}
+
+	c.JSON(http.StatusOK, verification)
+}
+
+func writeVerificationError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidDocumentKind), errors.Is(err, usecase.ErrUnsupportedDocumentType),
+		errors.Is(err, usecase.ErrInvalidNIT), errors.Is(err, usecase.ErrRUTRequired), errors.Is(err, usecase.ErrStatusReasonRequired):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrDocumentTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrAdminNotFound), errors.Is(err, usecase.ErrDocumentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrVerificationLocked), errors.Is(err, usecase.ErrInvalidVerificationTransition):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
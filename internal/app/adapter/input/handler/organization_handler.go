@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+const invalidOrganizationID = "invalid organization id"
+
+// OrganizationHandler lets admins manage the organizations they work for and their members
+type OrganizationHandler struct {
+	OrganizationUseCase usecase.IOrganizationUseCase
+}
+
+// NewOrganizationHandler creates a new instance of OrganizationHandler
+func NewOrganizationHandler(organizationUseCase usecase.IOrganizationUseCase) *OrganizationHandler {
+	return &OrganizationHandler{OrganizationUseCase: organizationUseCase}
+}
+
+type OrganizationInput struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// CreateOrganization creates an organization owned by the admin
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	var input OrganizationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidRequestBody})
+		return
+	}
+
+	organization, err := h.OrganizationUseCase.CreateOrganization(helpers.ExtractAdminID(c), input.Name)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidOrganizationName) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create organization"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, organization)
+}
+
+// ListOrganizations returns the organizations the admin is a member of
+func (h *OrganizationHandler) ListOrganizations(c *gin.Context) {
+	organizations, err := h.OrganizationUseCase.ListOrganizations(helpers.ExtractAdminID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list organizations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, organizations)
+}
+
+// ListMembers returns the memberships of the organization
+func (h *OrganizationHandler) ListMembers(c *gin.Context) {
+	organizationID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.OrganizationUseCase.ListMembers(helpers.ExtractAdminID(c), organizationID)
+	if err != nil {
+		writeOrganizationError(c, err, "Failed to list members")
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+// SetMember adds an admin to the organization or changes their role and parking lots
+func (h *OrganizationHandler) SetMember(c *gin.Context) {
+	organizationID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	var req usecase.MembershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidRequestBody})
+		return
+	}
+
+	membership, err := h.OrganizationUseCase.SetMember(helpers.ExtractAdminID(c), organizationID, req)
+	if err != nil {
+		writeOrganizationError(c, err, "Failed to update member")
+		return
+	}
+
+	c.JSON(http.StatusOK, membership)
+}
+
+// RemoveMember removes an admin from the organization
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	organizationID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+	memberID, err := strconv.ParseUint(c.Param("admin_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid admin id"})
+		return
+	}
+
+	if err := h.OrganizationUseCase.RemoveMember(helpers.ExtractAdminID(c), organizationID, uint(memberID)); err != nil {
+		writeOrganizationError(c, err, "Failed to remove member")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "Member removed"})
+}
+
+func parseOrganizationID(c *gin.Context) (uint, bool) {
+	organizationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidOrganizationID})
+		return 0, false
+	}
+	return uint(organizationID), true
+}
+
+func writeOrganizationError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, usecase.ErrOrganizationNotFound), errors.Is(err, usecase.ErrMemberNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrOrganizationOwnerRequired):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrLastOrganizationOwner):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidMembershipRole), errors.Is(err, usecase.ErrLotOutsideOrganization):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
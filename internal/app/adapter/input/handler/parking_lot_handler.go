@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
 	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
 	"github.com/CamiloLeonP/parking-radar/internal/helpers"
 	"github.com/CamiloLeonP/parking-radar/internal/hub"
@@ -18,14 +20,16 @@ const (
 )
 
 type ParkingLotHandler struct {
-	useCase      usecase.IParkingLotUseCase
-	webSocketHub *hub.WebSocketHub
+	useCase        usecase.IParkingLotUseCase
+	vehicleUseCase usecase.IVehicleUseCase
+	webSocketHub   *hub.WebSocketHub
 }
 
-func NewParkingLotHandler(useCase usecase.IParkingLotUseCase, wsHub *hub.WebSocketHub) *ParkingLotHandler {
+func NewParkingLotHandler(useCase usecase.IParkingLotUseCase, vehicleUseCase usecase.IVehicleUseCase, wsHub *hub.WebSocketHub) *ParkingLotHandler {
 	return &ParkingLotHandler{
-		useCase:      useCase,
-		webSocketHub: wsHub,
+		useCase:        useCase,
+		vehicleUseCase: vehicleUseCase,
+		webSocketHub:   wsHub,
 	}
 }
 
@@ -36,7 +40,7 @@ func (h *ParkingLotHandler) CreateParkingLot(c *gin.Context) {
 		return
 	}
 
-	adminUUID, _ := helpers.ExtractAdminIDAndRole(c)
+	adminUUID := helpers.ExtractAdminID(c)
 	if adminUUID == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid role for admin"})
 		return
@@ -49,6 +53,18 @@ func (h *ParkingLotHandler) CreateParkingLot(c *gin.Context) {
 func (h *ParkingLotHandler) processCreateParkingLot(c *gin.Context, req usecase.CreateParkingLotRequest) {
 	parkingLot, err := h.useCase.CreateParkingLot(req)
 	if err != nil {
+		if errors.Is(err, usecase.ErrParkingLotForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "you can't add parking lots to this organization"})
+			return
+		}
+		if errors.Is(err, usecase.ErrOperatorNotVerified) || errors.Is(err, usecase.ErrOperatorNotActive) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecase.ErrOrganizationRequired) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "parking lot already exists"})
 			return
@@ -66,26 +82,43 @@ func (h *ParkingLotHandler) processCreateParkingLot(c *gin.Context, req usecase.
 	c.JSON(http.StatusCreated, gin.H{"status": "parking lot created", "id": parkingLot.ID})
 }
 
-func (h *ParkingLotHandler) validateAccess(c *gin.Context) (uint, bool) {
+// authorizeParkingLot parses the :id parameter and checks that the admin may act on that parking
+// lot with authorizeParkingLotID.
+func authorizeParkingLot(c *gin.Context, useCase usecase.IParkingLotUseCase) (uint, bool) {
 	parkingLotID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": invalidParkingLotID})
 		return 0, false
 	}
 
-	adminUUID, isGlobalAdmin := helpers.ExtractAdminIDAndRole(c)
-	if !isGlobalAdmin {
-		if _, err := h.useCase.GetParkingLotWithOwnership(uint(parkingLotID), adminUUID); err != nil {
+	if !authorizeParkingLotID(c, useCase, uint(parkingLotID)) {
+		return 0, false
+	}
+	return uint(parkingLotID), true
+}
+
+// authorizeParkingLotID checks that a membership of the admin covers the parking lot with a role
+// granting the permissions declared with the route, unless they were granted access to every lot.
+// It writes the error response when they may not act on it.
+func authorizeParkingLotID(c *gin.Context, useCase usecase.IParkingLotUseCase, parkingLotID uint) bool {
+	if helpers.HasPermission(c, domain.PermissionLotAll) {
+		return true
+	}
+
+	adminUUID := helpers.ExtractAdminID(c)
+	if err := useCase.AuthorizeParkingLot(parkingLotID, adminUUID, helpers.RequiredPermissions(c)); err != nil {
+		if errors.Is(err, usecase.ErrParkingLotForbidden) {
 			c.JSON(http.StatusForbidden, gin.H{"error": dontHaveAccessToParkingLot})
-			return 0, false
+			return false
 		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check access to parking lot"})
+		return false
 	}
-
-	return uint(parkingLotID), true
+	return true
 }
 
 func (h *ParkingLotHandler) GetParkingLot(c *gin.Context) {
-	parkingLotID, ok := h.validateAccess(c)
+	parkingLotID, ok := authorizeParkingLot(c, h.useCase)
 	if !ok {
 		return
 	}
@@ -100,7 +133,7 @@ func (h *ParkingLotHandler) GetParkingLot(c *gin.Context) {
 }
 
 func (h *ParkingLotHandler) UpdateParkingLot(c *gin.Context) {
-	parkingLotID, ok := h.validateAccess(c)
+	parkingLotID, ok := authorizeParkingLot(c, h.useCase)
 	if !ok {
 		return
 	}
@@ -111,8 +144,12 @@ func (h *ParkingLotHandler) UpdateParkingLot(c *gin.Context) {
 		return
 	}
 
-	adminUUID, _ := helpers.ExtractAdminIDAndRole(c)
+	adminUUID := helpers.ExtractAdminID(c)
 	if err := h.useCase.UpdateParkingLot(parkingLotID, req, adminUUID); err != nil {
+		if errors.Is(err, usecase.ErrInvalidBillingFraction) || errors.Is(err, usecase.ErrInvalidOpeningHours) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update parking lot"})
 		return
 	}
@@ -127,12 +164,12 @@ func (h *ParkingLotHandler) UpdateParkingLot(c *gin.Context) {
 }
 
 func (h *ParkingLotHandler) DeleteParkingLot(c *gin.Context) {
-	parkingLotID, ok := h.validateAccess(c)
+	parkingLotID, ok := authorizeParkingLot(c, h.useCase)
 	if !ok {
 		return
 	}
 
-	adminUUID, _ := helpers.ExtractAdminIDAndRole(c)
+	adminUUID := helpers.ExtractAdminID(c)
 	if err := h.useCase.DeleteParkingLot(parkingLotID, adminUUID); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete parking lot"})
 		return
@@ -142,8 +179,22 @@ func (h *ParkingLotHandler) DeleteParkingLot(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "parking lot deleted"})
 }
 
+// ListParkingLots lists every parking lot, best rated first with `sort=rating`. For a signed-in
+// driver with a default vehicle only the spots that vehicle can use are counted as available.
 func (h *ParkingLotHandler) ListParkingLots(c *gin.Context) {
-	parkingLots, err := h.useCase.ListParkingLots()
+	sortBy := c.Query("sort")
+	if sortBy != "" && sortBy != usecase.ParkingLotSortRating {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sort, expected rating"})
+		return
+	}
+
+	spots, err := driverSpotFilter(c, h.vehicleUseCase)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load default vehicle"})
+		return
+	}
+
+	parkingLots, err := h.useCase.ListParkingLots(usecase.ParkingLotQuery{Spots: spots, SortBy: sortBy})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve parking lots"})
 		return
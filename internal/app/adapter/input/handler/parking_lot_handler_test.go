@@ -3,7 +3,9 @@ package handler
 import (
 	"bytes"
 	"encoding/json"
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
 	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/auth"
 	"github.com/CamiloLeonP/parking-radar/internal/hub"
 	middlewares "github.com/CamiloLeonP/parking-radar/internal/middleware"
 	"github.com/CamiloLeonP/parking-radar/internal/test/parking/mockgen"
@@ -14,6 +16,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 )
 
 // setupParkingLotHandler initializes the handler with middleware and mocks.
@@ -21,27 +24,40 @@ func setupParkingLotHandler(mockUseCase *mockgen.MockIParkingLotUseCase) (*gin.E
 	wsHub := hub.NewWebSocketHub()
 	go wsHub.Run()
 
-	parkingLotHandler := NewParkingLotHandler(mockUseCase, wsHub)
+	parkingLotHandler := NewParkingLotHandler(mockUseCase, nil, wsHub)
 
 	r := gin.Default()
 	parkingLots := r.Group("/parkinglots")
-	parkingLots.Use(middlewares.AuthMiddleware("admin_local", "admin_global"))
+	parkingLots.Use(middlewares.AuthMiddleware())
 	{
-		parkingLots.POST("/", parkingLotHandler.CreateParkingLot)
-		parkingLots.GET("/:id", parkingLotHandler.GetParkingLot)
-		parkingLots.PUT("/:id", parkingLotHandler.UpdateParkingLot)
-		parkingLots.DELETE("/:id", parkingLotHandler.DeleteParkingLot)
-		parkingLots.GET("/", parkingLotHandler.ListParkingLots)
+		parkingLots.POST("/", middlewares.RequirePermission(domain.PermissionLotWrite), parkingLotHandler.CreateParkingLot)
+		parkingLots.GET("/:id", middlewares.RequirePermission(domain.PermissionLotRead), parkingLotHandler.GetParkingLot)
+		parkingLots.PUT("/:id", middlewares.RequirePermission(domain.PermissionLotWrite), parkingLotHandler.UpdateParkingLot)
+		parkingLots.DELETE("/:id", middlewares.RequirePermission(domain.PermissionLotWrite), parkingLotHandler.DeleteParkingLot)
+		parkingLots.GET("/", middlewares.RequirePermission(domain.PermissionLotRead), parkingLotHandler.ListParkingLots)
 	}
 
 	return r, wsHub
 }
 
+// testIssuer signs the admin tokens of the handler tests, so they run without reaching Auth0.
+var testIssuer *auth.DevIssuer
+
+func TestMain(m *testing.M) {
+	issuer, err := auth.GenerateDevIssuer("")
+	if err != nil {
+		panic("failed to create test issuer: " + err.Error())
+	}
+	testIssuer = issuer
+	middlewares.ConfigureValidator(auth.NewValidator(issuer, auth.DevIssuerName, ""))
+
+	os.Exit(m.Run())
+}
+
 // Helper to generate a JWT for testing.
 func generateTestJWT() (string, error) {
-	privateKeyPEM := os.Getenv("RSA_PRIVATE_KEY")
-
-	return privateKeyPEM, nil
+	token, _, err := testIssuer.Mint("auth0|6721363081b8547d3f95a976", []string{"admin_local", "admin_global"}, time.Hour)
+	return token, err
 }
 
 // Test for creating a parking lot.
@@ -180,7 +196,7 @@ func TestListParkingLots(t *testing.T) {
 		{ID: 1, Name: "Lot 1", Address: "123 Test St", Latitude: 12.34, Longitude: 56.78, AvailableSpaces: 10},
 		{ID: 2, Name: "Lot 2", Address: "456 Test Ave", Latitude: 98.76, Longitude: 54.32, AvailableSpaces: 20},
 	}
-	mockUseCase.EXPECT().ListParkingLots().Return(mockParkingLots, nil)
+	mockUseCase.EXPECT().ListParkingLots(usecase.ParkingLotQuery{}).Return(mockParkingLots, nil)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/parkinglots/", nil)
@@ -194,3 +210,24 @@ func TestListParkingLots(t *testing.T) {
 	json.Unmarshal(w.Body.Bytes(), &responseParkingLots)
 	assert.Equal(t, mockParkingLots, responseParkingLots)
 }
+
+// Test that tokens whose roles lack the permission of the route are rejected.
+func TestParkingLotsRejectTokensWithoutPermission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mockgen.NewMockIParkingLotUseCase(ctrl)
+	r, wsHub := setupParkingLotHandler(mockUseCase)
+	defer wsHub.Stop()
+
+	tokenString, _, err := testIssuer.Mint("auth0|6721363081b8547d3f95a976", []string{"admin_default"}, time.Hour)
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/parkinglots/", nil)
+	req.Header.Set("Authorization", "Bearer "+tokenString)
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
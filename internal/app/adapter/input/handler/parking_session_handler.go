@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ParkingSessionHandler lets drivers start, stop and review their parking sessions and admins list the sessions of their lots
+type ParkingSessionHandler struct {
+	ParkingSessionUseCase usecase.IParkingSessionUseCase
+	ParkingLotUseCase     usecase.IParkingLotUseCase
+}
+
+// NewParkingSessionHandler creates a new instance of ParkingSessionHandler
+func NewParkingSessionHandler(sessionUseCase usecase.IParkingSessionUseCase, parkingLotUseCase usecase.IParkingLotUseCase) *ParkingSessionHandler {
+	return &ParkingSessionHandler{
+		ParkingSessionUseCase: sessionUseCase,
+		ParkingLotUseCase:     parkingLotUseCase,
+	}
+}
+
+type CheckInInput struct {
+	ParkingLotID uint `json:"parking_lot_id" binding:"required"`
+	SensorID     uint `json:"sensor_id" binding:"required"`
+}
+
+// StartSession starts a session from the app
+func (h *ParkingSessionHandler) StartSession(c *gin.Context) {
+	var input usecase.StartSessionRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.start(c, input, domain.ParkingSessionSourceApp)
+}
+
+// CheckIn starts or claims the session of the spot whose QR code the driver scanned
+func (h *ParkingSessionHandler) CheckIn(c *gin.Context) {
+	var input CheckInInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.start(c, usecase.StartSessionRequest{ParkingLotID: input.ParkingLotID, SensorID: input.SensorID}, domain.ParkingSessionSourceQR)
+}
+
+func (h *ParkingSessionHandler) start(c *gin.Context, req usecase.StartSessionRequest, source string) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	session, err := h.ParkingSessionUseCase.StartSession(userID, req, source)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Parking lot or spot not found"})
+		case errors.Is(err, usecase.ErrSensorNotInLot):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrSessionAlreadyActive), errors.Is(err, domain.ErrSpotInSession):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start parking session"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, session)
+}
+
+// StopSession ends a session of the driver and returns its cost
+func (h *ParkingSessionHandler) StopSession(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	sessionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session id"})
+		return
+	}
+
+	session, err := h.ParkingSessionUseCase.StopSession(userID, uint(sessionID))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Parking session not found"})
+		case errors.Is(err, usecase.ErrSessionNotActive):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to stop parking session"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// ListActiveSessions returns the running sessions of the driver
+func (h *ParkingSessionHandler) ListActiveSessions(c *gin.Context) {
+	h.listForUser(c, domain.ParkingSessionActive)
+}
+
+// ListPastSessions returns the completed sessions of the driver
+func (h *ParkingSessionHandler) ListPastSessions(c *gin.Context) {
+	h.listForUser(c, domain.ParkingSessionCompleted)
+}
+
+func (h *ParkingSessionHandler) listForUser(c *gin.Context, status string) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	sessions, err := h.ParkingSessionUseCase.ListByUser(userID, status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list parking sessions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// ListParkingLotSessions returns the sessions of a parking lot owned by the admin, optionally filtered by `status`
+func (h *ParkingSessionHandler) ListParkingLotSessions(c *gin.Context) {
+	parkingLotID, ok := authorizeParkingLot(c, h.ParkingLotUseCase)
+	if !ok {
+		return
+	}
+
+	status := c.Query("status")
+	if status != "" && status != domain.ParkingSessionActive && status != domain.ParkingSessionCompleted {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status, expected active or completed"})
+		return
+	}
+
+	sessions, err := h.ParkingSessionUseCase.ListByParkingLot(parkingLotID, status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list parking sessions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
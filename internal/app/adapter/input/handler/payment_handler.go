@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/payment"
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	idempotencyKeyHeader   = "Idempotency-Key"
+	webhookSignatureHeader = "X-Signature"
+)
+
+// PaymentHandler lets drivers pay sessions and reservations, receives provider webhooks and
+// gives admins the refunds, audit trail and revenue of their lots
+type PaymentHandler struct {
+	PaymentUseCase    usecase.IPaymentUseCase
+	ParkingLotUseCase usecase.IParkingLotUseCase
+}
+
+// NewPaymentHandler creates a new instance of PaymentHandler
+func NewPaymentHandler(paymentUseCase usecase.IPaymentUseCase, parkingLotUseCase usecase.IParkingLotUseCase) *PaymentHandler {
+	return &PaymentHandler{
+		PaymentUseCase:    paymentUseCase,
+		ParkingLotUseCase: parkingLotUseCase,
+	}
+}
+
+type RefundInput struct {
+	// Amount to refund; the whole refundable amount when empty.
+	Amount uint `json:"amount"`
+}
+
+// CreatePayment opens a payment for a parking session or reservation of the driver. Clients
+// should send an Idempotency-Key header so that retries do not create a second payment.
+func (h *PaymentHandler) CreatePayment(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input usecase.CreatePaymentRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	created, err := h.PaymentUseCase.CreatePayment(userID, input, c.GetHeader(idempotencyKeyHeader))
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidPaymentTarget):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Parking session or reservation not found"})
+		case errors.Is(err, usecase.ErrNothingToPay), errors.Is(err, domain.ErrAlreadyPaid):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create payment"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// ListMyPayments returns the payments of the driver
+func (h *PaymentHandler) ListMyPayments(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	payments, err := h.PaymentUseCase.ListByUser(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list payments"})
+		return
+	}
+
+	c.JSON(http.StatusOK, payments)
+}
+
+// HandleWebhook applies a payment status change sent by the provider in the :provider parameter
+func (h *PaymentHandler) HandleWebhook(c *gin.Context) {
+	payload, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidRequestBody})
+		return
+	}
+
+	err = h.PaymentUseCase.HandleWebhook(c.Param("provider"), payload, c.GetHeader(webhookSignatureHeader))
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUnknownPaymentProvider), errors.Is(err, payment.ErrUnknownPayment):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, payment.ErrInvalidSignature):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrPaymentConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			log.Println("Error handling payment webhook:", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process webhook"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "processed"})
+}
+
+// RefundPayment refunds a payment of a parking lot owned by the admin
+func (h *PaymentHandler) RefundPayment(c *gin.Context) {
+	parkingLotID, ok := authorizeParkingLot(c, h.ParkingLotUseCase)
+	if !ok {
+		return
+	}
+
+	paymentID, err := strconv.ParseUint(c.Param("payment_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payment id"})
+		return
+	}
+
+	var input RefundInput
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidRequestBody})
+		return
+	}
+
+	adminUUID := helpers.ExtractAdminID(c)
+	refunded, err := h.PaymentUseCase.Refund(parkingLotID, uint(paymentID), input.Amount, "admin:"+adminUUID, c.GetHeader(idempotencyKeyHeader))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "payment not found"})
+		case errors.Is(err, usecase.ErrInvalidRefundAmount):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrInvalidPaymentTransition), errors.Is(err, domain.ErrPaymentConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to refund payment"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, refunded)
+}
+
+// ListPaymentEvents returns the audit trail of a payment of a parking lot owned by the admin
+func (h *PaymentHandler) ListPaymentEvents(c *gin.Context) {
+	parkingLotID, ok := authorizeParkingLot(c, h.ParkingLotUseCase)
+	if !ok {
+		return
+	}
+
+	paymentID, err := strconv.ParseUint(c.Param("payment_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payment id"})
+		return
+	}
+
+	events, err := h.PaymentUseCase.ListEvents(parkingLotID, uint(paymentID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "payment not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list payment events"})
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// GetRevenue returns the revenue of a parking lot owned by the admin between the `from` and `to` dates
+func (h *PaymentHandler) GetRevenue(c *gin.Context) {
+	parkingLotID, ok := authorizeParkingLot(c, h.ParkingLotUseCase)
+	if !ok {
+		return
+	}
+
+	period, err := parseReportPeriod(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	revenue, err := h.PaymentUseCase.GetRevenue(parkingLotID, period)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to compute revenue"})
+		return
+	}
+
+	c.JSON(http.StatusOK, revenue)
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// PlaybackHandler reconstructs the historical state of parking lots
+type PlaybackHandler struct {
+	PlaybackUseCase   usecase.IPlaybackUseCase
+	ParkingLotUseCase usecase.IParkingLotUseCase
+}
+
+// NewPlaybackHandler creates a new instance of PlaybackHandler
+func NewPlaybackHandler(playbackUseCase usecase.IPlaybackUseCase, parkingLotUseCase usecase.IParkingLotUseCase) *PlaybackHandler {
+	return &PlaybackHandler{
+		PlaybackUseCase:   playbackUseCase,
+		ParkingLotUseCase: parkingLotUseCase,
+	}
+}
+
+// GetState returns the status of every sensor of the lot as of the `at` query instant
+func (h *PlaybackHandler) GetState(c *gin.Context) {
+	parkingLotID, ok := authorizeParkingLot(c, h.ParkingLotUseCase)
+	if !ok {
+		return
+	}
+
+	at, err := parseTimeQuery(c, "at")
+	if err != nil || at == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidTimeParam})
+		return
+	}
+
+	state, err := h.PlaybackUseCase.GetStateAt(parkingLotID, *at)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to rebuild parking lot state"})
+		return
+	}
+
+	c.JSON(http.StatusOK, state)
+}
+
+// Replay streams, as newline-delimited JSON, the lot state at `from` followed by every change until `to`
+func (h *PlaybackHandler) Replay(c *gin.Context) {
+	parkingLotID, ok := authorizeParkingLot(c, h.ParkingLotUseCase)
+	if !ok {
+		return
+	}
+
+	from, errFrom := parseTimeQuery(c, "from")
+	to, errTo := parseTimeQuery(c, "to")
+	if errFrom != nil || errTo != nil || from == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidTimeParam})
+		return
+	}
+	if to == nil {
+		now := time.Now()
+		to = &now
+	}
+	if to.Before(*from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must not be after to"})
+		return
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	encoder := json.NewEncoder(c.Writer)
+	err := h.PlaybackUseCase.Replay(parkingLotID, *from, *to, func(frame usecase.PlaybackFrame) error {
+		if err := encoder.Encode(frame); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	})
+	if err != nil {
+		if !c.Writer.Written() {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to replay parking lot"})
+			return
+		}
+		log.Println("Error streaming parking lot replay:", err)
+	}
+}
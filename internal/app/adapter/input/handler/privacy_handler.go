@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// PrivacyHandler handles data access and erasure requests of drivers and admins
+type PrivacyHandler struct {
+	PrivacyUseCase usecase.IPrivacyUseCase
+}
+
+// NewPrivacyHandler creates a new instance of PrivacyHandler
+func NewPrivacyHandler(privacyUseCase usecase.IPrivacyUseCase) *PrivacyHandler {
+	return &PrivacyHandler{PrivacyUseCase: privacyUseCase}
+}
+
+// ExportMyData downloads every piece of personal data of the authenticated driver as JSON
+func (h *PrivacyHandler) ExportMyData(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	export, err := h.PrivacyUseCase.ExportUser(userID)
+	if err != nil {
+		respondPrivacyError(c, err, "Failed to export user data")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"parking-radar-user-%d.json\"", userID))
+	c.JSON(http.StatusOK, export)
+}
+
+// EraseMyAccount anonymizes the authenticated driver and signs them out everywhere
+func (h *PrivacyHandler) EraseMyAccount(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.PrivacyUseCase.EraseUser(userID); err != nil {
+		respondPrivacyError(c, err, "Failed to erase account")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "Account erased"})
+}
+
+// ExportAdminData downloads the profile of the authenticated admin and their parking lots as JSON
+func (h *PrivacyHandler) ExportAdminData(c *gin.Context) {
+	adminUUID := helpers.ExtractAdminID(c)
+
+	export, err := h.PrivacyUseCase.ExportAdmin(adminUUID)
+	if err != nil {
+		respondPrivacyError(c, err, "Failed to export admin data")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"parking-radar-admin-%d.json\"", export.Profile.ID))
+	c.JSON(http.StatusOK, export)
+}
+
+// EraseAdminProfile anonymizes the profile of the authenticated admin
+func (h *PrivacyHandler) EraseAdminProfile(c *gin.Context) {
+	adminUUID := helpers.ExtractAdminID(c)
+
+	if err := h.PrivacyUseCase.EraseAdmin(adminUUID); err != nil {
+		respondPrivacyError(c, err, "Failed to erase admin profile")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "Admin profile erased"})
+}
+
+func respondPrivacyError(c *gin.Context, err error, message string) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+// RecommendationHandler recommends parking lots around the destination of a driver
+type RecommendationHandler struct {
+	RecommendationUseCase usecase.IRecommendationUseCase
+	VehicleUseCase        usecase.IVehicleUseCase
+}
+
+// NewRecommendationHandler creates a new instance of RecommendationHandler
+func NewRecommendationHandler(recommendationUseCase usecase.IRecommendationUseCase, vehicleUseCase usecase.IVehicleUseCase) *RecommendationHandler {
+	return &RecommendationHandler{
+		RecommendationUseCase: recommendationUseCase,
+		VehicleUseCase:        vehicleUseCase,
+	}
+}
+
+// Recommend ranks the lots around `dest_lat`/`dest_lng` for an arrival at `arrive_at`. The
+// `vehicle` type selects tariff and spots; signed-in drivers default to their default vehicle.
+// `duration_minutes`, `max_walk_km` and `weights` (e.g. "price=0.5,distance=0.2") are optional.
+func (h *RecommendationHandler) Recommend(c *gin.Context) {
+	lat, errLat := strconv.ParseFloat(c.Query("dest_lat"), 64)
+	lng, errLng := strconv.ParseFloat(c.Query("dest_lng"), 64)
+	if errLat != nil || errLng != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid dest_lat or dest_lng"})
+		return
+	}
+
+	arriveAt, err := parseTimeQuery(c, "arrive_at")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidTimeParam})
+		return
+	}
+
+	req := usecase.RecommendationRequest{
+		DestinationLatitude:  lat,
+		DestinationLongitude: lng,
+		ArriveAt:             arriveAt,
+	}
+
+	if value := c.Query("duration_minutes"); value != "" {
+		minutes, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || minutes == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid duration_minutes"})
+			return
+		}
+		req.Duration = time.Duration(minutes) * time.Minute
+	}
+
+	if value := c.Query("max_walk_km"); value != "" {
+		maxWalking, err := strconv.ParseFloat(value, 64)
+		if err != nil || maxWalking <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid max_walk_km"})
+			return
+		}
+		req.MaxWalkingKm = maxWalking
+	}
+
+	if value := c.Query("weights"); value != "" {
+		weights, err := usecase.ParseRecommendationWeights(value, usecase.DefaultRecommendationWeights)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.Weights = &weights
+	}
+
+	if vehicleType := c.Query("vehicle"); vehicleType != "" {
+		if !domain.ValidVehicleType(vehicleType) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": usecase.ErrInvalidVehicleType.Error()})
+			return
+		}
+		filter := domain.Vehicle{Type: vehicleType}.SpotFilter()
+		req.VehicleType = vehicleType
+		req.Spots = &filter
+	} else if userID, ok := helpers.ExtractUserID(c); ok {
+		vehicles, err := h.VehicleUseCase.ListVehicles(userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load default vehicle"})
+			return
+		}
+		if len(vehicles) > 0 && vehicles[0].IsDefault {
+			filter := vehicles[0].SpotFilter()
+			req.VehicleType = vehicles[0].Type
+			req.Spots = &filter
+		}
+	}
+
+	recommendations, err := h.RecommendationUseCase.Recommend(req)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidRecommendationWeights) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to recommend parking lots"})
+		return
+	}
+
+	c.JSON(http.StatusOK, recommendations)
+}
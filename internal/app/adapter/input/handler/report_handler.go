@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/report"
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+const reportDateLayout = "2006-01-02"
+
+// ReportHandler streams spreadsheet exports for admins
+type ReportHandler struct {
+	ReportUseCase usecase.IReportUseCase
+}
+
+// NewReportHandler creates a new instance of ReportHandler
+func NewReportHandler(reportUseCase usecase.IReportUseCase) *ReportHandler {
+	return &ReportHandler{ReportUseCase: reportUseCase}
+}
+
+// ExportOccupancy streams the occupancy history of the admin's parking lots
+func (h *ReportHandler) ExportOccupancy(c *gin.Context) {
+	h.export(c, "occupancy", h.ReportUseCase.ExportOccupancy)
+}
+
+// ExportDevices streams the device status of the admin's parking lots
+func (h *ReportHandler) ExportDevices(c *gin.Context) {
+	h.export(c, "devices", h.ReportUseCase.ExportDevices)
+}
+
+func (h *ReportHandler) export(c *gin.Context, name string, exportFn func(string, usecase.ReportPeriod, report.Writer) error) {
+	adminUUID := helpers.ExtractAdminID(c)
+
+	format := c.DefaultQuery("format", report.FormatCSV)
+	attachment := &attachmentWriter{c: c, contentType: report.ContentType(format)}
+	writer, err := report.NewWriter(format, attachment)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	period, err := parseReportPeriod(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("%s_%s_%s.%s", name,
+		period.From.Format(reportDateLayout), period.To.AddDate(0, 0, -1).Format(reportDateLayout), format)
+	attachment.disposition = fmt.Sprintf(`attachment; filename="%s"`, filename)
+
+	if err := exportFn(adminUUID, period, writer); err != nil {
+		if !c.Writer.Written() {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to export report"})
+			return
+		}
+		log.Printf("Error streaming %s report: %v", name, err)
+	}
+}
+
+// attachmentWriter sets the attachment headers right before the first bytes of the report, so
+// that an export failing before writing anything is answered with a plain JSON error.
+type attachmentWriter struct {
+	c           *gin.Context
+	contentType string
+	disposition string
+}
+
+func (w *attachmentWriter) Write(p []byte) (int, error) {
+	if !w.c.Writer.Written() {
+		w.c.Header("Content-Type", w.contentType)
+		w.c.Header("Content-Disposition", w.disposition)
+	}
+	return w.c.Writer.Write(p)
+}
+
+// parseReportPeriod reads the `from` and `to` query dates (YYYY-MM-DD, Bogota time, both inclusive).
+// It defaults to the current month.
+func parseReportPeriod(c *gin.Context) (usecase.ReportPeriod, error) {
+	period := usecase.CurrentMonthPeriod(time.Now())
+
+	if from := c.Query("from"); from != "" {
+		t, err := time.ParseInLocation(reportDateLayout, from, helpers.BogotaLocation)
+		if err != nil {
+			return period, fmt.Errorf("invalid from date, expected %s", reportDateLayout)
+		}
+		period.From = t
+	}
+	if to := c.Query("to"); to != "" {
+		t, err := time.ParseInLocation(reportDateLayout, to, helpers.BogotaLocation)
+		if err != nil {
+			return period, fmt.Errorf("invalid to date, expected %s", reportDateLayout)
+		}
+		period.To = t.AddDate(0, 0, 1)
+	}
+
+	if !period.From.Before(period.To) {
+		return period, errors.New("from must not be after to")
+	}
+	return period, nil
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/report"
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubReportUseCase runs export for both reports.
+type stubReportUseCase struct {
+	export func(w report.Writer) error
+}
+
+func (s stubReportUseCase) ExportOccupancy(_ string, _ usecase.ReportPeriod, w report.Writer) error {
+	return s.export(w)
+}
+
+func (s stubReportUseCase) ExportDevices(_ string, _ usecase.ReportPeriod, w report.Writer) error {
+	return s.export(w)
+}
+
+func serveReport(export func(w report.Writer) error, target string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/reports/occupancy", NewReportHandler(stubReportUseCase{export: export}).ExportOccupancy)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, target, nil)
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestExportSetsTheAttachmentHeadersWithTheReport(t *testing.T) {
+	w := serveReport(func(w report.Writer) error {
+		if err := w.StartSheet("Norte", []string{"parking_lot_id"}); err != nil {
+			return err
+		}
+		if err := w.WriteRow(1); err != nil {
+			return err
+		}
+		return w.Close()
+	}, "/reports/occupancy?from=2024-09-01&to=2024-09-30")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="occupancy_2024-09-01_2024-09-30.csv"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "parking_lot_id\n1\n", w.Body.String())
+}
+
+func TestExportFailingBeforeWritingAnswersWithAJSONError(t *testing.T) {
+	w := serveReport(func(report.Writer) error {
+		return errors.New("database unavailable")
+	}, "/reports/occupancy?format=xlsx")
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+	assert.JSONEq(t, `{"error":"failed to export report"}`, w.Body.String())
+}
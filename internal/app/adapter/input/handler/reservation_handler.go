@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ReservationHandler lets drivers hold spots and admins configure and review the reservations of their lots
+type ReservationHandler struct {
+	ReservationUseCase usecase.IReservationUseCase
+	ParkingLotUseCase  usecase.IParkingLotUseCase
+}
+
+// NewReservationHandler creates a new instance of ReservationHandler
+func NewReservationHandler(reservationUseCase usecase.IReservationUseCase, parkingLotUseCase usecase.IParkingLotUseCase) *ReservationHandler {
+	return &ReservationHandler{
+		ReservationUseCase: reservationUseCase,
+		ParkingLotUseCase:  parkingLotUseCase,
+	}
+}
+
+type ReservableSpotsInput struct {
+	ReservableSpots *uint `json:"reservable_spots" binding:"required"`
+}
+
+// CreateReservation holds a spot for the driver until they arrive
+func (h *ReservationHandler) CreateReservation(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input usecase.CreateReservationRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reservation, err := h.ReservationUseCase.Reserve(userID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidHoldWindow):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Parking lot or spot not found"})
+		case errors.Is(err, domain.ErrNoReservableSpots), errors.Is(err, domain.ErrSpotAlreadyReserved),
+			errors.Is(err, usecase.ErrNoFreeSpots), errors.Is(err, usecase.ErrSpotNotFree):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create reservation"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, reservation)
+}
+
+// ListMyReservations returns the reservations of the driver
+func (h *ReservationHandler) ListMyReservations(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	reservations, err := h.ReservationUseCase.ListByUser(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list reservations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, reservations)
+}
+
+// CancelReservation releases a spot held by the driver
+func (h *ReservationHandler) CancelReservation(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	reservationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid reservation id"})
+		return
+	}
+
+	if err := h.ReservationUseCase.Cancel(userID, uint(reservationID)); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Reservation not found"})
+		case errors.Is(err, usecase.ErrReservationNotHeld):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel reservation"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListParkingLotReservations returns the reservations of a parking lot owned by the admin
+func (h *ReservationHandler) ListParkingLotReservations(c *gin.Context) {
+	parkingLotID, ok := authorizeParkingLot(c, h.ParkingLotUseCase)
+	if !ok {
+		return
+	}
+
+	reservations, err := h.ReservationUseCase.ListByParkingLot(parkingLotID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list reservations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, reservations)
+}
+
+// UpdateReservableSpots configures how many spots of a parking lot drivers can hold at the same time
+func (h *ReservationHandler) UpdateReservableSpots(c *gin.Context) {
+	parkingLotID, ok := authorizeParkingLot(c, h.ParkingLotUseCase)
+	if !ok {
+		return
+	}
+
+	var input ReservableSpotsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidRequestBody})
+		return
+	}
+
+	if err := h.ReservationUseCase.SetReservableSpots(parkingLotID, *input.ReservableSpots); err != nil {
+		if errors.Is(err, usecase.ErrTooManyReservableSpots) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update reservable spots"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "reservable spots updated", "reservable_spots": *input.ReservableSpots})
+}
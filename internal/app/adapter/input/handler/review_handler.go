@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ReviewHandler serves parking lot reviews to drivers, replies to lot admins and the moderation
+// queue to global admins
+type ReviewHandler struct {
+	ReviewUseCase     usecase.IReviewUseCase
+	ParkingLotUseCase usecase.IParkingLotUseCase
+}
+
+// NewReviewHandler creates a new instance of ReviewHandler
+func NewReviewHandler(reviewUseCase usecase.IReviewUseCase, parkingLotUseCase usecase.IParkingLotUseCase) *ReviewHandler {
+	return &ReviewHandler{
+		ReviewUseCase:     reviewUseCase,
+		ParkingLotUseCase: parkingLotUseCase,
+	}
+}
+
+type ReportReviewInput struct {
+	Reason string `json:"reason"`
+}
+
+type ReplyReviewInput struct {
+	Reply string `json:"reply" binding:"required"`
+}
+
+// SubmitReview creates or updates the driver's review of a parking lot
+func (h *ReviewHandler) SubmitReview(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input usecase.ReviewRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	review, err := h.ReviewUseCase.SubmitReview(userID, input)
+	if err != nil {
+		respondReviewError(c, err, "Failed to save review")
+		return
+	}
+
+	c.JSON(http.StatusCreated, review)
+}
+
+// ListMyReviews returns the reviews written by the driver
+func (h *ReviewHandler) ListMyReviews(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	reviews, err := h.ReviewUseCase.ListMyReviews(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list reviews"})
+		return
+	}
+
+	c.JSON(http.StatusOK, reviews)
+}
+
+// DeleteReview removes a review of the driver
+func (h *ReviewHandler) DeleteReview(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	reviewID, ok := parseReviewID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.ReviewUseCase.DeleteReview(userID, reviewID); err != nil {
+		respondReviewError(c, err, "Failed to delete review")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ReportReview flags a review of another driver as abusive
+func (h *ReviewHandler) ReportReview(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	reviewID, ok := parseReviewID(c)
+	if !ok {
+		return
+	}
+
+	var input ReportReviewInput
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidRequestBody})
+		return
+	}
+
+	if err := h.ReviewUseCase.ReportReview(userID, reviewID, input.Reason); err != nil {
+		respondReviewError(c, err, "Failed to report review")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"status": "review reported"})
+}
+
+// ListParkingLotReviews returns the published reviews of a parking lot with its rating
+func (h *ReviewHandler) ListParkingLotReviews(c *gin.Context) {
+	parkingLotID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidParkingLotID})
+		return
+	}
+
+	reviews, err := h.ReviewUseCase.ListParkingLotReviews(uint(parkingLotID))
+	if err != nil {
+		respondReviewError(c, err, "Failed to list reviews")
+		return
+	}
+
+	c.JSON(http.StatusOK, reviews)
+}
+
+// ReplyToReview stores the admin's public answer to a review of their parking lot
+func (h *ReviewHandler) ReplyToReview(c *gin.Context) {
+	parkingLotID, ok := authorizeParkingLot(c, h.ParkingLotUseCase)
+	if !ok {
+		return
+	}
+
+	reviewID, ok := parseReviewID(c)
+	if !ok {
+		return
+	}
+
+	var input ReplyReviewInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	adminUUID := helpers.ExtractAdminID(c)
+	review, err := h.ReviewUseCase.ReplyToReview(parkingLotID, reviewID, adminUUID, input.Reply)
+	if err != nil {
+		respondReviewError(c, err, "failed to reply to review")
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// ListModerationQueue returns the reviews waiting for a global admin
+func (h *ReviewHandler) ListModerationQueue(c *gin.Context) {
+	reviews, err := h.ReviewUseCase.ListModerationQueue()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list moderation queue"})
+		return
+	}
+
+	c.JSON(http.StatusOK, reviews)
+}
+
+// HideReview removes a review from its parking lot
+func (h *ReviewHandler) HideReview(c *gin.Context) {
+	reviewID, ok := parseReviewID(c)
+	if !ok {
+		return
+	}
+
+	review, err := h.ReviewUseCase.HideReview(reviewID)
+	if err != nil {
+		respondReviewError(c, err, "failed to hide review")
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// RestoreReview publishes a moderated review again
+func (h *ReviewHandler) RestoreReview(c *gin.Context) {
+	reviewID, ok := parseReviewID(c)
+	if !ok {
+		return
+	}
+
+	review, err := h.ReviewUseCase.RestoreReview(reviewID)
+	if err != nil {
+		respondReviewError(c, err, "failed to restore review")
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+func parseReviewID(c *gin.Context) (uint, bool) {
+	reviewID, err := strconv.ParseUint(c.Param("review_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return 0, false
+	}
+	return uint(reviewID), true
+}
+
+func respondReviewError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidRating), errors.Is(err, usecase.ErrInvalidReviewText), errors.Is(err, usecase.ErrCannotReportOwnReview):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrReviewRateLimited), errors.Is(err, usecase.ErrReviewReportLimited):
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -1,25 +1,31 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
 	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
 	"github.com/CamiloLeonP/parking-radar/internal/hub"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // SensorHandler manages sensor operations and WebSocket notifications
 type SensorHandler struct {
-	SensorUseCase usecase.ISensorUseCase
-	WebSocketHub  *hub.WebSocketHub
+	SensorUseCase     usecase.ISensorUseCase
+	ParkingLotUseCase usecase.IParkingLotUseCase
+	WebSocketHub      *hub.WebSocketHub
 }
 
 // NewSensorHandler creates a new instance of SensorHandler
-func NewSensorHandler(sensorUseCase usecase.ISensorUseCase, wsHub *hub.WebSocketHub) *SensorHandler {
+func NewSensorHandler(sensorUseCase usecase.ISensorUseCase, parkingLotUseCase usecase.IParkingLotUseCase, wsHub *hub.WebSocketHub) *SensorHandler {
 	return &SensorHandler{
-		SensorUseCase: sensorUseCase,
-		WebSocketHub:  wsHub,
+		SensorUseCase:     sensorUseCase,
+		ParkingLotUseCase: parkingLotUseCase,
+		WebSocketHub:      wsHub,
 	}
 }
 
@@ -31,7 +37,15 @@ func (h *SensorHandler) CreateSensor(c *gin.Context) {
 		return
 	}
 
+	if !authorizeParkingLotID(c, h.ParkingLotUseCase, req.ParkingLotID) {
+		return
+	}
+
 	if err := h.SensorUseCase.CreateSensor(req); err != nil {
+		if errors.Is(err, usecase.ErrInvalidSpotType) || errors.Is(err, usecase.ErrDeviceNotFound) || errors.Is(err, usecase.ErrDeviceParkingLot) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -47,21 +61,15 @@ func (h *SensorHandler) CreateSensor(c *gin.Context) {
 
 // GetSensor retrieves a specific sensor by ID
 func (h *SensorHandler) GetSensor(c *gin.Context) {
-	sensorID, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
-		return
-	}
-
-	sensor, err := h.SensorUseCase.GetSensor(uint(sensorID))
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	sensor, ok := h.authorizeSensor(c)
+	if !ok {
 		return
 	}
 	c.JSON(http.StatusOK, sensor)
 }
 
-// UpdateSensor updates a sensor and notifies clients
+// UpdateSensor applies the status reported by the authenticated device to one of its sensors and
+// notifies clients
 func (h *SensorHandler) UpdateSensor(c *gin.Context) {
 	var req usecase.UpdateSensorRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -69,6 +77,13 @@ func (h *SensorHandler) UpdateSensor(c *gin.Context) {
 		return
 	}
 
+	value, _ := c.Get(helpers.DeviceKey)
+	device, ok := value.(*domain.Esp32Device)
+	if !ok || device.DeviceIdentifier != req.DeviceIdentifier {
+		c.JSON(http.StatusForbidden, gin.H{"error": "the sensor belongs to another device"})
+		return
+	}
+
 	sensor, err := h.SensorUseCase.GetSensorByDeviceAndNumber(req.DeviceIdentifier, req.SensorNumber)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "sensor not found"})
@@ -76,6 +91,10 @@ func (h *SensorHandler) UpdateSensor(c *gin.Context) {
 	}
 
 	if err := h.SensorUseCase.UpdateSensor(sensor.ID, req); err != nil {
+		if errors.Is(err, usecase.ErrOperatorSuspended) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -92,13 +111,13 @@ func (h *SensorHandler) UpdateSensor(c *gin.Context) {
 
 // DeleteSensor deletes a sensor and notifies clients
 func (h *SensorHandler) DeleteSensor(c *gin.Context) {
-	sensorID, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+	sensor, ok := h.authorizeSensor(c)
+	if !ok {
 		return
 	}
+	sensorID := sensor.ID
 
-	if err := h.SensorUseCase.DeleteSensor(uint(sensorID)); err != nil {
+	if err := h.SensorUseCase.DeleteSensor(sensorID); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -120,6 +139,10 @@ func (h *SensorHandler) ListSensors(c *gin.Context) {
 		return
 	}
 
+	if !authorizeParkingLotID(c, h.ParkingLotUseCase, uint(parkingID)) {
+		return
+	}
+
 	sensors, err := h.SensorUseCase.ListSensorsByParkingLot(uint(parkingID))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -129,6 +152,31 @@ func (h *SensorHandler) ListSensors(c *gin.Context) {
 	c.JSON(http.StatusOK, sensors)
 }
 
+// authorizeSensor loads the sensor of the :id parameter and checks that the admin may act on its
+// parking lot. It writes the error response when they may not.
+func (h *SensorHandler) authorizeSensor(c *gin.Context) (*usecase.SensorResponse, bool) {
+	sensorID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return nil, false
+	}
+
+	sensor, err := h.SensorUseCase.GetSensor(uint(sensorID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "sensor not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	if !authorizeParkingLotID(c, h.ParkingLotUseCase, sensor.ParkingLotID) {
+		return nil, false
+	}
+	return sensor, true
+}
+
 // NotifyChange sends a unified notification about sensor-related changes
 func (h *SensorHandler) NotifyChange(event string, details gin.H) {
 	h.WebSocketHub.Broadcast(gin.H{
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	middlewares "github.com/CamiloLeonP/parking-radar/internal/middleware"
+	"github.com/CamiloLeonP/parking-radar/internal/test/parking/mockgen"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupUserAuthHandler(mockUseCase *mockgen.MockIUserAuthUseCase, mockUserUseCase *mockgen.MockIUserUseCase) *gin.Engine {
+	userAuthHandler := NewUserAuthHandler(mockUseCase)
+	userHandler := NewUserHandler(mockUserUseCase)
+
+	r := gin.Default()
+	r.POST("/users/login", userAuthHandler.Login)
+	self := r.Group("/users/:id")
+	self.Use(middlewares.UserAuthMiddleware(mockUseCase), middlewares.SelfOnlyMiddleware("id"))
+	{
+		self.GET("", userHandler.GetUserByID)
+	}
+
+	return r
+}
+
+func TestLoginUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mockgen.NewMockIUserAuthUseCase(ctrl)
+	r := setupUserAuthHandler(mockUseCase, mockgen.NewMockIUserUseCase(ctrl))
+
+	mockUseCase.EXPECT().Login("testuser", "password").Return(&usecase.TokenPair{AccessToken: "access", RefreshToken: "refresh"}, nil)
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(LoginInput{Username: "testuser", Password: "password"})
+	req, _ := http.NewRequest("POST", "/users/login", bytes.NewBuffer(body))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response usecase.TokenPair
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, "refresh", response.RefreshToken)
+}
+
+func TestLoginUser_InvalidCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mockgen.NewMockIUserAuthUseCase(ctrl)
+	r := setupUserAuthHandler(mockUseCase, mockgen.NewMockIUserUseCase(ctrl))
+
+	mockUseCase.EXPECT().Login("testuser", "wrong").Return(nil, usecase.ErrInvalidCredentials)
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(LoginInput{Username: "testuser", Password: "wrong"})
+	req, _ := http.NewRequest("POST", "/users/login", bytes.NewBuffer(body))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestGetUserByID_OtherUserForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mockgen.NewMockIUserAuthUseCase(ctrl)
+	r := setupUserAuthHandler(mockUseCase, mockgen.NewMockIUserUseCase(ctrl))
+
+	mockUseCase.EXPECT().Authenticate("access").Return(uint(1), nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users/2", nil)
+	req.Header.Set("Authorization", "Bearer access")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// VehicleHandler manages the vehicles of the authenticated driver and quotes parking stays
+type VehicleHandler struct {
+	VehicleUseCase usecase.IVehicleUseCase
+}
+
+// NewVehicleHandler creates a new instance of VehicleHandler
+func NewVehicleHandler(vehicleUseCase usecase.IVehicleUseCase) *VehicleHandler {
+	return &VehicleHandler{VehicleUseCase: vehicleUseCase}
+}
+
+// ListVehicles returns the vehicles of the driver, the default one first
+func (h *VehicleHandler) ListVehicles(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	vehicles, err := h.VehicleUseCase.ListVehicles(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list vehicles"})
+		return
+	}
+
+	c.JSON(http.StatusOK, vehicles)
+}
+
+// CreateVehicle registers a vehicle of the driver
+func (h *VehicleHandler) CreateVehicle(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input usecase.VehicleRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	vehicle, err := h.VehicleUseCase.CreateVehicle(userID, input)
+	if err != nil {
+		respondVehicleError(c, err, "Failed to register vehicle")
+		return
+	}
+
+	c.JSON(http.StatusCreated, vehicle)
+}
+
+// UpdateVehicle changes a vehicle of the driver
+func (h *VehicleHandler) UpdateVehicle(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid vehicle id"})
+		return
+	}
+
+	var input usecase.VehicleRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	vehicle, err := h.VehicleUseCase.UpdateVehicle(userID, uint(vehicleID), input)
+	if err != nil {
+		respondVehicleError(c, err, "Failed to update vehicle")
+		return
+	}
+
+	c.JSON(http.StatusOK, vehicle)
+}
+
+// DeleteVehicle removes a vehicle of the driver
+func (h *VehicleHandler) DeleteVehicle(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid vehicle id"})
+		return
+	}
+
+	if err := h.VehicleUseCase.DeleteVehicle(userID, uint(vehicleID)); err != nil {
+		respondVehicleError(c, err, "Failed to delete vehicle")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SetDefaultVehicle makes a vehicle the default vehicle of the driver
+func (h *VehicleHandler) SetDefaultVehicle(c *gin.Context) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid vehicle id"})
+		return
+	}
+
+	if err := h.VehicleUseCase.SetDefaultVehicle(userID, uint(vehicleID)); err != nil {
+		respondVehicleError(c, err, "Failed to set default vehicle")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "default vehicle updated"})
+}
+
+// GetQuote prices a stay of `minutes` in a parking lot for the driver's vehicle. Anonymous
+// drivers may pass a `vehicle_type` instead.
+func (h *VehicleHandler) GetQuote(c *gin.Context) {
+	parkingLotID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidParkingLotID})
+		return
+	}
+
+	req := usecase.QuoteRequest{VehicleType: c.Query("vehicle_type")}
+	if minutes := c.Query("minutes"); minutes != "" {
+		value, err := strconv.ParseUint(minutes, 10, 32)
+		if err != nil || value == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid minutes"})
+			return
+		}
+		req.Minutes = uint(value)
+	}
+	if vehicleID := c.Query("vehicle_id"); vehicleID != "" {
+		value, err := strconv.ParseUint(vehicleID, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid vehicle id"})
+			return
+		}
+		req.VehicleID = uint(value)
+	}
+
+	userID, _ := helpers.ExtractUserID(c)
+	quote, err := h.VehicleUseCase.Quote(userID, uint(parkingLotID), req)
+	if err != nil {
+		respondVehicleError(c, err, "Failed to quote parking lot")
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
+
+func respondVehicleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidVehicleType), errors.Is(err, usecase.ErrInvalidPlate):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrDuplicatePlate):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// driverSpotFilter returns the spots the default vehicle of the authenticated driver can use, or
+// nil for anonymous drivers and drivers without vehicles
+func driverSpotFilter(c *gin.Context, vehicleUseCase usecase.IVehicleUseCase) (*domain.SpotFilter, error) {
+	userID, ok := helpers.ExtractUserID(c)
+	if !ok || vehicleUseCase == nil {
+		return nil, nil
+	}
+	return vehicleUseCase.DefaultSpotFilter(userID)
+}
@@ -3,7 +3,9 @@ package handler
 import (
 	"log"
 	"net/http"
+	"strings"
 
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
 	"github.com/CamiloLeonP/parking-radar/internal/hub"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
@@ -11,12 +13,13 @@ import (
 
 // WebSocketHandler encapsula la lógica de WebSocket y dependencias
 type WebSocketHandler struct {
-	hub *hub.WebSocketHub
+	hub             *hub.WebSocketHub
+	userAuthUseCase usecase.IUserAuthUseCase
 }
 
 // NewWebSocketHandler inicializa una nueva instancia de WebSocketHandler
-func NewWebSocketHandler(hub *hub.WebSocketHub) *WebSocketHandler {
-	return &WebSocketHandler{hub: hub}
+func NewWebSocketHandler(hub *hub.WebSocketHub, userAuthUseCase usecase.IUserAuthUseCase) *WebSocketHandler {
+	return &WebSocketHandler{hub: hub, userAuthUseCase: userAuthUseCase}
 }
 
 // Upgrader convierte una conexión HTTP a WebSocket
@@ -26,6 +29,17 @@ var upgrader = websocket.Upgrader{
 
 // HandleConnection maneja el ciclo de vida de la conexión WebSocket
 func (wsh *WebSocketHandler) HandleConnection(c *gin.Context) {
+	// Los conductores se identifican con su access token para recibir sus propios eventos
+	var userID uint
+	if token := connectionToken(c); token != "" {
+		id, err := wsh.userAuthUseCase.Authenticate(token)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+			return
+		}
+		userID = id
+	}
+
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		log.Println("Failed to upgrade to WebSocket:", err)
@@ -41,7 +55,7 @@ func (wsh *WebSocketHandler) HandleConnection(c *gin.Context) {
 	log.Println("New WebSocket connection established")
 
 	// Agregar al cliente al hub
-	wsh.hub.AddClient(conn)
+	wsh.hub.AddUserClient(conn, userID)
 
 	// Enviar mensaje de bienvenida
 	welcomeMessage := gin.H{
@@ -81,3 +95,12 @@ func (wsh *WebSocketHandler) HandleConnection(c *gin.Context) {
 		log.Printf("Received message: %s\n", message)
 	}
 }
+
+// connectionToken lee el access token del header Authorization o, ya que los navegadores no
+// permiten headers en WebSocket, del query param access_token
+func connectionToken(c *gin.Context) string {
+	if header := c.GetHeader("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		return strings.TrimPrefix(header, "Bearer ")
+	}
+	return c.Query("access_token")
+}
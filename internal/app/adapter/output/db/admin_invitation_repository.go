@@ -0,0 +1,122 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+)
+
+type AdminInvitationRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// Create saves a new invitation along with the audit event of its creation.
+func (r *AdminInvitationRepositoryImpl) Create(invitation *domain.AdminInvitation, event *domain.AdminInvitationEvent) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(invitation).Error; err != nil {
+			return err
+		}
+		event.InvitationID = invitation.ID
+		return tx.Create(event).Error
+	})
+}
+
+func (r *AdminInvitationRepositoryImpl) FindByID(id uint) (*domain.AdminInvitation, error) {
+	return r.findOne("id = ?", id)
+}
+
+func (r *AdminInvitationRepositoryImpl) FindByHash(tokenHash string) (*domain.AdminInvitation, error) {
+	return r.findOne("token_hash = ?", tokenHash)
+}
+
+func (r *AdminInvitationRepositoryImpl) List(organizationID *uint) ([]domain.AdminInvitation, error) {
+	query := r.DB.Order("created_at DESC")
+	if organizationID != nil {
+		query = query.Where("organization_id = ?", *organizationID)
+	}
+	var invitations []domain.AdminInvitation
+	if err := query.Find(&invitations).Error; err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// Accept closes the invitation and onboards the admin in one transaction, so that an accepted
+// invitation always has its admin and membership.
+func (r *AdminInvitationRepositoryImpl) Accept(invitation *domain.AdminInvitation, event *domain.AdminInvitationEvent, admin *domain.Admin, membership *domain.OrganizationMembership) (bool, error) {
+	return r.close(invitation, event, map[string]interface{}{
+		"accepted_at": invitation.AcceptedAt,
+		"accepted_by": invitation.AcceptedBy,
+	}, func(tx *gorm.DB) error {
+		if admin.ID == 0 {
+			if err := tx.Create(admin).Error; err != nil {
+				return err
+			}
+		}
+		if membership == nil {
+			return nil
+		}
+		membership.AdminID = admin.ID
+		return tx.Omit("Lots").Create(membership).Error
+	})
+}
+
+func (r *AdminInvitationRepositoryImpl) Revoke(invitation *domain.AdminInvitation, event *domain.AdminInvitationEvent) (bool, error) {
+	return r.close(invitation, event, map[string]interface{}{"revoked_at": invitation.RevokedAt}, nil)
+}
+
+func (r *AdminInvitationRepositoryImpl) Delete(invitation *domain.AdminInvitation) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("invitation_id = ?", invitation.ID).Delete(&domain.AdminInvitationEvent{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.AdminInvitation{}, "id = ?", invitation.ID).Error
+	})
+}
+
+func (r *AdminInvitationRepositoryImpl) ListEvents(invitationID uint) ([]domain.AdminInvitationEvent, error) {
+	var events []domain.AdminInvitationEvent
+	if err := r.DB.Where("invitation_id = ?", invitationID).Order("id").Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// close updates an open invitation, records the event and runs then, when set, in the same
+// transaction, so that an invitation is accepted or revoked only once.
+func (r *AdminInvitationRepositoryImpl) close(invitation *domain.AdminInvitation, event *domain.AdminInvitationEvent, updates map[string]interface{}, then func(tx *gorm.DB) error) (bool, error) {
+	closed := false
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.AdminInvitation{}).
+			Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", invitation.ID).
+			Updates(updates)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		event.InvitationID = invitation.ID
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+		if then != nil {
+			if err := then(tx); err != nil {
+				return err
+			}
+		}
+		closed = true
+		return nil
+	})
+	return closed && err == nil, err
+}
+
+func (r *AdminInvitationRepositoryImpl) findOne(query string, args ...interface{}) (*domain.AdminInvitation, error) {
+	var invitation domain.AdminInvitation
+	err := r.DB.Where(query, args...).First(&invitation).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
@@ -1,6 +1,8 @@
 package db
 
 import (
+	"strings"
+
 	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
 	"gorm.io/gorm"
 )
@@ -36,3 +38,92 @@ func (r *AdminRepositoryImpl) FindByAuth0UUID(auth0UUID string) (*domain.Admin,
 func (r *AdminRepositoryImpl) Update(admin *domain.Admin) error {
 	return r.DB.Save(admin).Error
 }
+
+// List retrieves all admins.
+func (r *AdminRepositoryImpl) List() ([]domain.Admin, error) {
+	var admins []domain.Admin
+	if err := r.DB.Find(&admins).Error; err != nil {
+		return nil, err
+	}
+	return admins, nil
+}
+
+// FindByID retrieves an admin by its ID.
+func (r *AdminRepositoryImpl) FindByID(id uint) (*domain.Admin, error) {
+	var admin domain.Admin
+	if err := r.DB.First(&admin, id).Error; err != nil {
+		return nil, err
+	}
+	return &admin, nil
+}
+
+// Search retrieves a page of the admins matching the filter, newest first.
+func (r *AdminRepositoryImpl) Search(filter domain.AdminFilter) ([]domain.Admin, int64, error) {
+	query := r.DB.Model(&domain.Admin{})
+	if filter.Query != "" {
+		pattern := "%" + strings.ToLower(filter.Query) + "%"
+		query = query.Where("LOWER(auth0_uuid) LIKE ? OR LOWER(nit) LIKE ?", pattern, pattern)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var admins []domain.Admin
+	if err := query.Order("created_at DESC").Offset(filter.Offset).Limit(filter.Limit).Find(&admins).Error; err != nil {
+		return nil, 0, err
+	}
+	return admins, total, nil
+}
+
+// ChangeStatus updates the status of the admin and records the event in the same transaction.
+func (r *AdminRepositoryImpl) ChangeStatus(admin *domain.Admin, event *domain.AdminStatusEvent) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(admin).Updates(map[string]interface{}{
+			"status":            admin.Status,
+			"status_reason":     admin.StatusReason,
+			"status_changed_at": admin.StatusChangedAt,
+		}).Error; err != nil {
+			return err
+		}
+		event.AdminID = admin.ID
+		return tx.Create(event).Error
+	})
+}
+
+// ListStatusEvents retrieves the status changes of the admin, oldest first.
+func (r *AdminRepositoryImpl) ListStatusEvents(adminID uint) ([]domain.AdminStatusEvent, error) {
+	var events []domain.AdminStatusEvent
+	if err := r.DB.Where("admin_id = ?", adminID).Order("id").Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// ChangeVerification updates the verification status of the admin and records the event in the
+// same transaction.
+func (r *AdminRepositoryImpl) ChangeVerification(admin *domain.Admin, event *domain.AdminVerificationEvent) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(admin).Updates(map[string]interface{}{
+			"verification_status": admin.VerificationStatus,
+			"verification_reason": admin.VerificationReason,
+			"verified_at":         admin.VerifiedAt,
+		}).Error; err != nil {
+			return err
+		}
+		event.AdminID = admin.ID
+		return tx.Create(event).Error
+	})
+}
+
+// ListVerificationEvents retrieves the verification changes of the admin, oldest first.
+func (r *AdminRepositoryImpl) ListVerificationEvents(adminID uint) ([]domain.AdminVerificationEvent, error) {
+	var events []domain.AdminVerificationEvent
+	if err := r.DB.Where("admin_id = ?", adminID).Order("id").Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,97 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type APIKeyRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (r *APIKeyRepositoryImpl) Create(key *domain.APIKey) error {
+	return r.DB.Create(key).Error
+}
+
+func (r *APIKeyRepositoryImpl) FindByID(id uint) (*domain.APIKey, error) {
+	var key domain.APIKey
+	err := r.DB.First(&key, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *APIKeyRepositoryImpl) FindByHash(keyHash string) (*domain.APIKey, error) {
+	var key domain.APIKey
+	err := r.DB.Where("key_hash = ?", keyHash).First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *APIKeyRepositoryImpl) List() ([]domain.APIKey, error) {
+	var keys []domain.APIKey
+	if err := r.DB.Order("created_at DESC").Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Revoke marks the key as revoked, keeping the first revocation time.
+func (r *APIKeyRepositoryImpl) Revoke(id uint, at time.Time) error {
+	return r.DB.Model(&domain.APIKey{}).Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
+
+func (r *APIKeyRepositoryImpl) TouchLastUsed(id uint, at time.Time) error {
+	return r.DB.Model(&domain.APIKey{}).Where("id = ?", id).UpdateColumn("last_used_at", at).Error
+}
+
+func (r *APIKeyRepositoryImpl) GetUsage(keyID uint, day time.Time) (*domain.APIKeyUsage, error) {
+	var usage domain.APIKeyUsage
+	err := r.DB.Where("api_key_id = ? AND day = ?", keyID, day).First(&usage).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
+
+// IncrementUsage counts a request made with the key on the day, creating the day's counters on
+// its first request.
+func (r *APIKeyRepositoryImpl) IncrementUsage(keyID uint, day time.Time, rejected bool) error {
+	usage := domain.APIKeyUsage{APIKeyID: keyID, Day: day, Requests: 1}
+	column := "requests"
+	if rejected {
+		usage = domain.APIKeyUsage{APIKeyID: keyID, Day: day, Rejected: 1}
+		column = "rejected"
+	}
+	return r.DB.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "api_key_id"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			column: gorm.Expr("api_key_usages." + column + " + 1"),
+		}),
+	}).Create(&usage).Error
+}
+
+func (r *APIKeyRepositoryImpl) ListUsageSince(keyID uint, since time.Time) ([]domain.APIKeyUsage, error) {
+	var usage []domain.APIKeyUsage
+	if err := r.DB.Where("api_key_id = ? AND day >= ?", keyID, since).Order("day ASC").Find(&usage).Error; err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+)
+
+type AvailabilityAlertRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// Create adds a new availability alert.
+func (r *AvailabilityAlertRepositoryImpl) Create(alert *domain.AvailabilityAlert) error {
+	return r.DB.Create(alert).Error
+}
+
+// Update modifies an existing availability alert.
+func (r *AvailabilityAlertRepositoryImpl) Update(alert *domain.AvailabilityAlert) error {
+	return r.DB.Save(alert).Error
+}
+
+// Delete removes an alert owned by the user.
+func (r *AvailabilityAlertRepositoryImpl) Delete(userID uint, alertID uint) error {
+	return r.DB.Where("id = ? AND user_id = ?", alertID, userID).Delete(&domain.AvailabilityAlert{}).Error
+}
+
+// ListByUser retrieves the alerts of a user.
+func (r *AvailabilityAlertRepositoryImpl) ListByUser(userID uint) ([]domain.AvailabilityAlert, error) {
+	var alerts []domain.AvailabilityAlert
+	if err := r.DB.Where("user_id = ?", userID).Order("created_at ASC").Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// ListActiveByParkingLot retrieves the active alerts subscribed to a parking lot.
+func (r *AvailabilityAlertRepositoryImpl) ListActiveByParkingLot(parkingLotID uint) ([]domain.AvailabilityAlert, error) {
+	var alerts []domain.AvailabilityAlert
+	if err := r.DB.Where("parking_lot_id = ? AND active = ?", parkingLotID, true).Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
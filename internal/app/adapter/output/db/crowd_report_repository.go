@@ -0,0 +1,87 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CrowdReportRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (r *CrowdReportRepositoryImpl) Create(report *domain.CrowdReport) error {
+	return r.DB.Create(report).Error
+}
+
+func (r *CrowdReportRepositoryImpl) FindLatestByUser(userID uint, parkingLotID uint) (*domain.CrowdReport, error) {
+	var report domain.CrowdReport
+	err := r.DB.Where("user_id = ? AND parking_lot_id = ?", userID, parkingLotID).
+		Order("reported_at DESC").First(&report).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r *CrowdReportRepositoryImpl) ListByUser(userID uint) ([]domain.CrowdReport, error) {
+	var reports []domain.CrowdReport
+	if err := r.DB.Where("user_id = ?", userID).Order("reported_at DESC").Find(&reports).Error; err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (r *CrowdReportRepositoryImpl) ListByParkingLotSince(parkingLotID uint, since time.Time) ([]domain.CrowdReport, error) {
+	var reports []domain.CrowdReport
+	if err := r.DB.Where("parking_lot_id = ? AND reported_at >= ?", parkingLotID, since).
+		Order("reported_at ASC").Find(&reports).Error; err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (r *CrowdReportRepositoryImpl) ListGroupedByParkingLotSince(since time.Time) (map[uint][]domain.CrowdReport, error) {
+	var reports []domain.CrowdReport
+	if err := r.DB.Where("reported_at >= ?", since).Order("reported_at ASC").Find(&reports).Error; err != nil {
+		return nil, err
+	}
+
+	reportMap := make(map[uint][]domain.CrowdReport)
+	for _, report := range reports {
+		reportMap[report.ParkingLotID] = append(reportMap[report.ParkingLotID], report)
+	}
+	return reportMap, nil
+}
+
+func (r *CrowdReportRepositoryImpl) GetReputation(userID uint) (*domain.ReporterReputation, error) {
+	var reputation domain.ReporterReputation
+	err := r.DB.First(&reputation, "user_id = ?", userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &reputation, nil
+}
+
+// AdjustReputation adds agreements and disagreements to the driver's reputation, creating it
+// on their first adjustment.
+func (r *CrowdReportRepositoryImpl) AdjustReputation(userID uint, agreements uint, disagreements uint) error {
+	reputation := domain.ReporterReputation{UserID: userID, Agreements: agreements, Disagreements: disagreements}
+	return r.DB.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"agreements":    gorm.Expr("reporter_reputations.agreements + ?", agreements),
+			"disagreements": gorm.Expr("reporter_reputations.disagreements + ?", disagreements),
+			"updated_at":    time.Now(),
+		}),
+	}).Create(&reputation).Error
+}
@@ -44,6 +44,17 @@ func (r *Esp32DeviceRepositoryImpl) GetByDeviceIdentifier(identifier string) (*d
 	return &device, nil
 }
 
+func (r *Esp32DeviceRepositoryImpl) GetByTokenHash(hash string) (*domain.Esp32Device, error) {
+	var device domain.Esp32Device
+	if err := r.DB.First(&device, "token_hash = ?", hash).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &device, nil
+}
+
 func (r *Esp32DeviceRepositoryImpl) Update(device *domain.Esp32Device) error {
 	return r.DB.Save(device).Error
 }
@@ -59,3 +70,11 @@ func (r *Esp32DeviceRepositoryImpl) ListAll() ([]domain.Esp32Device, error) {
 	}
 	return devices, nil
 }
+
+func (r *Esp32DeviceRepositoryImpl) ListForMember(adminID uint) ([]domain.Esp32Device, error) {
+	var devices []domain.Esp32Device
+	if err := r.DB.Scopes(coveredByMember("parking_lot_id", adminID)).Find(&devices).Error; err != nil {
+		return nil, err
+	}
+	return devices, nil
+}
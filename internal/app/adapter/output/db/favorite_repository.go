@@ -0,0 +1,31 @@
+package db
+
+import (
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FavoriteRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// Create saves a parking lot as favorite of a user. Saving an existing favorite is a no-op.
+func (r *FavoriteRepositoryImpl) Create(favorite *domain.FavoriteParkingLot) error {
+	return r.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(favorite).Error
+}
+
+// Delete removes a parking lot from the favorites of a user.
+func (r *FavoriteRepositoryImpl) Delete(userID uint, parkingLotID uint) error {
+	return r.DB.Where("user_id = ? AND parking_lot_id = ?", userID, parkingLotID).
+		Delete(&domain.FavoriteParkingLot{}).Error
+}
+
+// ListByUser retrieves the favorites of a user with their parking lot.
+func (r *FavoriteRepositoryImpl) ListByUser(userID uint) ([]domain.FavoriteParkingLot, error) {
+	var favorites []domain.FavoriteParkingLot
+	if err := r.DB.Preload("ParkingLot").Where("user_id = ?", userID).Order("created_at ASC").Find(&favorites).Error; err != nil {
+		return nil, err
+	}
+	return favorites, nil
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+)
+
+type ImpersonationRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (r *ImpersonationRepositoryImpl) Create(session *domain.ImpersonationSession) error {
+	return r.DB.Create(session).Error
+}
+
+func (r *ImpersonationRepositoryImpl) FindActive(actorSubject string, at time.Time) (*domain.ImpersonationSession, error) {
+	var session domain.ImpersonationSession
+	err := r.DB.
+		Where("actor_subject = ? AND ended_at IS NULL AND expires_at > ?", actorSubject, at).
+		Order("id DESC").
+		First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *ImpersonationRepositoryImpl) EndActive(actorSubject string, at time.Time) (bool, error) {
+	result := r.DB.Model(&domain.ImpersonationSession{}).
+		Where("actor_subject = ? AND ended_at IS NULL AND expires_at > ?", actorSubject, at).
+		Update("ended_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+// List retrieves every session, newest first.
+func (r *ImpersonationRepositoryImpl) List() ([]domain.ImpersonationSession, error) {
+	var sessions []domain.ImpersonationSession
+	if err := r.DB.Order("id DESC").Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (r *ImpersonationRepositoryImpl) CreateAuditEntry(entry *domain.ImpersonationAuditEntry) error {
+	return r.DB.Create(entry).Error
+}
+
+func (r *ImpersonationRepositoryImpl) UpdateAuditEntryStatus(entryID uint, status int) error {
+	return r.DB.Model(&domain.ImpersonationAuditEntry{}).Where("id = ?", entryID).Update("status", status).Error
+}
+
+// ListAuditEntries retrieves the requests audited in the session, oldest first.
+func (r *ImpersonationRepositoryImpl) ListAuditEntries(sessionID uint) ([]domain.ImpersonationAuditEntry, error) {
+	var entries []domain.ImpersonationAuditEntry
+	if err := r.DB.Where("session_id = ?", sessionID).Order("id").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
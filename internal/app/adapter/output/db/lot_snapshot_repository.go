@@ -0,0 +1,33 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+)
+
+type LotSnapshotRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// Create stores a new snapshot.
+func (r *LotSnapshotRepositoryImpl) Create(snapshot *domain.LotSnapshot) error {
+	return r.DB.Create(snapshot).Error
+}
+
+// FindLatestBefore retrieves the most recent snapshot of a parking lot taken at or before the
+// given instant. It returns nil when there is none.
+func (r *LotSnapshotRepositoryImpl) FindLatestBefore(parkingLotID uint, at time.Time) (*domain.LotSnapshot, error) {
+	var snapshot domain.LotSnapshot
+	if err := r.DB.Where("parking_lot_id = ? AND taken_at <= ?", parkingLotID, at).
+		Order("taken_at DESC").
+		First(&snapshot).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &snapshot, nil
+}
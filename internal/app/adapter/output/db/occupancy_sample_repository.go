@@ -0,0 +1,75 @@
+package db
+
+import (
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+)
+
+type OccupancySampleRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// Create stores a new occupancy sample.
+func (r *OccupancySampleRepositoryImpl) Create(sample *domain.OccupancySample) error {
+	return r.DB.Create(sample).Error
+}
+
+// ListByParkingLotSince retrieves the samples of a parking lot recorded after the given instant.
+func (r *OccupancySampleRepositoryImpl) ListByParkingLotSince(parkingLotID uint, since time.Time) ([]domain.OccupancySample, error) {
+	var samples []domain.OccupancySample
+	if err := r.DB.Where("parking_lot_id = ? AND recorded_at >= ?", parkingLotID, since).
+		Order("recorded_at ASC").
+		Find(&samples).Error; err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
+
+// ListByParkingLotsSince retrieves the samples of every given parking lot recorded after the
+// given instant in a single query.
+func (r *OccupancySampleRepositoryImpl) ListByParkingLotsSince(parkingLotIDs []uint, since time.Time) ([]domain.OccupancySample, error) {
+	var samples []domain.OccupancySample
+	if len(parkingLotIDs) == 0 {
+		return samples, nil
+	}
+	if err := r.DB.Where("parking_lot_id IN ? AND recorded_at >= ?", parkingLotIDs, since).
+		Order("recorded_at ASC").
+		Find(&samples).Error; err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
+
+// StreamByParkingLotBetween iterates over the samples of a parking lot in [from, to) without
+// loading them all in memory, calling fn for each one in chronological order.
+func (r *OccupancySampleRepositoryImpl) StreamByParkingLotBetween(parkingLotID uint, from, to time.Time, fn func(domain.OccupancySample) error) error {
+	return r.stream(r.DB, parkingLotID, from, to, fn)
+}
+
+func (r *OccupancySampleRepositoryImpl) StreamByParkingLotBetweenForMember(parkingLotID uint, adminID uint, from, to time.Time, fn func(domain.OccupancySample) error) error {
+	return r.stream(r.DB.Scopes(coveredByMember("parking_lot_id", adminID)), parkingLotID, from, to, fn)
+}
+
+func (r *OccupancySampleRepositoryImpl) stream(query *gorm.DB, parkingLotID uint, from, to time.Time, fn func(domain.OccupancySample) error) error {
+	rows, err := query.Model(&domain.OccupancySample{}).
+		Where("parking_lot_id = ? AND recorded_at >= ? AND recorded_at < ?", parkingLotID, from, to).
+		Order("recorded_at ASC").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sample domain.OccupancySample
+		if err := r.DB.ScanRows(rows, &sample); err != nil {
+			return err
+		}
+		if err := fn(sample); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
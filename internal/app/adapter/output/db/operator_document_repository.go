@@ -0,0 +1,37 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+)
+
+type OperatorDocumentRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (r *OperatorDocumentRepositoryImpl) Create(document *domain.OperatorDocument) error {
+	return r.DB.Create(document).Error
+}
+
+// ListByAdmin retrieves the documents the admin uploaded, oldest first.
+func (r *OperatorDocumentRepositoryImpl) ListByAdmin(adminID uint) ([]domain.OperatorDocument, error) {
+	var documents []domain.OperatorDocument
+	if err := r.DB.Where("admin_id = ?", adminID).Order("id").Find(&documents).Error; err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+func (r *OperatorDocumentRepositoryImpl) FindByAdmin(adminID, documentID uint) (*domain.OperatorDocument, error) {
+	var document domain.OperatorDocument
+	err := r.DB.Where("id = ? AND admin_id = ?", documentID, adminID).First(&document).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
@@ -0,0 +1,124 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+)
+
+type OrganizationRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// Create adds the organization together with the membership of its first owner.
+func (r *OrganizationRepositoryImpl) Create(organization *domain.Organization, owner *domain.OrganizationMembership) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(organization).Error; err != nil {
+			return err
+		}
+		owner.OrganizationID = organization.ID
+		return tx.Create(owner).Error
+	})
+}
+
+func (r *OrganizationRepositoryImpl) FindByID(id uint) (*domain.Organization, error) {
+	var organization domain.Organization
+	err := r.DB.First(&organization, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// List retrieves every organization.
+func (r *OrganizationRepositoryImpl) List() ([]domain.Organization, error) {
+	var organizations []domain.Organization
+	if err := r.DB.Order("id").Find(&organizations).Error; err != nil {
+		return nil, err
+	}
+	return organizations, nil
+}
+
+// ListByAdmin retrieves the organizations the admin is a member of.
+func (r *OrganizationRepositoryImpl) ListByAdmin(adminID uint) ([]domain.Organization, error) {
+	var organizations []domain.Organization
+	err := r.DB.
+		Joins("JOIN organization_memberships m ON m.organization_id = organizations.id AND m.admin_id = ?", adminID).
+		Order("organizations.id").
+		Find(&organizations).Error
+	if err != nil {
+		return nil, err
+	}
+	return organizations, nil
+}
+
+func (r *OrganizationRepositoryImpl) FindMembership(organizationID uint, adminID uint) (*domain.OrganizationMembership, error) {
+	var membership domain.OrganizationMembership
+	err := r.DB.Preload("Lots").
+		Where("organization_id = ? AND admin_id = ?", organizationID, adminID).
+		First(&membership).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &membership, nil
+}
+
+func (r *OrganizationRepositoryImpl) ListMemberships(organizationID uint) ([]domain.OrganizationMembership, error) {
+	var memberships []domain.OrganizationMembership
+	if err := r.DB.Preload("Lots").Where("organization_id = ?", organizationID).Order("id").Find(&memberships).Error; err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
+
+func (r *OrganizationRepositoryImpl) ListMembershipsByAdmin(adminID uint) ([]domain.OrganizationMembership, error) {
+	var memberships []domain.OrganizationMembership
+	if err := r.DB.Preload("Lots").Where("admin_id = ?", adminID).Order("id").Find(&memberships).Error; err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
+
+// SaveMembership creates or updates the membership, replacing the parking lots it is restricted to.
+func (r *OrganizationRepositoryImpl) SaveMembership(membership *domain.OrganizationMembership) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		lots := membership.Lots
+		if err := tx.Omit("Lots").Save(membership).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("membership_id = ?", membership.ID).Delete(&domain.OrganizationMembershipLot{}).Error; err != nil {
+			return err
+		}
+		for i := range lots {
+			lots[i].MembershipID = membership.ID
+		}
+		if len(lots) > 0 {
+			return tx.Create(&lots).Error
+		}
+		return nil
+	})
+}
+
+func (r *OrganizationRepositoryImpl) DeleteMembership(id uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("membership_id = ?", id).Delete(&domain.OrganizationMembershipLot{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.OrganizationMembership{}, id).Error
+	})
+}
+
+func (r *OrganizationRepositoryImpl) CountOwners(organizationID uint) (int64, error) {
+	var count int64
+	err := r.DB.Model(&domain.OrganizationMembership{}).
+		Where("organization_id = ? AND role = ?", organizationID, domain.MembershipRoleOwner).
+		Count(&count).Error
+	return count, err
+}
@@ -2,6 +2,8 @@ package db
 
 import (
 	"errors"
+	"slices"
+	"strings"
 
 	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
 	"gorm.io/gorm"
@@ -25,22 +27,49 @@ func (r *ParkingLotRepositoryImpl) GetByID(id uint) (*domain.ParkingLot, error)
 	return &parkingLot, nil
 }
 
-// GetByIDWithAdmin retrieves a parking lot by ID and verifies ownership.
-func (r *ParkingLotRepositoryImpl) GetByIDWithAdmin(parkingLotID uint, adminID uint) (*domain.ParkingLot, error) {
+// GetByIDForMember retrieves a parking lot together with the membership through which the admin
+// acts on it. Both are nil when the lot does not exist or no membership of the admin covers it.
+func (r *ParkingLotRepositoryImpl) GetByIDForMember(parkingLotID uint, adminID uint) (*domain.ParkingLot, *domain.OrganizationMembership, error) {
 	var parkingLot domain.ParkingLot
-	if err := r.DB.Where("id = ? AND admin_id = ?", parkingLotID, adminID).First(&parkingLot).Error; err != nil {
+	if err := r.DB.First(&parkingLot, "id = ?", parkingLotID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("forbidden: you don't have access to this parking lot")
+			return nil, nil, nil
 		}
+		return nil, nil, err
+	}
+
+	var membership domain.OrganizationMembership
+	err := r.DB.Preload("Lots").
+		Where("organization_id = ? AND admin_id = ?", parkingLot.OrganizationID, adminID).
+		First(&membership).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if !membership.CoversLot(parkingLot.ID) {
+		return nil, nil, nil
+	}
+	return &parkingLot, &membership, nil
+}
+
+// FindByMember retrieves the parking lots covered by the memberships of the admin.
+func (r *ParkingLotRepositoryImpl) FindByMember(adminID uint) ([]domain.ParkingLot, error) {
+	var parkingLots []domain.ParkingLot
+	err := r.DB.Scopes(coveredByMember("parking_lots.id", adminID)).
+		Order("parking_lots.id").
+		Find(&parkingLots).Error
+	if err != nil {
 		return nil, err
 	}
-	return &parkingLot, nil
+	return parkingLots, nil
 }
 
-// FindByAdminID retrieves all parking lots associated with a specific admin.
-func (r *ParkingLotRepositoryImpl) FindByAdminID(adminID uint) ([]domain.ParkingLot, error) {
+// FindByOrganizationID retrieves all parking lots owned by the organization.
+func (r *ParkingLotRepositoryImpl) FindByOrganizationID(organizationID uint) ([]domain.ParkingLot, error) {
 	var parkingLots []domain.ParkingLot
-	if err := r.DB.Where("admin_id = ?", adminID).Find(&parkingLots).Error; err != nil {
+	if err := r.DB.Where("organization_id = ?", organizationID).Order("id").Find(&parkingLots).Error; err != nil {
 		return nil, err
 	}
 	return parkingLots, nil
@@ -56,14 +85,6 @@ func (r *ParkingLotRepositoryImpl) Delete(id uint) error {
 	return r.DB.Delete(&domain.ParkingLot{}, "id = ?", id).Error
 }
 
-// DeleteWithAdmin removes a parking lot after verifying ownership.
-func (r *ParkingLotRepositoryImpl) DeleteWithAdmin(parkingLotID uint, adminID uint) error {
-	if _, err := r.GetByIDWithAdmin(parkingLotID, adminID); err != nil {
-		return err
-	}
-	return r.DB.Delete(&domain.ParkingLot{}, "id = ?", parkingLotID).Error
-}
-
 // List retrieves all parking lots from the database.
 func (r *ParkingLotRepositoryImpl) List() ([]domain.ParkingLot, error) {
 	var parkingLots []domain.ParkingLot
@@ -72,3 +93,90 @@ func (r *ParkingLotRepositoryImpl) List() ([]domain.ParkingLot, error) {
 	}
 	return parkingLots, nil
 }
+
+// ownerStatuses selects the statuses of the owners of the organization of each parking lot. The
+// owners are the operator: AdminID is only the member who registered the lot.
+const ownerStatuses = "SELECT admins.status FROM organization_memberships owners" +
+	" JOIN admins ON admins.id = owners.admin_id" +
+	" WHERE owners.organization_id = parking_lots.organization_id AND owners.role = ?"
+
+// publiclyListed restricts a query to the parking lots whose operator is active: the organization
+// needs an active owner and no suspended one.
+func publiclyListed(db *gorm.DB) *gorm.DB {
+	return db.
+		Where("? IN ("+ownerStatuses+")", domain.AdminStatusActive, domain.MembershipRoleOwner).
+		Where("? NOT IN ("+ownerStatuses+")", domain.AdminStatusSuspended, domain.MembershipRoleOwner)
+}
+
+// ListPublic retrieves the parking lots whose operator is active, along with the admin who
+// registered them.
+func (r *ParkingLotRepositoryImpl) ListPublic() ([]domain.ParkingLot, error) {
+	var parkingLots []domain.ParkingLot
+	if err := r.DB.Scopes(publiclyListed).Preload("Admin").Find(&parkingLots).Error; err != nil {
+		return nil, err
+	}
+	return parkingLots, nil
+}
+
+// GetPublicByID retrieves a parking lot only when ListPublic lists it, and gorm.ErrRecordNotFound
+// otherwise.
+func (r *ParkingLotRepositoryImpl) GetPublicByID(id uint) (*domain.ParkingLot, error) {
+	var parkingLot domain.ParkingLot
+	if err := r.DB.Scopes(publiclyListed).First(&parkingLot, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &parkingLot, nil
+}
+
+// Search retrieves a page of the parking lots matching the filter, ordered by ID.
+func (r *ParkingLotRepositoryImpl) Search(filter domain.ParkingLotFilter) ([]domain.ParkingLot, int64, error) {
+	query := r.DB.Model(&domain.ParkingLot{})
+	if filter.Query != "" {
+		pattern := "%" + strings.ToLower(filter.Query) + "%"
+		query = query.Where("LOWER(name) LIKE ? OR LOWER(address) LIKE ?", pattern, pattern)
+	}
+	if filter.AdminID != 0 {
+		query = query.Where("admin_id = ?", filter.AdminID)
+	}
+	if filter.OrganizationID != 0 {
+		query = query.Where("organization_id = ?", filter.OrganizationID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var parkingLots []domain.ParkingLot
+	if err := query.Preload("Admin").Order("id").Offset(filter.Offset).Limit(filter.Limit).Find(&parkingLots).Error; err != nil {
+		return nil, 0, err
+	}
+	return parkingLots, total, nil
+}
+
+// OperatorStatus retrieves the status of the owners of the organization of the parking lot.
+func (r *ParkingLotRepositoryImpl) OperatorStatus(parkingLotID uint) (string, error) {
+	var statuses []string
+	err := r.DB.Model(&domain.ParkingLot{}).
+		Joins("JOIN organization_memberships owners ON owners.organization_id = parking_lots.organization_id AND owners.role = ?", domain.MembershipRoleOwner).
+		Joins("JOIN admins ON admins.id = owners.admin_id").
+		Where("parking_lots.id = ?", parkingLotID).
+		Pluck("admins.status", &statuses).Error
+	if err != nil {
+		return "", err
+	}
+	return operatorStatus(statuses), nil
+}
+
+// operatorStatus combines the statuses of the owners of an organization: one suspended owner
+// suspends the operator, otherwise one active owner is enough for it to be active.
+func operatorStatus(ownerStatuses []string) string {
+	switch {
+	case slices.Contains(ownerStatuses, domain.AdminStatusSuspended):
+		return domain.AdminStatusSuspended
+	case slices.Contains(ownerStatuses, domain.AdminStatusActive):
+		return domain.AdminStatusActive
+	case len(ownerStatuses) > 0:
+		return ownerStatuses[0]
+	}
+	return ""
+}
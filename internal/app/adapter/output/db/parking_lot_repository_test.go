@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// statementRecorder keeps the SQL that gorm would run.
+type statementRecorder struct {
+	logger.Interface
+	statements []string
+}
+
+func (r *statementRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	sql, _ := fc()
+	r.statements = append(r.statements, sql)
+}
+
+// dryRunDB opens a Postgres gorm.DB that builds statements without connecting to a database.
+func dryRunDB(t *testing.T) (*gorm.DB, *statementRecorder) {
+	recorder := &statementRecorder{Interface: logger.Discard}
+	database, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=test"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               recorder,
+	})
+	assert.NoError(t, err)
+	return database, recorder
+}
+
+func TestListPublicKeysOnTheOwnersOfTheOrganization(t *testing.T) {
+	database, recorder := dryRunDB(t)
+	repository := &ParkingLotRepositoryImpl{DB: database}
+
+	_, err := repository.ListPublic()
+	assert.NoError(t, err)
+
+	assert.NotEmpty(t, recorder.statements)
+	query := recorder.statements[0]
+	assert.Contains(t, query, "owners.organization_id = parking_lots.organization_id AND owners.role = 'owner'")
+	assert.Contains(t, query, "'active' IN (SELECT admins.status")
+	assert.Contains(t, query, "'suspended' NOT IN (SELECT admins.status")
+	assert.NotContains(t, query, "admins.id = parking_lots.admin_id")
+}
+
+func TestGetPublicByIDOnlyFindsTheLotsListedPublicly(t *testing.T) {
+	database, recorder := dryRunDB(t)
+	repository := &ParkingLotRepositoryImpl{DB: database}
+
+	_, _ = repository.GetPublicByID(7)
+
+	assert.NotEmpty(t, recorder.statements)
+	query := recorder.statements[0]
+	assert.Contains(t, query, "id = 7")
+	assert.Contains(t, query, "'active' IN (SELECT admins.status")
+	assert.Contains(t, query, "'suspended' NOT IN (SELECT admins.status")
+}
+
+func TestOperatorStatusKeysOnTheOwnersOfTheOrganization(t *testing.T) {
+	database, recorder := dryRunDB(t)
+	repository := &ParkingLotRepositoryImpl{DB: database}
+
+	status, err := repository.OperatorStatus(5)
+	assert.NoError(t, err)
+	assert.Empty(t, status)
+
+	assert.Len(t, recorder.statements, 1)
+	query := recorder.statements[0]
+	assert.Contains(t, query, `SELECT "admins"."status" FROM "parking_lots"`)
+	assert.Contains(t, query, "JOIN organization_memberships owners ON owners.organization_id = parking_lots.organization_id AND owners.role = 'owner'")
+	assert.Contains(t, query, "JOIN admins ON admins.id = owners.admin_id")
+	assert.Contains(t, query, "parking_lots.id = 5")
+}
+
+func TestOperatorStatusCombinesTheOwners(t *testing.T) {
+	assert.Equal(t, "", operatorStatus(nil))
+	assert.Equal(t, domain.AdminStatusActive, operatorStatus([]string{domain.AdminStatusPending, domain.AdminStatusActive}))
+	assert.Equal(t, domain.AdminStatusSuspended, operatorStatus([]string{domain.AdminStatusActive, domain.AdminStatusSuspended}))
+	assert.Equal(t, domain.AdminStatusPending, operatorStatus([]string{domain.AdminStatusPending}))
+}
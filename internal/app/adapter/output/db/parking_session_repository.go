@@ -0,0 +1,99 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+)
+
+type ParkingSessionRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (r *ParkingSessionRepositoryImpl) Create(session *domain.ParkingSession) error {
+	return r.DB.Create(session).Error
+}
+
+func (r *ParkingSessionRepositoryImpl) GetByID(id uint) (*domain.ParkingSession, error) {
+	var session domain.ParkingSession
+	if err := r.DB.First(&session, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Claim assigns the session to its driver provided that it is still active and anonymous.
+func (r *ParkingSessionRepositoryImpl) Claim(session *domain.ParkingSession) (bool, error) {
+	result := r.DB.Model(&domain.ParkingSession{}).
+		Where("id = ? AND status = ? AND user_id IS NULL", session.ID, domain.ParkingSessionActive).
+		Updates(map[string]interface{}{
+			"user_id":     session.UserID,
+			"vehicle_id":  session.VehicleID,
+			"hourly_rate": session.HourlyRate,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Complete ends the session provided that it is still active.
+func (r *ParkingSessionRepositoryImpl) Complete(session *domain.ParkingSession) (bool, error) {
+	result := r.DB.Model(&domain.ParkingSession{}).
+		Where("id = ? AND status = ?", session.ID, domain.ParkingSessionActive).
+		Updates(map[string]interface{}{
+			"status":           session.Status,
+			"ended_at":         session.EndedAt,
+			"duration_seconds": session.DurationSeconds,
+			"cost":             session.Cost,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// FindActiveBySensor returns the session running on a spot, or nil when the spot is free.
+func (r *ParkingSessionRepositoryImpl) FindActiveBySensor(sensorID uint) (*domain.ParkingSession, error) {
+	return r.findActive("sensor_id = ?", sensorID)
+}
+
+// FindActiveByUser returns the running session of a driver, or nil when they are not parked.
+func (r *ParkingSessionRepositoryImpl) FindActiveByUser(userID uint) (*domain.ParkingSession, error) {
+	return r.findActive("user_id = ?", userID)
+}
+
+func (r *ParkingSessionRepositoryImpl) ListByUser(userID uint, status string) ([]domain.ParkingSession, error) {
+	return r.list("user_id = ?", userID, status)
+}
+
+func (r *ParkingSessionRepositoryImpl) ListByParkingLot(parkingLotID uint, status string) ([]domain.ParkingSession, error) {
+	return r.list("parking_lot_id = ?", parkingLotID, status)
+}
+
+func (r *ParkingSessionRepositoryImpl) findActive(condition string, value uint) (*domain.ParkingSession, error) {
+	var session domain.ParkingSession
+	err := r.DB.Where(condition, value).Where("status = ?", domain.ParkingSessionActive).
+		Order("started_at DESC").First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *ParkingSessionRepositoryImpl) list(condition string, value uint, status string) ([]domain.ParkingSession, error) {
+	query := r.DB.Where(condition, value)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var sessions []domain.ParkingSession
+	if err := query.Order("started_at DESC").Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
@@ -0,0 +1,185 @@
+package db
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+type PaymentRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// Create saves a new payment along with the audit event of its creation.
+func (r *PaymentRepositoryImpl) Create(payment *domain.Payment, event *domain.PaymentEvent) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(payment).Error; err != nil {
+			if isUniqueViolation(err, "idx_payment_idempotency") {
+				return domain.ErrDuplicatePayment
+			}
+			if isUniqueViolation(err, "idx_payment_open_") {
+				return domain.ErrAlreadyPaid
+			}
+			return err
+		}
+		event.PaymentID = payment.ID
+		return tx.Create(event).Error
+	})
+}
+
+func (r *PaymentRepositoryImpl) GetByID(id uint) (*domain.Payment, error) {
+	var payment domain.Payment
+	if err := r.DB.First(&payment, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+func (r *PaymentRepositoryImpl) FindByIdempotencyKey(userID uint, key string) (*domain.Payment, error) {
+	return r.findOne("user_id = ? AND idempotency_key = ?", userID, key)
+}
+
+func (r *PaymentRepositoryImpl) FindByProviderReference(provider, reference string) (*domain.Payment, error) {
+	return r.findOne("provider = ? AND provider_reference = ?", provider, reference)
+}
+
+func (r *PaymentRepositoryImpl) FindOpenByParkingSession(parkingSessionID uint) (*domain.Payment, error) {
+	return r.findOne("parking_session_id = ? AND status <> ?", parkingSessionID, domain.PaymentStatusFailed)
+}
+
+func (r *PaymentRepositoryImpl) FindOpenByReservation(reservationID uint) (*domain.Payment, error) {
+	return r.findOne("reservation_id = ? AND status <> ?", reservationID, domain.PaymentStatusFailed)
+}
+
+func (r *PaymentRepositoryImpl) HasEvent(dedupKey string) (bool, error) {
+	var count int64
+	if err := r.DB.Model(&domain.PaymentEvent{}).Where("dedup_key = ?", dedupKey).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ClaimEvent bumps updated_at of the payment if its status and updated_at are still the ones
+// read, and records the event as pending in the same transaction. The unique dedup_key makes a
+// second claim of the same key fail, which rolls the bump back.
+func (r *PaymentRepositoryImpl) ClaimEvent(payment *domain.Payment, event *domain.PaymentEvent) (bool, error) {
+	updatedAt := time.Now().Truncate(time.Microsecond)
+
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Payment{}).
+			Where("id = ? AND status = ? AND updated_at = ?", payment.ID, payment.Status, payment.UpdatedAt).
+			Update("updated_at", updatedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrPaymentConflict
+		}
+
+		event.PaymentID = payment.ID
+		event.Pending = true
+		return tx.Create(event).Error
+	})
+	if isUniqueViolation(err, "dedup_key") {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	payment.UpdatedAt = updatedAt
+	return true, nil
+}
+
+func (r *PaymentRepositoryImpl) ReleaseEvent(event *domain.PaymentEvent) error {
+	return r.DB.Where("id = ? AND pending", event.ID).Delete(&domain.PaymentEvent{}).Error
+}
+
+// ApplyEvent updates the payment only if its status and updated_at are still the ones read, so
+// that two concurrent changes cannot both succeed, and records the event in the same transaction.
+func (r *PaymentRepositoryImpl) ApplyEvent(payment *domain.Payment, event *domain.PaymentEvent) error {
+	// Postgres keeps microseconds, truncating lets the new updated_at be compared again later.
+	updatedAt := time.Now().Truncate(time.Microsecond)
+
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Payment{}).
+			Where("id = ? AND status = ? AND updated_at = ?", payment.ID, event.FromStatus, payment.UpdatedAt).
+			Updates(map[string]interface{}{
+				"status":          payment.Status,
+				"captured_amount": payment.CapturedAmount,
+				"refunded_amount": payment.RefundedAmount,
+				"captured_at":     payment.CapturedAt,
+				"updated_at":      updatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrPaymentConflict
+		}
+
+		if event.Pending {
+			return tx.Model(&domain.PaymentEvent{}).Where("id = ?", event.ID).Update("pending", false).Error
+		}
+		event.PaymentID = payment.ID
+		return tx.Create(event).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	event.Pending = false
+	payment.UpdatedAt = updatedAt
+	return nil
+}
+
+func (r *PaymentRepositoryImpl) ListByUser(userID uint) ([]domain.Payment, error) {
+	var payments []domain.Payment
+	if err := r.DB.Where("user_id = ?", userID).Order("created_at DESC").Find(&payments).Error; err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+func (r *PaymentRepositoryImpl) ListEvents(paymentID uint) ([]domain.PaymentEvent, error) {
+	var events []domain.PaymentEvent
+	if err := r.DB.Where("payment_id = ?", paymentID).Order("created_at ASC, id ASC").Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *PaymentRepositoryImpl) RevenueByParkingLot(parkingLotID uint, from, to time.Time) (*domain.Revenue, error) {
+	var revenue domain.Revenue
+	err := r.DB.Model(&domain.Payment{}).
+		Select("COUNT(*) AS payments, COALESCE(SUM(captured_amount), 0) AS captured, COALESCE(SUM(refunded_amount), 0) AS refunded").
+		Where("parking_lot_id = ? AND captured_at >= ? AND captured_at < ?", parkingLotID, from, to).
+		Scan(&revenue).Error
+	if err != nil {
+		return nil, err
+	}
+	return &revenue, nil
+}
+
+func (r *PaymentRepositoryImpl) findOne(condition string, args ...interface{}) (*domain.Payment, error) {
+	var payment domain.Payment
+	err := r.DB.Where(condition, args...).First(&payment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// isUniqueViolation tells whether err is Postgres rejecting a row that breaks the unique
+// constraint or index whose name contains constraint.
+func isUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && strings.Contains(pgErr.ConstraintName, constraint)
+}
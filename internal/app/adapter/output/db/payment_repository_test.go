@@ -0,0 +1,24 @@
+package db
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm/schema"
+)
+
+func TestPaymentsHaveOneOpenPaymentPerSessionAndReservation(t *testing.T) {
+	paymentSchema, err := schema.Parse(&domain.Payment{}, &sync.Map{}, schema.NamingStrategy{})
+	assert.NoError(t, err)
+
+	indexes := paymentSchema.ParseIndexes()
+	for _, name := range []string{"idx_payment_open_session", "idx_payment_open_reservation"} {
+		index, ok := indexes[name]
+		if assert.True(t, ok, name) {
+			assert.Equal(t, "UNIQUE", index.Class)
+			assert.Equal(t, "status <> 'failed'", index.Where)
+		}
+	}
+}
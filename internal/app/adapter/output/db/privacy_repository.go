@@ -0,0 +1,90 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+)
+
+type PrivacyRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// AnonymizeUser replaces the username and e-mail of the driver with placeholders, removes the
+// data that only describes them (vehicles, favorites, alerts, sessions of the app) and blanks
+// the free text they wrote. Parking sessions, reservations, payments, crowd reports and review
+// ratings stay, linked to the anonymized user, so occupancy, revenue and rating statistics do
+// not change.
+func (r *PrivacyRepositoryImpl) AnonymizeUser(userID uint, at time.Time) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&domain.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"username":          fmt.Sprintf("erased-user-%d", userID),
+			"email":             fmt.Sprintf("erased-user-%d@erased.invalid", userID),
+			"password_hash":     "",
+			"email_verified_at": nil,
+			"anonymized_at":     at,
+			"deleted_at":        at,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Model(&domain.ParkingSession{}).Where("user_id = ?", userID).Update("vehicle_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&domain.Review{}).Where("user_id = ?", userID).Update("comment", "").Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.ReviewReport{}).Where("user_id = ?", userID).Update("reason", "").Error; err != nil {
+			return err
+		}
+
+		for _, model := range []interface{}{
+			&domain.Vehicle{},
+			&domain.FavoriteParkingLot{},
+			&domain.AvailabilityAlert{},
+			&domain.ReporterReputation{},
+			&domain.RefreshToken{},
+			&domain.UserToken{},
+		} {
+			if err := tx.Unscoped().Where("user_id = ?", userID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// AnonymizeAdmin blanks the NIT, photo and phone of the admin, removes the operator documents
+// they uploaded and unlinks their Auth0 account, also from the actor of the payment events they
+// caused. Their parking lots keep their data.
+func (r *PrivacyRepositoryImpl) AnonymizeAdmin(adminID uint, at time.Time) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var admin domain.Admin
+		if err := tx.First(&admin, adminID).Error; err != nil {
+			return err
+		}
+
+		placeholder := fmt.Sprintf("erased-admin-%d", adminID)
+		if err := tx.Model(&domain.PaymentEvent{}).Where("actor = ?", "admin:"+admin.Auth0UUID).
+			Update("actor", "admin:"+placeholder).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("admin_id = ?", adminID).Delete(&domain.OperatorDocument{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&admin).Updates(map[string]interface{}{
+			"auth0_uuid":    placeholder,
+			"nit":           "",
+			"photo_url":     "",
+			"contact_phone": "",
+			"anonymized_at": at,
+		}).Error
+	})
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// Create stores a new refresh token.
+func (r *RefreshTokenRepositoryImpl) Create(token *domain.RefreshToken) error {
+	return r.DB.Create(token).Error
+}
+
+// FindByHash retrieves a refresh token by its hash. It returns nil when there is none.
+func (r *RefreshTokenRepositoryImpl) FindByHash(tokenHash string) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	if err := r.DB.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Revoke revokes a token that is not revoked yet, and reports whether it did.
+func (r *RefreshTokenRepositoryImpl) Revoke(id uint, at time.Time) (bool, error) {
+	result := r.DB.Model(&domain.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// RevokeFamily revokes every token of a session.
+func (r *RefreshTokenRepositoryImpl) RevokeFamily(familyID string, at time.Time) error {
+	return r.DB.Model(&domain.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", at).Error
+}
+
+// RevokeAllForUser revokes every token of every session of a user.
+func (r *RefreshTokenRepositoryImpl) RevokeAllForUser(userID uint, at time.Time) error {
+	return r.DB.Model(&domain.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", at).Error
+}
+
+// HasActiveInFamily reports whether a session still has a token that is neither revoked nor expired.
+func (r *RefreshTokenRepositoryImpl) HasActiveInFamily(familyID string, at time.Time) (bool, error) {
+	var count int64
+	if err := r.DB.Model(&domain.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL AND expires_at > ?", familyID, at).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,130 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReservationRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// CreateHold locks the parking lot row so that concurrent holds on the same lot are serialized,
+// then checks the lot's reservable spots and the spot before inserting the reservation.
+func (r *ReservationRepositoryImpl) CreateHold(reservation *domain.Reservation, now time.Time) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var parkingLot domain.ParkingLot
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&parkingLot, "id = ?", reservation.ParkingLotID).Error; err != nil {
+			return err
+		}
+
+		var lotHolds int64
+		if err := activeHolds(tx, now).Where("parking_lot_id = ?", reservation.ParkingLotID).
+			Count(&lotHolds).Error; err != nil {
+			return err
+		}
+		if uint(lotHolds) >= parkingLot.ReservableSpots {
+			return domain.ErrNoReservableSpots
+		}
+
+		var spotHolds int64
+		if err := activeHolds(tx, now).Where("sensor_id = ?", reservation.SensorID).
+			Count(&spotHolds).Error; err != nil {
+			return err
+		}
+		if spotHolds > 0 {
+			return domain.ErrSpotAlreadyReserved
+		}
+
+		return tx.Create(reservation).Error
+	})
+}
+
+func (r *ReservationRepositoryImpl) GetByID(id uint) (*domain.Reservation, error) {
+	var reservation domain.Reservation
+	if err := r.DB.First(&reservation, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+func (r *ReservationRepositoryImpl) Update(reservation *domain.Reservation) error {
+	return r.DB.Save(reservation).Error
+}
+
+func (r *ReservationRepositoryImpl) ListByUser(userID uint) ([]domain.Reservation, error) {
+	var reservations []domain.Reservation
+	if err := r.DB.Where("user_id = ?", userID).Order("created_at DESC").Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+func (r *ReservationRepositoryImpl) ListByParkingLot(parkingLotID uint) ([]domain.Reservation, error) {
+	var reservations []domain.Reservation
+	if err := r.DB.Where("parking_lot_id = ?", parkingLotID).Order("created_at DESC").Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+func (r *ReservationRepositoryImpl) ListActiveByParkingLot(parkingLotID uint, now time.Time) ([]domain.Reservation, error) {
+	var reservations []domain.Reservation
+	if err := activeHolds(r.DB, now).Where("parking_lot_id = ?", parkingLotID).Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// FindActiveBySensor returns the hold on a spot, or nil when the spot is not held.
+func (r *ReservationRepositoryImpl) FindActiveBySensor(sensorID uint, now time.Time) (*domain.Reservation, error) {
+	var reservation domain.Reservation
+	err := activeHolds(r.DB, now).Where("sensor_id = ?", sensorID).First(&reservation).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+func (r *ReservationRepositoryImpl) CountActiveGroupedByParkingLot(now time.Time) (map[uint]uint, error) {
+	type Result struct {
+		ParkingLotID uint
+		Held         uint
+	}
+
+	var results []Result
+	if err := activeHolds(r.DB, now).
+		Select("parking_lot_id, COUNT(*) AS held").
+		Group("parking_lot_id").
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+
+	heldMap := make(map[uint]uint)
+	for _, result := range results {
+		heldMap[result.ParkingLotID] = result.Held
+	}
+	return heldMap, nil
+}
+
+// ExpireHolds marks as expired the holds whose window has passed and returns how many there were.
+func (r *ReservationRepositoryImpl) ExpireHolds(now time.Time) (int64, error) {
+	result := r.DB.Model(&domain.Reservation{}).
+		Where("status = ? AND held_until <= ?", domain.ReservationStatusHeld, now).
+		Update("status", domain.ReservationStatusExpired)
+	return result.RowsAffected, result.Error
+}
+
+// activeHolds scopes a query to the reservations still holding their spot at now.
+func activeHolds(tx *gorm.DB, now time.Time) *gorm.DB {
+	return tx.Model(&domain.Reservation{}).
+		Where("status = ? AND held_until > ?", domain.ReservationStatusHeld, now)
+}
@@ -0,0 +1,178 @@
+package db
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReviewRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// ratingRow is an aggregate of the published reviews of a lot.
+type ratingRow struct {
+	ParkingLotID  uint
+	Reviews       uint
+	Safety        float64
+	Cleanliness   float64
+	PriceAccuracy float64
+}
+
+func (r *ReviewRepositoryImpl) Create(review *domain.Review) error {
+	return r.DB.Create(review).Error
+}
+
+func (r *ReviewRepositoryImpl) GetByID(id uint) (*domain.Review, error) {
+	var review domain.Review
+	if err := r.DB.First(&review, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *ReviewRepositoryImpl) Update(review *domain.Review) error {
+	return r.DB.Save(review).Error
+}
+
+// Restore brings back a deleted review with its new content.
+func (r *ReviewRepositoryImpl) Restore(review *domain.Review) error {
+	review.DeletedAt = gorm.DeletedAt{}
+	return r.DB.Unscoped().Save(review).Error
+}
+
+// Delete soft-deletes a review of the driver and drops its comment, which is no longer shown
+// anywhere. Its reports are kept.
+func (r *ReviewRepositoryImpl) Delete(userID uint, reviewID uint) error {
+	result := r.DB.Model(&domain.Review{}).
+		Where("id = ? AND user_id = ?", reviewID, userID).
+		Updates(map[string]interface{}{"comment": "", "deleted_at": time.Now()})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *ReviewRepositoryImpl) FindByUserAndParkingLot(userID uint, parkingLotID uint) (*domain.Review, error) {
+	var review domain.Review
+	err := r.DB.Unscoped().Where("user_id = ? AND parking_lot_id = ?", userID, parkingLotID).First(&review).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *ReviewRepositoryImpl) ListByUser(userID uint) ([]domain.Review, error) {
+	var reviews []domain.Review
+	if err := r.DB.Where("user_id = ?", userID).Order("created_at DESC").Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+func (r *ReviewRepositoryImpl) ListPublishedByParkingLot(parkingLotID uint) ([]domain.Review, error) {
+	var reviews []domain.Review
+	if err := r.DB.Where("parking_lot_id = ? AND status = ?", parkingLotID, domain.ReviewStatusPublished).
+		Order("created_at DESC").Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+func (r *ReviewRepositoryImpl) ListForModeration() ([]domain.Review, error) {
+	var reviews []domain.Review
+	err := r.DB.Where("status = ?", domain.ReviewStatusPending).
+		Or("status = ? AND report_count > 0", domain.ReviewStatusPublished).
+		Order("report_count DESC, created_at ASC").Find(&reviews).Error
+	if err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+func (r *ReviewRepositoryImpl) CountByUserSince(userID uint, since time.Time) (int64, error) {
+	var count int64
+	err := r.DB.Unscoped().Model(&domain.Review{}).Where("user_id = ? AND created_at >= ?", userID, since).Count(&count).Error
+	return count, err
+}
+
+func (r *ReviewRepositoryImpl) CountByParkingLotSince(parkingLotID uint, since time.Time) (int64, error) {
+	var count int64
+	err := r.DB.Unscoped().Model(&domain.Review{}).Where("parking_lot_id = ? AND created_at >= ?", parkingLotID, since).Count(&count).Error
+	return count, err
+}
+
+func (r *ReviewRepositoryImpl) CreateReport(report *domain.ReviewReport) (bool, error) {
+	created := false
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(report)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		created = true
+		return tx.Model(&domain.Review{}).Where("id = ?", report.ReviewID).
+			Update("report_count", gorm.Expr("report_count + 1")).Error
+	})
+	return created, err
+}
+
+func (r *ReviewRepositoryImpl) CountReportsByUserSince(userID uint, since time.Time) (int64, error) {
+	var count int64
+	err := r.DB.Model(&domain.ReviewReport{}).Where("user_id = ? AND created_at >= ?", userID, since).Count(&count).Error
+	return count, err
+}
+
+func (r *ReviewRepositoryImpl) GetRatingSummary(parkingLotID uint) (domain.RatingSummary, error) {
+	rows, err := r.ratingRows(r.DB.Where("parking_lot_id = ?", parkingLotID))
+	if err != nil || len(rows) == 0 {
+		return domain.RatingSummary{}, err
+	}
+	return rows[0].summary(), nil
+}
+
+func (r *ReviewRepositoryImpl) ListRatingSummaries() (map[uint]domain.RatingSummary, error) {
+	rows, err := r.ratingRows(r.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make(map[uint]domain.RatingSummary, len(rows))
+	for _, row := range rows {
+		summaries[row.ParkingLotID] = row.summary()
+	}
+	return summaries, nil
+}
+
+func (r *ReviewRepositoryImpl) ratingRows(query *gorm.DB) ([]ratingRow, error) {
+	var rows []ratingRow
+	err := query.Model(&domain.Review{}).
+		Select("parking_lot_id, COUNT(*) AS reviews, AVG(safety) AS safety, AVG(cleanliness) AS cleanliness, AVG(price_accuracy) AS price_accuracy").
+		Where("status = ?", domain.ReviewStatusPublished).
+		Group("parking_lot_id").
+		Find(&rows).Error
+	return rows, err
+}
+
+func (row ratingRow) summary() domain.RatingSummary {
+	round := func(value float64) float64 { return math.Round(value*100) / 100 }
+	return domain.RatingSummary{
+		Reviews:       row.Reviews,
+		Safety:        round(row.Safety),
+		Cleanliness:   round(row.Cleanliness),
+		PriceAccuracy: round(row.PriceAccuracy),
+		Overall:       round((row.Safety + row.Cleanliness + row.PriceAccuracy) / 3),
+	}
+}
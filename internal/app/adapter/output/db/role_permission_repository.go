@@ -0,0 +1,37 @@
+package db
+
+import (
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+)
+
+type RolePermissionRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// ListAll retrieves every permission granted to a role.
+func (r *RolePermissionRepositoryImpl) ListAll() ([]domain.RolePermission, error) {
+	var rolePermissions []domain.RolePermission
+	if err := r.DB.Order("role ASC, permission ASC").Find(&rolePermissions).Error; err != nil {
+		return nil, err
+	}
+	return rolePermissions, nil
+}
+
+// ReplaceRole replaces the permissions granted to the role in a single transaction.
+func (r *RolePermissionRepositoryImpl) ReplaceRole(role string, permissions []string) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role = ?", role).Delete(&domain.RolePermission{}).Error; err != nil {
+			return err
+		}
+		if len(permissions) == 0 {
+			return nil
+		}
+
+		rolePermissions := make([]domain.RolePermission, 0, len(permissions))
+		for _, permission := range permissions {
+			rolePermissions = append(rolePermissions, domain.RolePermission{Role: role, Permission: permission})
+		}
+		return tx.Create(&rolePermissions).Error
+	})
+}
@@ -0,0 +1,16 @@
+package db
+
+import "gorm.io/gorm"
+
+// coveredByMember restricts a query to the rows whose column holds a parking lot covered by a
+// membership of the admin: every lot of the organization, or only the lots the membership lists.
+// It keeps reads of operator data within the tenant even when the caller checked nothing.
+func coveredByMember(column string, adminID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(column+" IN (SELECT covered.id FROM parking_lots covered"+
+			" JOIN organization_memberships m ON m.organization_id = covered.organization_id AND m.admin_id = ?"+
+			" WHERE covered.deleted_at IS NULL"+
+			" AND (NOT EXISTS (SELECT 1 FROM organization_membership_lots l WHERE l.membership_id = m.id)"+
+			" OR EXISTS (SELECT 1 FROM organization_membership_lots l WHERE l.membership_id = m.id AND l.parking_lot_id = covered.id)))", adminID)
+	}
+}
@@ -0,0 +1,35 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListByParkingLotForMemberKeepsToTheLotsOfTheMember(t *testing.T) {
+	database, recorder := dryRunDB(t)
+	repository := &SensorRepositoryImpl{DB: database}
+
+	sensors, err := repository.ListByParkingLotForMember(3, 7)
+	assert.NoError(t, err)
+	assert.Empty(t, sensors)
+
+	assert.Len(t, recorder.statements, 1)
+	query := recorder.statements[0]
+	assert.Contains(t, query, "parking_lot_id IN (SELECT covered.id FROM parking_lots covered")
+	assert.Contains(t, query, "m.organization_id = covered.organization_id AND m.admin_id = 7")
+	assert.Contains(t, query, "l.parking_lot_id = covered.id")
+	assert.Contains(t, query, "parking_lot_id = 3")
+}
+
+func TestFindByMemberSharesTheMemberScope(t *testing.T) {
+	database, recorder := dryRunDB(t)
+	repository := &ParkingLotRepositoryImpl{DB: database}
+
+	_, err := repository.FindByMember(7)
+	assert.NoError(t, err)
+
+	assert.Len(t, recorder.statements, 1)
+	assert.Contains(t, recorder.statements[0], "parking_lots.id IN (SELECT covered.id FROM parking_lots covered")
+	assert.Contains(t, recorder.statements[0], "m.admin_id = 7")
+}
@@ -0,0 +1,41 @@
+package db
+
+import (
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+)
+
+type SensorEventRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// Create stores a new sensor event.
+func (r *SensorEventRepositoryImpl) Create(event *domain.SensorEvent) error {
+	return r.DB.Create(event).Error
+}
+
+// StreamByParkingLotBetween iterates over the events of a parking lot in (from, to] in
+// chronological order without loading them all in memory.
+func (r *SensorEventRepositoryImpl) StreamByParkingLotBetween(parkingLotID uint, from, to time.Time, fn func(domain.SensorEvent) error) error {
+	rows, err := r.DB.Model(&domain.SensorEvent{}).
+		Where("parking_lot_id = ? AND occurred_at > ? AND occurred_at <= ?", parkingLotID, from, to).
+		Order("occurred_at ASC, id ASC").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event domain.SensorEvent
+		if err := r.DB.ScanRows(rows, &event); err != nil {
+			return err
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
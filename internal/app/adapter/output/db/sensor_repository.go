@@ -1,6 +1,8 @@
 package db
 
 import (
+	"time"
+
 	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
 	"gorm.io/gorm"
 )
@@ -31,6 +33,43 @@ func (r *SensorRepositoryImpl) ListByParkingLot(parkingLotID uint) ([]domain.Sen
 	return sensors, nil
 }
 
+// ListByParkingLotBetween retrieves the sensors of a parking lot created by `to` and not deleted
+// before `from`.
+func (r *SensorRepositoryImpl) ListByParkingLotBetween(parkingLotID uint, from, to time.Time) ([]domain.Sensor, error) {
+	var sensors []domain.Sensor
+	err := r.DB.Unscoped().
+		Where("parking_lot_id = ? AND created_at <= ?", parkingLotID, to).
+		Where("deleted_at IS NULL OR deleted_at > ?", from).
+		Find(&sensors).Error
+	if err != nil {
+		return nil, err
+	}
+	return sensors, nil
+}
+
+// ListByParkingLots retrieves the sensors of every given parking lot in a single query.
+func (r *SensorRepositoryImpl) ListByParkingLots(parkingLotIDs []uint) ([]domain.Sensor, error) {
+	var sensors []domain.Sensor
+	if len(parkingLotIDs) == 0 {
+		return sensors, nil
+	}
+	if err := r.DB.Where("parking_lot_id IN ?", parkingLotIDs).Find(&sensors).Error; err != nil {
+		return nil, err
+	}
+	return sensors, nil
+}
+
+func (r *SensorRepositoryImpl) ListByParkingLotForMember(parkingLotID uint, adminID uint) ([]domain.Sensor, error) {
+	var sensors []domain.Sensor
+	err := r.DB.Scopes(coveredByMember("parking_lot_id", adminID)).
+		Where("parking_lot_id = ?", parkingLotID).
+		Find(&sensors).Error
+	if err != nil {
+		return nil, err
+	}
+	return sensors, nil
+}
+
 func (r *SensorRepositoryImpl) ListGroupedByParkingLot() (map[uint]uint, error) {
 	type Result struct {
 		ParkingLotID    uint
@@ -41,7 +80,7 @@ func (r *SensorRepositoryImpl) ListGroupedByParkingLot() (map[uint]uint, error)
 
 	err := r.DB.Table("sensors").
 		Select("parking_lot_id, COUNT(*) AS available_spaces").
-		Where("status = ?", "free").
+		Where("status = ?", domain.SensorStatusFree).
 		Group("parking_lot_id").
 		Find(&results).Error
 
@@ -57,6 +96,55 @@ func (r *SensorRepositoryImpl) ListGroupedByParkingLot() (map[uint]uint, error)
 	return sensorMap, nil
 }
 
+func (r *SensorRepositoryImpl) CountGroupedByParkingLot() (map[uint]uint, error) {
+	type Result struct {
+		ParkingLotID uint
+		Sensors      uint
+	}
+
+	var results []Result
+	if err := r.DB.Model(&domain.Sensor{}).
+		Select("parking_lot_id, COUNT(*) AS sensors").
+		Group("parking_lot_id").
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+
+	sensorMap := make(map[uint]uint)
+	for _, result := range results {
+		sensorMap[result.ParkingLotID] = result.Sensors
+	}
+
+	return sensorMap, nil
+}
+
+func (r *SensorRepositoryImpl) ListGroupedByParkingLotForSpots(filter domain.SpotFilter) (map[uint]uint, error) {
+	type Result struct {
+		ParkingLotID    uint
+		AvailableSpaces uint
+	}
+
+	var results []Result
+
+	query := r.DB.Table("sensors").
+		Select("parking_lot_id, COUNT(*) AS available_spaces").
+		Where("status = ? AND deleted_at IS NULL", domain.SensorStatusFree).
+		Where("spot_type IN ?", filter.SpotTypes)
+	if !filter.Accessible {
+		query = query.Where("accessible = ?", false)
+	}
+	if err := query.Group("parking_lot_id").Find(&results).Error; err != nil {
+		return nil, err
+	}
+
+	sensorMap := make(map[uint]uint)
+	for _, result := range results {
+		sensorMap[result.ParkingLotID] = result.AvailableSpaces
+	}
+
+	return sensorMap, nil
+}
+
 func (r *SensorRepositoryImpl) ListByEsp32DeviceID(esp32DeviceID uint64) ([]domain.Sensor, error) {
 	var sensors []domain.Sensor
 	if err := r.DB.Where("esp32_device_id = ?", esp32DeviceID).Find(&sensors).Error; err != nil {
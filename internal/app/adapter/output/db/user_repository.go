@@ -1,6 +1,8 @@
 package db
 
 import (
+	"time"
+
 	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
 	"gorm.io/gorm"
 )
@@ -29,10 +31,20 @@ func (r *UserRepositoryImpl) FindByUserName(username string) (*domain.User, erro
 	return &user, nil
 }
 
+func (r *UserRepositoryImpl) FindByEmail(email string) (*domain.User, error) {
+	var user domain.User
+	if err := r.DB.Where("LOWER(email) = LOWER(?)", email).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 func (r *UserRepositoryImpl) Update(user *domain.User) error {
 	return r.DB.Save(user).Error
 }
 
+// Delete anonymizes the user, since a plain soft delete would keep their personal data.
 func (r *UserRepositoryImpl) Delete(id uint) error {
-	return r.DB.Delete(&domain.User{}, id).Error
+	privacyRepository := &PrivacyRepositoryImpl{DB: r.DB}
+	return privacyRepository.AnonymizeUser(id, time.Now())
 }
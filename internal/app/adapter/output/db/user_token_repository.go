@@ -0,0 +1,58 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+)
+
+type UserTokenRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// Create stores a new user token.
+func (r *UserTokenRepositoryImpl) Create(token *domain.UserToken) error {
+	return r.DB.Create(token).Error
+}
+
+// FindByHash retrieves a user token by its hash. It returns nil when there is none.
+func (r *UserTokenRepositoryImpl) FindByHash(tokenHash string) (*domain.UserToken, error) {
+	var token domain.UserToken
+	if err := r.DB.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed redeems a token only if it is still unused, so that two concurrent requests cannot
+// both redeem it.
+func (r *UserTokenRepositoryImpl) MarkUsed(id uint, at time.Time) (bool, error) {
+	result := r.DB.Model(&domain.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// InvalidateForUser marks every unused token of the user for the purpose as used.
+func (r *UserTokenRepositoryImpl) InvalidateForUser(userID uint, purpose string, at time.Time) error {
+	return r.DB.Model(&domain.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", at).Error
+}
+
+// CountCreatedSince counts the tokens issued to the user for the purpose since the given time.
+func (r *UserTokenRepositoryImpl) CountCreatedSince(userID uint, purpose string, since time.Time) (int64, error) {
+	var count int64
+	err := r.DB.Model(&domain.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND created_at >= ?", userID, purpose, since).
+		Count(&count).Error
+	return count, err
+}
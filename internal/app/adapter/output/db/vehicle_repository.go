@@ -0,0 +1,96 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+)
+
+type VehicleRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (r *VehicleRepositoryImpl) Create(vehicle *domain.Vehicle) error {
+	return r.DB.Create(vehicle).Error
+}
+
+// GetByID retrieves a vehicle of the user.
+func (r *VehicleRepositoryImpl) GetByID(userID uint, vehicleID uint) (*domain.Vehicle, error) {
+	var vehicle domain.Vehicle
+	if err := r.DB.Where("id = ? AND user_id = ?", vehicleID, userID).First(&vehicle).Error; err != nil {
+		return nil, err
+	}
+	return &vehicle, nil
+}
+
+func (r *VehicleRepositoryImpl) Update(vehicle *domain.Vehicle) error {
+	return r.DB.Save(vehicle).Error
+}
+
+// Delete removes a vehicle of the user. The plate is freed so that it can be registered again.
+func (r *VehicleRepositoryImpl) Delete(userID uint, vehicleID uint) error {
+	result := r.DB.Unscoped().Where("id = ? AND user_id = ?", vehicleID, userID).Delete(&domain.Vehicle{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListByUser retrieves the vehicles of the user, the default one first.
+func (r *VehicleRepositoryImpl) ListByUser(userID uint) ([]domain.Vehicle, error) {
+	var vehicles []domain.Vehicle
+	if err := r.DB.Where("user_id = ?", userID).Order("is_default DESC, created_at ASC").Find(&vehicles).Error; err != nil {
+		return nil, err
+	}
+	return vehicles, nil
+}
+
+func (r *VehicleRepositoryImpl) FindByUserAndPlate(userID uint, plate string) (*domain.Vehicle, error) {
+	return r.findOne("user_id = ? AND plate = ?", userID, plate)
+}
+
+func (r *VehicleRepositoryImpl) FindDefault(userID uint) (*domain.Vehicle, error) {
+	return r.findOne("user_id = ? AND is_default = ?", userID, true)
+}
+
+func (r *VehicleRepositoryImpl) SetDefault(userID uint, vehicleID uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Vehicle{}).Where("user_id = ? AND id <> ?", userID, vehicleID).
+			Update("is_default", false).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&domain.Vehicle{}).Where("user_id = ? AND id = ?", userID, vehicleID).
+			Update("is_default", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *VehicleRepositoryImpl) ListByPlate(plate string) ([]domain.Vehicle, error) {
+	var vehicles []domain.Vehicle
+	if err := r.DB.Where("plate = ?", plate).Find(&vehicles).Error; err != nil {
+		return nil, err
+	}
+	return vehicles, nil
+}
+
+func (r *VehicleRepositoryImpl) findOne(condition string, args ...interface{}) (*domain.Vehicle, error) {
+	var vehicle domain.Vehicle
+	err := r.DB.Where(condition, args...).First(&vehicle).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &vehicle, nil
+}
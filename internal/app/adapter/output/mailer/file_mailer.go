@@ -0,0 +1,43 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync/atomic"
+	"time"
+)
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// FileMailer writes every e-mail to its own .eml file in Dir, so that links in verification and
+// password reset e-mails can be followed without a mail server.
+type FileMailer struct {
+	Dir     string
+	From    string
+	counter uint64
+	now     func() time.Time
+}
+
+// NewFileMailer creates a new instance of FileMailer writing to dir.
+func NewFileMailer(dir, from string) *FileMailer {
+	return &FileMailer{Dir: dir, From: from, now: time.Now}
+}
+
+func (m *FileMailer) Send(message Message) error {
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return err
+	}
+
+	now := m.now()
+	sequence := atomic.AddUint64(&m.counter, 1)
+	name := fmt.Sprintf("%s-%04d-%s.eml", now.UTC().Format("20060102T150405"), sequence, unsafeFileChars.ReplaceAllString(message.To, "_"))
+	return os.WriteFile(filepath.Join(m.Dir, name), formatMessage(m.From, message, now), 0o600)
+}
+
+// formatMessage renders the message as an RFC 5322 e-mail.
+func formatMessage(from string, message Message, date time.Time) []byte {
+	return []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		from, message.To, message.Subject, date.Format(time.RFC1123Z), message.Body))
+}
@@ -0,0 +1,27 @@
+package mailer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileMailerWritesOneFilePerMessage(t *testing.T) {
+	dir := t.TempDir()
+	m := NewFileMailer(dir, "no-reply@parking-radar.local")
+
+	assert.NoError(t, m.Send(Message{To: "driver@example.com", Subject: "Verify", Body: "https://app/verify?token=abc"}))
+	assert.NoError(t, m.Send(Message{To: "driver@example.com", Subject: "Reset", Body: "https://app/reset?token=def"}))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	content, err := os.ReadFile(files[0])
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "To: driver@example.com\r\n")
+	assert.Contains(t, string(content), "Subject: Verify\r\n")
+	assert.Contains(t, string(content), "https://app/verify?token=abc")
+}
@@ -0,0 +1,56 @@
+package mailer
+
+import (
+	"log"
+	"sync"
+)
+
+// Message is a plain text e-mail addressed to a single recipient.
+type Message struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Mailer is the port to the service that delivers e-mails, such as an SMTP relay.
+type Mailer interface {
+	Send(message Message) error
+}
+
+// LogMailer writes e-mails to the application log. It is meant for local development.
+type LogMailer struct{}
+
+// NewLogMailer creates a new instance of LogMailer.
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(message Message) error {
+	log.Printf("E-mail to %s: %s\n%s", message.To, message.Subject, message.Body)
+	return nil
+}
+
+// MemoryMailer keeps the e-mails it receives so that tests can inspect them.
+type MemoryMailer struct {
+	mutex    sync.Mutex
+	messages []Message
+}
+
+// NewMemoryMailer creates a new instance of MemoryMailer.
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+func (m *MemoryMailer) Send(message Message) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+// Sent returns a copy of the e-mails received so far.
+func (m *MemoryMailer) Sent() []Message {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]Message(nil), m.messages...)
+}
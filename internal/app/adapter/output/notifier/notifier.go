@@ -0,0 +1,59 @@
+package notifier
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Notification is a message addressed to a single driver.
+type Notification struct {
+	UserID uint                   `json:"user_id"`
+	Title  string                 `json:"title"`
+	Body   string                 `json:"body"`
+	Data   map[string]interface{} `json:"data,omitempty"`
+	SentAt time.Time              `json:"sent_at"`
+}
+
+// Notifier delivers notifications to drivers. Push, e-mail or SMS gateways implement it.
+type Notifier interface {
+	Notify(notification Notification) error
+}
+
+// LogNotifier writes notifications to the application log. It is meant for local development.
+type LogNotifier struct{}
+
+// NewLogNotifier creates a new instance of LogNotifier.
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(notification Notification) error {
+	log.Printf("Notification to user %d: %s - %s", notification.UserID, notification.Title, notification.Body)
+	return nil
+}
+
+// MemoryNotifier keeps the notifications it receives so that tests can inspect them.
+type MemoryNotifier struct {
+	mutex         sync.Mutex
+	notifications []Notification
+}
+
+// NewMemoryNotifier creates a new instance of MemoryNotifier.
+func NewMemoryNotifier() *MemoryNotifier {
+	return &MemoryNotifier{}
+}
+
+func (n *MemoryNotifier) Notify(notification Notification) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.notifications = append(n.notifications, notification)
+	return nil
+}
+
+// Sent returns a copy of the notifications received so far.
+func (n *MemoryNotifier) Sent() []Notification {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return append([]Notification(nil), n.notifications...)
+}
@@ -0,0 +1,190 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+const FakeProviderName = "fake"
+
+// FakeProvider is a fully local payment provider for development and tests. Intents are kept in
+// memory and webhooks are signed with HMAC-SHA256 of the payload.
+type FakeProvider struct {
+	mutex   sync.Mutex
+	secret  []byte
+	intents map[string]*fakeIntent
+	// intentsByKey and applied remember the idempotency keys of the intents created and of the
+	// captures and refunds applied.
+	intentsByKey map[string]*Intent
+	applied      map[string]bool
+}
+
+type fakeIntent struct {
+	amount   uint
+	captured uint
+	refunded uint
+	status   string
+}
+
+// NewFakeProvider creates a new instance of FakeProvider signing webhooks with secret.
+func NewFakeProvider(secret []byte) *FakeProvider {
+	return &FakeProvider{
+		secret:       secret,
+		intents:      make(map[string]*fakeIntent),
+		intentsByKey: make(map[string]*Intent),
+		applied:      make(map[string]bool),
+	}
+}
+
+func (p *FakeProvider) Name() string {
+	return FakeProviderName
+}
+
+func (p *FakeProvider) CreateIntent(req IntentRequest) (*Intent, error) {
+	if req.Amount == 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	reference, err := randomHex(12)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	reference = "fake_" + reference
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if intent, ok := p.intentsByKey[req.IdempotencyKey]; ok && req.IdempotencyKey != "" {
+		return intent, nil
+	}
+	p.intents[reference] = &fakeIntent{amount: req.Amount, status: StatusPending}
+
+	intent := &Intent{
+		ProviderReference: reference,
+		Status:            StatusPending,
+		ClientSecret:      reference + "_secret_" + secret,
+		CheckoutURL:       "fake://checkout/" + reference,
+	}
+	if req.IdempotencyKey != "" {
+		p.intentsByKey[req.IdempotencyKey] = intent
+	}
+	return intent, nil
+}
+
+// Authorize simulates the driver completing the payment and returns the signed webhook the
+// provider would send.
+func (p *FakeProvider) Authorize(providerReference string) ([]byte, string, error) {
+	p.mutex.Lock()
+	intent, ok := p.intents[providerReference]
+	if ok {
+		intent.status = StatusAuthorized
+	}
+	p.mutex.Unlock()
+	if !ok {
+		return nil, "", ErrUnknownPayment
+	}
+
+	eventID, err := randomHex(8)
+	if err != nil {
+		return nil, "", err
+	}
+	return p.SignedWebhook(WebhookEvent{
+		ID:                "evt_" + eventID,
+		ProviderReference: providerReference,
+		Status:            StatusAuthorized,
+		Amount:            intent.amount,
+	})
+}
+
+// SignedWebhook encodes an event and signs it like the provider does.
+func (p *FakeProvider) SignedWebhook(event WebhookEvent) ([]byte, string, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, "", err
+	}
+	return payload, p.sign(payload), nil
+}
+
+func (p *FakeProvider) Capture(providerReference string, amount uint, idempotencyKey string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if idempotencyKey != "" && p.applied[idempotencyKey] {
+		return nil
+	}
+	intent, ok := p.intents[providerReference]
+	if !ok {
+		return ErrUnknownPayment
+	}
+	if intent.status != StatusAuthorized {
+		return fmt.Errorf("cannot capture a %s payment", intent.status)
+	}
+	if amount == 0 || amount > intent.amount {
+		return ErrInvalidAmount
+	}
+	intent.captured = amount
+	intent.status = StatusCaptured
+	if idempotencyKey != "" {
+		p.applied[idempotencyKey] = true
+	}
+	return nil
+}
+
+func (p *FakeProvider) Refund(providerReference string, amount uint, idempotencyKey string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if idempotencyKey != "" && p.applied[idempotencyKey] {
+		return nil
+	}
+	intent, ok := p.intents[providerReference]
+	if !ok {
+		return ErrUnknownPayment
+	}
+	if intent.status != StatusCaptured {
+		return fmt.Errorf("cannot refund a %s payment", intent.status)
+	}
+	if amount == 0 || intent.refunded+amount > intent.captured {
+		return ErrInvalidAmount
+	}
+	intent.refunded += amount
+	if idempotencyKey != "" {
+		p.applied[idempotencyKey] = true
+	}
+	return nil
+}
+
+func (p *FakeProvider) ParseWebhook(payload []byte, signature string) (*WebhookEvent, error) {
+	expected := p.sign(payload)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (p *FakeProvider) sign(payload []byte) string {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
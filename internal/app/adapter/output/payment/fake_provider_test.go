@@ -0,0 +1,57 @@
+package payment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeProviderLifecycle(t *testing.T) {
+	provider := NewFakeProvider([]byte("secret"))
+
+	intent, err := provider.CreateIntent(IntentRequest{Reference: "pay-1", Amount: 4500, Currency: "COP"})
+	assert.NoError(t, err)
+	assert.Equal(t, StatusPending, intent.Status)
+
+	assert.Error(t, provider.Capture(intent.ProviderReference, 4500, ""), "pending payments cannot be captured")
+
+	payload, signature, err := provider.Authorize(intent.ProviderReference)
+	assert.NoError(t, err)
+	event, err := provider.ParseWebhook(payload, signature)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusAuthorized, event.Status)
+	assert.Equal(t, intent.ProviderReference, event.ProviderReference)
+
+	assert.NoError(t, provider.Capture(intent.ProviderReference, 4500, "capture:1"))
+	assert.NoError(t, provider.Refund(intent.ProviderReference, 1500, "refund:1"))
+	assert.ErrorIs(t, provider.Refund(intent.ProviderReference, 3001, "refund:2"), ErrInvalidAmount)
+}
+
+func TestFakeProviderAppliesIdempotencyKeysOnce(t *testing.T) {
+	provider := NewFakeProvider([]byte("secret"))
+
+	intent, err := provider.CreateIntent(IntentRequest{Reference: "pay-1", IdempotencyKey: "key-1", Amount: 4500, Currency: "COP"})
+	assert.NoError(t, err)
+	retried, err := provider.CreateIntent(IntentRequest{Reference: "pay-2", IdempotencyKey: "key-1", Amount: 4500, Currency: "COP"})
+	assert.NoError(t, err)
+	assert.Equal(t, intent.ProviderReference, retried.ProviderReference)
+
+	_, _, err = provider.Authorize(intent.ProviderReference)
+	assert.NoError(t, err)
+	assert.NoError(t, provider.Capture(intent.ProviderReference, 4500, "capture:1"))
+	assert.NoError(t, provider.Capture(intent.ProviderReference, 4500, "capture:1"))
+
+	assert.NoError(t, provider.Refund(intent.ProviderReference, 3000, "refund:1"))
+	assert.NoError(t, provider.Refund(intent.ProviderReference, 3000, "refund:1"), "a retry is not refunded twice")
+	assert.ErrorIs(t, provider.Refund(intent.ProviderReference, 3000, "refund:2"), ErrInvalidAmount)
+}
+
+func TestFakeProviderRejectsTamperedWebhook(t *testing.T) {
+	provider := NewFakeProvider([]byte("secret"))
+
+	payload, signature, err := provider.SignedWebhook(WebhookEvent{ID: "evt_1", ProviderReference: "fake_1", Status: StatusAuthorized})
+	assert.NoError(t, err)
+
+	_, err = provider.ParseWebhook(append(payload, ' '), signature)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
@@ -0,0 +1,61 @@
+package payment
+
+import "errors"
+
+// Statuses reported by providers for a payment.
+const (
+	StatusPending    = "pending"
+	StatusAuthorized = "authorized"
+	StatusCaptured   = "captured"
+	StatusFailed     = "failed"
+	StatusRefunded   = "refunded"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrUnknownPayment   = errors.New("unknown payment reference")
+	ErrInvalidAmount    = errors.New("invalid payment amount")
+)
+
+// IntentRequest asks a provider to prepare the collection of an amount from a driver.
+type IntentRequest struct {
+	// Reference is our own identifier of the payment, sent back in webhooks.
+	Reference string
+	// IdempotencyKey makes the provider return the same intent when the request is retried.
+	IdempotencyKey string
+	Amount         uint
+	Currency       string
+	Description    string
+}
+
+// Intent is the provider side of a payment. ClientSecret or CheckoutURL is handed to the app
+// so that the driver completes the payment with the provider.
+type Intent struct {
+	ProviderReference string
+	Status            string
+	ClientSecret      string
+	CheckoutURL       string
+}
+
+// WebhookEvent is a status change notified by a provider.
+type WebhookEvent struct {
+	ID                string `json:"id"`
+	ProviderReference string `json:"provider_reference"`
+	Status            string `json:"status"`
+	Amount            uint   `json:"amount"`
+}
+
+// PaymentProvider is the port to a payment gateway such as Wompi or PayU. Amounts are in the
+// smallest unit of the currency. Calls carrying an idempotency key already seen by the provider
+// are not applied again.
+type PaymentProvider interface {
+	// Name identifies the provider in stored payments and webhook routes.
+	Name() string
+	CreateIntent(req IntentRequest) (*Intent, error)
+	// Capture collects an authorized payment.
+	Capture(providerReference string, amount uint, idempotencyKey string) error
+	// Refund returns part or all of a captured payment.
+	Refund(providerReference string, amount uint, idempotencyKey string) error
+	// ParseWebhook verifies the signature of a webhook and decodes its event.
+	ParseWebhook(payload []byte, signature string) (*WebhookEvent, error)
+}
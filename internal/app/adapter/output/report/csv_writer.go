@@ -0,0 +1,39 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// CSVWriter writes every sheet into a single CSV stream. The header is written once,
+// so sheets are expected to share the same columns.
+type CSVWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+// NewCSVWriter creates a CSVWriter on top of w.
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{writer: csv.NewWriter(w)}
+}
+
+func (w *CSVWriter) StartSheet(_ string, headers []string) error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+	return w.writer.Write(headers)
+}
+
+func (w *CSVWriter) WriteRow(cells ...interface{}) error {
+	record := make([]string, len(cells))
+	for i, cell := range cells {
+		record[i] = formatCell(cell)
+	}
+	return w.writer.Write(record)
+}
+
+func (w *CSVWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
@@ -0,0 +1,65 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// Writer streams tabular reports sheet by sheet. Rows are written as soon as they are
+// received so that large exports never need to be held in memory.
+type Writer interface {
+	// StartSheet begins a new sheet with the given header row.
+	StartSheet(name string, headers []string) error
+	// WriteRow appends a row to the current sheet.
+	WriteRow(cells ...interface{}) error
+	// Close flushes any pending data and finishes the document.
+	Close() error
+}
+
+// NewWriter returns a Writer for the requested format.
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w), nil
+	case FormatXLSX:
+		return NewXLSXWriter(w), nil
+	default:
+		return nil, fmt.Errorf("unsupported report format: %s", format)
+	}
+}
+
+// ContentType returns the MIME type of the given format.
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// formatCell renders a cell value as text, using RFC3339 for timestamps.
+func formatCell(cell interface{}) string {
+	switch v := cell.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,55 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVWriterWritesHeaderOnce(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf)
+
+	assert.NoError(t, w.StartSheet("Lot A", []string{"lot", "free"}))
+	assert.NoError(t, w.WriteRow("Lot A", 3))
+	assert.NoError(t, w.StartSheet("Lot B", []string{"lot", "free"}))
+	assert.NoError(t, w.WriteRow("Lot B", 1.5))
+	assert.NoError(t, w.Close())
+
+	assert.Equal(t, "lot,free\nLot A,3\nLot B,1.5\n", buf.String())
+}
+
+func TestXLSXWriterProducesWorkbook(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewXLSXWriter(&buf)
+
+	recordedAt := time.Date(2024, time.May, 2, 8, 30, 0, 0, time.FixedZone("COT", -5*60*60))
+	assert.NoError(t, w.StartSheet("Lot <A>", []string{"recorded_at", "free"}))
+	assert.NoError(t, w.WriteRow(recordedAt, 3))
+	assert.NoError(t, w.StartSheet("Lot <A>", []string{"recorded_at", "free"}))
+	assert.NoError(t, w.Close())
+
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+
+	files := map[string]string{}
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		assert.NoError(t, err)
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(content)
+	}
+
+	assert.Contains(t, files, "[Content_Types].xml")
+	assert.Contains(t, files, "xl/worksheets/sheet2.xml")
+	assert.Contains(t, files["xl/workbook.xml"], `name="Lot &lt;A&gt;"`)
+	assert.Contains(t, files["xl/workbook.xml"], `name="Lot &lt;A&gt; (2)"`)
+	assert.True(t, strings.Contains(files["xl/worksheets/sheet1.xml"], "2024-05-02T08:30:00-05:00"))
+	assert.True(t, strings.Contains(files["xl/worksheets/sheet1.xml"], "<c><v>3</v></c>"))
+}
@@ -0,0 +1,192 @@
+package report
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const maxSheetNameLength = 31
+
+// XLSXWriter streams an Office Open XML workbook. Each sheet is written as its own zip
+// entry while rows arrive; the workbook index is written on Close.
+type XLSXWriter struct {
+	zip        *zip.Writer
+	sheet      io.Writer
+	sheetNames []string
+	rowIndex   int
+	closed     bool
+}
+
+// NewXLSXWriter creates an XLSXWriter on top of w.
+func NewXLSXWriter(w io.Writer) *XLSXWriter {
+	return &XLSXWriter{zip: zip.NewWriter(w)}
+}
+
+func (w *XLSXWriter) StartSheet(name string, headers []string) error {
+	if err := w.finishSheet(); err != nil {
+		return err
+	}
+
+	w.sheetNames = append(w.sheetNames, w.uniqueSheetName(name))
+	sheet, err := w.zip.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", len(w.sheetNames)))
+	if err != nil {
+		return err
+	}
+	w.sheet = sheet
+	w.rowIndex = 0
+
+	if _, err := io.WriteString(w.sheet, xml.Header+
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`); err != nil {
+		return err
+	}
+
+	cells := make([]interface{}, len(headers))
+	for i, header := range headers {
+		cells[i] = header
+	}
+	return w.WriteRow(cells...)
+}
+
+func (w *XLSXWriter) WriteRow(cells ...interface{}) error {
+	if w.sheet == nil {
+		return errors.New("xlsx: no sheet started")
+	}
+
+	w.rowIndex++
+	var b strings.Builder
+	fmt.Fprintf(&b, `<row r="%d">`, w.rowIndex)
+	for _, cell := range cells {
+		switch v := cell.(type) {
+		case int, int64, uint, uint64, float64:
+			fmt.Fprintf(&b, `<c><v>%s</v></c>`, formatCell(v))
+		default:
+			b.WriteString(`<c t="inlineStr"><is><t>`)
+			if err := xml.EscapeText(&b, []byte(formatCell(v))); err != nil {
+				return err
+			}
+			b.WriteString(`</t></is></c>`)
+		}
+	}
+	b.WriteString(`</row>`)
+
+	_, err := io.WriteString(w.sheet, b.String())
+	return err
+}
+
+func (w *XLSXWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	if len(w.sheetNames) == 0 {
+		if err := w.StartSheet("Report", nil); err != nil {
+			return err
+		}
+	}
+	if err := w.finishSheet(); err != nil {
+		return err
+	}
+
+	var contentTypes, workbook, workbookRels strings.Builder
+	contentTypes.WriteString(xml.Header +
+		`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	workbook.WriteString(xml.Header +
+		`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	workbookRels.WriteString(xml.Header +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+
+	for i, name := range w.sheetNames {
+		n := i + 1
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		workbook.WriteString(`<sheet name="`)
+		if err := xml.EscapeText(&workbook, []byte(name)); err != nil {
+			return err
+		}
+		fmt.Fprintf(&workbook, `" sheetId="%d" r:id="rId%d"/>`, n, n)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n)
+	}
+
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	workbookRels.WriteString(`</Relationships>`)
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypes.String()},
+		{"_rels/.rels", xml.Header +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", workbook.String()},
+		{"xl/_rels/workbook.xml.rels", workbookRels.String()},
+	}
+	for _, file := range files {
+		f, err := w.zip.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, file.content); err != nil {
+			return err
+		}
+	}
+
+	return w.zip.Close()
+}
+
+// finishSheet closes the XML of the sheet being written, if any.
+func (w *XLSXWriter) finishSheet() error {
+	if w.sheet == nil {
+		return nil
+	}
+	_, err := io.WriteString(w.sheet, `</sheetData></worksheet>`)
+	w.sheet = nil
+	return err
+}
+
+// uniqueSheetName sanitizes a sheet name to Excel's rules and de-duplicates it.
+func (w *XLSXWriter) uniqueSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if name == "" {
+		name = "Sheet"
+	}
+
+	candidate := truncateRunes(name, maxSheetNameLength)
+	for i := 2; w.hasSheet(candidate); i++ {
+		suffix := fmt.Sprintf(" (%d)", i)
+		candidate = truncateRunes(name, maxSheetNameLength-len(suffix)) + suffix
+	}
+	return candidate
+}
+
+func (w *XLSXWriter) hasSheet(name string) bool {
+	for _, existing := range w.sheetNames {
+		if strings.EqualFold(existing, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func truncateRunes(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+var (
+	ErrInvalidKey = errors.New("invalid document key")
+
+	unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+)
+
+// DocumentStore is the port to where uploaded documents are kept. Save returns the key that
+// opens the document later; Delete succeeds when the document is already gone.
+type DocumentStore interface {
+	Save(name string, content io.Reader) (string, error)
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+// LocalStore keeps every document in its own file in Dir, named after a random prefix and the
+// name it was uploaded with.
+type LocalStore struct {
+	Dir string
+}
+
+// NewLocalStore creates a new instance of LocalStore writing to dir.
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{Dir: dir}
+}
+
+func (s *LocalStore) Save(name string, content io.Reader) (string, error) {
+	if err := os.MkdirAll(s.Dir, 0o750); err != nil {
+		return "", err
+	}
+
+	prefix := make([]byte, 16)
+	if _, err := rand.Read(prefix); err != nil {
+		return "", err
+	}
+	key := hex.EncodeToString(prefix) + "-" + unsafeFileChars.ReplaceAllString(filepath.Base(name), "_")
+
+	file, err := os.OpenFile(filepath.Join(s.Dir, key), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(file, content); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return "", err
+	}
+	return key, file.Close()
+}
+
+// Open opens the document saved under the key; keys never leave Dir.
+func (s *LocalStore) Open(key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+// Delete removes the document saved under the key, if it is still there.
+func (s *LocalStore) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	if key == "" || key != filepath.Base(key) || key == "." || key == ".." {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.Dir, key), nil
+}
@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStoreSavesAndOpensDocuments(t *testing.T) {
+	store := NewLocalStore(t.TempDir())
+
+	key, err := store.Save("../RUT 2024.pdf", strings.NewReader("%PDF-1.4"))
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(key, "-RUT_2024.pdf"), key)
+
+	file, err := store.Open(key)
+	assert.NoError(t, err)
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "%PDF-1.4", string(content))
+
+	_, err = store.Open("../" + key)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestLocalStoreDeletesDocuments(t *testing.T) {
+	store := NewLocalStore(t.TempDir())
+
+	key, err := store.Save("camara.pdf", strings.NewReader("%PDF-1.4"))
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.Delete(key))
+	_, err = store.Open(key)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	assert.NoError(t, store.Delete(key))
+	assert.ErrorIs(t, store.Delete("../"+key), ErrInvalidKey)
+}
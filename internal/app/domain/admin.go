@@ -2,15 +2,40 @@ package domain
 
 import "time"
 
+// Statuses of an operator. Newly registered operators wait for a global admin to approve them;
+// only the lots of active operators are listed publicly.
+const (
+	AdminStatusPending   = "pending"
+	AdminStatusActive    = "active"
+	AdminStatusSuspended = "suspended"
+)
+
+// Verification statuses of an operator. Operators upload their documents and submit them for
+// review; a global admin then verifies or rejects them, recording the reason on the admin. Only
+// verified operators publish lots.
+const (
+	VerificationPendingDocuments = "pending_documents"
+	VerificationUnderReview      = "under_review"
+	VerificationVerified         = "verified"
+	VerificationRejected         = "rejected"
+)
+
 type Admin struct {
-	ID           uint         `gorm:"primaryKey" json:"id"`
-	Auth0UUID    string       `gorm:"uniqueIndex" json:"auth0_uuid"`
-	NIT          string       `gorm:"not null" json:"nit"`
-	PhotoURL     string       `json:"photo_url"`
-	ContactPhone string       `json:"contact_phone"`
-	CreatedAt    time.Time    `json:"created_at"`
-	UpdatedAt    time.Time    `json:"updated_at"`
-	ParkingLots  []ParkingLot `gorm:"foreignKey:AdminID" json:"parking_lots"`
+	ID                 uint         `gorm:"primaryKey" json:"id"`
+	Auth0UUID          string       `gorm:"uniqueIndex" json:"auth0_uuid"`
+	NIT                string       `gorm:"not null" json:"nit"`
+	PhotoURL           string       `json:"photo_url"`
+	ContactPhone       string       `json:"contact_phone"`
+	Status             string       `gorm:"type:varchar(20);not null;default:active;index" json:"status"`
+	StatusReason       string       `json:"status_reason,omitempty"`
+	StatusChangedAt    *time.Time   `json:"status_changed_at,omitempty"`
+	VerificationStatus string       `gorm:"type:varchar(20);not null;default:pending_documents;index" json:"verification_status"`
+	VerificationReason string       `json:"verification_reason,omitempty"`
+	VerifiedAt         *time.Time   `json:"verified_at,omitempty"`
+	AnonymizedAt       *time.Time   `json:"anonymized_at,omitempty"`
+	CreatedAt          time.Time    `json:"created_at"`
+	UpdatedAt          time.Time    `json:"updated_at"`
+	ParkingLots        []ParkingLot `gorm:"foreignKey:AdminID" json:"parking_lots"`
 }
 
 type AdminProfileData struct {
@@ -18,3 +43,62 @@ type AdminProfileData struct {
 	PhotoURL     string `json:"photo_url"`
 	ContactPhone string `json:"contact_phone"`
 }
+
+// AdminStatusEvent records who changed the status of an operator and why.
+type AdminStatusEvent struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	AdminID    uint      `gorm:"not null;index" json:"admin_id"`
+	FromStatus string    `gorm:"not null" json:"from_status"`
+	ToStatus   string    `gorm:"not null" json:"to_status"`
+	Reason     string    `gorm:"not null" json:"reason"`
+	Actor      string    `gorm:"not null" json:"actor"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// AdminFilter narrows the admin search. Query matches part of the Auth0 UUID or the NIT.
+type AdminFilter struct {
+	Query  string
+	Status string
+	Offset int
+	Limit  int
+}
+
+// Kinds of documents operators upload to be verified. The RUT is required.
+const (
+	DocumentKindRUT               = "rut"
+	DocumentKindChamberOfCommerce = "chamber_of_commerce"
+	DocumentKindIdentity          = "identity"
+)
+
+// ValidDocumentKind reports whether operators may upload documents of the kind.
+func ValidDocumentKind(kind string) bool {
+	switch kind {
+	case DocumentKindRUT, DocumentKindChamberOfCommerce, DocumentKindIdentity:
+		return true
+	}
+	return false
+}
+
+// OperatorDocument is a document an operator uploaded to be verified. StorageKey locates its
+// content in the document store.
+type OperatorDocument struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	AdminID     uint      `gorm:"not null;index" json:"admin_id"`
+	Kind        string    `gorm:"type:varchar(30);not null" json:"kind"`
+	FileName    string    `gorm:"not null" json:"file_name"`
+	ContentType string    `gorm:"not null" json:"content_type"`
+	Size        int64     `gorm:"not null" json:"size"`
+	StorageKey  string    `gorm:"not null" json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// AdminVerificationEvent records who moved an operator through the verification and why.
+type AdminVerificationEvent struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	AdminID    uint      `gorm:"not null;index" json:"admin_id"`
+	FromStatus string    `gorm:"not null" json:"from_status"`
+	ToStatus   string    `gorm:"not null" json:"to_status"`
+	Reason     string    `json:"reason,omitempty"`
+	Actor      string    `gorm:"not null" json:"actor"`
+	CreatedAt  time.Time `json:"created_at"`
+}
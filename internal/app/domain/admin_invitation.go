@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// Actions recorded in the audit trail of an invitation.
+const (
+	InvitationActionCreated  = "created"
+	InvitationActionAccepted = "accepted"
+	InvitationActionRevoked  = "revoked"
+)
+
+// AdminInvitation invites the holder of an e-mail address to become an admin. Invitations to an
+// organization give the role in it once accepted; the others, only sent by global admins, register
+// an operator of their own. Only the SHA-256 hash of the e-mailed token is stored. AcceptedBy is
+// the Auth0 subject the admin record was bound to.
+type AdminInvitation struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	Email          string     `gorm:"not null;index" json:"email"`
+	OrganizationID *uint      `gorm:"index" json:"organization_id,omitempty"`
+	Role           string     `gorm:"type:varchar(20)" json:"role,omitempty"`
+	TokenHash      string     `gorm:"uniqueIndex;not null" json:"-"`
+	InvitedBy      string     `gorm:"not null" json:"invited_by"`
+	ExpiresAt      time.Time  `gorm:"not null" json:"expires_at"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty"`
+	AcceptedBy     string     `json:"accepted_by,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// Pending reports whether the invitation can still be accepted at the time.
+func (i AdminInvitation) Pending(at time.Time) bool {
+	return i.AcceptedAt == nil && i.RevokedAt == nil && at.Before(i.ExpiresAt)
+}
+
+// AdminInvitationEvent is the audit trail of an invitation: who created, accepted or revoked it.
+type AdminInvitationEvent struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	InvitationID uint      `gorm:"not null;index" json:"invitation_id"`
+	Action       string    `gorm:"not null" json:"action"`
+	Actor        string    `gorm:"not null" json:"actor"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Scopes granted to API keys. Webhooks can be granted ahead of the webhook subscriptions it
+// will allow; no route accepts it yet.
+const (
+	APIScopeAvailabilityRead = "availability:read"
+	APIScopeHistoryRead      = "history:read"
+	APIScopeWebhooks         = "webhooks"
+)
+
+// APIScopes lists every scope that can be granted to an API key.
+var APIScopes = []string{APIScopeAvailabilityRead, APIScopeHistoryRead, APIScopeWebhooks}
+
+// APIKey lets a third-party integrator, such as a navigation app, read our data without an admin
+// account. Only the SHA-256 hash of the key is stored; Prefix identifies it in listings. Scopes
+// are stored comma-separated. A DailyQuota of zero means no quota.
+type APIKey struct {
+	ID                 uint       `gorm:"primaryKey" json:"id"`
+	Name               string     `gorm:"not null" json:"name"`
+	Prefix             string     `gorm:"not null;index" json:"prefix"`
+	KeyHash            string     `gorm:"uniqueIndex;not null" json:"-"`
+	Scopes             string     `gorm:"not null" json:"-"`
+	RateLimitPerMinute uint       `gorm:"not null" json:"rate_limit_per_minute"`
+	DailyQuota         uint       `gorm:"not null;default:0" json:"daily_quota"`
+	CreatedBy          string     `gorm:"not null" json:"created_by"`
+	ExpiresAt          *time.Time `json:"expires_at,omitempty"`
+	RevokedAt          *time.Time `json:"revoked_at,omitempty"`
+	LastUsedAt         *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// ScopeList returns the scopes granted to the key.
+func (k *APIKey) ScopeList() []string {
+	if k.Scopes == "" {
+		return nil
+	}
+	return strings.Split(k.Scopes, ",")
+}
+
+// HasScope reports whether the key was granted the scope.
+func (k *APIKey) HasScope(scope string) bool {
+	for _, granted := range k.ScopeList() {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeyUsage counts the requests made with an API key on a day, in UTC.
+type APIKeyUsage struct {
+	ID       uint      `gorm:"primaryKey" json:"-"`
+	APIKeyID uint      `gorm:"not null;uniqueIndex:idx_api_key_usage_day" json:"api_key_id"`
+	Day      time.Time `gorm:"type:date;not null;uniqueIndex:idx_api_key_usage_day" json:"day"`
+	Requests uint      `gorm:"not null;default:0" json:"requests"`
+	Rejected uint      `gorm:"not null;default:0" json:"rejected"`
+}
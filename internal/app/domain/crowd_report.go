@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+const (
+	CrowdLevelFull   = "full"
+	CrowdLevelFew    = "few"
+	CrowdLevelPlenty = "plenty"
+)
+
+// CrowdReport is a driver's quick estimate of how full a parking lot without sensors is.
+// Weight is the reporter's reputation when the report was made.
+type CrowdReport struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	UserID       uint      `gorm:"not null;index" json:"user_id"`
+	ParkingLotID uint      `gorm:"not null;index:idx_crowd_report_lot_time" json:"parking_lot_id"`
+	Level        string    `gorm:"not null" json:"level"`
+	Weight       float64   `gorm:"not null" json:"weight"`
+	ReportedAt   time.Time `gorm:"not null;index:idx_crowd_report_lot_time" json:"reported_at"`
+}
+
+// ReporterReputation tracks how often a driver's crowd reports agree with those of other
+// drivers about the same lot at about the same time.
+type ReporterReputation struct {
+	UserID        uint      `gorm:"primaryKey" json:"user_id"`
+	Agreements    uint      `gorm:"not null;default:0" json:"agreements"`
+	Disagreements uint      `gorm:"not null;default:0" json:"disagreements"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Score is the share of agreements, smoothed so that new reporters start at 0.5.
+func (r ReporterReputation) Score() float64 {
+	return (float64(r.Agreements) + 1) / (float64(r.Agreements+r.Disagreements) + 2)
+}
@@ -4,6 +4,8 @@ import "time"
 
 type Esp32Device struct {
 	ID                uint64    `gorm:"primaryKey" json:"id"`
+	ParkingLotID      uint      `gorm:"not null;default:0;index" json:"parking_lot_id"`
 	DeviceIdentifier  string    `json:"device_identifier"`
+	TokenHash         string    `gorm:"index" json:"-"` // SHA-256 of the token the device reports with
 	LastCommunication time.Time `json:"last_communication"`
 }
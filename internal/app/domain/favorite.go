@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// FavoriteParkingLot is a parking lot saved by a driver.
+type FavoriteParkingLot struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	UserID       uint       `gorm:"not null;uniqueIndex:idx_favorite_user_lot" json:"user_id"`
+	ParkingLotID uint       `gorm:"not null;uniqueIndex:idx_favorite_user_lot" json:"parking_lot_id"`
+	ParkingLot   ParkingLot `gorm:"foreignKey:ParkingLotID" json:"-"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// AvailabilityAlert asks to notify a driver when a parking lot has at least MinFreeSpaces free
+// spaces during a daily time window, expressed in minutes since midnight in Bogota time.
+type AvailabilityAlert struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	UserID         uint       `gorm:"not null;index" json:"user_id"`
+	ParkingLotID   uint       `gorm:"not null;index" json:"parking_lot_id"`
+	MinFreeSpaces  uint       `gorm:"not null" json:"min_free_spaces"`
+	WindowStart    int        `gorm:"not null" json:"window_start"`
+	WindowEnd      int        `gorm:"not null" json:"window_end"`
+	Active         bool       `gorm:"not null;default:true" json:"active"`
+	LastNotifiedAt *time.Time `json:"last_notified_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
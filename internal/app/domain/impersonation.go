@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// ImpersonationSession lets a global admin, the actor, act as an admin until the session ends
+// or expires. Reason is why support staff needed to see what the admin sees.
+type ImpersonationSession struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	ActorSubject string     `gorm:"not null;index" json:"actor_subject"`
+	AdminID      uint       `gorm:"not null;index" json:"admin_id"`
+	AdminSubject string     `gorm:"not null" json:"admin_subject"`
+	Reason       string     `gorm:"not null" json:"reason"`
+	ExpiresAt    time.Time  `gorm:"not null" json:"expires_at"`
+	EndedAt      *time.Time `json:"ended_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// Active reports whether requests of the actor run as the admin at the time.
+func (s ImpersonationSession) Active(at time.Time) bool {
+	return s.EndedAt == nil && at.Before(s.ExpiresAt)
+}
+
+// ImpersonationAuditEntry records a mutating request a global admin made while impersonating an
+// admin. Route is the route pattern and Path the requested path. The entry is written before the
+// request is handled; Status stays 0 until the response is known.
+type ImpersonationAuditEntry struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	SessionID    uint      `gorm:"not null;index" json:"session_id"`
+	ActorSubject string    `gorm:"not null;index" json:"actor_subject"`
+	AdminSubject string    `gorm:"not null" json:"admin_subject"`
+	Method       string    `gorm:"type:varchar(10);not null" json:"method"`
+	Route        string    `json:"route"`
+	Path         string    `gorm:"not null" json:"path"`
+	Status       int       `gorm:"not null" json:"status"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+// nitWeights are the factors DIAN applies to the digits of a NIT, from the rightmost one, to
+// compute its check digit.
+var nitWeights = []int{3, 7, 13, 17, 19, 23, 29, 37, 41, 43, 47, 53, 59, 67, 71}
+
+// NormalizeNIT validates a Colombian NIT along with its check digit (dígito de verificación)
+// and returns it as digits, a dash and the check digit, e.g. 800197268-4. Dots and spaces are
+// ignored; without a dash the last digit is taken as the check digit.
+func NormalizeNIT(value string) (string, bool) {
+	value = strings.NewReplacer(".", "", " ", "", ",", "").Replace(strings.TrimSpace(value))
+	number, checkDigit, found := strings.Cut(value, "-")
+	if !found {
+		if len(value) < 2 {
+			return "", false
+		}
+		number, checkDigit = value[:len(value)-1], value[len(value)-1:]
+	}
+	if len(number) == 0 || len(number) > len(nitWeights) || !isDigits(number) || len(checkDigit) != 1 || !isDigits(checkDigit) {
+		return "", false
+	}
+	if strconv.Itoa(NITCheckDigit(number)) != checkDigit {
+		return "", false
+	}
+	return number + "-" + checkDigit, true
+}
+
+// NITCheckDigit computes the check digit of the digits of a NIT.
+func NITCheckDigit(number string) int {
+	sum := 0
+	for i := 0; i < len(number); i++ {
+		sum += int(number[len(number)-1-i]-'0') * nitWeights[i]
+	}
+	remainder := sum % 11
+	if remainder > 1 {
+		return 11 - remainder
+	}
+	return remainder
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
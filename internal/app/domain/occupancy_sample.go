@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// OccupancySample is a point-in-time reading of a parking lot's free and total spaces.
+type OccupancySample struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	ParkingLotID uint      `gorm:"not null;index:idx_occupancy_lot_recorded" json:"parking_lot_id"`
+	FreeSpaces   uint      `gorm:"not null" json:"free_spaces"`
+	TotalSpaces  uint      `gorm:"not null" json:"total_spaces"`
+	RecordedAt   time.Time `gorm:"not null;index:idx_occupancy_lot_recorded" json:"recorded_at"`
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Roles an admin can hold in an organization.
+const (
+	MembershipRoleOwner      = "owner"
+	MembershipRoleManager    = "manager"
+	MembershipRoleAttendant  = "attendant"
+	MembershipRoleTechnician = "technician"
+)
+
+// MembershipRolePermissions is what each organization role may do on the parking lots it covers.
+// Only owners manage the organization and its members.
+var MembershipRolePermissions = map[string][]string{
+	MembershipRoleOwner: {
+		PermissionLotRead, PermissionLotWrite, PermissionSensorWrite, PermissionDeviceManage, PermissionReportRead,
+		PermissionPaymentRefund, PermissionReviewReply,
+	},
+	MembershipRoleManager: {
+		PermissionLotRead, PermissionLotWrite, PermissionSensorWrite, PermissionDeviceManage, PermissionReportRead,
+		PermissionPaymentRefund, PermissionReviewReply,
+	},
+	MembershipRoleAttendant:  {PermissionLotRead},
+	MembershipRoleTechnician: {PermissionLotRead, PermissionSensorWrite, PermissionDeviceManage},
+}
+
+// Organization is a parking operator. It owns parking lots and admins act on them through their
+// membership.
+type Organization struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	Name      string         `gorm:"not null" json:"name"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// OrganizationMembership gives an admin a role in an organization. A membership listing Lots only
+// covers those parking lots; without them it covers every lot of the organization.
+type OrganizationMembership struct {
+	ID             uint                        `gorm:"primaryKey" json:"id"`
+	OrganizationID uint                        `gorm:"not null;uniqueIndex:idx_organization_admin" json:"organization_id"`
+	AdminID        uint                        `gorm:"not null;uniqueIndex:idx_organization_admin;index" json:"admin_id"`
+	Role           string                      `gorm:"type:varchar(20);not null" json:"role"`
+	Lots           []OrganizationMembershipLot `gorm:"foreignKey:MembershipID;constraint:OnDelete:CASCADE" json:"lots,omitempty"`
+	CreatedAt      time.Time                   `json:"created_at"`
+	UpdatedAt      time.Time                   `json:"updated_at"`
+}
+
+// OrganizationMembershipLot restricts a membership to one parking lot of the organization.
+type OrganizationMembershipLot struct {
+	MembershipID uint `gorm:"primaryKey" json:"-"`
+	ParkingLotID uint `gorm:"primaryKey" json:"parking_lot_id"`
+}
+
+// ValidMembershipRole reports whether role is one of the organization roles.
+func ValidMembershipRole(role string) bool {
+	_, ok := MembershipRolePermissions[role]
+	return ok
+}
+
+// CoversLot reports whether the membership applies to the parking lot of its organization.
+func (m OrganizationMembership) CoversLot(parkingLotID uint) bool {
+	if len(m.Lots) == 0 {
+		return true
+	}
+	for _, lot := range m.Lots {
+		if lot.ParkingLotID == parkingLotID {
+			return true
+		}
+	}
+	return false
+}
+
+// Can reports whether the role of the membership grants the permission.
+func (m OrganizationMembership) Can(permission string) bool {
+	return slices.Contains(MembershipRolePermissions[m.Role], permission)
+}
+
+// DefaultOrganizationName names the organization created for an admin operating on their own.
+func DefaultOrganizationName(admin Admin) string {
+	if admin.NIT != "" {
+		return "NIT " + admin.NIT
+	}
+	return fmt.Sprintf("Operator %d", admin.ID)
+}
@@ -6,17 +6,49 @@ import (
 	"gorm.io/gorm"
 )
 
+// ParkingLot is a parking lot owned by an organization; AdminID is the admin who registered it.
+// ReservableSpots is how many of its spots drivers may hold at the same time. HourlyRate is its
+// tariff in Colombian pesos, charged per started billing fraction; motorcycles pay
+// MotorcycleHourlyRate when it is set. Capacity is the declared number of spots, used to
+// estimate the availability of lots without sensors.
+// OpensAt and ClosesAt are its daily opening hours as HH:MM in Bogotá time; lots without them
+// are open around the clock.
 type ParkingLot struct {
-	ID           uint           `gorm:"primaryKey" json:"id"`
-	Name         string         `gorm:"not null" json:"name"`
-	Address      string         `gorm:"type:varchar(40);not null" json:"address"`
-	Latitude     float64        `gorm:"not null;uniqueIndex:idx_lat_long" json:"latitude"`
-	Longitude    float64        `gorm:"not null;uniqueIndex:idx_lat_long" json:"longitude"`
-	ContactName  string         `gorm:"type:varchar(40);not null" json:"contact_name"`
-	ContactPhone string         `gorm:"type:varchar(40);not null" json:"contact_phone"`
-	AdminID      uint           `gorm:"not null" json:"admin_id"`
-	Admin        Admin          `gorm:"foreignKey:AdminID"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	ID                     uint           `gorm:"primaryKey" json:"id"`
+	Name                   string         `gorm:"not null" json:"name"`
+	Address                string         `gorm:"type:varchar(40);not null" json:"address"`
+	Latitude               float64        `gorm:"not null;uniqueIndex:idx_lat_long" json:"latitude"`
+	Longitude              float64        `gorm:"not null;uniqueIndex:idx_lat_long" json:"longitude"`
+	ContactName            string         `gorm:"type:varchar(40);not null" json:"contact_name"`
+	ContactPhone           string         `gorm:"type:varchar(40);not null" json:"contact_phone"`
+	AdminID                uint           `gorm:"not null" json:"admin_id"`
+	OrganizationID         uint           `gorm:"not null;default:0;index" json:"organization_id"`
+	Capacity               uint           `gorm:"not null;default:0" json:"capacity"`
+	ReservableSpots        uint           `gorm:"not null;default:0" json:"reservable_spots"`
+	HourlyRate             uint           `gorm:"not null;default:0" json:"hourly_rate"`
+	MotorcycleHourlyRate   uint           `gorm:"not null;default:0" json:"motorcycle_hourly_rate"`
+	BillingFractionMinutes uint           `gorm:"not null;default:1" json:"billing_fraction_minutes"`
+	OpensAt                string         `gorm:"type:varchar(5)" json:"opens_at,omitempty"`
+	ClosesAt               string         `gorm:"type:varchar(5)" json:"closes_at,omitempty"`
+	Admin                  Admin          `gorm:"foreignKey:AdminID"`
+	CreatedAt              time.Time      `json:"created_at"`
+	UpdatedAt              time.Time      `json:"updated_at"`
+	DeletedAt              gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// HourlyRateFor returns the tariff the lot charges to the vehicle type.
+func (p ParkingLot) HourlyRateFor(vehicleType string) uint {
+	if vehicleType == VehicleTypeMotorcycle && p.MotorcycleHourlyRate > 0 {
+		return p.MotorcycleHourlyRate
+	}
+	return p.HourlyRate
+}
+
+// ParkingLotFilter narrows the parking lot search. Query matches part of the name or address.
+type ParkingLotFilter struct {
+	Query          string
+	AdminID        uint
+	OrganizationID uint
+	Offset         int
+	Limit          int
 }
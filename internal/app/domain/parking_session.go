@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	ParkingSessionActive    = "active"
+	ParkingSessionCompleted = "completed"
+
+	ParkingSessionSourceSensor = "sensor"
+	ParkingSessionSourceQR     = "qr"
+	ParkingSessionSourceApp    = "app"
+)
+
+var ErrSpotInSession = errors.New("spot is in use by another driver")
+
+// ParkingSession is a stay in a parking lot. Sessions started by a sensor have no driver until
+// one checks in on the spot. The lot's tariff for the driver's default vehicle is copied when
+// the session starts or is claimed, and the cost is computed when it stops.
+type ParkingSession struct {
+	ID                     uint       `gorm:"primaryKey" json:"id"`
+	UserID                 *uint      `gorm:"index" json:"user_id,omitempty"`
+	ParkingLotID           uint       `gorm:"not null;index" json:"parking_lot_id"`
+	SensorID               *uint      `gorm:"index" json:"sensor_id,omitempty"`
+	VehicleID              *uint      `gorm:"index" json:"vehicle_id,omitempty"`
+	Source                 string     `gorm:"not null" json:"source"`
+	Status                 string     `gorm:"not null;index" json:"status"`
+	HourlyRate             uint       `gorm:"not null" json:"hourly_rate"`
+	BillingFractionMinutes uint       `gorm:"not null" json:"billing_fraction_minutes"`
+	StartedAt              time.Time  `gorm:"not null" json:"started_at"`
+	EndedAt                *time.Time `json:"ended_at,omitempty"`
+	DurationSeconds        int64      `json:"duration_seconds"`
+	Cost                   uint       `json:"cost"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              time.Time  `json:"updated_at"`
+}
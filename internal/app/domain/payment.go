@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	PaymentStatusPending           = "pending"
+	PaymentStatusAuthorized        = "authorized"
+	PaymentStatusCaptured          = "captured"
+	PaymentStatusPartiallyRefunded = "partially_refunded"
+	PaymentStatusRefunded          = "refunded"
+	PaymentStatusFailed            = "failed"
+
+	PaymentEventSourceAPI     = "api"
+	PaymentEventSourceWebhook = "webhook"
+)
+
+var (
+	ErrPaymentConflict  = errors.New("payment was modified concurrently")
+	ErrDuplicatePayment = errors.New("a payment with this idempotency key already exists")
+	ErrAlreadyPaid      = errors.New("a payment already exists for this item")
+)
+
+// Payment collects the cost of a parking session or a reservation through a payment provider.
+// Amounts are in Colombian pesos. A session or reservation has at most one payment that has not
+// failed.
+type Payment struct {
+	ID                uint       `gorm:"primaryKey" json:"id"`
+	UserID            uint       `gorm:"not null;index;uniqueIndex:idx_payment_idempotency" json:"user_id"`
+	IdempotencyKey    *string    `gorm:"uniqueIndex:idx_payment_idempotency" json:"-"`
+	ParkingLotID      uint       `gorm:"not null;index" json:"parking_lot_id"`
+	ParkingSessionID  *uint      `gorm:"uniqueIndex:idx_payment_open_session,where:status <> 'failed'" json:"parking_session_id,omitempty"`
+	ReservationID     *uint      `gorm:"uniqueIndex:idx_payment_open_reservation,where:status <> 'failed'" json:"reservation_id,omitempty"`
+	Amount            uint       `gorm:"not null" json:"amount"`
+	Currency          string     `gorm:"not null" json:"currency"`
+	Provider          string     `gorm:"not null" json:"provider"`
+	ProviderReference string     `gorm:"uniqueIndex;not null" json:"provider_reference"`
+	ClientSecret      string     `json:"client_secret,omitempty"`
+	CheckoutURL       string     `json:"checkout_url,omitempty"`
+	Status            string     `gorm:"not null;index" json:"status"`
+	CapturedAmount    uint       `gorm:"not null;default:0" json:"captured_amount"`
+	RefundedAmount    uint       `gorm:"not null;default:0" json:"refunded_amount"`
+	CapturedAt        *time.Time `gorm:"index" json:"captured_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// PaymentEvent is the audit trail of a payment: one row per state change or refund. DedupKey
+// identifies provider webhooks and client retries so that each is applied only once. Pending
+// events are claimed before calling the provider and completed once the payment is saved.
+type PaymentEvent struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	PaymentID  uint      `gorm:"not null;index" json:"payment_id"`
+	FromStatus string    `gorm:"not null" json:"from_status"`
+	ToStatus   string    `gorm:"not null" json:"to_status"`
+	Amount     uint      `gorm:"not null;default:0" json:"amount"`
+	Source     string    `gorm:"not null" json:"source"`
+	Actor      string    `json:"actor"`
+	DedupKey   *string   `gorm:"uniqueIndex" json:"-"`
+	Pending    bool      `gorm:"not null;default:false" json:"pending,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Revenue sums the payments of a parking lot captured in a period.
+type Revenue struct {
+	Payments uint `json:"payments"`
+	Captured uint `json:"captured"`
+	Refunded uint `json:"refunded"`
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// RefreshToken is a rotating refresh token issued to a driver. Only the SHA-256 hash of the
+// token is stored. Every token obtained by rotation shares the FamilyID of the original login,
+// which also identifies the session of the access tokens issued with it.
+type RefreshToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	TokenHash string     `gorm:"uniqueIndex;not null" json:"-"`
+	FamilyID  string     `gorm:"not null;index" json:"family_id"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	ReservationStatusHeld      = "held"
+	ReservationStatusConfirmed = "confirmed"
+	ReservationStatusExpired   = "expired"
+	ReservationStatusCancelled = "cancelled"
+)
+
+var (
+	ErrNoReservableSpots   = errors.New("no reservable spots left in this parking lot")
+	ErrSpotAlreadyReserved = errors.New("spot is already reserved")
+)
+
+// Reservation holds a spot of a parking lot for a driver until HeldUntil. A held spot is excluded
+// from the available spaces of its lot; the reservation is confirmed when the spot's sensor
+// reports it occupied and expires when the driver does not arrive in time.
+type Reservation struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	UserID       uint       `gorm:"not null;index" json:"user_id"`
+	ParkingLotID uint       `gorm:"not null;index:idx_reservation_lot_status" json:"parking_lot_id"`
+	SensorID     uint       `gorm:"not null;index" json:"sensor_id"`
+	Status       string     `gorm:"not null;index:idx_reservation_lot_status" json:"status"`
+	HeldUntil    time.Time  `gorm:"not null" json:"held_until"`
+	ConfirmedAt  *time.Time `json:"confirmed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	ReviewStatusPublished = "published"
+	ReviewStatusPending   = "pending"
+	ReviewStatusHidden    = "hidden"
+)
+
+// Review is the rating a driver gives a parking lot on safety, cleanliness and price accuracy,
+// each from 1 to 5. A driver has one review per lot. Pending reviews wait for a global admin
+// because they were reported too often or arrived during a burst on the lot; only published
+// reviews are shown and count toward the lot's rating. Deleted reviews are kept so that they still
+// count toward the limits on writing reviews.
+type Review struct {
+	ID            uint           `gorm:"primaryKey" json:"id"`
+	UserID        uint           `gorm:"not null;uniqueIndex:idx_review_user_lot" json:"user_id"`
+	ParkingLotID  uint           `gorm:"not null;index;uniqueIndex:idx_review_user_lot" json:"parking_lot_id"`
+	Safety        uint           `gorm:"not null" json:"safety"`
+	Cleanliness   uint           `gorm:"not null" json:"cleanliness"`
+	PriceAccuracy uint           `gorm:"not null" json:"price_accuracy"`
+	Comment       string         `gorm:"type:varchar(1000)" json:"comment"`
+	Status        string         `gorm:"not null;index" json:"status"`
+	ReportCount   uint           `gorm:"not null;default:0" json:"report_count"`
+	Reply         string         `gorm:"type:varchar(1000)" json:"reply,omitempty"`
+	ReplyAdminID  *uint          `json:"reply_admin_id,omitempty"`
+	RepliedAt     *time.Time     `json:"replied_at,omitempty"`
+	ModeratedAt   *time.Time     `json:"moderated_at,omitempty"`
+	CreatedAt     time.Time      `gorm:"index" json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// ReviewReport is a driver flagging a review as abusive. A driver reports a review once.
+type ReviewReport struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	ReviewID  uint      `gorm:"not null;uniqueIndex:idx_review_report_user" json:"review_id"`
+	UserID    uint      `gorm:"not null;index;uniqueIndex:idx_review_report_user" json:"user_id"`
+	Reason    string    `gorm:"type:varchar(1000)" json:"reason"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+// RatingSummary averages the published reviews of a parking lot.
+type RatingSummary struct {
+	Reviews       uint    `json:"reviews"`
+	Safety        float64 `json:"safety"`
+	Cleanliness   float64 `json:"cleanliness"`
+	PriceAccuracy float64 `json:"price_accuracy"`
+	Overall       float64 `json:"overall"`
+}
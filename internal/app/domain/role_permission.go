@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+// Permissions granted to admin roles.
+const (
+	PermissionLotRead        = "lot:read"
+	PermissionLotWrite       = "lot:write"
+	PermissionLotAll         = "lot:all" // any parking lot, regardless of who owns it
+	PermissionSensorWrite    = "sensor:write"
+	PermissionDeviceManage   = "device:manage"
+	PermissionReportRead     = "report:read"
+	PermissionPaymentRefund  = "payment:refund"
+	PermissionReviewReply    = "review:reply"
+	PermissionReviewModerate = "review:moderate"
+	PermissionProfileManage  = "profile:manage"
+	PermissionAdminManage    = "admin:manage"
+)
+
+// Permissions lists every permission that can be granted to a role.
+var Permissions = []string{
+	PermissionLotRead, PermissionLotWrite, PermissionLotAll, PermissionSensorWrite, PermissionDeviceManage,
+	PermissionReportRead, PermissionPaymentRefund, PermissionReviewReply, PermissionReviewModerate,
+	PermissionProfileManage, PermissionAdminManage,
+}
+
+// Admin roles issued by the identity provider.
+const (
+	RoleAdminDefault = "admin_default"
+	RoleAdminLocal   = "admin_local"
+	RoleAdminGlobal  = "admin_global"
+)
+
+// DefaultRolePermissions is the role to permission mapping the migrations store when none exists yet.
+var DefaultRolePermissions = map[string][]string{
+	RoleAdminLocal: {
+		PermissionLotRead, PermissionLotWrite, PermissionSensorWrite, PermissionDeviceManage, PermissionReportRead,
+		PermissionPaymentRefund, PermissionReviewReply, PermissionProfileManage,
+	},
+	RoleAdminGlobal: Permissions,
+}
+
+// RolePermission grants a permission to every admin holding the role.
+type RolePermission struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	Role       string    `gorm:"not null;uniqueIndex:idx_role_permission" json:"role"`
+	Permission string    `gorm:"not null;uniqueIndex:idx_role_permission" json:"permission"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -6,6 +6,17 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	SensorStatusFree     = "free"
+	SensorStatusOccupied = "occupied"
+	SensorStatusFault    = "fault"
+
+	SpotTypeCar        = "car"
+	SpotTypeMotorcycle = "motorcycle"
+	SpotTypeEV         = "ev"
+	SpotTypeVan        = "van"
+)
+
 type Sensor struct {
 	ID               uint           `gorm:"primaryKey" json:"id"`
 	Esp32DeviceID    uint           `gorm:"not null" json:"esp32_device_id"`
@@ -15,7 +26,18 @@ type Sensor struct {
 	Status           string         `gorm:"not null" json:"status"`
 	SensorNumber     int            `json:"sensor_number"`
 	DeviceIdentifier string         `json:"device_identifier"`
+	SpotType         string         `gorm:"not null;default:car" json:"spot_type"`
+	Accessible       bool           `gorm:"not null;default:false" json:"accessible"`
 	CreatedAt        time.Time      `json:"created_at"`
 	UpdatedAt        time.Time      `json:"updated_at"`
 	DeletedAt        gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
+
+// ValidSpotType reports whether the spot type is supported.
+func ValidSpotType(spotType string) bool {
+	switch spotType {
+	case SpotTypeCar, SpotTypeMotorcycle, SpotTypeEV, SpotTypeVan:
+		return true
+	}
+	return false
+}
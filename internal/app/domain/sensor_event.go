@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// SensorEvent records a status change reported by a sensor.
+type SensorEvent struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	SensorID       uint      `gorm:"not null;index" json:"sensor_id"`
+	ParkingLotID   uint      `gorm:"not null;index:idx_sensor_event_lot_occurred" json:"parking_lot_id"`
+	PreviousStatus string    `json:"previous_status"`
+	Status         string    `gorm:"not null" json:"status"`
+	OccurredAt     time.Time `gorm:"not null;index:idx_sensor_event_lot_occurred" json:"occurred_at"`
+}
+
+// LotSnapshot stores the status of every sensor of a parking lot at a given instant, so that
+// the state at any time can be rebuilt by replaying the events recorded after it.
+type LotSnapshot struct {
+	ID           uint          `gorm:"primaryKey" json:"id"`
+	ParkingLotID uint          `gorm:"not null;index:idx_lot_snapshot_lot_taken" json:"parking_lot_id"`
+	TakenAt      time.Time     `gorm:"not null;index:idx_lot_snapshot_lot_taken" json:"taken_at"`
+	Sensors      []SensorState `gorm:"serializer:json" json:"sensors"`
+}
+
+// SensorState is the status of a single sensor inside a LotSnapshot.
+type SensorState struct {
+	SensorID uint      `json:"sensor_id"`
+	Status   string    `json:"status"`
+	Since    time.Time `json:"since"`
+}
@@ -7,11 +7,18 @@ import (
 )
 
 type User struct {
-	ID           uint           `gorm:"primaryKey" json:"id"`
-	Username     string         `gorm:"unique;not null" json:"username"`
-	PasswordHash string         `gorm:"not null" json:"-"`
-	Email        string         `gorm:"unique;not null" json:"email"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	ID              uint           `gorm:"primaryKey" json:"id"`
+	Username        string         `gorm:"unique;not null" json:"username"`
+	PasswordHash    string         `gorm:"not null" json:"-"`
+	Email           string         `gorm:"unique;not null" json:"email"`
+	EmailVerifiedAt *time.Time     `json:"email_verified_at,omitempty"`
+	AnonymizedAt    *time.Time     `json:"anonymized_at,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// EmailVerified reports whether the driver confirmed they own their e-mail address.
+func (u User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
 }
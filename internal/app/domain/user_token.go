@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// Purposes of the single-use tokens e-mailed to drivers.
+const (
+	UserTokenEmailVerification = "email_verification"
+	UserTokenPasswordReset     = "password_reset"
+)
+
+// UserToken is a single-use token e-mailed to a driver to verify their address or reset their
+// password. Only the SHA-256 hash of the token is stored; UsedAt is set once it is redeemed or
+// superseded by a newer token. Email is the address the token was sent to, so that it only
+// vouches for that address.
+type UserToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index:idx_user_token_user_purpose" json:"user_id"`
+	Purpose   string     `gorm:"not null;index:idx_user_token_user_purpose" json:"purpose"`
+	TokenHash string     `gorm:"uniqueIndex;not null" json:"-"`
+	Email     string     `gorm:"not null;default:''" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
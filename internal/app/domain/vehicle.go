@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	VehicleTypeCar        = "car"
+	VehicleTypeMotorcycle = "motorcycle"
+	VehicleTypeEV         = "ev"
+	VehicleTypeVan        = "van"
+)
+
+var (
+	// carPlatePattern is the Colombian plate of cars, vans and electric vehicles, e.g. ABC123.
+	carPlatePattern = regexp.MustCompile(`^[A-Z]{3}[0-9]{3}$`)
+	// motorcyclePlatePattern is the Colombian plate of motorcycles, e.g. ABC12D.
+	motorcyclePlatePattern = regexp.MustCompile(`^[A-Z]{3}[0-9]{2}[A-Z]$`)
+
+	// vehicleSpotTypes lists the spot types each vehicle type fits in.
+	vehicleSpotTypes = map[string][]string{
+		VehicleTypeCar:        {SpotTypeCar},
+		VehicleTypeMotorcycle: {SpotTypeMotorcycle},
+		VehicleTypeEV:         {SpotTypeEV, SpotTypeCar},
+		VehicleTypeVan:        {SpotTypeVan},
+	}
+)
+
+// Vehicle is a vehicle registered by a driver. Plate is stored normalized, so that plates read
+// by license-plate-recognition cameras can be looked up directly. The default vehicle of the
+// driver selects the spots and tariff used in searches, quotes and sessions.
+type Vehicle struct {
+	ID                  uint           `gorm:"primaryKey" json:"id"`
+	UserID              uint           `gorm:"not null;uniqueIndex:idx_vehicle_user_plate" json:"user_id"`
+	Plate               string         `gorm:"type:varchar(10);not null;index;uniqueIndex:idx_vehicle_user_plate" json:"plate"`
+	Type                string         `gorm:"not null" json:"type"`
+	AccessibilityPermit bool           `gorm:"not null;default:false" json:"accessibility_permit"`
+	IsDefault           bool           `gorm:"not null;default:false" json:"is_default"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// SpotFilter restricts availability to the spots a vehicle can use. Accessible spots are only
+// counted for vehicles with an accessibility permit.
+type SpotFilter struct {
+	SpotTypes  []string
+	Accessible bool
+}
+
+// SpotFilter returns the spots the vehicle can park in.
+func (v Vehicle) SpotFilter() SpotFilter {
+	return SpotFilter{SpotTypes: vehicleSpotTypes[v.Type], Accessible: v.AccessibilityPermit}
+}
+
+// Matches reports whether the sensor's spot can be used under the filter.
+func (f SpotFilter) Matches(sensor Sensor) bool {
+	if sensor.Accessible && !f.Accessible {
+		return false
+	}
+	for _, spotType := range f.SpotTypes {
+		if sensor.SpotType == spotType {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidVehicleType reports whether the vehicle type is supported.
+func ValidVehicleType(vehicleType string) bool {
+	_, ok := vehicleSpotTypes[vehicleType]
+	return ok
+}
+
+// NormalizePlate uppercases a plate and strips the spaces, dashes and dots used when writing it.
+func NormalizePlate(plate string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.':
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(plate)))
+}
+
+// ValidPlate reports whether a normalized plate has the Colombian format of the vehicle type.
+func ValidPlate(plate, vehicleType string) bool {
+	if vehicleType == VehicleTypeMotorcycle {
+		return motorcyclePlatePattern.MatchString(plate)
+	}
+	return carPlatePattern.MatchString(plate)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+
+//go:generate mockgen -source=./admin_invitation_repository.go -destination=./../../test/shared/mockgen/mock_admin_invitation_repository.go -package=mockgen
+type IAdminInvitationRepository interface {
+	Create(invitation *domain.AdminInvitation, event *domain.AdminInvitationEvent) error
+	// FindByID and FindByHash return nil when there is no such invitation.
+	FindByID(id uint) (*domain.AdminInvitation, error)
+	FindByHash(tokenHash string) (*domain.AdminInvitation, error)
+	// List returns every invitation, or those of the organization when organizationID is set.
+	List(organizationID *uint) ([]domain.AdminInvitation, error)
+	// Accept and Revoke record the event and report true, provided that the invitation was
+	// neither accepted nor revoked yet. Accept also creates the admin when it has no ID yet and
+	// the membership when there is one, in the same transaction.
+	Accept(invitation *domain.AdminInvitation, event *domain.AdminInvitationEvent, admin *domain.Admin, membership *domain.OrganizationMembership) (bool, error)
+	Revoke(invitation *domain.AdminInvitation, event *domain.AdminInvitationEvent) (bool, error)
+	// Delete removes an invitation that could not be sent, along with its events.
+	Delete(invitation *domain.AdminInvitation) error
+	ListEvents(invitationID uint) ([]domain.AdminInvitationEvent, error)
+}
@@ -8,4 +8,15 @@ type IAdminRepository interface {
 	ExistsByAuth0UUID(auth0UUID string) (bool, error)
 	FindByAuth0UUID(auth0UUID string) (*domain.Admin, error)
 	Update(admin *domain.Admin) error
+	List() ([]domain.Admin, error)
+	FindByID(id uint) (*domain.Admin, error)
+	// Search returns a page of the admins matching the filter and how many match in total.
+	Search(filter domain.AdminFilter) ([]domain.Admin, int64, error)
+	// ChangeStatus saves the status of the admin along with the event recording the change.
+	ChangeStatus(admin *domain.Admin, event *domain.AdminStatusEvent) error
+	ListStatusEvents(adminID uint) ([]domain.AdminStatusEvent, error)
+	// ChangeVerification saves the verification status of the admin along with the event
+	// recording the change.
+	ChangeVerification(admin *domain.Admin, event *domain.AdminVerificationEvent) error
+	ListVerificationEvents(adminID uint) ([]domain.AdminVerificationEvent, error)
 }
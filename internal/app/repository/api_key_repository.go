@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+)
+
+//go:generate mockgen -source=./api_key_repository.go -destination=./../../test/shared/mockgen/mock_api_key_repository.go -package=mockgen
+type IAPIKeyRepository interface {
+	Create(key *domain.APIKey) error
+	FindByID(id uint) (*domain.APIKey, error)
+	FindByHash(keyHash string) (*domain.APIKey, error)
+	List() ([]domain.APIKey, error)
+	Revoke(id uint, at time.Time) error
+	TouchLastUsed(id uint, at time.Time) error
+	GetUsage(keyID uint, day time.Time) (*domain.APIKeyUsage, error)
+	IncrementUsage(keyID uint, day time.Time, rejected bool) error
+	ListUsageSince(keyID uint, since time.Time) ([]domain.APIKeyUsage, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+
+//go:generate mockgen -source=./availability_alert_repository.go -destination=./../../test/shared/mockgen/mock_availability_alert_repository.go -package=mockgen
+type IAvailabilityAlertRepository interface {
+	Create(alert *domain.AvailabilityAlert) error
+	Update(alert *domain.AvailabilityAlert) error
+	Delete(userID uint, alertID uint) error
+	ListByUser(userID uint) ([]domain.AvailabilityAlert, error)
+	ListActiveByParkingLot(parkingLotID uint) ([]domain.AvailabilityAlert, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+)
+
+//go:generate mockgen -source=./crowd_report_repository.go -destination=./../../test/shared/mockgen/mock_crowd_report_repository.go -package=mockgen
+type ICrowdReportRepository interface {
+	Create(report *domain.CrowdReport) error
+	// FindLatestByUser returns the latest report of the driver for the lot, or nil.
+	FindLatestByUser(userID uint, parkingLotID uint) (*domain.CrowdReport, error)
+	ListByUser(userID uint) ([]domain.CrowdReport, error)
+	ListByParkingLotSince(parkingLotID uint, since time.Time) ([]domain.CrowdReport, error)
+	// ListGroupedByParkingLotSince returns the reports made since the given time by lot.
+	ListGroupedByParkingLotSince(since time.Time) (map[uint][]domain.CrowdReport, error)
+	// GetReputation returns the reputation of the driver, or nil when they never reported.
+	GetReputation(userID uint) (*domain.ReporterReputation, error)
+	AdjustReputation(userID uint, agreements uint, disagreements uint) error
+}
@@ -2,12 +2,16 @@ package repository
 
 import "github.com/CamiloLeonP/parking-radar/internal/app/domain"
 
+//go:generate mockgen -source=./esp32_device_repository.go -destination=./../../test/shared/mockgen/mock_esp32_device_repository.go -package=mockgen
 type IEsp32DeviceRepository interface {
 	Create(device *domain.Esp32Device) error
 	GetByID(id uint64) (*domain.Esp32Device, error)
 	GetByDeviceIdentifier(identifier string) (*domain.Esp32Device, error)
+	GetByTokenHash(hash string) (*domain.Esp32Device, error)
 	ListByDeviceIdentifier(identifier string) ([]domain.Esp32Device, error)
 	ListAll() ([]domain.Esp32Device, error)
+	// ListForMember lists the devices of the parking lots covered by a membership of the admin.
+	ListForMember(adminID uint) ([]domain.Esp32Device, error)
 	Update(device *domain.Esp32Device) error
 	Delete(id uint64) error
 }
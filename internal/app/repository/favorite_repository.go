@@ -0,0 +1,10 @@
+package repository
+
+import "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+
+//go:generate mockgen -source=./favorite_repository.go -destination=./../../test/shared/mockgen/mock_favorite_repository.go -package=mockgen
+type IFavoriteRepository interface {
+	Create(favorite *domain.FavoriteParkingLot) error
+	Delete(userID uint, parkingLotID uint) error
+	ListByUser(userID uint) ([]domain.FavoriteParkingLot, error)
+}
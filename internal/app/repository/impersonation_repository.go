@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+)
+
+//go:generate mockgen -source=./impersonation_repository.go -destination=./../../test/shared/mockgen/mock_impersonation_repository.go -package=mockgen
+type IImpersonationRepository interface {
+	Create(session *domain.ImpersonationSession) error
+	// FindActive returns the session of the actor active at the time, or nil when there is none.
+	FindActive(actorSubject string, at time.Time) (*domain.ImpersonationSession, error)
+	// EndActive ends the sessions of the actor that are still open and reports whether there
+	// was any.
+	EndActive(actorSubject string, at time.Time) (bool, error)
+	List() ([]domain.ImpersonationSession, error)
+	CreateAuditEntry(entry *domain.ImpersonationAuditEntry) error
+	// UpdateAuditEntryStatus records the response status of an audited request.
+	UpdateAuditEntryStatus(entryID uint, status int) error
+	ListAuditEntries(sessionID uint) ([]domain.ImpersonationAuditEntry, error)
+}
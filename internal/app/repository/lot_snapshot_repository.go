@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+)
+
+//go:generate mockgen -source=./lot_snapshot_repository.go -destination=./../../test/shared/mockgen/mock_lot_snapshot_repository.go -package=mockgen
+type ILotSnapshotRepository interface {
+	Create(snapshot *domain.LotSnapshot) error
+	FindLatestBefore(parkingLotID uint, at time.Time) (*domain.LotSnapshot, error)
+}
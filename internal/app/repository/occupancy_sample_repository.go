@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+)
+
+//go:generate mockgen -source=./occupancy_sample_repository.go -destination=./../../test/shared/mockgen/mock_occupancy_sample_repository.go -package=mockgen
+type IOccupancySampleRepository interface {
+	Create(sample *domain.OccupancySample) error
+	ListByParkingLotSince(parkingLotID uint, since time.Time) ([]domain.OccupancySample, error)
+	ListByParkingLotsSince(parkingLotIDs []uint, since time.Time) ([]domain.OccupancySample, error)
+	StreamByParkingLotBetween(parkingLotID uint, from, to time.Time, fn func(domain.OccupancySample) error) error
+	// StreamByParkingLotBetweenForMember streams the samples only when a membership of the admin
+	// covers the lot, and none otherwise.
+	StreamByParkingLotBetweenForMember(parkingLotID uint, adminID uint, from, to time.Time, fn func(domain.OccupancySample) error) error
+}
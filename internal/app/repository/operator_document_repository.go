@@ -0,0 +1,11 @@
+package repository
+
+import "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+
+//go:generate mockgen -source=./operator_document_repository.go -destination=./../../test/shared/mockgen/mock_operator_document_repository.go -package=mockgen
+type IOperatorDocumentRepository interface {
+	Create(document *domain.OperatorDocument) error
+	ListByAdmin(adminID uint) ([]domain.OperatorDocument, error)
+	// FindByAdmin returns nil when the admin has no such document.
+	FindByAdmin(adminID, documentID uint) (*domain.OperatorDocument, error)
+}
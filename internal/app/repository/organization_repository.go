@@ -0,0 +1,17 @@
+package repository
+
+import "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+
+//go:generate mockgen -source=./organization_repository.go -destination=./../../test/shared/mockgen/mock_organization_repository.go -package=mockgen
+type IOrganizationRepository interface {
+	Create(organization *domain.Organization, owner *domain.OrganizationMembership) error
+	FindByID(id uint) (*domain.Organization, error)
+	List() ([]domain.Organization, error)
+	ListByAdmin(adminID uint) ([]domain.Organization, error)
+	FindMembership(organizationID uint, adminID uint) (*domain.OrganizationMembership, error)
+	ListMemberships(organizationID uint) ([]domain.OrganizationMembership, error)
+	ListMembershipsByAdmin(adminID uint) ([]domain.OrganizationMembership, error)
+	SaveMembership(membership *domain.OrganizationMembership) error
+	DeleteMembership(id uint) error
+	CountOwners(organizationID uint) (int64, error)
+}
@@ -9,6 +9,18 @@ type IParkingLotRepository interface {
 	Update(parkingLot *domain.ParkingLot) error
 	Delete(id uint) error
 	List() ([]domain.ParkingLot, error)
-	GetByIDWithAdmin(parkingLotID uint, adminID uint) (*domain.ParkingLot, error)
-	FindByAdminID(adminID uint) ([]domain.ParkingLot, error)
+	// ListPublic retrieves the parking lots of active operators, the ones shown to drivers, with
+	// the admin who registered them. The operator of a lot is the owners of its organization.
+	ListPublic() ([]domain.ParkingLot, error)
+	// GetPublicByID retrieves a parking lot only when ListPublic lists it.
+	GetPublicByID(id uint) (*domain.ParkingLot, error)
+	// Search returns a page of the parking lots matching the filter, with their operator, and how
+	// many match in total.
+	Search(filter domain.ParkingLotFilter) ([]domain.ParkingLot, int64, error)
+	// OperatorStatus returns the status of the operator of the parking lot: suspended when an
+	// owner of its organization is, active when an owner is, or "" when there is no such lot.
+	OperatorStatus(parkingLotID uint) (string, error)
+	GetByIDForMember(parkingLotID uint, adminID uint) (*domain.ParkingLot, *domain.OrganizationMembership, error)
+	FindByMember(adminID uint) ([]domain.ParkingLot, error)
+	FindByOrganizationID(organizationID uint) ([]domain.ParkingLot, error)
 }
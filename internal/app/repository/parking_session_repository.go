@@ -0,0 +1,20 @@
+package repository
+
+import "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+
+//go:generate mockgen -source=./parking_session_repository.go -destination=./../../test/shared/mockgen/mock_parking_session_repository.go -package=mockgen
+type IParkingSessionRepository interface {
+	Create(session *domain.ParkingSession) error
+	GetByID(id uint) (*domain.ParkingSession, error)
+	// Claim assigns an active anonymous session to the driver of the session, with its vehicle
+	// and hourly rate. It reports false when the session was claimed or ended meanwhile.
+	Claim(session *domain.ParkingSession) (bool, error)
+	// Complete stores the end, duration and cost of an active session. It reports false when the
+	// session was already ended.
+	Complete(session *domain.ParkingSession) (bool, error)
+	FindActiveBySensor(sensorID uint) (*domain.ParkingSession, error)
+	FindActiveByUser(userID uint) (*domain.ParkingSession, error)
+	// ListByUser and ListByParkingLot return every session when status is empty.
+	ListByUser(userID uint, status string) ([]domain.ParkingSession, error)
+	ListByParkingLot(parkingLotID uint, status string) ([]domain.ParkingSession, error)
+}
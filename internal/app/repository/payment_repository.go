@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+)
+
+//go:generate mockgen -source=./payment_repository.go -destination=./../../test/shared/mockgen/mock_payment_repository.go -package=mockgen
+type IPaymentRepository interface {
+	// Create returns domain.ErrDuplicatePayment when the user already has a payment with the
+	// idempotency key, and domain.ErrAlreadyPaid when the session or reservation already has a
+	// payment that has not failed.
+	Create(payment *domain.Payment, event *domain.PaymentEvent) error
+	GetByID(id uint) (*domain.Payment, error)
+	// FindByIdempotencyKey and FindByProviderReference return nil when there is no such payment.
+	FindByIdempotencyKey(userID uint, key string) (*domain.Payment, error)
+	FindByProviderReference(provider, reference string) (*domain.Payment, error)
+	// FindOpenByParkingSession and FindOpenByReservation return the payment of the target that
+	// has not failed, or nil.
+	FindOpenByParkingSession(parkingSessionID uint) (*domain.Payment, error)
+	FindOpenByReservation(reservationID uint) (*domain.Payment, error)
+	// HasEvent tells whether an event with the deduplication key was already applied or claimed.
+	HasEvent(dedupKey string) (bool, error)
+	// ClaimEvent records the event as pending before the provider is called, provided that the
+	// payment is still in its status and unchanged since it was read; it returns
+	// domain.ErrPaymentConflict otherwise, so that a single change of the payment is in flight at
+	// a time. It returns false when the deduplication key was already claimed.
+	ClaimEvent(payment *domain.Payment, event *domain.PaymentEvent) (bool, error)
+	// ReleaseEvent deletes a pending event whose provider call failed.
+	ReleaseEvent(event *domain.PaymentEvent) error
+	// ApplyEvent saves the payment and its audit event atomically, provided that the payment is
+	// still in event.FromStatus and unchanged since it was read. It returns
+	// domain.ErrPaymentConflict otherwise. A claimed event is completed instead of recorded again.
+	ApplyEvent(payment *domain.Payment, event *domain.PaymentEvent) error
+	ListByUser(userID uint) ([]domain.Payment, error)
+	ListEvents(paymentID uint) ([]domain.PaymentEvent, error)
+	RevenueByParkingLot(parkingLotID uint, from, to time.Time) (*domain.Revenue, error)
+}
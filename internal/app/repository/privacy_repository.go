@@ -0,0 +1,13 @@
+package repository
+
+import "time"
+
+//go:generate mockgen -source=./privacy_repository.go -destination=./../../test/shared/mockgen/mock_privacy_repository.go -package=mockgen
+type IPrivacyRepository interface {
+	// AnonymizeUser erases the personal data of a driver while keeping the rows that feed
+	// aggregate statistics, such as sessions, payments and review ratings.
+	AnonymizeUser(userID uint, at time.Time) error
+	// AnonymizeAdmin erases the profile and the operator document rows of an admin while
+	// keeping the parking lots they manage.
+	AnonymizeAdmin(adminID uint, at time.Time) error
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+)
+
+//go:generate mockgen -source=./refresh_token_repository.go -destination=./../../test/shared/mockgen/mock_refresh_token_repository.go -package=mockgen
+type IRefreshTokenRepository interface {
+	Create(token *domain.RefreshToken) error
+	FindByHash(tokenHash string) (*domain.RefreshToken, error)
+	// Revoke revokes the token unless it already was, and reports whether it did: of two
+	// concurrent rotations of a token only one revokes it.
+	Revoke(id uint, at time.Time) (bool, error)
+	RevokeFamily(familyID string, at time.Time) error
+	RevokeAllForUser(userID uint, at time.Time) error
+	HasActiveInFamily(familyID string, at time.Time) (bool, error)
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+)
+
+//go:generate mockgen -source=./reservation_repository.go -destination=./../../test/shared/mockgen/mock_reservation_repository.go -package=mockgen
+type IReservationRepository interface {
+	// CreateHold stores a held reservation unless the lot already has as many active holds as
+	// reservable spots (domain.ErrNoReservableSpots) or the spot is already held
+	// (domain.ErrSpotAlreadyReserved). The check and the insert are atomic.
+	CreateHold(reservation *domain.Reservation, now time.Time) error
+	GetByID(id uint) (*domain.Reservation, error)
+	Update(reservation *domain.Reservation) error
+	ListByUser(userID uint) ([]domain.Reservation, error)
+	ListByParkingLot(parkingLotID uint) ([]domain.Reservation, error)
+	ListActiveByParkingLot(parkingLotID uint, now time.Time) ([]domain.Reservation, error)
+	FindActiveBySensor(sensorID uint, now time.Time) (*domain.Reservation, error)
+	CountActiveGroupedByParkingLot(now time.Time) (map[uint]uint, error)
+	ExpireHolds(now time.Time) (int64, error)
+}
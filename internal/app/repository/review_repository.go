@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+)
+
+//go:generate mockgen -source=./review_repository.go -destination=./../../test/shared/mockgen/mock_review_repository.go -package=mockgen
+type IReviewRepository interface {
+	Create(review *domain.Review) error
+	GetByID(id uint) (*domain.Review, error)
+	Update(review *domain.Review) error
+	// Restore brings back a deleted review, saving its new content.
+	Restore(review *domain.Review) error
+	// Delete soft-deletes the review; it keeps counting toward CountByUserSince and
+	// CountByParkingLotSince.
+	Delete(userID uint, reviewID uint) error
+	// FindByUserAndParkingLot returns the review of the driver for the lot, including one they
+	// deleted, or nil.
+	FindByUserAndParkingLot(userID uint, parkingLotID uint) (*domain.Review, error)
+	ListByUser(userID uint) ([]domain.Review, error)
+	ListPublishedByParkingLot(parkingLotID uint) ([]domain.Review, error)
+	// ListForModeration returns the pending reviews and the published reviews that were reported.
+	ListForModeration() ([]domain.Review, error)
+	CountByUserSince(userID uint, since time.Time) (int64, error)
+	CountByParkingLotSince(parkingLotID uint, since time.Time) (int64, error)
+	// CreateReport stores the report and increments the review's report count; reporting the
+	// same review twice is a no-op that returns false.
+	CreateReport(report *domain.ReviewReport) (bool, error)
+	CountReportsByUserSince(userID uint, since time.Time) (int64, error)
+	GetRatingSummary(parkingLotID uint) (domain.RatingSummary, error)
+	ListRatingSummaries() (map[uint]domain.RatingSummary, error)
+}
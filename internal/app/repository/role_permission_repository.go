@@ -0,0 +1,9 @@
+package repository
+
+import "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+
+//go:generate mockgen -source=./role_permission_repository.go -destination=./../../test/shared/mockgen/mock_role_permission_repository.go -package=mockgen
+type IRolePermissionRepository interface {
+	ListAll() ([]domain.RolePermission, error)
+	ReplaceRole(role string, permissions []string) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+)
+
+//go:generate mockgen -source=./sensor_event_repository.go -destination=./../../test/shared/mockgen/mock_sensor_event_repository.go -package=mockgen
+type ISensorEventRepository interface {
+	Create(event *domain.SensorEvent) error
+	StreamByParkingLotBetween(parkingLotID uint, from, to time.Time, fn func(domain.SensorEvent) error) error
+}
@@ -1,13 +1,28 @@
 package repository
 
-import "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+import (
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+)
 
 //go:generate mockgen -source=./sensor_repository.go -destination=./../../test/shared/mocks/mock_sensor_repository.go -package=mockgen
 type ISensorRepository interface {
 	Create(sensor *domain.Sensor) error
 	GetByID(id uint) (*domain.Sensor, error)
 	ListByParkingLot(parkingLotID uint) ([]domain.Sensor, error)
+	ListByParkingLots(parkingLotIDs []uint) ([]domain.Sensor, error)
+	// ListByParkingLotBetween lists the sensors the lot had at some instant between from and to,
+	// including the ones deleted since.
+	ListByParkingLotBetween(parkingLotID uint, from, to time.Time) ([]domain.Sensor, error)
+	// ListByParkingLotForMember lists the sensors of the lot only when a membership of the admin
+	// covers it, and none otherwise.
+	ListByParkingLotForMember(parkingLotID uint, adminID uint) ([]domain.Sensor, error)
 	ListGroupedByParkingLot() (map[uint]uint, error)
+	// CountGroupedByParkingLot counts every sensor of each lot, whatever its status.
+	CountGroupedByParkingLot() (map[uint]uint, error)
+	// ListGroupedByParkingLotForSpots counts the free spots of each lot that match the filter.
+	ListGroupedByParkingLotForSpots(filter domain.SpotFilter) (map[uint]uint, error)
 	ListByEsp32DeviceID(esp32DeviceID uint64) ([]domain.Sensor, error)
 	GetByDeviceAndNumber(deviceIdentifier string, sensorNumber int) (*domain.Sensor, error)
 	Update(sensor *domain.Sensor) error
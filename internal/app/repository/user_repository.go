@@ -2,10 +2,12 @@ package repository
 
 import "github.com/CamiloLeonP/parking-radar/internal/app/domain"
 
+//go:generate mockgen -source=./user_repository.go -destination=./../../test/shared/mockgen/mock_user_repository.go -package=mockgen
 type IUserRepository interface {
 	Create(user *domain.User) error
 	FindByID(id uint) (*domain.User, error)
 	FindByUserName(username string) (*domain.User, error)
+	FindByEmail(email string) (*domain.User, error)
 	Update(user *domain.User) error
 	Delete(id uint) error
 }
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+)
+
+//go:generate mockgen -source=./user_token_repository.go -destination=./../../test/shared/mockgen/mock_user_token_repository.go -package=mockgen
+type IUserTokenRepository interface {
+	Create(token *domain.UserToken) error
+	// FindByHash returns the token with the given hash, or nil.
+	FindByHash(tokenHash string) (*domain.UserToken, error)
+	// MarkUsed redeems an unused token. It reports false when the token was already used.
+	MarkUsed(id uint, at time.Time) (bool, error)
+	// InvalidateForUser marks every unused token of the user for the purpose as used.
+	InvalidateForUser(userID uint, purpose string, at time.Time) error
+	CountCreatedSince(userID uint, purpose string, since time.Time) (int64, error)
+}
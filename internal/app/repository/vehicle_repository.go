@@ -0,0 +1,21 @@
+package repository
+
+import "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+
+//go:generate mockgen -source=./vehicle_repository.go -destination=./../../test/shared/mockgen/mock_vehicle_repository.go -package=mockgen
+type IVehicleRepository interface {
+	Create(vehicle *domain.Vehicle) error
+	GetByID(userID uint, vehicleID uint) (*domain.Vehicle, error)
+	Update(vehicle *domain.Vehicle) error
+	Delete(userID uint, vehicleID uint) error
+	ListByUser(userID uint) ([]domain.Vehicle, error)
+	// FindByUserAndPlate returns the vehicle of the user with the normalized plate, or nil.
+	FindByUserAndPlate(userID uint, plate string) (*domain.Vehicle, error)
+	// FindDefault returns the default vehicle of the user, or nil when they have none.
+	FindDefault(userID uint) (*domain.Vehicle, error)
+	// SetDefault makes the vehicle the only default vehicle of the user.
+	SetDefault(userID uint, vehicleID uint) error
+	// ListByPlate returns the vehicles registered with the normalized plate, for
+	// license-plate-recognition lookups.
+	ListByPlate(plate string) ([]domain.Vehicle, error)
+}
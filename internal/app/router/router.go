@@ -2,6 +2,7 @@ package router
 
 import (
 	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/input/handler"
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
 	"github.com/CamiloLeonP/parking-radar/internal/config"
 	middlewares "github.com/CamiloLeonP/parking-radar/internal/middleware"
 	"github.com/gin-gonic/gin"
@@ -19,72 +20,229 @@ func SetupRouter() *gin.Engine {
 
 	handlers := config.SetupDependencies()
 
+	// can declares the permissions an admin route needs
+	can := middlewares.RequirePermission
+
 	r.GET("/ws", handlers.WebSocketHandler.HandleConnection)
 
 	// Routes for users
 	users := r.Group("/users")
 	{
 		users.POST(REGISTER, handlers.UserHandler.Register)
-		users.GET("/:id", handlers.UserHandler.GetUserByID)
-		users.PUT("/:id", handlers.UserHandler.UpdateUser)
-		users.DELETE("/:id", handlers.UserHandler.DeleteUser)
+		users.POST("/login", handlers.UserAuthHandler.Login)
+		users.POST("/refresh", handlers.UserAuthHandler.Refresh)
+		users.POST("/logout", handlers.UserAuthHandler.Logout)
+		users.POST("/verify-email", handlers.AccountHandler.VerifyEmail)
+		users.POST("/password/forgot", handlers.AccountHandler.ForgotPassword)
+		users.POST("/password/reset", handlers.AccountHandler.ResetPassword)
+	}
+
+	// Routes for the authenticated driver
+	authenticatedUsers := r.Group("/users")
+	authenticatedUsers.Use(middlewares.UserAuthMiddleware(handlers.UserAuthHandler.UserAuthUseCase))
+	{
+		authenticatedUsers.POST("/logout-all", handlers.UserAuthHandler.LogoutAll)
+		authenticatedUsers.POST("/me/verification-email", handlers.AccountHandler.SendVerification)
+		authenticatedUsers.GET("/me/export", handlers.PrivacyHandler.ExportMyData)
+		authenticatedUsers.DELETE("/me", handlers.PrivacyHandler.EraseMyAccount)
+		authenticatedUsers.GET("/me/favorites", handlers.FavoriteHandler.ListFavorites)
+		authenticatedUsers.POST("/me/favorites", handlers.FavoriteHandler.AddFavorite)
+		authenticatedUsers.DELETE("/me/favorites/:parking_lot_id", handlers.FavoriteHandler.RemoveFavorite)
+		authenticatedUsers.GET("/me/alerts", handlers.AlertHandler.ListAlerts)
+		authenticatedUsers.POST("/me/alerts", handlers.AlertHandler.CreateAlert)
+		authenticatedUsers.DELETE("/me/alerts/:id", handlers.AlertHandler.DeleteAlert)
+		authenticatedUsers.GET("/me/reservations", handlers.ReservationHandler.ListMyReservations)
+		authenticatedUsers.DELETE("/me/reservations/:id", handlers.ReservationHandler.CancelReservation)
+		authenticatedUsers.GET("/me/sessions/active", handlers.SessionHandler.ListActiveSessions)
+		authenticatedUsers.GET("/me/sessions/past", handlers.SessionHandler.ListPastSessions)
+		authenticatedUsers.POST("/me/sessions/:id/stop", handlers.SessionHandler.StopSession)
+		authenticatedUsers.GET("/me/payments", handlers.PaymentHandler.ListMyPayments)
+		authenticatedUsers.GET("/me/vehicles", handlers.VehicleHandler.ListVehicles)
+		authenticatedUsers.POST("/me/vehicles", handlers.VehicleHandler.CreateVehicle)
+		authenticatedUsers.PUT("/me/vehicles/:id", handlers.VehicleHandler.UpdateVehicle)
+		authenticatedUsers.DELETE("/me/vehicles/:id", handlers.VehicleHandler.DeleteVehicle)
+		authenticatedUsers.POST("/me/vehicles/:id/default", handlers.VehicleHandler.SetDefaultVehicle)
+		authenticatedUsers.GET("/me/reviews", handlers.ReviewHandler.ListMyReviews)
+		authenticatedUsers.DELETE("/me/reviews/:review_id", handlers.ReviewHandler.DeleteReview)
+	}
+
+	// Routes that book, pay or publish, only for drivers who verified their e-mail address
+	verifiedUsers := r.Group("/users")
+	verifiedUsers.Use(middlewares.UserAuthMiddleware(handlers.UserAuthHandler.UserAuthUseCase), middlewares.VerifiedEmailMiddleware(handlers.AccountHandler.AccountUseCase))
+	{
+		verifiedUsers.POST("/me/reservations", handlers.ReservationHandler.CreateReservation)
+		verifiedUsers.POST("/me/sessions", handlers.SessionHandler.StartSession)
+		verifiedUsers.POST("/me/sessions/check-in", handlers.SessionHandler.CheckIn)
+		verifiedUsers.POST("/me/payments", handlers.PaymentHandler.CreatePayment)
+		verifiedUsers.POST("/me/reviews", handlers.ReviewHandler.SubmitReview)
+		verifiedUsers.POST("/me/crowd-reports", handlers.CrowdReportHandler.SubmitReport)
 	}
 
-	// Group for parking lots
+	// Routes for drivers acting on reviews written by others
+	reviews := r.Group("/reviews")
+	reviews.Use(middlewares.UserAuthMiddleware(handlers.UserAuthHandler.UserAuthUseCase), middlewares.VerifiedEmailMiddleware(handlers.AccountHandler.AccountUseCase))
+	{
+		reviews.POST("/:review_id/report", handlers.ReviewHandler.ReportReview)
+	}
+
+	// Routes a driver can only use on their own record
+	selfUsers := r.Group("/users/:id")
+	selfUsers.Use(middlewares.UserAuthMiddleware(handlers.UserAuthHandler.UserAuthUseCase), middlewares.SelfOnlyMiddleware("id"))
+	{
+		selfUsers.GET("", handlers.UserHandler.GetUserByID)
+		selfUsers.PUT("", handlers.UserHandler.UpdateUser)
+		selfUsers.DELETE("", handlers.UserHandler.DeleteUser)
+	}
+
+	// Group for parking lots, tailored to the driver's default vehicle when they are signed in
 	publicParkingLots := r.Group("/parking-lots")
+	publicParkingLots.Use(middlewares.OptionalUserAuthMiddleware(handlers.UserAuthHandler.UserAuthUseCase))
 	{
 		publicParkingLots.GET("/", handlers.ParkingLotHandler.ListParkingLots)
+		publicParkingLots.GET("/nearby", handlers.ForecastHandler.ListNearby)
+		publicParkingLots.GET("/:id/forecast", handlers.ForecastHandler.GetForecast)
+		publicParkingLots.GET("/:id/quote", handlers.VehicleHandler.GetQuote)
+		publicParkingLots.GET("/:id/reviews", handlers.ReviewHandler.ListParkingLotReviews)
 	}
 
 	// Group for protected parking lots
 	protectedParkingLots := r.Group("/parking-lots")
-	protectedParkingLots.Use(middlewares.AuthMiddleware("admin_local", "admin_global"))
+	protectedParkingLots.Use(middlewares.AuthMiddleware())
 	{
-		protectedParkingLots.POST("/", handlers.ParkingLotHandler.CreateParkingLot)
-		protectedParkingLots.GET("/:id", handlers.ParkingLotHandler.GetParkingLot)
-		protectedParkingLots.PUT("/:id", handlers.ParkingLotHandler.UpdateParkingLot)
-		protectedParkingLots.DELETE("/:id", handlers.ParkingLotHandler.DeleteParkingLot)
+		protectedParkingLots.POST("/", can(domain.PermissionLotWrite), handlers.ParkingLotHandler.CreateParkingLot)
+		protectedParkingLots.GET("/:id", can(domain.PermissionLotRead), handlers.ParkingLotHandler.GetParkingLot)
+		protectedParkingLots.PUT("/:id", can(domain.PermissionLotWrite), handlers.ParkingLotHandler.UpdateParkingLot)
+		protectedParkingLots.DELETE("/:id", can(domain.PermissionLotWrite), handlers.ParkingLotHandler.DeleteParkingLot)
+		protectedParkingLots.GET("/:id/state", can(domain.PermissionLotRead), handlers.PlaybackHandler.GetState)
+		protectedParkingLots.GET("/:id/replay", can(domain.PermissionLotRead), handlers.PlaybackHandler.Replay)
+		protectedParkingLots.GET("/:id/reservations", can(domain.PermissionLotRead), handlers.ReservationHandler.ListParkingLotReservations)
+		protectedParkingLots.PUT("/:id/reservable-spots", can(domain.PermissionLotWrite), handlers.ReservationHandler.UpdateReservableSpots)
+		protectedParkingLots.GET("/:id/sessions", can(domain.PermissionLotRead), handlers.SessionHandler.ListParkingLotSessions)
+		protectedParkingLots.GET("/:id/revenue", can(domain.PermissionReportRead), handlers.PaymentHandler.GetRevenue)
+		protectedParkingLots.GET("/:id/payments/:payment_id/events", can(domain.PermissionReportRead), handlers.PaymentHandler.ListPaymentEvents)
+		protectedParkingLots.POST("/:id/payments/:payment_id/refund", can(domain.PermissionPaymentRefund), handlers.PaymentHandler.RefundPayment)
+		protectedParkingLots.PUT("/:id/reviews/:review_id/reply", can(domain.PermissionReviewReply), handlers.ReviewHandler.ReplyToReview)
 	}
+	// Lots recommended around the destination of the driver
+	r.GET("/recommendations", middlewares.OptionalUserAuthMiddleware(handlers.UserAuthHandler.UserAuthUseCase), handlers.RecommendationHandler.Recommend)
+
 	// Routes for sensors
 	sensors := r.Group("/sensors")
+	sensors.Use(middlewares.AuthMiddleware())
 	{
-		sensors.POST("/", handlers.SensorHandler.CreateSensor)
-		sensors.GET("/", handlers.SensorHandler.ListSensors)
-		sensors.GET("/:id", handlers.SensorHandler.GetSensor)
-		sensors.PUT("/:sensor_number", handlers.SensorHandler.UpdateSensor)
-		sensors.DELETE("/:id", handlers.SensorHandler.DeleteSensor)
+		sensors.POST("/", can(domain.PermissionSensorWrite), handlers.SensorHandler.CreateSensor)
+		sensors.GET("/", can(domain.PermissionLotRead), handlers.SensorHandler.ListSensors)
+		sensors.GET("/:id", can(domain.PermissionLotRead), handlers.SensorHandler.GetSensor)
+		sensors.DELETE("/:id", can(domain.PermissionSensorWrite), handlers.SensorHandler.DeleteSensor)
 	}
+	// Devices report the status of their sensors with the token issued at their registration
+	r.PUT("/sensors/:sensor_number", middlewares.DeviceAuthMiddleware(handlers.Esp32DeviceHandler.Esp32DeviceUseCase), handlers.SensorHandler.UpdateSensor)
 
-	// Group for register Admin
+	// Admins register by accepting an invitation with their own token
 	admins := r.Group("/admins")
-	admins.Use(middlewares.AuthMiddleware("admin_default", "admin_global"))
+	admins.Use(middlewares.AuthMiddleware())
 	{
-		admins.POST(REGISTER, handlers.AdminHandler.RegisterAdmin)
+		admins.POST("/invitations/accept", handlers.InvitationHandler.AcceptInvitation)
 	}
 
 	// Group for protected Admin Profile
 	protectedAdmins := r.Group("/admins")
-	protectedAdmins.Use(middlewares.AuthMiddleware("admin_local", "admin_global"))
+	protectedAdmins.Use(middlewares.AuthMiddleware())
 	{
-		protectedAdmins.GET("/parking-lots", handlers.AdminHandler.GetParkingLotsByAdmin)
-		protectedAdmins.POST("/complete-profile", handlers.AdminHandler.CompleteAdminProfile)
-		protectedAdmins.GET("/profile", handlers.AdminHandler.GetAdminProfile)
+		protectedAdmins.GET("/parking-lots", can(domain.PermissionLotRead), handlers.AdminHandler.GetParkingLotsByAdmin)
+		protectedAdmins.POST("/complete-profile", can(domain.PermissionProfileManage), handlers.AdminHandler.CompleteAdminProfile)
+		protectedAdmins.GET("/profile", can(domain.PermissionProfileManage), handlers.AdminHandler.GetAdminProfile)
+		protectedAdmins.GET("/profile/export", can(domain.PermissionProfileManage), handlers.PrivacyHandler.ExportAdminData)
+		protectedAdmins.DELETE("/profile", can(domain.PermissionProfileManage), handlers.PrivacyHandler.EraseAdminProfile)
+		protectedAdmins.GET("/dashboard", can(domain.PermissionReportRead), handlers.DashboardHandler.GetDashboard)
+		protectedAdmins.GET("/reports/occupancy", can(domain.PermissionReportRead), handlers.ReportHandler.ExportOccupancy)
+		protectedAdmins.GET("/reports/devices", can(domain.PermissionReportRead), handlers.ReportHandler.ExportDevices)
+		protectedAdmins.GET("/invitations", can(domain.PermissionProfileManage), handlers.InvitationHandler.ListInvitations)
+		protectedAdmins.POST("/invitations", can(domain.PermissionProfileManage), handlers.InvitationHandler.CreateInvitation)
+		protectedAdmins.DELETE("/invitations/:id", can(domain.PermissionProfileManage), handlers.InvitationHandler.RevokeInvitation)
+		protectedAdmins.GET("/invitations/:id/events", can(domain.PermissionProfileManage), handlers.InvitationHandler.ListInvitationEvents)
+		protectedAdmins.GET("/verification", can(domain.PermissionProfileManage), handlers.VerificationHandler.GetVerification)
+		protectedAdmins.POST("/verification/documents", can(domain.PermissionProfileManage), handlers.VerificationHandler.UploadDocument)
+		protectedAdmins.POST("/verification/submit", can(domain.PermissionProfileManage), handlers.VerificationHandler.SubmitForReview)
+		protectedAdmins.GET("/organizations", can(domain.PermissionProfileManage), handlers.OrganizationHandler.ListOrganizations)
+		protectedAdmins.POST("/organizations", can(domain.PermissionProfileManage), handlers.OrganizationHandler.CreateOrganization)
+		protectedAdmins.GET("/organizations/:id/members", can(domain.PermissionProfileManage), handlers.OrganizationHandler.ListMembers)
+		protectedAdmins.PUT("/organizations/:id/members", can(domain.PermissionProfileManage), handlers.OrganizationHandler.SetMember)
+		protectedAdmins.DELETE("/organizations/:id/members/:admin_id", can(domain.PermissionProfileManage), handlers.OrganizationHandler.RemoveMember)
+	}
 
+	// Routes for global admins across every parking lot
+	global := r.Group("/global")
+	global.Use(middlewares.AuthMiddleware())
+	{
+		global.GET("/reviews/moderation", can(domain.PermissionReviewModerate), handlers.ReviewHandler.ListModerationQueue)
+		global.POST("/reviews/:review_id/hide", can(domain.PermissionReviewModerate), handlers.ReviewHandler.HideReview)
+		global.POST("/reviews/:review_id/restore", can(domain.PermissionReviewModerate), handlers.ReviewHandler.RestoreReview)
+		global.GET("/roles", can(domain.PermissionAdminManage), handlers.AuthorizationHandler.ListRolePermissions)
+		global.PUT("/roles/:role", can(domain.PermissionAdminManage), handlers.AuthorizationHandler.SetRolePermissions)
+		global.GET("/api-keys", can(domain.PermissionAdminManage), handlers.APIKeyHandler.ListAPIKeys)
+		global.POST("/api-keys", can(domain.PermissionAdminManage), handlers.APIKeyHandler.CreateAPIKey)
+		global.DELETE("/api-keys/:id", can(domain.PermissionAdminManage), handlers.APIKeyHandler.RevokeAPIKey)
+		global.GET("/api-keys/:id/usage", can(domain.PermissionAdminManage), handlers.APIKeyHandler.GetAPIKeyUsage)
+		global.GET("/admins", can(domain.PermissionAdminManage), handlers.GlobalAdminHandler.ListAdmins)
+		global.GET("/admins/:id", can(domain.PermissionAdminManage), handlers.GlobalAdminHandler.GetAdmin)
+		global.POST("/admins/:id/approve", can(domain.PermissionAdminManage), handlers.GlobalAdminHandler.ApproveAdmin)
+		global.POST("/admins/:id/suspend", can(domain.PermissionAdminManage), handlers.GlobalAdminHandler.SuspendAdmin)
+		global.POST("/admins/:id/reinstate", can(domain.PermissionAdminManage), handlers.GlobalAdminHandler.ReinstateAdmin)
+		global.GET("/admins/:id/verification", can(domain.PermissionAdminManage), handlers.VerificationHandler.GetOperatorVerification)
+		global.GET("/admins/:id/documents/:document_id", can(domain.PermissionAdminManage), handlers.VerificationHandler.DownloadDocument)
+		global.POST("/admins/:id/verify", can(domain.PermissionAdminManage), handlers.VerificationHandler.VerifyOperator)
+		global.POST("/admins/:id/reject", can(domain.PermissionAdminManage), handlers.VerificationHandler.RejectOperator)
+		global.GET("/parking-lots", can(domain.PermissionLotAll), handlers.GlobalAdminHandler.ListParkingLots)
+	}
+
+	// Routes for global admins to act as an admin; they always run as the global admin
+	impersonation := r.Group("/global/impersonation")
+	impersonation.Use(middlewares.RealAdminAuthMiddleware())
+	{
+		impersonation.GET("", can(domain.PermissionAdminManage), handlers.ImpersonationHandler.GetImpersonation)
+		impersonation.POST("", can(domain.PermissionAdminManage), handlers.ImpersonationHandler.StartImpersonation)
+		impersonation.DELETE("", can(domain.PermissionAdminManage), handlers.ImpersonationHandler.StopImpersonation)
+		impersonation.GET("/sessions", can(domain.PermissionAdminManage), handlers.ImpersonationHandler.ListSessions)
+		impersonation.GET("/sessions/:id/audit", can(domain.PermissionAdminManage), handlers.ImpersonationHandler.ListAuditEntries)
+	}
+
+	// Routes for third-party integrators, with an API key or an admin token
+	apiKeyUseCase := handlers.APIKeyHandler.APIKeyUseCase
+	integrations := r.Group("/integrations/v1")
+	{
+		integrations.GET("/availability", middlewares.APIKeyOrAuthMiddleware(apiKeyUseCase, domain.APIScopeAvailabilityRead, domain.PermissionLotRead), handlers.IntegrationHandler.ListAvailability)
+		integrations.GET("/parking-lots/:id/history", middlewares.APIKeyOrAuthMiddleware(apiKeyUseCase, domain.APIScopeHistoryRead, domain.PermissionReportRead), handlers.IntegrationHandler.GetOccupancyHistory)
 	}
 
 	// Routes for esp32 devices
 	esp32Devices := r.Group("/esp32-devices")
+	esp32Devices.Use(middlewares.AuthMiddleware())
 	{
-		esp32Devices.POST(REGISTER, handlers.Esp32DeviceHandler.CreateEsp32Device)
-		esp32Devices.GET("/list", handlers.Esp32DeviceHandler.ListEsp32Devices)
-		esp32Devices.GET("/:id", handlers.Esp32DeviceHandler.GetEsp32Device)
-		esp32Devices.PUT("/:id", handlers.Esp32DeviceHandler.UpdateEsp32Device)
-		esp32Devices.DELETE("/:id", handlers.Esp32DeviceHandler.DeleteEsp32Device)
+		esp32Devices.POST(REGISTER, can(domain.PermissionDeviceManage), handlers.Esp32DeviceHandler.CreateEsp32Device)
+		esp32Devices.GET("/list", can(domain.PermissionDeviceManage), handlers.Esp32DeviceHandler.ListEsp32Devices)
+		esp32Devices.GET("/:id", can(domain.PermissionDeviceManage), handlers.Esp32DeviceHandler.GetEsp32Device)
+		esp32Devices.PUT("/:id", can(domain.PermissionDeviceManage), handlers.Esp32DeviceHandler.UpdateEsp32Device)
+		esp32Devices.DELETE("/:id", can(domain.PermissionDeviceManage), handlers.Esp32DeviceHandler.DeleteEsp32Device)
+		esp32Devices.POST("/:id/token", can(domain.PermissionDeviceManage), handlers.Esp32DeviceHandler.RotateEsp32DeviceToken)
 	}
 
+	// Payment provider callbacks, authenticated by their signature
+	r.POST("/payments/webhooks/:provider", handlers.PaymentHandler.HandleWebhook)
+
 	r.POST("/ping", handler.PinHandler)
 
 	r.GET("/init", middlewares.AuthMiddleware(), handler.InitHandler)
 
+	// Health of the cache of Auth0 signing keys
+	if handlers.AuthKeysHandler != nil {
+		r.GET("/health/auth-keys", handlers.AuthKeysHandler.GetMetrics)
+	}
+
+	// Admin tokens minted locally, only when the dev issuer is enabled
+	if handlers.DevTokenHandler != nil {
+		r.POST("/dev/tokens", handlers.DevTokenHandler.MintToken)
+	}
+
 	return r
 }
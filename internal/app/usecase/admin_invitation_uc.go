@@ -0,0 +1,267 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/mailer"
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+	"gorm.io/gorm"
+)
+
+const AdminInvitationTTL = 7 * 24 * time.Hour
+
+var (
+	ErrInvalidInvitation      = errors.New("invitation is invalid, expired or already used")
+	ErrInvitationClosed       = errors.New("invitation was already accepted or revoked")
+	ErrInvitationNotFound     = errors.New("invitation not found")
+	ErrInvitationForbidden    = errors.New("only owners of the organization or global admins can manage its invitations")
+	ErrInvalidInvitationEmail = errors.New("a valid email is required")
+	ErrAlreadyMember          = errors.New("admin is already a member of the organization")
+	ErrInvitationNotSent      = errors.New("the invitation e-mail could not be sent, try again later")
+)
+
+type IAdminInvitationUseCase interface {
+	Invite(inviterUUID string, isGlobalAdmin bool, req InvitationRequest) (*domain.AdminInvitation, error)
+	ListInvitations(inviterUUID string, isGlobalAdmin bool, organizationID *uint) ([]domain.AdminInvitation, error)
+	RevokeInvitation(inviterUUID string, isGlobalAdmin bool, invitationID uint) error
+	ListInvitationEvents(inviterUUID string, isGlobalAdmin bool, invitationID uint) ([]domain.AdminInvitationEvent, error)
+	AcceptInvitation(subject string, token string) (*domain.Admin, error)
+}
+
+// InvitationRequest invites Email to the organization with the role. Global admins may leave the
+// organization out to invite a new operator.
+type InvitationRequest struct {
+	Email          string `json:"email"`
+	OrganizationID *uint  `json:"organization_id"`
+	Role           string `json:"role"`
+}
+
+// AdminInvitationUseCase onboards admins: an organization owner or a global admin invites an
+// e-mail address and whoever opens the e-mailed link, signed in with their own token, becomes
+// the admin. Links in the e-mails point to the app at baseURL.
+type AdminInvitationUseCase struct {
+	AdminInvitationRepository repository.IAdminInvitationRepository
+	AdminRepository           repository.IAdminRepository
+	OrganizationRepository    repository.IOrganizationRepository
+	Mailer                    mailer.Mailer
+	baseURL                   string
+	now                       func() time.Time
+}
+
+// NewAdminInvitationUseCase creates a new instance of AdminInvitationUseCase.
+func NewAdminInvitationUseCase(invitationRepo repository.IAdminInvitationRepository, adminRepo repository.IAdminRepository, organizationRepo repository.IOrganizationRepository, m mailer.Mailer, baseURL string) IAdminInvitationUseCase {
+	return &AdminInvitationUseCase{
+		AdminInvitationRepository: invitationRepo,
+		AdminRepository:           adminRepo,
+		OrganizationRepository:    organizationRepo,
+		Mailer:                    m,
+		baseURL:                   strings.TrimRight(baseURL, "/"),
+		now:                       time.Now,
+	}
+}
+
+// Invite stores an invitation and e-mails its link to the invitee. The invitation is deleted when
+// the e-mail cannot be sent, since nobody else ever sees its token.
+func (uc *AdminInvitationUseCase) Invite(inviterUUID string, isGlobalAdmin bool, req InvitationRequest) (*domain.AdminInvitation, error) {
+	address, err := mail.ParseAddress(strings.TrimSpace(req.Email))
+	if err != nil {
+		return nil, ErrInvalidInvitationEmail
+	}
+	if err := uc.authorize(inviterUUID, isGlobalAdmin, req.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	role := ""
+	organizationName := "Parking Radar"
+	if req.OrganizationID != nil {
+		if !domain.ValidMembershipRole(req.Role) {
+			return nil, ErrInvalidMembershipRole
+		}
+		organization, err := uc.OrganizationRepository.FindByID(*req.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+		if organization == nil {
+			return nil, ErrOrganizationNotFound
+		}
+		role = req.Role
+		organizationName = organization.Name
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	invitation := &domain.AdminInvitation{
+		Email:          strings.ToLower(address.Address),
+		OrganizationID: req.OrganizationID,
+		Role:           role,
+		TokenHash:      hashToken(token),
+		InvitedBy:      inviterUUID,
+		ExpiresAt:      now.Add(AdminInvitationTTL),
+	}
+	event := &domain.AdminInvitationEvent{Action: domain.InvitationActionCreated, Actor: inviterUUID}
+	if err := uc.AdminInvitationRepository.Create(invitation, event); err != nil {
+		return nil, err
+	}
+
+	if err := uc.Mailer.Send(mailer.Message{
+		To:      invitation.Email,
+		Subject: fmt.Sprintf("You are invited to manage parking lots of %s", organizationName),
+		Body: fmt.Sprintf("Hi,\n\nYou were invited to join %s on Parking Radar. Sign in and accept the invitation by opening this link within %s:\n\n%s\n",
+			organizationName, AdminInvitationTTL, fmt.Sprintf("%s/accept-invitation?token=%s", uc.baseURL, url.QueryEscape(token))),
+	}); err != nil {
+		log.Printf("Error sending invitation %d: %v", invitation.ID, err)
+		if deleteErr := uc.AdminInvitationRepository.Delete(invitation); deleteErr != nil {
+			return nil, deleteErr
+		}
+		return nil, ErrInvitationNotSent
+	}
+	return invitation, nil
+}
+
+// ListInvitations lists the invitations of the organization. Global admins may leave it out to
+// list every invitation.
+func (uc *AdminInvitationUseCase) ListInvitations(inviterUUID string, isGlobalAdmin bool, organizationID *uint) ([]domain.AdminInvitation, error) {
+	if err := uc.authorize(inviterUUID, isGlobalAdmin, organizationID); err != nil {
+		return nil, err
+	}
+	return uc.AdminInvitationRepository.List(organizationID)
+}
+
+// RevokeInvitation stops a pending invitation from being accepted.
+func (uc *AdminInvitationUseCase) RevokeInvitation(inviterUUID string, isGlobalAdmin bool, invitationID uint) error {
+	invitation, err := uc.managedInvitation(inviterUUID, isGlobalAdmin, invitationID)
+	if err != nil {
+		return err
+	}
+
+	now := uc.now()
+	invitation.RevokedAt = &now
+	revoked, err := uc.AdminInvitationRepository.Revoke(invitation, &domain.AdminInvitationEvent{
+		Action: domain.InvitationActionRevoked,
+		Actor:  inviterUUID,
+	})
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrInvitationClosed
+	}
+	return nil
+}
+
+// ListInvitationEvents returns the audit trail of an invitation.
+func (uc *AdminInvitationUseCase) ListInvitationEvents(inviterUUID string, isGlobalAdmin bool, invitationID uint) ([]domain.AdminInvitationEvent, error) {
+	if _, err := uc.managedInvitation(inviterUUID, isGlobalAdmin, invitationID); err != nil {
+		return nil, err
+	}
+	return uc.AdminInvitationRepository.ListEvents(invitationID)
+}
+
+// AcceptInvitation redeems the invitation for the authenticated subject: the admin record is
+// created for it if needed and it joins the organization with the invited role. New operators,
+// invited without an organization, wait for a global admin to approve them.
+func (uc *AdminInvitationUseCase) AcceptInvitation(subject string, token string) (*domain.Admin, error) {
+	invitation, err := uc.AdminInvitationRepository.FindByHash(hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	now := uc.now()
+	if invitation == nil || !invitation.Pending(now) {
+		return nil, ErrInvalidInvitation
+	}
+
+	admin, err := uc.AdminRepository.FindByAuth0UUID(subject)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if admin != nil && invitation.OrganizationID != nil {
+		membership, err := uc.OrganizationRepository.FindMembership(*invitation.OrganizationID, admin.ID)
+		if err != nil {
+			return nil, err
+		}
+		if membership != nil {
+			return nil, ErrAlreadyMember
+		}
+	}
+
+	if admin == nil {
+		admin = &domain.Admin{Auth0UUID: subject, Status: domain.AdminStatusActive}
+		if invitation.OrganizationID == nil {
+			admin.Status = domain.AdminStatusPending
+		}
+	}
+	var membership *domain.OrganizationMembership
+	if invitation.OrganizationID != nil {
+		membership = &domain.OrganizationMembership{
+			OrganizationID: *invitation.OrganizationID,
+			AdminID:        admin.ID,
+			Role:           invitation.Role,
+		}
+	}
+
+	invitation.AcceptedAt = &now
+	invitation.AcceptedBy = subject
+	accepted, err := uc.AdminInvitationRepository.Accept(invitation, &domain.AdminInvitationEvent{
+		Action: domain.InvitationActionAccepted,
+		Actor:  subject,
+	}, admin, membership)
+	if err != nil {
+		return nil, err
+	}
+	if !accepted {
+		return nil, ErrInvalidInvitation
+	}
+	return admin, nil
+}
+
+// managedInvitation retrieves an invitation the admin may manage.
+func (uc *AdminInvitationUseCase) managedInvitation(inviterUUID string, isGlobalAdmin bool, invitationID uint) (*domain.AdminInvitation, error) {
+	invitation, err := uc.AdminInvitationRepository.FindByID(invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if invitation == nil {
+		return nil, ErrInvitationNotFound
+	}
+	if err := uc.authorize(inviterUUID, isGlobalAdmin, invitation.OrganizationID); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// authorize lets global admins manage every invitation and owners those of their organization.
+func (uc *AdminInvitationUseCase) authorize(inviterUUID string, isGlobalAdmin bool, organizationID *uint) error {
+	if isGlobalAdmin {
+		return nil
+	}
+	if organizationID == nil {
+		return ErrInvitationForbidden
+	}
+
+	admin, err := uc.AdminRepository.FindByAuth0UUID(inviterUUID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvitationForbidden
+		}
+		return err
+	}
+	membership, err := uc.OrganizationRepository.FindMembership(*organizationID, admin.ID)
+	if err != nil {
+		return err
+	}
+	if membership == nil || membership.Role != domain.MembershipRoleOwner {
+		return ErrInvitationForbidden
+	}
+	return nil
+}
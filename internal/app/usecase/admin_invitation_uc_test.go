@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/mailer"
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var invitationNow = time.Date(2024, time.December, 2, 9, 0, 0, 0, time.UTC)
+
+type invitationMocks struct {
+	invitation   *mockgen.MockIAdminInvitationRepository
+	admin        *mockgen.MockIAdminRepository
+	organization *mockgen.MockIOrganizationRepository
+	mailer       *mailer.MemoryMailer
+}
+
+func setupInvitationTest(t *testing.T) (*gomock.Controller, invitationMocks, *AdminInvitationUseCase) {
+	ctrl := gomock.NewController(t)
+	m := invitationMocks{
+		invitation:   mockgen.NewMockIAdminInvitationRepository(ctrl),
+		admin:        mockgen.NewMockIAdminRepository(ctrl),
+		organization: mockgen.NewMockIOrganizationRepository(ctrl),
+		mailer:       mailer.NewMemoryMailer(),
+	}
+	useCase := NewAdminInvitationUseCase(m.invitation, m.admin, m.organization, m.mailer, "https://admin.example.com").(*AdminInvitationUseCase)
+	useCase.now = func() time.Time { return invitationNow }
+	return ctrl, m, useCase
+}
+
+func TestInviteByOwnerMailsTheToken(t *testing.T) {
+	ctrl, m, useCase := setupInvitationTest(t)
+	defer ctrl.Finish()
+
+	organizationID := uint(3)
+	m.admin.EXPECT().FindByAuth0UUID("auth0|owner").Return(&domain.Admin{ID: 1}, nil)
+	m.organization.EXPECT().FindMembership(organizationID, uint(1)).Return(&domain.OrganizationMembership{Role: domain.MembershipRoleOwner}, nil)
+	m.organization.EXPECT().FindByID(organizationID).Return(&domain.Organization{ID: 3, Name: "Parqueaderos Centro"}, nil)
+	var stored *domain.AdminInvitation
+	m.invitation.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(invitation *domain.AdminInvitation, event *domain.AdminInvitationEvent) error {
+		stored = invitation
+		assert.Equal(t, domain.AdminInvitationEvent{Action: domain.InvitationActionCreated, Actor: "auth0|owner"}, *event)
+		return nil
+	})
+
+	invitation, err := useCase.Invite("auth0|owner", false, InvitationRequest{
+		Email: " Ana <Ana@Example.com>", OrganizationID: &organizationID, Role: domain.MembershipRoleAttendant,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ana@example.com", invitation.Email)
+	assert.Equal(t, invitationNow.Add(AdminInvitationTTL), invitation.ExpiresAt)
+
+	assert.Len(t, m.mailer.Sent(), 1)
+	token := tokenFromMail(t, m.mailer.Sent()[0])
+	assert.Contains(t, m.mailer.Sent()[0].Body, "https://admin.example.com/accept-invitation?token=")
+	assert.Equal(t, hashToken(token), stored.TokenHash)
+}
+
+type failingMailer struct{}
+
+func (failingMailer) Send(mailer.Message) error {
+	return errors.New("smtp unavailable")
+}
+
+func TestInviteDeletesTheInvitationWhenTheMailFails(t *testing.T) {
+	ctrl, m, useCase := setupInvitationTest(t)
+	defer ctrl.Finish()
+	useCase.Mailer = failingMailer{}
+
+	m.invitation.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(invitation *domain.AdminInvitation, event *domain.AdminInvitationEvent) error {
+		invitation.ID = 6
+		return nil
+	})
+	m.invitation.EXPECT().Delete(gomock.Any()).DoAndReturn(func(invitation *domain.AdminInvitation) error {
+		assert.Equal(t, uint(6), invitation.ID)
+		return nil
+	})
+
+	_, err := useCase.Invite("auth0|global", true, InvitationRequest{Email: "operator@example.com"})
+	assert.ErrorIs(t, err, ErrInvitationNotSent)
+}
+
+func TestInviteRequiresOwnerOrGlobalAdmin(t *testing.T) {
+	ctrl, m, useCase := setupInvitationTest(t)
+	defer ctrl.Finish()
+
+	organizationID := uint(3)
+	_, err := useCase.Invite("auth0|manager", false, InvitationRequest{Email: "new@example.com"})
+	assert.ErrorIs(t, err, ErrInvitationForbidden)
+
+	m.admin.EXPECT().FindByAuth0UUID("auth0|manager").Return(&domain.Admin{ID: 2}, nil)
+	m.organization.EXPECT().FindMembership(organizationID, uint(2)).Return(&domain.OrganizationMembership{Role: domain.MembershipRoleManager}, nil)
+	_, err = useCase.Invite("auth0|manager", false, InvitationRequest{Email: "new@example.com", OrganizationID: &organizationID, Role: domain.MembershipRoleAttendant})
+	assert.ErrorIs(t, err, ErrInvitationForbidden)
+
+	_, err = useCase.Invite("auth0|global", true, InvitationRequest{Email: "not an address"})
+	assert.ErrorIs(t, err, ErrInvalidInvitationEmail)
+
+	m.invitation.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	invitation, err := useCase.Invite("auth0|global", true, InvitationRequest{Email: "operator@example.com", Role: domain.MembershipRoleOwner})
+	assert.NoError(t, err)
+	assert.Nil(t, invitation.OrganizationID)
+	assert.Empty(t, invitation.Role)
+}
+
+func TestAcceptInvitationBindsTheTokenSubject(t *testing.T) {
+	ctrl, m, useCase := setupInvitationTest(t)
+	defer ctrl.Finish()
+
+	organizationID := uint(3)
+	invitation := &domain.AdminInvitation{ID: 5, OrganizationID: &organizationID, Role: domain.MembershipRoleTechnician, ExpiresAt: invitationNow.Add(time.Hour)}
+	m.invitation.EXPECT().FindByHash(hashToken("secret")).Return(invitation, nil)
+	m.admin.EXPECT().FindByAuth0UUID("auth0|invitee").Return(nil, gorm.ErrRecordNotFound)
+	m.invitation.EXPECT().Accept(invitation, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(accepted *domain.AdminInvitation, event *domain.AdminInvitationEvent, admin *domain.Admin, membership *domain.OrganizationMembership) (bool, error) {
+		assert.Equal(t, "auth0|invitee", accepted.AcceptedBy)
+		assert.Equal(t, domain.InvitationActionAccepted, event.Action)
+		assert.Equal(t, "auth0|invitee", admin.Auth0UUID)
+		assert.Equal(t, domain.AdminStatusActive, admin.Status)
+		assert.Equal(t, domain.OrganizationMembership{OrganizationID: 3, Role: domain.MembershipRoleTechnician}, *membership)
+		admin.ID = 8
+		return true, nil
+	})
+
+	admin, err := useCase.AcceptInvitation("auth0|invitee", "secret")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(8), admin.ID)
+}
+
+func TestAcceptInvitationCreatesNothingWhenAlreadyAccepted(t *testing.T) {
+	ctrl, m, useCase := setupInvitationTest(t)
+	defer ctrl.Finish()
+
+	invitation := &domain.AdminInvitation{ID: 5, ExpiresAt: invitationNow.Add(time.Hour)}
+	m.invitation.EXPECT().FindByHash(hashToken("secret")).Return(invitation, nil)
+	m.admin.EXPECT().FindByAuth0UUID("auth0|invitee").Return(nil, gorm.ErrRecordNotFound)
+	m.invitation.EXPECT().Accept(invitation, gomock.Any(), gomock.Any(), nil).Return(false, nil)
+
+	_, err := useCase.AcceptInvitation("auth0|invitee", "secret")
+	assert.ErrorIs(t, err, ErrInvalidInvitation)
+}
+
+func TestAcceptInvitationRejectsClosedInvitations(t *testing.T) {
+	ctrl, m, useCase := setupInvitationTest(t)
+	defer ctrl.Finish()
+
+	revokedAt := invitationNow.Add(-time.Minute)
+	m.invitation.EXPECT().FindByHash(hashToken("unknown")).Return(nil, nil)
+	m.invitation.EXPECT().FindByHash(hashToken("expired")).Return(&domain.AdminInvitation{ExpiresAt: invitationNow}, nil)
+	m.invitation.EXPECT().FindByHash(hashToken("revoked")).Return(&domain.AdminInvitation{ExpiresAt: invitationNow.Add(time.Hour), RevokedAt: &revokedAt}, nil)
+
+	for _, token := range []string{"unknown", "expired", "revoked"} {
+		_, err := useCase.AcceptInvitation("auth0|invitee", token)
+		assert.ErrorIs(t, err, ErrInvalidInvitation, token)
+	}
+}
+
+func TestRevokeInvitation(t *testing.T) {
+	ctrl, m, useCase := setupInvitationTest(t)
+	defer ctrl.Finish()
+
+	invitation := &domain.AdminInvitation{ID: 5, ExpiresAt: invitationNow.Add(time.Hour)}
+	m.invitation.EXPECT().FindByID(uint(5)).Return(invitation, nil).Times(2)
+	m.invitation.EXPECT().Revoke(invitation, &domain.AdminInvitationEvent{Action: domain.InvitationActionRevoked, Actor: "auth0|global"}).Return(true, nil)
+	m.invitation.EXPECT().Revoke(invitation, gomock.Any()).Return(false, nil)
+
+	assert.NoError(t, useCase.RevokeInvitation("auth0|global", true, 5))
+	assert.ErrorIs(t, useCase.RevokeInvitation("auth0|global", true, 5), ErrInvitationClosed)
+}
@@ -1,14 +1,12 @@
 package usecase
 
 import (
-	"errors"
 	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
 	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
 )
 
 //go:generate mockgen -source=./admin_uc.go -destination=./../../test/parking/mocks/mock_admin_uc.go -package=mocks
 type IAdminUseCase interface {
-	RegisterAdmin(adminID string) error
 	CompleteAdminProfile(adminID string, profileData domain.AdminProfileData) error
 	GetAdminProfile(adminID string) (*domain.Admin, error)
 	GetParkingLotsByAdmin(adminUUID string) ([]domain.ParkingLot, error)
@@ -25,7 +23,7 @@ func (uc *AdminUseCase) GetParkingLotsByAdmin(adminUUID string) ([]domain.Parkin
 	if err != nil {
 		return nil, err
 	}
-	return uc.ParkingLotRepository.FindByAdminID(admin.ID)
+	return uc.ParkingLotRepository.FindByMember(admin.ID)
 }
 
 func NewAdminUseCase(adminRepo repository.IAdminRepository) IAdminUseCase {
@@ -34,28 +32,23 @@ func NewAdminUseCase(adminRepo repository.IAdminRepository) IAdminUseCase {
 	}
 }
 
-func (uc *AdminUseCase) RegisterAdmin(adminID string) error {
-	exists, err := uc.AdminRepository.ExistsByAuth0UUID(adminID)
-	if err != nil {
-		return err
-	}
-	if exists {
-		return errors.New("admin already registered")
-	}
-
-	admin := domain.Admin{
-		Auth0UUID: adminID,
+// CompleteAdminProfile saves the contact details and NIT of the admin. The NIT is stored with its
+// check digit after a dash and cannot change while the operator is under review or verified.
+func (uc *AdminUseCase) CompleteAdminProfile(adminID string, profileData domain.AdminProfileData) error {
+	nit, ok := domain.NormalizeNIT(profileData.NIT)
+	if !ok {
+		return ErrInvalidNIT
 	}
-	return uc.AdminRepository.Create(&admin)
-}
 
-func (uc *AdminUseCase) CompleteAdminProfile(adminID string, profileData domain.AdminProfileData) error {
 	admin, err := uc.AdminRepository.FindByAuth0UUID(adminID)
 	if err != nil {
 		return err
 	}
+	if nit != admin.NIT && !acceptsDocuments(admin) {
+		return ErrVerificationLocked
+	}
 
-	admin.NIT = profileData.NIT
+	admin.NIT = nit
 	admin.PhotoURL = profileData.PhotoURL
 	admin.ContactPhone = profileData.ContactPhone
 
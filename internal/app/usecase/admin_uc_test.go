@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompleteAdminProfileNormalizesTheNIT(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	adminRepo := mockgen.NewMockIAdminRepository(ctrl)
+	useCase := NewAdminUseCase(adminRepo)
+
+	for _, nit := range []string{"800.197.268-4", "8001972684", " 800197268-4 "} {
+		adminRepo.EXPECT().FindByAuth0UUID("auth0|operator").Return(&domain.Admin{ID: 1, VerificationStatus: domain.VerificationPendingDocuments}, nil)
+		adminRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(admin *domain.Admin) error {
+			assert.Equal(t, "800197268-4", admin.NIT)
+			return nil
+		})
+		assert.NoError(t, useCase.CompleteAdminProfile("auth0|operator", domain.AdminProfileData{NIT: nit}), nit)
+	}
+
+	for _, nit := range []string{"", "800197268-5", "800197268", "80019726A-4", "800197268-44"} {
+		assert.ErrorIs(t, useCase.CompleteAdminProfile("auth0|operator", domain.AdminProfileData{NIT: nit}), ErrInvalidNIT, nit)
+	}
+}
+
+func TestCompleteAdminProfileKeepsTheNITOfVerifiedOperators(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	adminRepo := mockgen.NewMockIAdminRepository(ctrl)
+	useCase := NewAdminUseCase(adminRepo)
+
+	verified := &domain.Admin{ID: 1, NIT: "800197268-4", VerificationStatus: domain.VerificationVerified}
+	adminRepo.EXPECT().FindByAuth0UUID("auth0|operator").Return(verified, nil).Times(2)
+	adminRepo.EXPECT().Update(verified).Return(nil)
+
+	assert.ErrorIs(t, useCase.CompleteAdminProfile("auth0|operator", domain.AdminProfileData{NIT: "900123456-8"}), ErrVerificationLocked)
+	assert.NoError(t, useCase.CompleteAdminProfile("auth0|operator", domain.AdminProfileData{NIT: "800197268-4", ContactPhone: "3001234567"}))
+}
@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/notifier"
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+)
+
+const alertTimeLayout = "15:04"
+
+var ErrInvalidAlertWindow = errors.New("invalid alert window, expected HH:MM with start before end")
+
+type IAlertUseCase interface {
+	CreateAlert(userID uint, req CreateAlertRequest) (*domain.AvailabilityAlert, error)
+	DeleteAlert(userID uint, alertID uint) error
+	ListAlerts(userID uint) ([]AlertResponse, error)
+	OnSensorChange(change SensorChange)
+}
+
+type AlertUseCase struct {
+	AlertRepository      repository.IAvailabilityAlertRepository
+	ParkingLotRepository repository.IParkingLotRepository
+	SensorRepository     repository.ISensorRepository
+	Notifier             notifier.Notifier
+	now                  func() time.Time
+}
+
+type CreateAlertRequest struct {
+	ParkingLotID  uint   `json:"parking_lot_id" binding:"required"`
+	MinFreeSpaces uint   `json:"min_free_spaces" binding:"required"`
+	Start         string `json:"start" binding:"required"`
+	End           string `json:"end" binding:"required"`
+}
+
+type AlertResponse struct {
+	ID             uint       `json:"id"`
+	ParkingLotID   uint       `json:"parking_lot_id"`
+	MinFreeSpaces  uint       `json:"min_free_spaces"`
+	Start          string     `json:"start"`
+	End            string     `json:"end"`
+	Active         bool       `json:"active"`
+	LastNotifiedAt *time.Time `json:"last_notified_at,omitempty"`
+}
+
+// NewAlertUseCase creates a new instance of AlertUseCase.
+func NewAlertUseCase(alertRepo repository.IAvailabilityAlertRepository, parkingLotRepo repository.IParkingLotRepository, sensorRepo repository.ISensorRepository, n notifier.Notifier) IAlertUseCase {
+	return &AlertUseCase{
+		AlertRepository:      alertRepo,
+		ParkingLotRepository: parkingLotRepo,
+		SensorRepository:     sensorRepo,
+		Notifier:             n,
+		now:                  time.Now,
+	}
+}
+
+// CreateAlert subscribes the user to the availability of a parking lot during a daily window.
+func (uc *AlertUseCase) CreateAlert(userID uint, req CreateAlertRequest) (*domain.AvailabilityAlert, error) {
+	start, errStart := parseMinuteOfDay(req.Start)
+	end, errEnd := parseMinuteOfDay(req.End)
+	if errStart != nil || errEnd != nil || start >= end {
+		return nil, ErrInvalidAlertWindow
+	}
+
+	if _, err := uc.ParkingLotRepository.GetByID(req.ParkingLotID); err != nil {
+		return nil, err
+	}
+
+	alert := &domain.AvailabilityAlert{
+		UserID:        userID,
+		ParkingLotID:  req.ParkingLotID,
+		MinFreeSpaces: req.MinFreeSpaces,
+		WindowStart:   start,
+		WindowEnd:     end,
+		Active:        true,
+	}
+	if err := uc.AlertRepository.Create(alert); err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
+
+// DeleteAlert removes an alert of the user.
+func (uc *AlertUseCase) DeleteAlert(userID uint, alertID uint) error {
+	return uc.AlertRepository.Delete(userID, alertID)
+}
+
+// ListAlerts retrieves the alerts of the user.
+func (uc *AlertUseCase) ListAlerts(userID uint) ([]AlertResponse, error) {
+	alerts, err := uc.AlertRepository.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []AlertResponse{}
+	for _, alert := range alerts {
+		response = append(response, AlertResponse{
+			ID:             alert.ID,
+			ParkingLotID:   alert.ParkingLotID,
+			MinFreeSpaces:  alert.MinFreeSpaces,
+			Start:          formatMinuteOfDay(alert.WindowStart),
+			End:            formatMinuteOfDay(alert.WindowEnd),
+			Active:         alert.Active,
+			LastNotifiedAt: alert.LastNotifiedAt,
+		})
+	}
+	return response, nil
+}
+
+// OnSensorChange matches the alerts of the sensor's parking lot against its new availability.
+// Each alert fires at most once per daily window.
+func (uc *AlertUseCase) OnSensorChange(change SensorChange) {
+	parkingLotID := change.Sensor.ParkingLotID
+
+	alerts, err := uc.AlertRepository.ListActiveByParkingLot(parkingLotID)
+	if err != nil {
+		log.Println("Error loading availability alerts:", err)
+		return
+	}
+	if len(alerts) == 0 {
+		return
+	}
+
+	sensors, err := uc.SensorRepository.ListByParkingLot(parkingLotID)
+	if err != nil {
+		log.Println("Error loading sensors for availability alerts:", err)
+		return
+	}
+	free := countAvailableSpaces(sensors)
+
+	now := uc.now().In(helpers.BogotaLocation)
+	minute := now.Hour()*60 + now.Minute()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, helpers.BogotaLocation)
+
+	for _, alert := range alerts {
+		if minute < alert.WindowStart || minute >= alert.WindowEnd || free < alert.MinFreeSpaces {
+			continue
+		}
+		windowStart := startOfDay.Add(time.Duration(alert.WindowStart) * time.Minute)
+		if alert.LastNotifiedAt != nil && !alert.LastNotifiedAt.Before(windowStart) {
+			continue
+		}
+
+		notification := notifier.Notification{
+			UserID: alert.UserID,
+			Title:  "Parking available",
+			Body:   fmt.Sprintf("Parking lot %d has %d free spaces", parkingLotID, free),
+			Data: map[string]interface{}{
+				"alert_id":         alert.ID,
+				"parking_lot_id":   parkingLotID,
+				"available_spaces": free,
+			},
+			SentAt: now,
+		}
+		if err := uc.Notifier.Notify(notification); err != nil {
+			log.Println("Error sending availability alert:", err)
+			continue
+		}
+
+		notifiedAt := now
+		alert.LastNotifiedAt = &notifiedAt
+		if err := uc.AlertRepository.Update(&alert); err != nil {
+			log.Println("Error updating availability alert:", err)
+		}
+	}
+}
+
+func parseMinuteOfDay(value string) (int, error) {
+	t, err := time.Parse(alertTimeLayout, value)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func formatMinuteOfDay(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
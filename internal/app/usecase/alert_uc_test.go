@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/notifier"
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAlertUseCase(ctrl *gomock.Controller, now time.Time) (*AlertUseCase, *mockgen.MockIAvailabilityAlertRepository, *mockgen.MockISensorRepository, *notifier.MemoryNotifier) {
+	alertRepo := mockgen.NewMockIAvailabilityAlertRepository(ctrl)
+	sensorRepo := mockgen.NewMockISensorRepository(ctrl)
+	memory := notifier.NewMemoryNotifier()
+	useCase := NewAlertUseCase(alertRepo, mockgen.NewMockIParkingLotRepository(ctrl), sensorRepo, memory).(*AlertUseCase)
+	useCase.now = func() time.Time { return now }
+	return useCase, alertRepo, sensorRepo, memory
+}
+
+func TestOnSensorChangeNotifiesOncePerWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, time.September, 10, 8, 30, 0, 0, helpers.BogotaLocation)
+	useCase, alertRepo, sensorRepo, memory := newTestAlertUseCase(ctrl, now)
+
+	yesterday := now.Add(-24 * time.Hour)
+	alreadyNotified := now.Add(-10 * time.Minute)
+	alertRepo.EXPECT().ListActiveByParkingLot(uint(3)).Return([]domain.AvailabilityAlert{
+		{ID: 1, UserID: 10, ParkingLotID: 3, MinFreeSpaces: 2, WindowStart: 7 * 60, WindowEnd: 9 * 60, Active: true, LastNotifiedAt: &yesterday},
+		{ID: 2, UserID: 11, ParkingLotID: 3, MinFreeSpaces: 2, WindowStart: 7 * 60, WindowEnd: 9 * 60, Active: true, LastNotifiedAt: &alreadyNotified},
+		{ID: 3, UserID: 12, ParkingLotID: 3, MinFreeSpaces: 5, WindowStart: 7 * 60, WindowEnd: 9 * 60, Active: true},
+		{ID: 4, UserID: 13, ParkingLotID: 3, MinFreeSpaces: 1, WindowStart: 17 * 60, WindowEnd: 19 * 60, Active: true},
+	}, nil)
+	sensorRepo.EXPECT().ListByParkingLot(uint(3)).Return([]domain.Sensor{
+		{ID: 1, Status: domain.SensorStatusFree},
+		{ID: 2, Status: domain.SensorStatusFree},
+		{ID: 3, Status: domain.SensorStatusOccupied},
+	}, nil)
+	alertRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(alert *domain.AvailabilityAlert) error {
+		assert.Equal(t, uint(1), alert.ID)
+		assert.True(t, alert.LastNotifiedAt.Equal(now))
+		return nil
+	})
+
+	useCase.OnSensorChange(SensorChange{Sensor: domain.Sensor{ID: 1, ParkingLotID: 3, Status: domain.SensorStatusFree}})
+
+	sent := memory.Sent()
+	assert.Len(t, sent, 1)
+	assert.Equal(t, uint(10), sent[0].UserID)
+	assert.Equal(t, uint(2), sent[0].Data["available_spaces"])
+}
+
+func TestCreateAlertRejectsInvalidWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase, _, _, _ := newTestAlertUseCase(ctrl, time.Now())
+
+	_, err := useCase.CreateAlert(10, CreateAlertRequest{ParkingLotID: 3, MinFreeSpaces: 1, Start: "09:00", End: "08:00"})
+	assert.ErrorIs(t, err, ErrInvalidAlertWindow)
+
+	_, err = useCase.CreateAlert(10, CreateAlertRequest{ParkingLotID: 3, MinFreeSpaces: 1, Start: "9am", End: "10:00"})
+	assert.ErrorIs(t, err, ErrInvalidAlertWindow)
+}
@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+)
+
+const (
+	DefaultAPIKeyRateLimit = 60
+	// MaxAPIKeyUsageDays caps how many days of usage are returned at once.
+	MaxAPIKeyUsageDays = 90
+	apiKeyPrefix       = "prk_"
+	// apiKeyTouchInterval limits how often the last use of a key is written.
+	apiKeyTouchInterval = time.Minute
+)
+
+var (
+	ErrAPIKeyNotFound       = errors.New("api key not found")
+	ErrInvalidAPIKey        = errors.New("invalid, expired or revoked api key")
+	ErrAPIKeyScope          = errors.New("api key is not allowed to access this resource")
+	ErrAPIKeyRateLimited    = errors.New("api key rate limit exceeded")
+	ErrAPIKeyQuotaExceeded  = errors.New("api key daily quota exceeded")
+	ErrUnknownAPIScope      = errors.New("unknown api key scope")
+	ErrInvalidAPIKeyRequest = errors.New("name and at least one scope are required")
+)
+
+type IAPIKeyUseCase interface {
+	CreateKey(adminUUID string, req CreateAPIKeyRequest) (*CreatedAPIKeyResponse, error)
+	ListKeys() ([]APIKeyResponse, error)
+	RevokeKey(id uint) error
+	GetUsage(id uint, days int) ([]domain.APIKeyUsage, error)
+	AuthenticateAPIKey(key string, scope string) (*domain.APIKey, error)
+}
+
+type CreateAPIKeyRequest struct {
+	Name               string     `json:"name"`
+	Scopes             []string   `json:"scopes"`
+	RateLimitPerMinute uint       `json:"rate_limit_per_minute"`
+	DailyQuota         uint       `json:"daily_quota"`
+	ExpiresAt          *time.Time `json:"expires_at"`
+}
+
+type APIKeyResponse struct {
+	domain.APIKey
+	Scopes []string `json:"scopes"`
+}
+
+// CreatedAPIKeyResponse carries the key itself, which is only shown once.
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+// APIKeyUseCase issues API keys to integrators and authenticates their requests, enforcing the
+// scopes, the per-minute rate limit and the daily quota of each key. Rate limits are counted in
+// memory per instance; quotas and usage are counted in the database.
+type APIKeyUseCase struct {
+	APIKeyRepository repository.IAPIKeyRepository
+	mutex            sync.Mutex
+	windows          map[uint]*rateWindow
+	now              func() time.Time
+}
+
+// rateWindow counts the requests of a key during the current minute.
+type rateWindow struct {
+	start    time.Time
+	requests uint
+}
+
+// NewAPIKeyUseCase creates a new instance of APIKeyUseCase.
+func NewAPIKeyUseCase(apiKeyRepo repository.IAPIKeyRepository) IAPIKeyUseCase {
+	return &APIKeyUseCase{
+		APIKeyRepository: apiKeyRepo,
+		windows:          make(map[uint]*rateWindow),
+		now:              time.Now,
+	}
+}
+
+// CreateKey issues a new key. The returned key is not stored and cannot be retrieved again.
+func (uc *APIKeyUseCase) CreateKey(adminUUID string, req CreateAPIKeyRequest) (*CreatedAPIKeyResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(req.Scopes) == 0 {
+		return nil, ErrInvalidAPIKeyRequest
+	}
+	scopes := append([]string{}, req.Scopes...)
+	for _, scope := range scopes {
+		if !slices.Contains(domain.APIScopes, scope) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownAPIScope, scope)
+		}
+	}
+	sort.Strings(scopes)
+	scopes = slices.Compact(scopes)
+
+	now := uc.now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidAPIKeyRequest)
+	}
+	rateLimit := req.RateLimitPerMinute
+	if rateLimit == 0 {
+		rateLimit = DefaultAPIKeyRateLimit
+	}
+
+	secret, err := randomToken(24)
+	if err != nil {
+		return nil, err
+	}
+	key := apiKeyPrefix + secret
+
+	apiKey := &domain.APIKey{
+		Name:               name,
+		Prefix:             key[:len(apiKeyPrefix)+8],
+		KeyHash:            hashToken(key),
+		Scopes:             strings.Join(scopes, ","),
+		RateLimitPerMinute: rateLimit,
+		DailyQuota:         req.DailyQuota,
+		CreatedBy:          adminUUID,
+		ExpiresAt:          req.ExpiresAt,
+	}
+	if err := uc.APIKeyRepository.Create(apiKey); err != nil {
+		return nil, err
+	}
+
+	return &CreatedAPIKeyResponse{APIKeyResponse: toAPIKeyResponse(*apiKey), Key: key}, nil
+}
+
+// ListKeys retrieves every key, newest first.
+func (uc *APIKeyUseCase) ListKeys() ([]APIKeyResponse, error) {
+	keys, err := uc.APIKeyRepository.List()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		responses = append(responses, toAPIKeyResponse(key))
+	}
+	return responses, nil
+}
+
+// RevokeKey stops the key from authenticating any further request.
+func (uc *APIKeyUseCase) RevokeKey(id uint) error {
+	key, err := uc.APIKeyRepository.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return ErrAPIKeyNotFound
+	}
+	return uc.APIKeyRepository.Revoke(id, uc.now())
+}
+
+// GetUsage retrieves the daily counters of the key over the last days.
+func (uc *APIKeyUseCase) GetUsage(id uint, days int) ([]domain.APIKeyUsage, error) {
+	key, err := uc.APIKeyRepository.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	days = min(max(days, 1), MaxAPIKeyUsageDays)
+	since := usageDay(uc.now()).AddDate(0, 0, 1-days)
+	return uc.APIKeyRepository.ListUsageSince(id, since)
+}
+
+// AuthenticateAPIKey returns the key if it is valid and granted the scope, counting the request
+// against its rate limit and daily quota. Rejected requests of a valid key are counted apart.
+func (uc *APIKeyUseCase) AuthenticateAPIKey(key string, scope string) (*domain.APIKey, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+	apiKey, err := uc.APIKeyRepository.FindByHash(hashToken(key))
+	if err != nil {
+		return nil, err
+	}
+	now := uc.now()
+	if apiKey == nil || apiKey.RevokedAt != nil || (apiKey.ExpiresAt != nil && !now.Before(*apiKey.ExpiresAt)) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	day := usageDay(now)
+	rejection, err := uc.checkLimits(apiKey, scope, day, now)
+	if err != nil {
+		return nil, err
+	}
+	if rejection != nil {
+		if err := uc.APIKeyRepository.IncrementUsage(apiKey.ID, day, true); err != nil {
+			return nil, err
+		}
+		return nil, rejection
+	}
+
+	if err := uc.APIKeyRepository.IncrementUsage(apiKey.ID, day, false); err != nil {
+		return nil, err
+	}
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval {
+		if err := uc.APIKeyRepository.TouchLastUsed(apiKey.ID, now); err != nil {
+			return nil, err
+		}
+	}
+	return apiKey, nil
+}
+
+// checkLimits returns why the request must be rejected, or a nil rejection when it may proceed.
+func (uc *APIKeyUseCase) checkLimits(apiKey *domain.APIKey, scope string, day, now time.Time) (rejection error, err error) {
+	if !apiKey.HasScope(scope) {
+		return ErrAPIKeyScope, nil
+	}
+
+	if apiKey.DailyQuota > 0 {
+		usage, err := uc.APIKeyRepository.GetUsage(apiKey.ID, day)
+		if err != nil {
+			return nil, err
+		}
+		if usage != nil && usage.Requests >= apiKey.DailyQuota {
+			return ErrAPIKeyQuotaExceeded, nil
+		}
+	}
+
+	uc.mutex.Lock()
+	defer uc.mutex.Unlock()
+	window, ok := uc.windows[apiKey.ID]
+	if !ok || now.Sub(window.start) >= time.Minute {
+		window = &rateWindow{start: now}
+		uc.windows[apiKey.ID] = window
+	}
+	if window.requests >= apiKey.RateLimitPerMinute {
+		return ErrAPIKeyRateLimited, nil
+	}
+	window.requests++
+	return nil, nil
+}
+
+// usageDay returns the UTC day usage is counted on.
+func usageDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func toAPIKeyResponse(key domain.APIKey) APIKeyResponse {
+	return APIKeyResponse{APIKey: key, Scopes: key.ScopeList()}
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var apiKeyNow = time.Date(2024, time.November, 4, 15, 30, 0, 0, time.UTC)
+
+func setupAPIKeyTest(t *testing.T) (*gomock.Controller, *mockgen.MockIAPIKeyRepository, *APIKeyUseCase) {
+	ctrl := gomock.NewController(t)
+	apiKeyRepo := mockgen.NewMockIAPIKeyRepository(ctrl)
+	useCase := NewAPIKeyUseCase(apiKeyRepo).(*APIKeyUseCase)
+	useCase.now = func() time.Time { return apiKeyNow }
+	return ctrl, apiKeyRepo, useCase
+}
+
+func TestCreateAPIKeyStoresOnlyTheHash(t *testing.T) {
+	ctrl, apiKeyRepo, useCase := setupAPIKeyTest(t)
+	defer ctrl.Finish()
+
+	var stored *domain.APIKey
+	apiKeyRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(key *domain.APIKey) error {
+		stored = key
+		key.ID = 3
+		return nil
+	})
+
+	created, err := useCase.CreateKey("auth0|global", CreateAPIKeyRequest{
+		Name:   "City mobility office",
+		Scopes: []string{domain.APIScopeHistoryRead, domain.APIScopeAvailabilityRead, domain.APIScopeHistoryRead},
+	})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Key, apiKeyPrefix))
+	assert.Equal(t, hashToken(created.Key), stored.KeyHash)
+	assert.True(t, strings.HasPrefix(created.Key, stored.Prefix))
+	assert.Equal(t, "availability:read,history:read", stored.Scopes)
+	assert.Equal(t, uint(DefaultAPIKeyRateLimit), stored.RateLimitPerMinute)
+	assert.Equal(t, []string{domain.APIScopeAvailabilityRead, domain.APIScopeHistoryRead}, created.Scopes)
+}
+
+func TestCreateAPIKeyRejectsUnknownScopes(t *testing.T) {
+	ctrl, _, useCase := setupAPIKeyTest(t)
+	defer ctrl.Finish()
+
+	_, err := useCase.CreateKey("auth0|global", CreateAPIKeyRequest{Name: "Navigation app", Scopes: []string{"admin"}})
+	assert.True(t, errors.Is(err, ErrUnknownAPIScope))
+}
+
+func TestAuthenticateAPIKeyCountsUsage(t *testing.T) {
+	ctrl, apiKeyRepo, useCase := setupAPIKeyTest(t)
+	defer ctrl.Finish()
+
+	key := &domain.APIKey{ID: 3, Scopes: "availability:read", RateLimitPerMinute: 60, DailyQuota: 1000}
+	day := time.Date(2024, time.November, 4, 0, 0, 0, 0, time.UTC)
+	apiKeyRepo.EXPECT().FindByHash(hashToken("prk_secret")).Return(key, nil)
+	apiKeyRepo.EXPECT().GetUsage(uint(3), day).Return(&domain.APIKeyUsage{Requests: 999}, nil)
+	apiKeyRepo.EXPECT().IncrementUsage(uint(3), day, false).Return(nil)
+	apiKeyRepo.EXPECT().TouchLastUsed(uint(3), apiKeyNow).Return(nil)
+
+	authenticated, err := useCase.AuthenticateAPIKey("prk_secret", domain.APIScopeAvailabilityRead)
+	assert.NoError(t, err)
+	assert.Equal(t, key, authenticated)
+}
+
+func TestAuthenticateAPIKeyRejectsExpiredRevokedAndUnknownKeys(t *testing.T) {
+	ctrl, apiKeyRepo, useCase := setupAPIKeyTest(t)
+	defer ctrl.Finish()
+
+	expired := apiKeyNow.Add(-time.Minute)
+	apiKeyRepo.EXPECT().FindByHash(hashToken("prk_expired")).Return(&domain.APIKey{ID: 1, ExpiresAt: &expired}, nil)
+	apiKeyRepo.EXPECT().FindByHash(hashToken("prk_revoked")).Return(&domain.APIKey{ID: 2, RevokedAt: &expired}, nil)
+	apiKeyRepo.EXPECT().FindByHash(hashToken("prk_unknown")).Return(nil, nil)
+
+	for _, key := range []string{"prk_expired", "prk_revoked", "prk_unknown", "not-a-key"} {
+		_, err := useCase.AuthenticateAPIKey(key, domain.APIScopeAvailabilityRead)
+		assert.True(t, errors.Is(err, ErrInvalidAPIKey), key)
+	}
+}
+
+func TestAuthenticateAPIKeyEnforcesScopeQuotaAndRateLimit(t *testing.T) {
+	ctrl, apiKeyRepo, useCase := setupAPIKeyTest(t)
+	defer ctrl.Finish()
+
+	day := time.Date(2024, time.November, 4, 0, 0, 0, 0, time.UTC)
+	limited := &domain.APIKey{ID: 4, Scopes: "availability:read", RateLimitPerMinute: 2, LastUsedAt: &apiKeyNow}
+	quota := &domain.APIKey{ID: 5, Scopes: "availability:read", RateLimitPerMinute: 60, DailyQuota: 10}
+
+	apiKeyRepo.EXPECT().FindByHash(hashToken("prk_limited")).Return(limited, nil).Times(4)
+	apiKeyRepo.EXPECT().IncrementUsage(uint(4), day, false).Return(nil).Times(2)
+	apiKeyRepo.EXPECT().IncrementUsage(uint(4), day, true).Return(nil).Times(2)
+	apiKeyRepo.EXPECT().FindByHash(hashToken("prk_quota")).Return(quota, nil)
+	apiKeyRepo.EXPECT().GetUsage(uint(5), day).Return(&domain.APIKeyUsage{Requests: 10}, nil)
+	apiKeyRepo.EXPECT().IncrementUsage(uint(5), day, true).Return(nil)
+
+	_, err := useCase.AuthenticateAPIKey("prk_limited", domain.APIScopeHistoryRead)
+	assert.True(t, errors.Is(err, ErrAPIKeyScope))
+	for i := 0; i < 2; i++ {
+		_, err = useCase.AuthenticateAPIKey("prk_limited", domain.APIScopeAvailabilityRead)
+		assert.NoError(t, err)
+	}
+	_, err = useCase.AuthenticateAPIKey("prk_limited", domain.APIScopeAvailabilityRead)
+	assert.True(t, errors.Is(err, ErrAPIKeyRateLimited))
+
+	_, err = useCase.AuthenticateAPIKey("prk_quota", domain.APIScopeAvailabilityRead)
+	assert.True(t, errors.Is(err, ErrAPIKeyQuotaExceeded))
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+)
+
+// rolePermissionCacheTTL is how long the role to permission mapping is kept in memory, so
+// changes made by another instance apply within this delay.
+const rolePermissionCacheTTL = time.Minute
+
+var (
+	ErrUnknownPermission = errors.New("unknown permission")
+	ErrInvalidRole       = errors.New("role must not be empty")
+	ErrGlobalAdminLocked = errors.New("admin_global must keep admin:manage")
+)
+
+type IAuthorizationUseCase interface {
+	PermissionsFor(roles []string) ([]string, error)
+	ListRolePermissions() (map[string][]string, error)
+	SetRolePermissions(role string, permissions []string) error
+}
+
+// AuthorizationUseCase resolves the permissions of admin roles from the mapping stored in the
+// database. The default mapping is stored by the migrations.
+type AuthorizationUseCase struct {
+	RolePermissionRepository repository.IRolePermissionRepository
+	mutex                    sync.Mutex
+	permissions              map[string][]string
+	loadedAt                 time.Time
+	now                      func() time.Time
+}
+
+// NewAuthorizationUseCase creates a new instance of AuthorizationUseCase.
+func NewAuthorizationUseCase(rolePermissionRepo repository.IRolePermissionRepository) IAuthorizationUseCase {
+	return &AuthorizationUseCase{
+		RolePermissionRepository: rolePermissionRepo,
+		now:                      time.Now,
+	}
+}
+
+// PermissionsFor returns the permissions granted by any of the roles, sorted and deduplicated.
+func (uc *AuthorizationUseCase) PermissionsFor(roles []string) ([]string, error) {
+	mapping, err := uc.mapping()
+	if err != nil {
+		return nil, err
+	}
+
+	var permissions []string
+	for _, role := range roles {
+		permissions = append(permissions, mapping[role]...)
+	}
+	sort.Strings(permissions)
+	return slices.Compact(permissions), nil
+}
+
+// ListRolePermissions returns the permissions granted to each role.
+func (uc *AuthorizationUseCase) ListRolePermissions() (map[string][]string, error) {
+	mapping, err := uc.mapping()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]string, len(mapping))
+	for role, permissions := range mapping {
+		result[role] = append([]string{}, permissions...)
+	}
+	return result, nil
+}
+
+// SetRolePermissions replaces the permissions granted to the role.
+func (uc *AuthorizationUseCase) SetRolePermissions(role string, permissions []string) error {
+	role = strings.TrimSpace(role)
+	if role == "" {
+		return ErrInvalidRole
+	}
+	for _, permission := range permissions {
+		if !slices.Contains(domain.Permissions, permission) {
+			return fmt.Errorf("%w: %s", ErrUnknownPermission, permission)
+		}
+	}
+	// Without admin:manage no global admin could reach the roles again.
+	if role == domain.RoleAdminGlobal && !slices.Contains(permissions, domain.PermissionAdminManage) {
+		return ErrGlobalAdminLocked
+	}
+
+	sorted := append([]string{}, permissions...)
+	sort.Strings(sorted)
+	sorted = slices.Compact(sorted)
+	if err := uc.RolePermissionRepository.ReplaceRole(role, sorted); err != nil {
+		return err
+	}
+
+	uc.mutex.Lock()
+	defer uc.mutex.Unlock()
+	uc.permissions = nil
+	return nil
+}
+
+// mapping returns the cached role to permission mapping, loading it when stale.
+func (uc *AuthorizationUseCase) mapping() (map[string][]string, error) {
+	uc.mutex.Lock()
+	defer uc.mutex.Unlock()
+
+	now := uc.now()
+	if uc.permissions != nil && now.Before(uc.loadedAt.Add(rolePermissionCacheTTL)) {
+		return uc.permissions, nil
+	}
+
+	rolePermissions, err := uc.RolePermissionRepository.ListAll()
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := make(map[string][]string)
+	for _, rolePermission := range rolePermissions {
+		// Rows of retired permissions may still be stored; they grant nothing.
+		if !slices.Contains(domain.Permissions, rolePermission.Permission) {
+			continue
+		}
+		mapping[rolePermission.Role] = append(mapping[rolePermission.Role], rolePermission.Permission)
+	}
+	uc.permissions = mapping
+	uc.loadedAt = now
+	return mapping, nil
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupAuthorizationTest(t *testing.T) (*gomock.Controller, *mockgen.MockIRolePermissionRepository, *AuthorizationUseCase, *time.Time) {
+	ctrl := gomock.NewController(t)
+	rolePermissionRepo := mockgen.NewMockIRolePermissionRepository(ctrl)
+	useCase := NewAuthorizationUseCase(rolePermissionRepo).(*AuthorizationUseCase)
+	now := time.Date(2024, time.October, 1, 9, 0, 0, 0, time.UTC)
+	useCase.now = func() time.Time { return now }
+	return ctrl, rolePermissionRepo, useCase, &now
+}
+
+func TestPermissionsForMergesRolesAndCachesMapping(t *testing.T) {
+	ctrl, rolePermissionRepo, useCase, now := setupAuthorizationTest(t)
+	defer ctrl.Finish()
+
+	rolePermissionRepo.EXPECT().ListAll().Return([]domain.RolePermission{
+		{Role: "operator", Permission: domain.PermissionLotRead},
+		{Role: "operator", Permission: domain.PermissionLotWrite},
+		{Role: "auditor", Permission: domain.PermissionLotRead},
+		{Role: "auditor", Permission: domain.PermissionReportRead},
+	}, nil).Times(1)
+
+	permissions, err := useCase.PermissionsFor([]string{"operator", "auditor", "unknown"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{domain.PermissionLotRead, domain.PermissionLotWrite, domain.PermissionReportRead}, permissions)
+
+	*now = now.Add(30 * time.Second)
+	permissions, err = useCase.PermissionsFor([]string{"auditor"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{domain.PermissionLotRead, domain.PermissionReportRead}, permissions)
+}
+
+func TestPermissionsForLeavesAnEmptyMappingAlone(t *testing.T) {
+	ctrl, rolePermissionRepo, useCase, _ := setupAuthorizationTest(t)
+	defer ctrl.Finish()
+
+	// The defaults are stored by the migrations; the mock fails on any write.
+	rolePermissionRepo.EXPECT().ListAll().Return(nil, nil)
+
+	permissions, err := useCase.PermissionsFor([]string{domain.RoleAdminGlobal})
+	assert.NoError(t, err)
+	assert.Empty(t, permissions)
+}
+
+func TestPermissionsForIgnoresRetiredPermissions(t *testing.T) {
+	ctrl, rolePermissionRepo, useCase, _ := setupAuthorizationTest(t)
+	defer ctrl.Finish()
+
+	rolePermissionRepo.EXPECT().ListAll().Return([]domain.RolePermission{
+		{Role: domain.RoleAdminDefault, Permission: "admin:register"},
+		{Role: domain.RoleAdminLocal, Permission: domain.PermissionLotRead},
+	}, nil)
+
+	permissions, err := useCase.PermissionsFor([]string{domain.RoleAdminDefault, domain.RoleAdminLocal})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{domain.PermissionLotRead}, permissions)
+}
+
+func TestSetRolePermissionsRejectsUnknownPermissions(t *testing.T) {
+	ctrl, _, useCase, _ := setupAuthorizationTest(t)
+	defer ctrl.Finish()
+
+	err := useCase.SetRolePermissions("operator", []string{domain.PermissionLotRead, "lot:destroy"})
+	assert.True(t, errors.Is(err, ErrUnknownPermission))
+}
+
+func TestSetRolePermissionsKeepsAdminManageOnGlobalAdmins(t *testing.T) {
+	ctrl, rolePermissionRepo, useCase, _ := setupAuthorizationTest(t)
+	defer ctrl.Finish()
+
+	err := useCase.SetRolePermissions(" admin_global ", []string{domain.PermissionLotAll})
+	assert.ErrorIs(t, err, ErrGlobalAdminLocked)
+	assert.ErrorIs(t, useCase.SetRolePermissions(domain.RoleAdminGlobal, nil), ErrGlobalAdminLocked)
+
+	rolePermissionRepo.EXPECT().ReplaceRole(domain.RoleAdminGlobal, []string{domain.PermissionAdminManage}).Return(nil)
+	assert.NoError(t, useCase.SetRolePermissions(domain.RoleAdminGlobal, []string{domain.PermissionAdminManage}))
+}
+
+func TestSetRolePermissionsReloadsMapping(t *testing.T) {
+	ctrl, rolePermissionRepo, useCase, _ := setupAuthorizationTest(t)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		rolePermissionRepo.EXPECT().ListAll().Return([]domain.RolePermission{{Role: "operator", Permission: domain.PermissionLotRead}}, nil),
+		rolePermissionRepo.EXPECT().ReplaceRole("operator", []string{domain.PermissionLotRead, domain.PermissionLotWrite}).Return(nil),
+		rolePermissionRepo.EXPECT().ListAll().Return([]domain.RolePermission{
+			{Role: "operator", Permission: domain.PermissionLotRead},
+			{Role: "operator", Permission: domain.PermissionLotWrite},
+		}, nil),
+	)
+
+	_, err := useCase.PermissionsFor([]string{"operator"})
+	assert.NoError(t, err)
+	assert.NoError(t, useCase.SetRolePermissions("operator", []string{domain.PermissionLotWrite, domain.PermissionLotRead, domain.PermissionLotWrite}))
+
+	permissions, err := useCase.PermissionsFor([]string{"operator"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{domain.PermissionLotRead, domain.PermissionLotWrite}, permissions)
+}
@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+)
+
+const (
+	AvailabilitySourceSensors = "sensors"
+	AvailabilitySourceCrowd   = "crowd"
+	AvailabilitySourceNone    = "none"
+
+	// crowdReportMaxAge is how long a crowd report counts toward the estimate.
+	crowdReportMaxAge = 2 * time.Hour
+	// crowdReportHalfLife is the age at which a report weighs half as much as a fresh one.
+	crowdReportHalfLife = 20 * time.Minute
+	// crowdReportCooldown is how long a driver waits before reporting the same lot again.
+	crowdReportCooldown = 10 * time.Minute
+	// crowdAgreementWindow is how close in time two reports must be to compare them for reputation.
+	crowdAgreementWindow = 30 * time.Minute
+)
+
+// crowdLevelFreeShare is the share of free spots each reported level stands for.
+var crowdLevelFreeShare = map[string]float64{
+	domain.CrowdLevelFull:   0,
+	domain.CrowdLevelFew:    0.2,
+	domain.CrowdLevelPlenty: 0.7,
+}
+
+var (
+	ErrInvalidCrowdLevel  = errors.New("level must be one of full, few or plenty")
+	ErrCrowdReportTooSoon = errors.New("you already reported this parking lot recently")
+	ErrLotHasSensors      = errors.New("parking lot availability comes from its sensors")
+)
+
+type ICrowdReportUseCase interface {
+	SubmitReport(userID uint, req CrowdReportRequest) (*CrowdReportResponse, error)
+}
+
+type CrowdReportUseCase struct {
+	CrowdReportRepository repository.ICrowdReportRepository
+	ParkingLotRepository  repository.IParkingLotRepository
+	SensorRepository      repository.ISensorRepository
+	now                   func() time.Time
+}
+
+type CrowdReportRequest struct {
+	ParkingLotID uint   `json:"parking_lot_id" binding:"required"`
+	Level        string `json:"level" binding:"required"`
+}
+
+// CrowdEstimate is the availability of a lot without sensors estimated from driver reports.
+// FreeShare is the estimated share of free spots; EstimatedSpaces is only known when the lot
+// declares its capacity.
+type CrowdEstimate struct {
+	Level           string    `json:"level"`
+	FreeShare       float64   `json:"free_share"`
+	EstimatedSpaces *uint     `json:"estimated_spaces,omitempty"`
+	Confidence      float64   `json:"confidence"`
+	Reports         int       `json:"reports"`
+	LastReportedAt  time.Time `json:"last_reported_at"`
+}
+
+type CrowdReportResponse struct {
+	Report   domain.CrowdReport `json:"report"`
+	Estimate *CrowdEstimate     `json:"estimate"`
+}
+
+// NewCrowdReportUseCase creates a new instance of CrowdReportUseCase.
+func NewCrowdReportUseCase(reportRepo repository.ICrowdReportRepository, parkingLotRepo repository.IParkingLotRepository, sensorRepo repository.ISensorRepository) ICrowdReportUseCase {
+	return &CrowdReportUseCase{
+		CrowdReportRepository: reportRepo,
+		ParkingLotRepository:  parkingLotRepo,
+		SensorRepository:      sensorRepo,
+		now:                   time.Now,
+	}
+}
+
+// SubmitReport records how full a driver says a lot without sensors is. The report weighs as
+// much as the driver's reputation, which grows when their reports agree with the ones other
+// drivers made about the same lot shortly before.
+func (uc *CrowdReportUseCase) SubmitReport(userID uint, req CrowdReportRequest) (*CrowdReportResponse, error) {
+	if _, ok := crowdLevelFreeShare[req.Level]; !ok {
+		return nil, ErrInvalidCrowdLevel
+	}
+
+	parkingLot, err := uc.ParkingLotRepository.GetByID(req.ParkingLotID)
+	if err != nil {
+		return nil, err
+	}
+	sensors, err := uc.SensorRepository.ListByParkingLot(parkingLot.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(sensors) > 0 {
+		return nil, ErrLotHasSensors
+	}
+
+	now := uc.now()
+	latest, err := uc.CrowdReportRepository.FindLatestByUser(userID, parkingLot.ID)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && now.Sub(latest.ReportedAt) < crowdReportCooldown {
+		return nil, ErrCrowdReportTooSoon
+	}
+
+	reputation, err := uc.CrowdReportRepository.GetReputation(userID)
+	if err != nil {
+		return nil, err
+	}
+	weight := domain.ReporterReputation{}.Score()
+	if reputation != nil {
+		weight = reputation.Score()
+	}
+
+	recent, err := uc.CrowdReportRepository.ListByParkingLotSince(parkingLot.ID, now.Add(-crowdReportMaxAge))
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.updateReputations(userID, req.Level, recent, now); err != nil {
+		return nil, err
+	}
+
+	report := domain.CrowdReport{
+		UserID:       userID,
+		ParkingLotID: parkingLot.ID,
+		Level:        req.Level,
+		Weight:       weight,
+		ReportedAt:   now,
+	}
+	if err := uc.CrowdReportRepository.Create(&report); err != nil {
+		return nil, err
+	}
+
+	return &CrowdReportResponse{
+		Report:   report,
+		Estimate: estimateCrowdAvailability(append(recent, report), parkingLot.Capacity, now),
+	}, nil
+}
+
+// updateReputations compares the new report with the latest report of every other driver made
+// within crowdAgreementWindow. Equal levels agree; full against plenty disagree.
+func (uc *CrowdReportUseCase) updateReputations(userID uint, level string, recent []domain.CrowdReport, now time.Time) error {
+	latestByUser := make(map[uint]domain.CrowdReport)
+	for _, report := range recent {
+		if report.UserID == userID || now.Sub(report.ReportedAt) > crowdAgreementWindow {
+			continue
+		}
+		latestByUser[report.UserID] = report
+	}
+
+	var agreements, disagreements uint
+	for peerID, peer := range latestByUser {
+		switch {
+		case peer.Level == level:
+			agreements++
+			if err := uc.CrowdReportRepository.AdjustReputation(peerID, 1, 0); err != nil {
+				return err
+			}
+		case math.Abs(crowdLevelFreeShare[peer.Level]-crowdLevelFreeShare[level]) > 0.5:
+			disagreements++
+			if err := uc.CrowdReportRepository.AdjustReputation(peerID, 0, 1); err != nil {
+				return err
+			}
+		}
+	}
+
+	if agreements == 0 && disagreements == 0 {
+		return nil
+	}
+	return uc.CrowdReportRepository.AdjustReputation(userID, agreements, disagreements)
+}
+
+// estimateCrowdAvailability averages the reports weighted by reporter reputation, halving the
+// weight of a report every crowdReportHalfLife. It returns nil when no report counts anymore.
+func estimateCrowdAvailability(reports []domain.CrowdReport, capacity uint, now time.Time) *CrowdEstimate {
+	var totalWeight, weightedShare float64
+	var counted int
+	var lastReportedAt time.Time
+	for _, report := range reports {
+		age := now.Sub(report.ReportedAt)
+		if age < 0 {
+			age = 0
+		}
+		if age > crowdReportMaxAge {
+			continue
+		}
+
+		weight := report.Weight * math.Pow(0.5, float64(age)/float64(crowdReportHalfLife))
+		totalWeight += weight
+		weightedShare += weight * crowdLevelFreeShare[report.Level]
+		counted++
+		if report.ReportedAt.After(lastReportedAt) {
+			lastReportedAt = report.ReportedAt
+		}
+	}
+	if totalWeight == 0 {
+		return nil
+	}
+
+	share := weightedShare / totalWeight
+	estimate := &CrowdEstimate{
+		Level:          crowdLevelFor(share),
+		FreeShare:      roundTo(share, 2),
+		Confidence:     roundTo(1-math.Exp(-totalWeight), 2),
+		Reports:        counted,
+		LastReportedAt: lastReportedAt,
+	}
+	if capacity > 0 {
+		spaces := uint(math.Round(share * float64(capacity)))
+		estimate.EstimatedSpaces = &spaces
+	}
+	return estimate
+}
+
+// crowdLevelFor maps an estimated share of free spots back to the closest reported level.
+func crowdLevelFor(share float64) string {
+	switch {
+	case share < 0.1:
+		return domain.CrowdLevelFull
+	case share < 0.45:
+		return domain.CrowdLevelFew
+	default:
+		return domain.CrowdLevelPlenty
+	}
+}
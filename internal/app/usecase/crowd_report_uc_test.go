@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var crowdNow = time.Date(2024, time.September, 10, 8, 0, 0, 0, time.UTC)
+
+func setupCrowdReportTest(t *testing.T) (*gomock.Controller, *mockgen.MockICrowdReportRepository, *mockgen.MockIParkingLotRepository, *mockgen.MockISensorRepository, *CrowdReportUseCase) {
+	ctrl := gomock.NewController(t)
+	reportRepo := mockgen.NewMockICrowdReportRepository(ctrl)
+	parkingLotRepo := mockgen.NewMockIParkingLotRepository(ctrl)
+	sensorRepo := mockgen.NewMockISensorRepository(ctrl)
+	useCase := NewCrowdReportUseCase(reportRepo, parkingLotRepo, sensorRepo).(*CrowdReportUseCase)
+	useCase.now = func() time.Time { return crowdNow }
+	return ctrl, reportRepo, parkingLotRepo, sensorRepo, useCase
+}
+
+func TestSubmitCrowdReportAdjustsReputations(t *testing.T) {
+	ctrl, reportRepo, parkingLotRepo, sensorRepo, useCase := setupCrowdReportTest(t)
+	defer ctrl.Finish()
+
+	parkingLotRepo.EXPECT().GetByID(uint(1)).Return(&domain.ParkingLot{ID: 1, Capacity: 50}, nil)
+	sensorRepo.EXPECT().ListByParkingLot(uint(1)).Return(nil, nil)
+	reportRepo.EXPECT().FindLatestByUser(uint(7), uint(1)).Return(nil, nil)
+	reportRepo.EXPECT().GetReputation(uint(7)).Return(&domain.ReporterReputation{Agreements: 3, Disagreements: 1}, nil)
+	reportRepo.EXPECT().ListByParkingLotSince(uint(1), crowdNow.Add(-crowdReportMaxAge)).Return([]domain.CrowdReport{
+		{UserID: 8, ParkingLotID: 1, Level: domain.CrowdLevelPlenty, Weight: 0.5, ReportedAt: crowdNow.Add(-10 * time.Minute)},
+		{UserID: 9, ParkingLotID: 1, Level: domain.CrowdLevelFull, Weight: 0.5, ReportedAt: crowdNow.Add(-5 * time.Minute)},
+		{UserID: 10, ParkingLotID: 1, Level: domain.CrowdLevelPlenty, Weight: 0.5, ReportedAt: crowdNow.Add(-90 * time.Minute)},
+	}, nil)
+	reportRepo.EXPECT().AdjustReputation(uint(8), uint(1), uint(0)).Return(nil)
+	reportRepo.EXPECT().AdjustReputation(uint(9), uint(0), uint(1)).Return(nil)
+	reportRepo.EXPECT().AdjustReputation(uint(7), uint(1), uint(1)).Return(nil)
+	reportRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	response, err := useCase.SubmitReport(7, CrowdReportRequest{ParkingLotID: 1, Level: domain.CrowdLevelPlenty})
+	assert.NoError(t, err)
+	assert.Equal(t, 4.0/6.0, response.Report.Weight)
+	assert.Equal(t, 4, response.Estimate.Reports)
+	assert.NotNil(t, response.Estimate.EstimatedSpaces)
+}
+
+func TestSubmitCrowdReportRejectsLotsWithSensors(t *testing.T) {
+	ctrl, _, parkingLotRepo, sensorRepo, useCase := setupCrowdReportTest(t)
+	defer ctrl.Finish()
+
+	parkingLotRepo.EXPECT().GetByID(uint(1)).Return(&domain.ParkingLot{ID: 1}, nil)
+	sensorRepo.EXPECT().ListByParkingLot(uint(1)).Return([]domain.Sensor{{ID: 3, ParkingLotID: 1}}, nil)
+
+	_, err := useCase.SubmitReport(7, CrowdReportRequest{ParkingLotID: 1, Level: domain.CrowdLevelFull})
+	assert.ErrorIs(t, err, ErrLotHasSensors)
+}
+
+func TestSubmitCrowdReportEnforcesCooldown(t *testing.T) {
+	ctrl, reportRepo, parkingLotRepo, sensorRepo, useCase := setupCrowdReportTest(t)
+	defer ctrl.Finish()
+
+	parkingLotRepo.EXPECT().GetByID(uint(1)).Return(&domain.ParkingLot{ID: 1}, nil)
+	sensorRepo.EXPECT().ListByParkingLot(uint(1)).Return(nil, nil)
+	reportRepo.EXPECT().FindLatestByUser(uint(7), uint(1)).Return(&domain.CrowdReport{ReportedAt: crowdNow.Add(-3 * time.Minute)}, nil)
+
+	_, err := useCase.SubmitReport(7, CrowdReportRequest{ParkingLotID: 1, Level: domain.CrowdLevelFew})
+	assert.ErrorIs(t, err, ErrCrowdReportTooSoon)
+}
+
+func TestSubmitCrowdReportRejectsUnknownLevel(t *testing.T) {
+	ctrl, _, _, _, useCase := setupCrowdReportTest(t)
+	defer ctrl.Finish()
+
+	_, err := useCase.SubmitReport(7, CrowdReportRequest{ParkingLotID: 1, Level: "packed"})
+	assert.ErrorIs(t, err, ErrInvalidCrowdLevel)
+}
+
+func TestEstimateCrowdAvailabilityFavoursFreshReputableReports(t *testing.T) {
+	reports := []domain.CrowdReport{
+		{Level: domain.CrowdLevelPlenty, Weight: 0.5, ReportedAt: crowdNow.Add(-60 * time.Minute)},
+		{Level: domain.CrowdLevelFull, Weight: 0.9, ReportedAt: crowdNow.Add(-2 * time.Minute)},
+		{Level: domain.CrowdLevelPlenty, Weight: 0.9, ReportedAt: crowdNow.Add(-3 * time.Hour)},
+	}
+
+	estimate := estimateCrowdAvailability(reports, 20, crowdNow)
+	assert.Equal(t, domain.CrowdLevelFull, estimate.Level)
+	assert.Equal(t, 2, estimate.Reports)
+	assert.Equal(t, crowdNow.Add(-2*time.Minute), estimate.LastReportedAt)
+	assert.Less(t, estimate.FreeShare, 0.1)
+
+	assert.Nil(t, estimateCrowdAvailability(reports[2:], 20, crowdNow))
+}
@@ -0,0 +1,283 @@
+package usecase
+
+import (
+	"sort"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+)
+
+const (
+	deviceOnlineWindow = 5 * time.Minute
+	maxRecentAlerts    = 10
+
+	AlertDeviceOffline  = "device_offline"
+	AlertSensorFault    = "sensor_fault"
+	AlertParkingLotFull = "parking_lot_full"
+)
+
+type IDashboardUseCase interface {
+	GetDashboard(adminUUID string, isGlobalAdmin bool) (*DashboardResponse, error)
+}
+
+type DashboardUseCase struct {
+	AdminRepository           repository.IAdminRepository
+	ParkingLotRepository      repository.IParkingLotRepository
+	SensorRepository          repository.ISensorRepository
+	Esp32DeviceRepository     repository.IEsp32DeviceRepository
+	OccupancySampleRepository repository.IOccupancySampleRepository
+	OrganizationRepository    repository.IOrganizationRepository
+	now                       func() time.Time
+}
+
+type DashboardSummary struct {
+	TotalParkingLots int              `json:"total_parking_lots"`
+	TotalSpaces      uint             `json:"total_spaces"`
+	FreeSpaces       uint             `json:"free_spaces"`
+	DevicesOnline    int              `json:"devices_online"`
+	DevicesOffline   int              `json:"devices_offline"`
+	SensorsInFault   int              `json:"sensors_in_fault"`
+	PeakOccupancy    *PeakOccupancy   `json:"peak_occupancy_today"`
+	RecentAlerts     []DashboardAlert `json:"recent_alerts"`
+}
+
+type PeakOccupancy struct {
+	ParkingLotID   uint      `json:"parking_lot_id"`
+	OccupiedSpaces uint      `json:"occupied_spaces"`
+	TotalSpaces    uint      `json:"total_spaces"`
+	OccupancyPct   float64   `json:"occupancy_pct"`
+	At             time.Time `json:"at"`
+}
+
+type DashboardAlert struct {
+	Type         string    `json:"type"`
+	ParkingLotID uint      `json:"parking_lot_id"`
+	Message      string    `json:"message"`
+	Since        time.Time `json:"since"`
+}
+
+// OperatorDashboard is the summary of the parking lots of one organization.
+type OperatorDashboard struct {
+	OrganizationID uint   `json:"organization_id"`
+	Name           string `json:"name"`
+	DashboardSummary
+}
+
+type DashboardResponse struct {
+	DashboardSummary
+	Operators []OperatorDashboard `json:"operators,omitempty"`
+}
+
+// NewDashboardUseCase creates a new instance of DashboardUseCase.
+func NewDashboardUseCase(adminRepo repository.IAdminRepository, parkingLotRepo repository.IParkingLotRepository, sensorRepo repository.ISensorRepository, esp32DeviceRepo repository.IEsp32DeviceRepository, sampleRepo repository.IOccupancySampleRepository, organizationRepo repository.IOrganizationRepository) IDashboardUseCase {
+	return &DashboardUseCase{
+		AdminRepository:           adminRepo,
+		ParkingLotRepository:      parkingLotRepo,
+		SensorRepository:          sensorRepo,
+		Esp32DeviceRepository:     esp32DeviceRepo,
+		OccupancySampleRepository: sampleRepo,
+		OrganizationRepository:    organizationRepo,
+		now:                       time.Now,
+	}
+}
+
+// GetDashboard summarizes the parking lots covered by the memberships of the admin. Global
+// admins get the summary of every parking lot plus a breakdown per operator.
+func (uc *DashboardUseCase) GetDashboard(adminUUID string, isGlobalAdmin bool) (*DashboardResponse, error) {
+	if !isGlobalAdmin {
+		admin, err := uc.AdminRepository.FindByAuth0UUID(adminUUID)
+		if err != nil {
+			return nil, err
+		}
+		parkingLots, err := uc.ParkingLotRepository.FindByMember(admin.ID)
+		if err != nil {
+			return nil, err
+		}
+		lots, err := uc.loadLots(parkingLots)
+		if err != nil {
+			return nil, err
+		}
+		return &DashboardResponse{DashboardSummary: uc.summarize(lots)}, nil
+	}
+
+	parkingLots, err := uc.ParkingLotRepository.List()
+	if err != nil {
+		return nil, err
+	}
+	lots, err := uc.loadLots(parkingLots)
+	if err != nil {
+		return nil, err
+	}
+
+	organizations, err := uc.OrganizationRepository.List()
+	if err != nil {
+		return nil, err
+	}
+
+	lotsByOrganization := make(map[uint][]dashboardLot)
+	for _, lot := range lots {
+		lotsByOrganization[lot.OrganizationID] = append(lotsByOrganization[lot.OrganizationID], lot)
+	}
+
+	response := &DashboardResponse{DashboardSummary: uc.summarize(lots), Operators: []OperatorDashboard{}}
+	for _, organization := range organizations {
+		response.Operators = append(response.Operators, OperatorDashboard{
+			OrganizationID:   organization.ID,
+			Name:             organization.Name,
+			DashboardSummary: uc.summarize(lotsByOrganization[organization.ID]),
+		})
+	}
+
+	return response, nil
+}
+
+// dashboardLot is the state of a parking lot the summaries are built from.
+type dashboardLot struct {
+	domain.ParkingLot
+	sensors []domain.Sensor
+	devices []domain.Esp32Device
+	samples []domain.OccupancySample
+}
+
+// loadLots reads the sensors, devices and today's occupancy samples of each lot once, so that
+// the same lots can be summarized several times without querying them again.
+func (uc *DashboardUseCase) loadLots(parkingLots []domain.ParkingLot) ([]dashboardLot, error) {
+	local := uc.now().In(helpers.BogotaLocation)
+	startOfDay := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, helpers.BogotaLocation)
+
+	devices := make(map[uint64]*domain.Esp32Device)
+	lots := make([]dashboardLot, 0, len(parkingLots))
+	for _, parkingLot := range parkingLots {
+		lot := dashboardLot{ParkingLot: parkingLot}
+
+		sensors, err := uc.SensorRepository.ListByParkingLot(parkingLot.ID)
+		if err != nil {
+			return nil, err
+		}
+		lot.sensors = sensors
+
+		seen := make(map[uint64]bool)
+		for _, sensor := range sensors {
+			deviceID := uint64(sensor.Esp32DeviceID)
+			if seen[deviceID] {
+				continue
+			}
+			seen[deviceID] = true
+
+			device, loaded := devices[deviceID]
+			if !loaded {
+				device, err = uc.Esp32DeviceRepository.GetByID(deviceID)
+				if err != nil {
+					return nil, err
+				}
+				devices[deviceID] = device
+			}
+			if device != nil {
+				lot.devices = append(lot.devices, *device)
+			}
+		}
+
+		samples, err := uc.OccupancySampleRepository.ListByParkingLotSince(parkingLot.ID, startOfDay)
+		if err != nil {
+			return nil, err
+		}
+		lot.samples = samples
+
+		lots = append(lots, lot)
+	}
+	return lots, nil
+}
+
+// summarize aggregates the current sensor and device state and today's occupancy of the lots.
+func (uc *DashboardUseCase) summarize(lots []dashboardLot) DashboardSummary {
+	now := uc.now()
+
+	summary := DashboardSummary{
+		TotalParkingLots: len(lots),
+		RecentAlerts:     []DashboardAlert{},
+	}
+	seenDevices := make(map[uint64]bool)
+
+	for _, lot := range lots {
+		free := countAvailableSpaces(lot.sensors)
+		summary.TotalSpaces += uint(len(lot.sensors))
+		summary.FreeSpaces += free
+		if len(lot.sensors) > 0 && free == 0 {
+			summary.RecentAlerts = append(summary.RecentAlerts, DashboardAlert{
+				Type:         AlertParkingLotFull,
+				ParkingLotID: lot.ID,
+				Message:      lot.Name + " has no free spaces",
+				Since:        latestSensorUpdate(lot.sensors),
+			})
+		}
+
+		for _, sensor := range lot.sensors {
+			if sensor.Status == domain.SensorStatusFault {
+				summary.SensorsInFault++
+				summary.RecentAlerts = append(summary.RecentAlerts, DashboardAlert{
+					Type:         AlertSensorFault,
+					ParkingLotID: lot.ID,
+					Message:      "sensor " + sensor.DeviceIdentifier + " reports a fault",
+					Since:        sensor.UpdatedAt,
+				})
+			}
+		}
+
+		for _, device := range lot.devices {
+			if seenDevices[device.ID] {
+				continue
+			}
+			seenDevices[device.ID] = true
+
+			if now.Sub(device.LastCommunication) <= deviceOnlineWindow {
+				summary.DevicesOnline++
+				continue
+			}
+			summary.DevicesOffline++
+			summary.RecentAlerts = append(summary.RecentAlerts, DashboardAlert{
+				Type:         AlertDeviceOffline,
+				ParkingLotID: lot.ID,
+				Message:      "device " + device.DeviceIdentifier + " is offline",
+				Since:        device.LastCommunication,
+			})
+		}
+
+		for _, sample := range lot.samples {
+			if sample.TotalSpaces == 0 {
+				continue
+			}
+			occupied := sample.TotalSpaces - sample.FreeSpaces
+			pct := roundTo(100*float64(occupied)/float64(sample.TotalSpaces), 2)
+			if summary.PeakOccupancy == nil || pct > summary.PeakOccupancy.OccupancyPct {
+				summary.PeakOccupancy = &PeakOccupancy{
+					ParkingLotID:   lot.ID,
+					OccupiedSpaces: occupied,
+					TotalSpaces:    sample.TotalSpaces,
+					OccupancyPct:   pct,
+					At:             sample.RecordedAt.In(helpers.BogotaLocation),
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(summary.RecentAlerts, func(i, j int) bool {
+		return summary.RecentAlerts[i].Since.After(summary.RecentAlerts[j].Since)
+	})
+	if len(summary.RecentAlerts) > maxRecentAlerts {
+		summary.RecentAlerts = summary.RecentAlerts[:maxRecentAlerts]
+	}
+
+	return summary
+}
+
+func latestSensorUpdate(sensors []domain.Sensor) time.Time {
+	var latest time.Time
+	for _, sensor := range sensors {
+		if sensor.UpdatedAt.After(latest) {
+			latest = sensor.UpdatedAt
+		}
+	}
+	return latest
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type dashboardMocks struct {
+	admins        *mockgen.MockIAdminRepository
+	parkingLots   *mockgen.MockIParkingLotRepository
+	sensors       *mockgen.MockISensorRepository
+	devices       *mockgen.MockIEsp32DeviceRepository
+	samples       *mockgen.MockIOccupancySampleRepository
+	organizations *mockgen.MockIOrganizationRepository
+}
+
+func newTestDashboardUseCase(ctrl *gomock.Controller, now time.Time) (*DashboardUseCase, dashboardMocks) {
+	mocks := dashboardMocks{
+		admins:        mockgen.NewMockIAdminRepository(ctrl),
+		parkingLots:   mockgen.NewMockIParkingLotRepository(ctrl),
+		sensors:       mockgen.NewMockISensorRepository(ctrl),
+		devices:       mockgen.NewMockIEsp32DeviceRepository(ctrl),
+		samples:       mockgen.NewMockIOccupancySampleRepository(ctrl),
+		organizations: mockgen.NewMockIOrganizationRepository(ctrl),
+	}
+	useCase := NewDashboardUseCase(mocks.admins, mocks.parkingLots, mocks.sensors, mocks.devices, mocks.samples, mocks.organizations).(*DashboardUseCase)
+	useCase.now = func() time.Time { return now }
+	return useCase, mocks
+}
+
+// expectDashboardLots makes every lot readable exactly once: lots 1 and 2 share the online device
+// 5, lot 3 has a faulty sensor on the offline device 6.
+func expectDashboardLots(mocks dashboardMocks, now time.Time) {
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, helpers.BogotaLocation)
+
+	mocks.sensors.EXPECT().ListByParkingLot(uint(1)).Return([]domain.Sensor{
+		{ID: 1, ParkingLotID: 1, Esp32DeviceID: 5, Status: domain.SensorStatusFree},
+		{ID: 2, ParkingLotID: 1, Esp32DeviceID: 5, Status: domain.SensorStatusOccupied},
+	}, nil)
+	mocks.sensors.EXPECT().ListByParkingLot(uint(2)).Return([]domain.Sensor{
+		{ID: 3, ParkingLotID: 2, Esp32DeviceID: 5, Status: domain.SensorStatusFree},
+	}, nil)
+	mocks.sensors.EXPECT().ListByParkingLot(uint(3)).Return([]domain.Sensor{
+		{ID: 4, ParkingLotID: 3, Esp32DeviceID: 6, Status: domain.SensorStatusFault, DeviceIdentifier: "s-4", UpdatedAt: now.Add(-time.Hour)},
+	}, nil)
+	mocks.devices.EXPECT().GetByID(uint64(5)).Return(&domain.Esp32Device{ID: 5, DeviceIdentifier: "esp-5", LastCommunication: now.Add(-time.Minute)}, nil)
+	mocks.devices.EXPECT().GetByID(uint64(6)).Return(&domain.Esp32Device{ID: 6, DeviceIdentifier: "esp-6", LastCommunication: now.Add(-2 * time.Hour)}, nil)
+	mocks.samples.EXPECT().ListByParkingLotSince(uint(1), startOfDay).Return([]domain.OccupancySample{
+		{ParkingLotID: 1, FreeSpaces: 1, TotalSpaces: 2, RecordedAt: now.Add(-3 * time.Hour)},
+	}, nil)
+	mocks.samples.EXPECT().ListByParkingLotSince(uint(2), startOfDay).Return(nil, nil)
+	mocks.samples.EXPECT().ListByParkingLotSince(uint(3), startOfDay).Return([]domain.OccupancySample{
+		{ParkingLotID: 3, FreeSpaces: 0, TotalSpaces: 1, RecordedAt: now.Add(-2 * time.Hour)},
+	}, nil)
+}
+
+func TestGetDashboardForAGlobalAdminReadsEachLotOnceAndGroupsByOrganization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, time.September, 10, 15, 0, 0, 0, helpers.BogotaLocation)
+	useCase, mocks := newTestDashboardUseCase(ctrl, now)
+
+	mocks.parkingLots.EXPECT().List().Return([]domain.ParkingLot{
+		{ID: 1, Name: "Norte", AdminID: 100, OrganizationID: 10},
+		{ID: 2, Name: "Centro", AdminID: 101, OrganizationID: 10},
+		{ID: 3, Name: "Sur", AdminID: 100, OrganizationID: 20},
+	}, nil)
+	mocks.organizations.EXPECT().List().Return([]domain.Organization{
+		{ID: 10, Name: "Parqueaderos Norte"},
+		{ID: 20, Name: "Parqueaderos Sur"},
+		{ID: 30, Name: "Sin lotes"},
+	}, nil)
+	expectDashboardLots(mocks, now)
+
+	dashboard, err := useCase.GetDashboard("global", true)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 3, dashboard.TotalParkingLots)
+	assert.Equal(t, uint(4), dashboard.TotalSpaces)
+	assert.Equal(t, uint(2), dashboard.FreeSpaces)
+	assert.Equal(t, 1, dashboard.DevicesOnline)
+	assert.Equal(t, 1, dashboard.DevicesOffline)
+	assert.Equal(t, 1, dashboard.SensorsInFault)
+	assert.Equal(t, uint(3), dashboard.PeakOccupancy.ParkingLotID)
+	assert.Equal(t, 100.0, dashboard.PeakOccupancy.OccupancyPct)
+
+	assert.Len(t, dashboard.Operators, 3)
+	north := dashboard.Operators[0]
+	assert.Equal(t, uint(10), north.OrganizationID)
+	assert.Equal(t, "Parqueaderos Norte", north.Name)
+	assert.Equal(t, 2, north.TotalParkingLots)
+	assert.Equal(t, uint(3), north.TotalSpaces)
+	assert.Equal(t, 1, north.DevicesOnline)
+	assert.Equal(t, 0, north.DevicesOffline)
+	assert.Equal(t, 50.0, north.PeakOccupancy.OccupancyPct)
+	assert.Empty(t, north.RecentAlerts)
+
+	south := dashboard.Operators[1]
+	assert.Equal(t, uint(20), south.OrganizationID)
+	assert.Equal(t, 1, south.TotalParkingLots)
+	assert.Equal(t, 1, south.SensorsInFault)
+	assert.Equal(t, 1, south.DevicesOffline)
+	assert.Len(t, south.RecentAlerts, 3)
+
+	empty := dashboard.Operators[2]
+	assert.Equal(t, 0, empty.TotalParkingLots)
+	assert.Nil(t, empty.PeakOccupancy)
+	assert.Empty(t, empty.RecentAlerts)
+}
+
+func TestGetDashboardForAnOperatorSummarizesTheLotsOfTheMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, time.September, 10, 15, 0, 0, 0, helpers.BogotaLocation)
+	useCase, mocks := newTestDashboardUseCase(ctrl, now)
+
+	mocks.admins.EXPECT().FindByAuth0UUID("operator").Return(&domain.Admin{ID: 100}, nil)
+	mocks.parkingLots.EXPECT().FindByMember(uint(100)).Return([]domain.ParkingLot{
+		{ID: 1, Name: "Norte", OrganizationID: 10},
+		{ID: 2, Name: "Centro", OrganizationID: 10},
+		{ID: 3, Name: "Sur", OrganizationID: 20},
+	}, nil)
+	expectDashboardLots(mocks, now)
+
+	dashboard, err := useCase.GetDashboard("operator", false)
+	assert.NoError(t, err)
+
+	assert.Nil(t, dashboard.Operators)
+	assert.Equal(t, 3, dashboard.TotalParkingLots)
+	assert.Equal(t, uint(2), dashboard.FreeSpaces)
+	assert.Equal(t, 1, dashboard.DevicesOnline)
+	assert.Equal(t, 1, dashboard.DevicesOffline)
+	if assert.Len(t, dashboard.RecentAlerts, 3) {
+		assert.Equal(t, AlertParkingLotFull, dashboard.RecentAlerts[0].Type)
+		assert.Equal(t, AlertSensorFault, dashboard.RecentAlerts[1].Type)
+		assert.Equal(t, AlertDeviceOffline, dashboard.RecentAlerts[2].Type)
+		assert.Equal(t, uint(3), dashboard.RecentAlerts[2].ParkingLotID)
+	}
+}
@@ -1,26 +1,38 @@
 package usecase
 
 import (
+	"errors"
+	"strings"
+	"time"
+
 	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
 	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
-	"time"
 )
 
+// deviceTokenPrefix marks the tokens devices report their sensors with.
+const deviceTokenPrefix = "prd_"
+
+var ErrInvalidDeviceToken = errors.New("invalid device token")
+
 type IEsp32DeviceUseCase interface {
-	CreateEsp32Device(req CreateEsp32DeviceRequest) error
+	CreateEsp32Device(req CreateEsp32DeviceRequest) (*Esp32DeviceTokenResponse, error)
 	GetEsp32Device(id uint64) (*Esp32DeviceResponse, error)
 	GetEsp32DeviceByIdentifier(identifier string) (*domain.Esp32Device, error)
 	UpdateEsp32Device(id uint64, req UpdateEsp32DeviceRequest) error
 	DeleteEsp32Device(id uint64) error
-	ListEsp32Devices() ([]domain.Esp32Device, error)
+	ListEsp32Devices(adminUUID string, global bool) ([]domain.Esp32Device, error)
+	RotateEsp32DeviceToken(id uint64) (*Esp32DeviceTokenResponse, error)
+	AuthenticateDevice(token string) (*domain.Esp32Device, error)
 }
 
 type Esp32DeviceUseCase struct {
 	Esp32DeviceRepository repository.IEsp32DeviceRepository
 	SensorRepository      repository.ISensorRepository
+	AdminRepository       repository.IAdminRepository
 }
 
 type CreateEsp32DeviceRequest struct {
+	ParkingLotID     uint   `json:"parking_lot_id" binding:"required"`
 	DeviceIdentifier string `json:"device_identifier"`
 }
 
@@ -30,27 +42,49 @@ type UpdateEsp32DeviceRequest struct {
 
 type Esp32DeviceResponse struct {
 	ID                uint64          `json:"id"`
+	ParkingLotID      uint            `json:"parking_lot_id"`
 	DeviceIdentifier  string          `json:"device_identifier"`
 	LastCommunication string          `json:"last_communication"`
 	Sensors           []domain.Sensor `json:"sensors"`
 }
 
-func NewEsp32DeviceUseCase(esp32DeviceRepo repository.IEsp32DeviceRepository, sensorRepo repository.ISensorRepository) IEsp32DeviceUseCase {
+// Esp32DeviceTokenResponse carries the token of a device; it is only returned when the token is
+// issued.
+type Esp32DeviceTokenResponse struct {
+	ID               uint64 `json:"id"`
+	ParkingLotID     uint   `json:"parking_lot_id"`
+	DeviceIdentifier string `json:"device_identifier"`
+	Token            string `json:"token"`
+}
+
+func NewEsp32DeviceUseCase(esp32DeviceRepo repository.IEsp32DeviceRepository, sensorRepo repository.ISensorRepository, adminRepo repository.IAdminRepository) IEsp32DeviceUseCase {
 	return &Esp32DeviceUseCase{
 		Esp32DeviceRepository: esp32DeviceRepo,
 		SensorRepository:      sensorRepo,
+		AdminRepository:       adminRepo,
 	}
 }
 
-func (uc *Esp32DeviceUseCase) CreateEsp32Device(req CreateEsp32DeviceRequest) error {
+// CreateEsp32Device registers a device on a parking lot and issues the token it reports with.
+func (uc *Esp32DeviceUseCase) CreateEsp32Device(req CreateEsp32DeviceRequest) (*Esp32DeviceTokenResponse, error) {
+	token, err := newDeviceToken()
+	if err != nil {
+		return nil, err
+	}
+
 	device := domain.Esp32Device{
+		ParkingLotID:     req.ParkingLotID,
 		DeviceIdentifier: req.DeviceIdentifier,
+		TokenHash:        hashToken(token),
+	}
+	if err := uc.Esp32DeviceRepository.Create(&device); err != nil {
+		return nil, err
 	}
-	return uc.Esp32DeviceRepository.Create(&device)
+	return newEsp32DeviceTokenResponse(device, token), nil
 }
 
 func (uc *Esp32DeviceUseCase) GetEsp32Device(id uint64) (*Esp32DeviceResponse, error) {
-	device, err := uc.Esp32DeviceRepository.GetByID(id)
+	device, err := uc.getEsp32Device(id)
 	if err != nil {
 		return nil, err
 	}
@@ -63,6 +97,7 @@ func (uc *Esp32DeviceUseCase) GetEsp32Device(id uint64) (*Esp32DeviceResponse, e
 
 	response := &Esp32DeviceResponse{
 		ID:                device.ID,
+		ParkingLotID:      device.ParkingLotID,
 		DeviceIdentifier:  device.DeviceIdentifier,
 		LastCommunication: device.LastCommunication.Format(time.RFC3339), // Formato ISO 8601
 		Sensors:           sensors,
@@ -76,7 +111,7 @@ func (uc *Esp32DeviceUseCase) GetEsp32DeviceByIdentifier(identifier string) (*do
 }
 
 func (uc *Esp32DeviceUseCase) UpdateEsp32Device(id uint64, req UpdateEsp32DeviceRequest) error {
-	device, err := uc.Esp32DeviceRepository.GetByID(id)
+	device, err := uc.getEsp32Device(id)
 	if err != nil {
 		return err
 	}
@@ -90,6 +125,76 @@ func (uc *Esp32DeviceUseCase) DeleteEsp32Device(id uint64) error {
 	return uc.Esp32DeviceRepository.Delete(id)
 }
 
-func (uc *Esp32DeviceUseCase) ListEsp32Devices() ([]domain.Esp32Device, error) {
-	return uc.Esp32DeviceRepository.ListAll()
+// ListEsp32Devices lists every device for a global view, otherwise only the devices of the
+// parking lots the admin is a member of.
+func (uc *Esp32DeviceUseCase) ListEsp32Devices(adminUUID string, global bool) ([]domain.Esp32Device, error) {
+	if global {
+		return uc.Esp32DeviceRepository.ListAll()
+	}
+	admin, err := uc.AdminRepository.FindByAuth0UUID(adminUUID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.Esp32DeviceRepository.ListForMember(admin.ID)
+}
+
+// RotateEsp32DeviceToken issues a new token for the device; the previous one stops working.
+func (uc *Esp32DeviceUseCase) RotateEsp32DeviceToken(id uint64) (*Esp32DeviceTokenResponse, error) {
+	device, err := uc.getEsp32Device(id)
+	if err != nil {
+		return nil, err
+	}
+	token, err := newDeviceToken()
+	if err != nil {
+		return nil, err
+	}
+
+	device.TokenHash = hashToken(token)
+	if err := uc.Esp32DeviceRepository.Update(device); err != nil {
+		return nil, err
+	}
+	return newEsp32DeviceTokenResponse(*device, token), nil
+}
+
+// AuthenticateDevice returns the device a token was issued to.
+func (uc *Esp32DeviceUseCase) AuthenticateDevice(token string) (*domain.Esp32Device, error) {
+	if !strings.HasPrefix(token, deviceTokenPrefix) {
+		return nil, ErrInvalidDeviceToken
+	}
+	device, err := uc.Esp32DeviceRepository.GetByTokenHash(hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if device == nil {
+		return nil, ErrInvalidDeviceToken
+	}
+	return device, nil
+}
+
+func (uc *Esp32DeviceUseCase) getEsp32Device(id uint64) (*domain.Esp32Device, error) {
+	device, err := uc.Esp32DeviceRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if device == nil {
+		return nil, ErrDeviceNotFound
+	}
+	return device, nil
+}
+
+func newDeviceToken() (string, error) {
+	token, err := randomToken(24)
+	if err != nil {
+		return "", err
+	}
+	return deviceTokenPrefix + token, nil
+}
+
+func newEsp32DeviceTokenResponse(device domain.Esp32Device, token string) *Esp32DeviceTokenResponse {
+	return &Esp32DeviceTokenResponse{
+		ID:               device.ID,
+		ParkingLotID:     device.ParkingLotID,
+		DeviceIdentifier: device.DeviceIdentifier,
+		Token:            token,
+	}
 }
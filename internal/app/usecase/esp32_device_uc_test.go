@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateEsp32DeviceIssuesATokenAndStoresItsHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	devices := mockgen.NewMockIEsp32DeviceRepository(ctrl)
+	useCase := NewEsp32DeviceUseCase(devices, mockgen.NewMockISensorRepository(ctrl), mockgen.NewMockIAdminRepository(ctrl))
+
+	var stored domain.Esp32Device
+	devices.EXPECT().Create(gomock.Any()).DoAndReturn(func(device *domain.Esp32Device) error {
+		device.ID = 7
+		stored = *device
+		return nil
+	})
+
+	created, err := useCase.CreateEsp32Device(CreateEsp32DeviceRequest{ParkingLotID: 3, DeviceIdentifier: "AA:BB"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), created.ID)
+	assert.Equal(t, uint(3), stored.ParkingLotID)
+	assert.True(t, strings.HasPrefix(created.Token, deviceTokenPrefix))
+	assert.Equal(t, hashToken(created.Token), stored.TokenHash)
+
+	devices.EXPECT().GetByTokenHash(stored.TokenHash).Return(&stored, nil)
+	device, err := useCase.AuthenticateDevice(created.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, "AA:BB", device.DeviceIdentifier)
+}
+
+func TestAuthenticateDeviceRejectsUnknownTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	devices := mockgen.NewMockIEsp32DeviceRepository(ctrl)
+	useCase := NewEsp32DeviceUseCase(devices, mockgen.NewMockISensorRepository(ctrl), mockgen.NewMockIAdminRepository(ctrl))
+
+	_, err := useCase.AuthenticateDevice("AA:BB")
+	assert.ErrorIs(t, err, ErrInvalidDeviceToken)
+
+	devices.EXPECT().GetByTokenHash(hashToken(deviceTokenPrefix+"unknown")).Return(nil, nil)
+	_, err = useCase.AuthenticateDevice(deviceTokenPrefix + "unknown")
+	assert.ErrorIs(t, err, ErrInvalidDeviceToken)
+}
+
+func TestListEsp32DevicesOnlyListsTheDevicesOfTheMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	devices := mockgen.NewMockIEsp32DeviceRepository(ctrl)
+	admins := mockgen.NewMockIAdminRepository(ctrl)
+	useCase := NewEsp32DeviceUseCase(devices, mockgen.NewMockISensorRepository(ctrl), admins)
+
+	admins.EXPECT().FindByAuth0UUID("operator").Return(&domain.Admin{ID: 100}, nil)
+	devices.EXPECT().ListForMember(uint(100)).Return([]domain.Esp32Device{{ID: 1, ParkingLotID: 3}}, nil)
+
+	listed, err := useCase.ListEsp32Devices("operator", false)
+	assert.NoError(t, err)
+	assert.Len(t, listed, 1)
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+)
+
+type IFavoriteUseCase interface {
+	AddFavorite(userID uint, parkingLotID uint) error
+	RemoveFavorite(userID uint, parkingLotID uint) error
+	ListFavorites(userID uint) ([]ParkingLotResponse, error)
+}
+
+type FavoriteUseCase struct {
+	FavoriteRepository    repository.IFavoriteRepository
+	ParkingLotRepository  repository.IParkingLotRepository
+	SensorRepository      repository.ISensorRepository
+	ReservationRepository repository.IReservationRepository
+}
+
+// NewFavoriteUseCase creates a new instance of FavoriteUseCase.
+func NewFavoriteUseCase(favoriteRepo repository.IFavoriteRepository, parkingLotRepo repository.IParkingLotRepository, sensorRepo repository.ISensorRepository, reservationRepo repository.IReservationRepository) IFavoriteUseCase {
+	return &FavoriteUseCase{
+		FavoriteRepository:    favoriteRepo,
+		ParkingLotRepository:  parkingLotRepo,
+		SensorRepository:      sensorRepo,
+		ReservationRepository: reservationRepo,
+	}
+}
+
+// AddFavorite saves a parking lot as favorite of the user.
+func (uc *FavoriteUseCase) AddFavorite(userID uint, parkingLotID uint) error {
+	if _, err := uc.ParkingLotRepository.GetByID(parkingLotID); err != nil {
+		return err
+	}
+
+	return uc.FavoriteRepository.Create(&domain.FavoriteParkingLot{
+		UserID:       userID,
+		ParkingLotID: parkingLotID,
+	})
+}
+
+// RemoveFavorite removes a parking lot from the favorites of the user.
+func (uc *FavoriteUseCase) RemoveFavorite(userID uint, parkingLotID uint) error {
+	return uc.FavoriteRepository.Delete(userID, parkingLotID)
+}
+
+// ListFavorites retrieves the favorite parking lots of the user with their available spaces.
+func (uc *FavoriteUseCase) ListFavorites(userID uint) ([]ParkingLotResponse, error) {
+	favorites, err := uc.FavoriteRepository.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sensorMap, err := uc.SensorRepository.ListGroupedByParkingLot()
+	if err != nil {
+		return nil, err
+	}
+
+	heldMap, err := uc.ReservationRepository.CountActiveGroupedByParkingLot(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	response := []ParkingLotResponse{}
+	for _, favorite := range favorites {
+		lot := favorite.ParkingLot
+		response = append(response, ParkingLotResponse{
+			ID:                     lot.ID,
+			Name:                   lot.Name,
+			Address:                lot.Address,
+			Latitude:               lot.Latitude,
+			Longitude:              lot.Longitude,
+			AvailableSpaces:        excludeHeldSpaces(sensorMap[lot.ID], heldMap[lot.ID]),
+			HourlyRate:             lot.HourlyRate,
+			BillingFractionMinutes: lot.BillingFractionMinutes,
+		})
+	}
+
+	return response, nil
+}
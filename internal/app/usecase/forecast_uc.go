@@ -0,0 +1,314 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+)
+
+const (
+	forecastLookback      = 8 * 7 * 24 * time.Hour
+	forecastMinSamples    = 5
+	forecastConfidence    = 0.95
+	forecastZScore        = 1.96
+	forecastCurrentDecay  = 60 * time.Minute
+	defaultNearbyRadiusKm = 2.0
+
+	ForecastBasisWeekdayHour = "weekday_hour"
+	ForecastBasisHour        = "hour"
+	ForecastBasisCurrent     = "current"
+)
+
+type IForecastUseCase interface {
+	ForecastAvailability(parkingLotID uint, at time.Time) (*ForecastResponse, error)
+	ListNearby(req NearbySearchRequest) ([]NearbyParkingLotResponse, error)
+}
+
+type ForecastUseCase struct {
+	ParkingLotRepository      repository.IParkingLotRepository
+	SensorRepository          repository.ISensorRepository
+	OccupancySampleRepository repository.IOccupancySampleRepository
+	ReservationRepository     repository.IReservationRepository
+	now                       func() time.Time
+}
+
+type ForecastResponse struct {
+	ParkingLotID       uint      `json:"parking_lot_id"`
+	At                 time.Time `json:"at"`
+	TotalSpaces        uint      `json:"total_spaces"`
+	CurrentFreeSpaces  uint      `json:"current_free_spaces"`
+	ExpectedFreeSpaces float64   `json:"expected_free_spaces"`
+	LowerBound         float64   `json:"lower_bound"`
+	UpperBound         float64   `json:"upper_bound"`
+	Confidence         float64   `json:"confidence"`
+	Holiday            bool      `json:"holiday"`
+	Basis              string    `json:"basis"`
+	Samples            int       `json:"samples"`
+}
+
+type NearbySearchRequest struct {
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64
+	ArriveAt  *time.Time
+	Spots     *domain.SpotFilter
+}
+
+type NearbyParkingLotResponse struct {
+	ParkingLotResponse
+	DistanceKm          float64  `json:"distance_km"`
+	PredictedFreeSpaces *float64 `json:"predicted_free_spaces,omitempty"`
+}
+
+// NewForecastUseCase creates a new instance of ForecastUseCase.
+func NewForecastUseCase(parkingLotRepo repository.IParkingLotRepository, sensorRepo repository.ISensorRepository, sampleRepo repository.IOccupancySampleRepository, reservationRepo repository.IReservationRepository) IForecastUseCase {
+	return &ForecastUseCase{
+		ParkingLotRepository:      parkingLotRepo,
+		SensorRepository:          sensorRepo,
+		OccupancySampleRepository: sampleRepo,
+		ReservationRepository:     reservationRepo,
+		now:                       time.Now,
+	}
+}
+
+// ForecastAvailability estimates the free spaces of a parking lot at the given instant using
+// the weekday/hour-of-day occupancy profile learned from historical samples. Colombian holidays
+// use the Sunday profile, and short horizons are blended with the current sensor state.
+func (uc *ForecastUseCase) ForecastAvailability(parkingLotID uint, at time.Time) (*ForecastResponse, error) {
+	if _, err := uc.ParkingLotRepository.GetPublicByID(parkingLotID); err != nil {
+		return nil, err
+	}
+
+	sensors, err := uc.SensorRepository.ListByParkingLot(parkingLotID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	var samples []domain.OccupancySample
+	if needsProfile(sensors, at, now) {
+		samples, err = uc.OccupancySampleRepository.ListByParkingLotSince(parkingLotID, now.Add(-forecastLookback))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return forecast(parkingLotID, sensors, samples, at, now), nil
+}
+
+// needsProfile reports whether forecasting the lot at the instant uses the historical samples.
+// Instants not in the future, or lots without sensors, just report the current state.
+func needsProfile(sensors []domain.Sensor, at, now time.Time) bool {
+	return at.After(now) && len(sensors) > 0
+}
+
+// forecast estimates the free spaces of the lot at the instant from its sensors and, when
+// needsProfile holds, its samples since the forecast lookback.
+func forecast(parkingLotID uint, sensors []domain.Sensor, samples []domain.OccupancySample, at, now time.Time) *ForecastResponse {
+	total := uint(len(sensors))
+	current := countAvailableSpaces(sensors)
+
+	response := &ForecastResponse{
+		ParkingLotID:      parkingLotID,
+		At:                at,
+		TotalSpaces:       total,
+		CurrentFreeSpaces: current,
+		Confidence:        forecastConfidence,
+		Holiday:           helpers.IsColombianHoliday(at),
+		Basis:             ForecastBasisCurrent,
+	}
+
+	if !needsProfile(sensors, at, now) {
+		response.ExpectedFreeSpaces = float64(current)
+		response.LowerBound = float64(current)
+		response.UpperBound = float64(current)
+		return response
+	}
+
+	ratios, basis := profileRatios(samples, at)
+	if len(ratios) < forecastMinSamples {
+		response.ExpectedFreeSpaces = float64(current)
+		response.LowerBound = 0
+		response.UpperBound = float64(total)
+		response.Samples = len(ratios)
+		return response
+	}
+
+	mean, stdDev := meanAndStdDev(ratios)
+	currentWeight := math.Exp(-float64(at.Sub(now)) / float64(forecastCurrentDecay))
+	expectedRatio := currentWeight*(float64(current)/float64(total)) + (1-currentWeight)*mean
+	margin := forecastZScore * stdDev * (1 - currentWeight)
+
+	response.Basis = basis
+	response.Samples = len(ratios)
+	response.ExpectedFreeSpaces = roundTo(expectedRatio*float64(total), 2)
+	response.LowerBound = roundTo(math.Max(0, (expectedRatio-margin)*float64(total)), 2)
+	response.UpperBound = roundTo(math.Min(float64(total), (expectedRatio+margin)*float64(total)), 2)
+
+	return response
+}
+
+// ListNearby lists the public parking lots within the search radius. When an arrival time is
+// given the lots are ranked by predicted availability at that time, otherwise by distance.
+// Available spaces only count the spots matching req.Spots when it is set.
+func (uc *ForecastUseCase) ListNearby(req NearbySearchRequest) ([]NearbyParkingLotResponse, error) {
+	radius := req.RadiusKm
+	if radius <= 0 {
+		radius = defaultNearbyRadiusKm
+	}
+
+	parkingLots, err := uc.ParkingLotRepository.ListPublic()
+	if err != nil {
+		return nil, err
+	}
+
+	sensorMap, err := freeSpacesByParkingLot(uc.SensorRepository, req.Spots)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	heldMap, err := uc.ReservationRepository.CountActiveGroupedByParkingLot(now)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []NearbyParkingLotResponse{}
+	for _, lot := range parkingLots {
+		distance := haversineKm(req.Latitude, req.Longitude, lot.Latitude, lot.Longitude)
+		if distance > radius {
+			continue
+		}
+
+		response = append(response, NearbyParkingLotResponse{
+			ParkingLotResponse: ParkingLotResponse{
+				ID:                     lot.ID,
+				Name:                   lot.Name,
+				Address:                lot.Address,
+				Latitude:               lot.Latitude,
+				Longitude:              lot.Longitude,
+				AvailableSpaces:        excludeHeldSpaces(sensorMap[lot.ID], heldMap[lot.ID]),
+				HourlyRate:             lot.HourlyRate,
+				BillingFractionMinutes: lot.BillingFractionMinutes,
+			},
+			DistanceKm: roundTo(distance, 3),
+		})
+	}
+
+	if req.ArriveAt != nil {
+		if err := uc.predictNearby(response, *req.ArriveAt, now); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(response, func(i, j int) bool {
+		if req.ArriveAt != nil && *response[i].PredictedFreeSpaces != *response[j].PredictedFreeSpaces {
+			return *response[i].PredictedFreeSpaces > *response[j].PredictedFreeSpaces
+		}
+		return response[i].DistanceKm < response[j].DistanceKm
+	})
+
+	return response, nil
+}
+
+// predictNearby fills the predicted free spaces of the lots at the arrival time, loading the
+// sensors and samples of all of them at once.
+func (uc *ForecastUseCase) predictNearby(lots []NearbyParkingLotResponse, at, now time.Time) error {
+	if len(lots) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(lots))
+	for _, lot := range lots {
+		ids = append(ids, lot.ID)
+	}
+
+	sensors, err := uc.SensorRepository.ListByParkingLots(ids)
+	if err != nil {
+		return err
+	}
+	sensorsByLot := make(map[uint][]domain.Sensor)
+	for _, sensor := range sensors {
+		sensorsByLot[sensor.ParkingLotID] = append(sensorsByLot[sensor.ParkingLotID], sensor)
+	}
+
+	samplesByLot := make(map[uint][]domain.OccupancySample)
+	if at.After(now) {
+		samples, err := uc.OccupancySampleRepository.ListByParkingLotsSince(ids, now.Add(-forecastLookback))
+		if err != nil {
+			return err
+		}
+		for _, sample := range samples {
+			samplesByLot[sample.ParkingLotID] = append(samplesByLot[sample.ParkingLotID], sample)
+		}
+	}
+
+	for i := range lots {
+		predicted := forecast(lots[i].ID, sensorsByLot[lots[i].ID], samplesByLot[lots[i].ID], at, now).ExpectedFreeSpaces
+		lots[i].PredictedFreeSpaces = &predicted
+	}
+	return nil
+}
+
+// profileRatios selects the free-space ratios of the samples matching the target's day type and
+// hour. It falls back to the same hour on any day when the weekday bucket is too sparse.
+func profileRatios(samples []domain.OccupancySample, at time.Time) ([]float64, string) {
+	targetDay, targetHour := profileBucket(at)
+
+	var sameDay, sameHour []float64
+	for _, sample := range samples {
+		if sample.TotalSpaces == 0 {
+			continue
+		}
+		day, hour := profileBucket(sample.RecordedAt)
+		if hour != targetHour {
+			continue
+		}
+		ratio := float64(sample.FreeSpaces) / float64(sample.TotalSpaces)
+		sameHour = append(sameHour, ratio)
+		if day == targetDay {
+			sameDay = append(sameDay, ratio)
+		}
+	}
+
+	if len(sameDay) >= forecastMinSamples {
+		return sameDay, ForecastBasisWeekdayHour
+	}
+	return sameHour, ForecastBasisHour
+}
+
+// profileBucket maps an instant to its weekday and hour in Bogota, treating holidays as Sundays.
+func profileBucket(t time.Time) (time.Weekday, int) {
+	local := t.In(helpers.BogotaLocation)
+	day := local.Weekday()
+	if helpers.IsColombianHoliday(local) {
+		day = time.Sunday
+	}
+	return day, local.Hour()
+}
+
+func meanAndStdDev(values []float64) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	if len(values) > 1 {
+		variance /= float64(len(values) - 1)
+	}
+	return mean, math.Sqrt(variance)
+}
+
+func roundTo(value float64, decimals int) float64 {
+	factor := math.Pow(10, float64(decimals))
+	return math.Round(value*factor) / factor
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupForecastTest(t *testing.T, now time.Time) (*gomock.Controller, *mockgen.MockIParkingLotRepository, *mockgen.MockISensorRepository, *mockgen.MockIOccupancySampleRepository, *ForecastUseCase) {
+	ctrl := gomock.NewController(t)
+	parkingLotRepo := mockgen.NewMockIParkingLotRepository(ctrl)
+	sensorRepo := mockgen.NewMockISensorRepository(ctrl)
+	sampleRepo := mockgen.NewMockIOccupancySampleRepository(ctrl)
+	useCase := NewForecastUseCase(parkingLotRepo, sensorRepo, sampleRepo, mockgen.NewMockIReservationRepository(ctrl)).(*ForecastUseCase)
+	useCase.now = func() time.Time { return now }
+	return ctrl, parkingLotRepo, sensorRepo, sampleRepo, useCase
+}
+
+func TestForecastAvailabilityUsesWeekdayProfile(t *testing.T) {
+	now := time.Date(2024, time.September, 17, 6, 0, 0, 0, helpers.BogotaLocation)
+	ctrl, parkingLotRepo, sensorRepo, sampleRepo, useCase := setupForecastTest(t, now)
+	defer ctrl.Finish()
+
+	var samples []domain.OccupancySample
+	for week := 1; week <= 6; week++ {
+		samples = append(samples, domain.OccupancySample{
+			ParkingLotID: 1,
+			FreeSpaces:   2,
+			TotalSpaces:  4,
+			RecordedAt:   time.Date(2024, time.September, 17, 8, 15, 0, 0, helpers.BogotaLocation).AddDate(0, 0, -7*week),
+		})
+	}
+
+	parkingLotRepo.EXPECT().GetPublicByID(uint(1)).Return(&domain.ParkingLot{ID: 1}, nil)
+	sensorRepo.EXPECT().ListByParkingLot(uint(1)).Return([]domain.Sensor{
+		{Status: "free"}, {Status: "busy"}, {Status: "busy"}, {Status: "busy"},
+	}, nil)
+	sampleRepo.EXPECT().ListByParkingLotSince(uint(1), gomock.Any()).Return(samples, nil)
+
+	at := time.Date(2024, time.September, 24, 8, 30, 0, 0, helpers.BogotaLocation)
+	forecast, err := useCase.ForecastAvailability(1, at)
+	assert.NoError(t, err)
+	assert.Equal(t, ForecastBasisWeekdayHour, forecast.Basis)
+	assert.Equal(t, 6, forecast.Samples)
+	assert.InDelta(t, 2.0, forecast.ExpectedFreeSpaces, 0.01)
+	assert.InDelta(t, 2.0, forecast.LowerBound, 0.01)
+	assert.InDelta(t, 2.0, forecast.UpperBound, 0.01)
+}
+
+func TestForecastAvailabilityFallsBackToCurrentState(t *testing.T) {
+	now := time.Date(2024, time.June, 17, 6, 0, 0, 0, helpers.BogotaLocation)
+	ctrl, parkingLotRepo, sensorRepo, sampleRepo, useCase := setupForecastTest(t, now)
+	defer ctrl.Finish()
+
+	parkingLotRepo.EXPECT().GetPublicByID(uint(1)).Return(&domain.ParkingLot{ID: 1}, nil)
+	sensorRepo.EXPECT().ListByParkingLot(uint(1)).Return([]domain.Sensor{
+		{Status: "free"}, {Status: "busy"},
+	}, nil)
+	sampleRepo.EXPECT().ListByParkingLotSince(uint(1), gomock.Any()).Return(nil, nil)
+
+	forecast, err := useCase.ForecastAvailability(1, now.Add(20*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, ForecastBasisCurrent, forecast.Basis)
+	assert.Equal(t, float64(1), forecast.ExpectedFreeSpaces)
+	assert.Equal(t, float64(0), forecast.LowerBound)
+	assert.Equal(t, float64(2), forecast.UpperBound)
+}
+
+func TestListNearbyLoadsTheCandidatesAtOnce(t *testing.T) {
+	now := time.Date(2024, time.September, 17, 6, 0, 0, 0, helpers.BogotaLocation)
+	ctrl, parkingLotRepo, sensorRepo, sampleRepo, useCase := setupForecastTest(t, now)
+	defer ctrl.Finish()
+	reservationRepo := mockgen.NewMockIReservationRepository(ctrl)
+	useCase.ReservationRepository = reservationRepo
+
+	at := time.Date(2024, time.September, 24, 8, 30, 0, 0, helpers.BogotaLocation)
+	var samples []domain.OccupancySample
+	for week := 1; week <= 6; week++ {
+		samples = append(samples, domain.OccupancySample{
+			ParkingLotID: 2,
+			FreeSpaces:   2,
+			TotalSpaces:  2,
+			RecordedAt:   at.AddDate(0, 0, -7*week),
+		})
+	}
+
+	parkingLotRepo.EXPECT().ListPublic().Return([]domain.ParkingLot{
+		{ID: 1, Latitude: 4.6097, Longitude: -74.0817},
+		{ID: 2, Latitude: 4.6150, Longitude: -74.0817},
+		{ID: 3, Latitude: 6.2442, Longitude: -75.5812},
+	}, nil)
+	sensorRepo.EXPECT().ListGroupedByParkingLot().Return(map[uint]uint{1: 1, 2: 0}, nil)
+	reservationRepo.EXPECT().CountActiveGroupedByParkingLot(now).Return(map[uint]uint{}, nil)
+	sensorRepo.EXPECT().ListByParkingLots([]uint{1, 2}).Return([]domain.Sensor{
+		{ParkingLotID: 1, Status: domain.SensorStatusFree},
+		{ParkingLotID: 1, Status: domain.SensorStatusOccupied},
+		{ParkingLotID: 2, Status: domain.SensorStatusOccupied},
+		{ParkingLotID: 2, Status: domain.SensorStatusOccupied},
+	}, nil)
+	sampleRepo.EXPECT().ListByParkingLotsSince([]uint{1, 2}, now.Add(-forecastLookback)).Return(samples, nil)
+
+	lots, err := useCase.ListNearby(NearbySearchRequest{Latitude: 4.6097, Longitude: -74.0817, ArriveAt: &at})
+	assert.NoError(t, err)
+	if assert.Len(t, lots, 2) {
+		assert.Equal(t, uint(2), lots[0].ID)
+		assert.InDelta(t, 2.0, *lots[0].PredictedFreeSpaces, 0.01)
+		assert.Equal(t, uint(1), lots[1].ID)
+		assert.Equal(t, 1.0, *lots[1].PredictedFreeSpaces)
+	}
+}
@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+var (
+	ErrAdminNotFound            = errors.New("admin not found")
+	ErrStatusReasonRequired     = errors.New("a reason is required")
+	ErrInvalidStatusTransition  = errors.New("the admin cannot change to that status from its current one")
+	ErrInvalidAdminStatusFilter = errors.New("status must be pending, active or suspended")
+)
+
+type IGlobalAdminUseCase interface {
+	ListAdmins(query AdminQuery) (*AdminPage, error)
+	GetAdmin(adminID uint) (*AdminDetail, error)
+	ApproveAdmin(actorUUID string, adminID uint, reason string) (*domain.Admin, error)
+	SuspendAdmin(actorUUID string, adminID uint, reason string) (*domain.Admin, error)
+	ReinstateAdmin(actorUUID string, adminID uint, reason string) (*domain.Admin, error)
+	ListParkingLots(query GlobalParkingLotQuery) (*ParkingLotPage, error)
+}
+
+// Page selects a page of results; pages start at 1.
+type Page struct {
+	Page     int `json:"page"`
+	PageSize int `json:"page_size"`
+}
+
+type AdminQuery struct {
+	Search string
+	Status string
+	Page
+}
+
+type GlobalParkingLotQuery struct {
+	Search         string
+	AdminID        uint
+	OrganizationID uint
+	Page
+}
+
+type AdminPage struct {
+	Admins []domain.Admin `json:"admins"`
+	Total  int64          `json:"total"`
+	Page
+}
+
+type ParkingLotPage struct {
+	ParkingLots []domain.ParkingLot `json:"parking_lots"`
+	Total       int64               `json:"total"`
+	Page
+}
+
+// AdminDetail is an admin with the history of their status.
+type AdminDetail struct {
+	domain.Admin
+	StatusEvents []domain.AdminStatusEvent `json:"status_events"`
+}
+
+// GlobalAdminUseCase lets global admins oversee every operator: approve newly registered ones,
+// suspend them, which hides their lots and rejects their telemetry, and reinstate them.
+type GlobalAdminUseCase struct {
+	AdminRepository      repository.IAdminRepository
+	ParkingLotRepository repository.IParkingLotRepository
+	now                  func() time.Time
+}
+
+// NewGlobalAdminUseCase creates a new instance of GlobalAdminUseCase.
+func NewGlobalAdminUseCase(adminRepo repository.IAdminRepository, parkingLotRepo repository.IParkingLotRepository) IGlobalAdminUseCase {
+	return &GlobalAdminUseCase{
+		AdminRepository:      adminRepo,
+		ParkingLotRepository: parkingLotRepo,
+		now:                  time.Now,
+	}
+}
+
+// ListAdmins searches the admins, newest first.
+func (uc *GlobalAdminUseCase) ListAdmins(query AdminQuery) (*AdminPage, error) {
+	switch query.Status {
+	case "", domain.AdminStatusPending, domain.AdminStatusActive, domain.AdminStatusSuspended:
+	default:
+		return nil, ErrInvalidAdminStatusFilter
+	}
+
+	page := query.Page.normalized()
+	admins, total, err := uc.AdminRepository.Search(domain.AdminFilter{
+		Query:  strings.TrimSpace(query.Search),
+		Status: query.Status,
+		Offset: page.offset(),
+		Limit:  page.PageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &AdminPage{Admins: admins, Total: total, Page: page}, nil
+}
+
+// GetAdmin retrieves an admin with their status history.
+func (uc *GlobalAdminUseCase) GetAdmin(adminID uint) (*AdminDetail, error) {
+	admin, err := findAdminByID(uc.AdminRepository, adminID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := uc.AdminRepository.ListStatusEvents(adminID)
+	if err != nil {
+		return nil, err
+	}
+	return &AdminDetail{Admin: *admin, StatusEvents: events}, nil
+}
+
+// ApproveAdmin activates a newly registered operator.
+func (uc *GlobalAdminUseCase) ApproveAdmin(actorUUID string, adminID uint, reason string) (*domain.Admin, error) {
+	return uc.changeStatus(actorUUID, adminID, reason, domain.AdminStatusActive, domain.AdminStatusPending)
+}
+
+// SuspendAdmin suspends an operator, pending or active.
+func (uc *GlobalAdminUseCase) SuspendAdmin(actorUUID string, adminID uint, reason string) (*domain.Admin, error) {
+	return uc.changeStatus(actorUUID, adminID, reason, domain.AdminStatusSuspended, domain.AdminStatusPending, domain.AdminStatusActive)
+}
+
+// ReinstateAdmin activates a suspended operator again.
+func (uc *GlobalAdminUseCase) ReinstateAdmin(actorUUID string, adminID uint, reason string) (*domain.Admin, error) {
+	return uc.changeStatus(actorUUID, adminID, reason, domain.AdminStatusActive, domain.AdminStatusSuspended)
+}
+
+// ListParkingLots searches every parking lot, whatever the status of its operator.
+func (uc *GlobalAdminUseCase) ListParkingLots(query GlobalParkingLotQuery) (*ParkingLotPage, error) {
+	page := query.Page.normalized()
+	parkingLots, total, err := uc.ParkingLotRepository.Search(domain.ParkingLotFilter{
+		Query:          strings.TrimSpace(query.Search),
+		AdminID:        query.AdminID,
+		OrganizationID: query.OrganizationID,
+		Offset:         page.offset(),
+		Limit:          page.PageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ParkingLotPage{ParkingLots: parkingLots, Total: total, Page: page}, nil
+}
+
+// changeStatus moves the admin to status, provided that they are in one of the from statuses,
+// and records the reason.
+func (uc *GlobalAdminUseCase) changeStatus(actorUUID string, adminID uint, reason, status string, from ...string) (*domain.Admin, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrStatusReasonRequired
+	}
+
+	admin, err := findAdminByID(uc.AdminRepository, adminID)
+	if err != nil {
+		return nil, err
+	}
+	previous := admin.Status
+	if !slices.Contains(from, previous) {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	now := uc.now()
+	admin.Status = status
+	admin.StatusReason = reason
+	admin.StatusChangedAt = &now
+	if err := uc.AdminRepository.ChangeStatus(admin, &domain.AdminStatusEvent{
+		FromStatus: previous,
+		ToStatus:   status,
+		Reason:     reason,
+		Actor:      actorUUID,
+	}); err != nil {
+		return nil, err
+	}
+	return admin, nil
+}
+
+// findAdminByID retrieves an admin, failing with ErrAdminNotFound when there is none.
+func findAdminByID(adminRepo repository.IAdminRepository, adminID uint) (*domain.Admin, error) {
+	admin, err := adminRepo.FindByID(adminID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAdminNotFound
+		}
+		return nil, err
+	}
+	return admin, nil
+}
+
+func (p Page) normalized() Page {
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	if p.PageSize < 1 {
+		p.PageSize = DefaultPageSize
+	}
+	p.PageSize = min(p.PageSize, MaxPageSize)
+	return p
+}
+
+func (p Page) offset() int {
+	return (p.Page - 1) * p.PageSize
+}
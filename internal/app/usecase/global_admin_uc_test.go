@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var globalAdminNow = time.Date(2024, time.December, 9, 15, 0, 0, 0, time.UTC)
+
+func setupGlobalAdminTest(t *testing.T) (*gomock.Controller, *mockgen.MockIAdminRepository, *mockgen.MockIParkingLotRepository, *GlobalAdminUseCase) {
+	ctrl := gomock.NewController(t)
+	adminRepo := mockgen.NewMockIAdminRepository(ctrl)
+	parkingLotRepo := mockgen.NewMockIParkingLotRepository(ctrl)
+	useCase := NewGlobalAdminUseCase(adminRepo, parkingLotRepo).(*GlobalAdminUseCase)
+	useCase.now = func() time.Time { return globalAdminNow }
+	return ctrl, adminRepo, parkingLotRepo, useCase
+}
+
+func TestApproveAdminRecordsTheReason(t *testing.T) {
+	ctrl, adminRepo, _, useCase := setupGlobalAdminTest(t)
+	defer ctrl.Finish()
+
+	adminRepo.EXPECT().FindByID(uint(4)).Return(&domain.Admin{ID: 4, Status: domain.AdminStatusPending}, nil)
+	adminRepo.EXPECT().ChangeStatus(gomock.Any(), &domain.AdminStatusEvent{
+		FromStatus: domain.AdminStatusPending,
+		ToStatus:   domain.AdminStatusActive,
+		Reason:     "RUT verified",
+		Actor:      "auth0|global",
+	}).Return(nil)
+
+	admin, err := useCase.ApproveAdmin("auth0|global", 4, " RUT verified ")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.AdminStatusActive, admin.Status)
+	assert.Equal(t, "RUT verified", admin.StatusReason)
+	assert.Equal(t, globalAdminNow, *admin.StatusChangedAt)
+}
+
+func TestAdminStatusTransitions(t *testing.T) {
+	ctrl, adminRepo, _, useCase := setupGlobalAdminTest(t)
+	defer ctrl.Finish()
+
+	_, err := useCase.SuspendAdmin("auth0|global", 4, "  ")
+	assert.ErrorIs(t, err, ErrStatusReasonRequired)
+
+	adminRepo.EXPECT().FindByID(uint(4)).Return(&domain.Admin{ID: 4, Status: domain.AdminStatusActive}, nil)
+	_, err = useCase.ApproveAdmin("auth0|global", 4, "already approved")
+	assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+
+	adminRepo.EXPECT().FindByID(uint(5)).Return(&domain.Admin{ID: 5, Status: domain.AdminStatusActive}, nil)
+	_, err = useCase.ReinstateAdmin("auth0|global", 5, "not suspended")
+	assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+
+	adminRepo.EXPECT().FindByID(uint(6)).Return(&domain.Admin{ID: 6, Status: domain.AdminStatusActive}, nil)
+	adminRepo.EXPECT().ChangeStatus(gomock.Any(), gomock.Any()).Return(nil)
+	admin, err := useCase.SuspendAdmin("auth0|global", 6, "Fake occupancy reports")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.AdminStatusSuspended, admin.Status)
+
+	adminRepo.EXPECT().FindByID(uint(7)).Return(nil, gorm.ErrRecordNotFound)
+	_, err = useCase.SuspendAdmin("auth0|global", 7, "Unknown")
+	assert.ErrorIs(t, err, ErrAdminNotFound)
+}
+
+func TestListAdminsNormalizesThePage(t *testing.T) {
+	ctrl, adminRepo, parkingLotRepo, useCase := setupGlobalAdminTest(t)
+	defer ctrl.Finish()
+
+	_, err := useCase.ListAdmins(AdminQuery{Status: "banned"})
+	assert.ErrorIs(t, err, ErrInvalidAdminStatusFilter)
+
+	adminRepo.EXPECT().Search(domain.AdminFilter{Query: "900", Status: domain.AdminStatusPending, Offset: 0, Limit: DefaultPageSize}).
+		Return([]domain.Admin{{ID: 1}}, int64(1), nil)
+	page, err := useCase.ListAdmins(AdminQuery{Search: " 900 ", Status: domain.AdminStatusPending})
+	assert.NoError(t, err)
+	assert.Equal(t, Page{Page: 1, PageSize: DefaultPageSize}, page.Page)
+	assert.Equal(t, int64(1), page.Total)
+
+	parkingLotRepo.EXPECT().Search(domain.ParkingLotFilter{AdminID: 2, Offset: 200, Limit: MaxPageSize}).Return(nil, int64(0), nil)
+	_, err = useCase.ListParkingLots(GlobalParkingLotQuery{AdminID: 2, Page: Page{Page: 3, PageSize: 500}})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+)
+
+const (
+	DefaultImpersonationDuration = 30 * time.Minute
+	MaxImpersonationDuration     = 2 * time.Hour
+)
+
+var (
+	ErrImpersonateSelf              = errors.New("you cannot impersonate yourself")
+	ErrInvalidImpersonationDuration = errors.New("minutes must be between 1 and 120")
+	ErrNoImpersonation              = errors.New("you are not impersonating any admin")
+)
+
+type IImpersonationUseCase interface {
+	StartImpersonation(actorUUID string, req ImpersonationRequest) (*domain.ImpersonationSession, error)
+	StopImpersonation(actorUUID string) error
+	CurrentImpersonation(actorUUID string) (*domain.ImpersonationSession, error)
+	ListSessions() ([]domain.ImpersonationSession, error)
+	ListAuditEntries(sessionID uint) ([]domain.ImpersonationAuditEntry, error)
+	ActiveImpersonation(actorSubject string) (*domain.ImpersonationSession, error)
+	RecordImpersonatedRequest(entry *domain.ImpersonationAuditEntry) error
+	CompleteImpersonatedRequest(entry *domain.ImpersonationAuditEntry) error
+}
+
+// ImpersonationRequest starts impersonating the admin for Minutes, DefaultImpersonationDuration
+// when left out.
+type ImpersonationRequest struct {
+	AdminID uint   `json:"admin_id"`
+	Reason  string `json:"reason"`
+	Minutes int    `json:"minutes"`
+}
+
+// ImpersonationUseCase lets global admins act as an admin to see exactly what they see. While a
+// session is active every request of the global admin runs as the admin, and the mutating ones
+// are audited with the real actor.
+type ImpersonationUseCase struct {
+	ImpersonationRepository repository.IImpersonationRepository
+	AdminRepository         repository.IAdminRepository
+	now                     func() time.Time
+}
+
+// NewImpersonationUseCase creates a new instance of ImpersonationUseCase.
+func NewImpersonationUseCase(impersonationRepo repository.IImpersonationRepository, adminRepo repository.IAdminRepository) IImpersonationUseCase {
+	return &ImpersonationUseCase{
+		ImpersonationRepository: impersonationRepo,
+		AdminRepository:         adminRepo,
+		now:                     time.Now,
+	}
+}
+
+// StartImpersonation starts a session for the actor, ending the one they had.
+func (uc *ImpersonationUseCase) StartImpersonation(actorUUID string, req ImpersonationRequest) (*domain.ImpersonationSession, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, ErrStatusReasonRequired
+	}
+	duration := DefaultImpersonationDuration
+	if req.Minutes != 0 {
+		duration = time.Duration(req.Minutes) * time.Minute
+		if req.Minutes < 0 || duration > MaxImpersonationDuration {
+			return nil, ErrInvalidImpersonationDuration
+		}
+	}
+
+	admin, err := findAdminByID(uc.AdminRepository, req.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin.Auth0UUID == actorUUID {
+		return nil, ErrImpersonateSelf
+	}
+
+	now := uc.now()
+	if _, err := uc.ImpersonationRepository.EndActive(actorUUID, now); err != nil {
+		return nil, err
+	}
+	session := &domain.ImpersonationSession{
+		ActorSubject: actorUUID,
+		AdminID:      admin.ID,
+		AdminSubject: admin.Auth0UUID,
+		Reason:       reason,
+		ExpiresAt:    now.Add(duration),
+	}
+	if err := uc.ImpersonationRepository.Create(session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// StopImpersonation ends the active session of the actor.
+func (uc *ImpersonationUseCase) StopImpersonation(actorUUID string) error {
+	ended, err := uc.ImpersonationRepository.EndActive(actorUUID, uc.now())
+	if err != nil {
+		return err
+	}
+	if !ended {
+		return ErrNoImpersonation
+	}
+	return nil
+}
+
+// CurrentImpersonation returns the active session of the actor.
+func (uc *ImpersonationUseCase) CurrentImpersonation(actorUUID string) (*domain.ImpersonationSession, error) {
+	session, err := uc.ActiveImpersonation(actorUUID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrNoImpersonation
+	}
+	return session, nil
+}
+
+// ListSessions returns every session, newest first.
+func (uc *ImpersonationUseCase) ListSessions() ([]domain.ImpersonationSession, error) {
+	return uc.ImpersonationRepository.List()
+}
+
+// ListAuditEntries returns the requests audited in the session.
+func (uc *ImpersonationUseCase) ListAuditEntries(sessionID uint) ([]domain.ImpersonationAuditEntry, error) {
+	return uc.ImpersonationRepository.ListAuditEntries(sessionID)
+}
+
+// ActiveImpersonation returns the session the actor's requests run in, or nil when they are not
+// impersonating anyone. Expired sessions are never returned.
+func (uc *ImpersonationUseCase) ActiveImpersonation(actorSubject string) (*domain.ImpersonationSession, error) {
+	return uc.ImpersonationRepository.FindActive(actorSubject, uc.now())
+}
+
+// RecordImpersonatedRequest adds a request made while impersonating to the audit log, before it
+// is handled.
+func (uc *ImpersonationUseCase) RecordImpersonatedRequest(entry *domain.ImpersonationAuditEntry) error {
+	return uc.ImpersonationRepository.CreateAuditEntry(entry)
+}
+
+// CompleteImpersonatedRequest records the response status of an audited request.
+func (uc *ImpersonationUseCase) CompleteImpersonatedRequest(entry *domain.ImpersonationAuditEntry) error {
+	return uc.ImpersonationRepository.UpdateAuditEntryStatus(entry.ID, entry.Status)
+}
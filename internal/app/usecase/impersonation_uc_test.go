@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var impersonationNow = time.Date(2024, time.December, 20, 14, 0, 0, 0, time.UTC)
+
+func setupImpersonationTest(t *testing.T) (*gomock.Controller, *mockgen.MockIImpersonationRepository, *mockgen.MockIAdminRepository, *ImpersonationUseCase) {
+	ctrl := gomock.NewController(t)
+	impersonationRepo := mockgen.NewMockIImpersonationRepository(ctrl)
+	adminRepo := mockgen.NewMockIAdminRepository(ctrl)
+	useCase := NewImpersonationUseCase(impersonationRepo, adminRepo).(*ImpersonationUseCase)
+	useCase.now = func() time.Time { return impersonationNow }
+	return ctrl, impersonationRepo, adminRepo, useCase
+}
+
+func TestStartImpersonationReplacesTheActiveSession(t *testing.T) {
+	ctrl, impersonationRepo, adminRepo, useCase := setupImpersonationTest(t)
+	defer ctrl.Finish()
+
+	adminRepo.EXPECT().FindByID(uint(4)).Return(&domain.Admin{ID: 4, Auth0UUID: "auth0|operator"}, nil).Times(2)
+	gomock.InOrder(
+		impersonationRepo.EXPECT().EndActive("auth0|support", impersonationNow).Return(true, nil),
+		impersonationRepo.EXPECT().Create(&domain.ImpersonationSession{
+			ActorSubject: "auth0|support",
+			AdminID:      4,
+			AdminSubject: "auth0|operator",
+			Reason:       "Ticket 812: lot missing from the map",
+			ExpiresAt:    impersonationNow.Add(DefaultImpersonationDuration),
+		}).Return(nil),
+	)
+
+	_, err := useCase.StartImpersonation("auth0|support", ImpersonationRequest{AdminID: 4, Reason: " Ticket 812: lot missing from the map "})
+	assert.NoError(t, err)
+
+	impersonationRepo.EXPECT().EndActive("auth0|support", impersonationNow).Return(false, nil)
+	impersonationRepo.EXPECT().Create(gomock.Any()).Return(nil)
+	session, err := useCase.StartImpersonation("auth0|support", ImpersonationRequest{AdminID: 4, Reason: "Follow-up", Minutes: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, impersonationNow.Add(5*time.Minute), session.ExpiresAt)
+}
+
+func TestStartImpersonationValidatesTheRequest(t *testing.T) {
+	ctrl, _, adminRepo, useCase := setupImpersonationTest(t)
+	defer ctrl.Finish()
+
+	_, err := useCase.StartImpersonation("auth0|support", ImpersonationRequest{AdminID: 4})
+	assert.ErrorIs(t, err, ErrStatusReasonRequired)
+	_, err = useCase.StartImpersonation("auth0|support", ImpersonationRequest{AdminID: 4, Reason: "Ticket", Minutes: 121})
+	assert.ErrorIs(t, err, ErrInvalidImpersonationDuration)
+
+	adminRepo.EXPECT().FindByID(uint(1)).Return(&domain.Admin{ID: 1, Auth0UUID: "auth0|support"}, nil)
+	_, err = useCase.StartImpersonation("auth0|support", ImpersonationRequest{AdminID: 1, Reason: "Ticket"})
+	assert.ErrorIs(t, err, ErrImpersonateSelf)
+
+	adminRepo.EXPECT().FindByID(uint(9)).Return(nil, gorm.ErrRecordNotFound)
+	_, err = useCase.StartImpersonation("auth0|support", ImpersonationRequest{AdminID: 9, Reason: "Ticket"})
+	assert.ErrorIs(t, err, ErrAdminNotFound)
+}
+
+func TestStopImpersonation(t *testing.T) {
+	ctrl, impersonationRepo, _, useCase := setupImpersonationTest(t)
+	defer ctrl.Finish()
+
+	impersonationRepo.EXPECT().EndActive("auth0|support", impersonationNow).Return(true, nil)
+	impersonationRepo.EXPECT().EndActive("auth0|support", impersonationNow).Return(false, nil)
+	impersonationRepo.EXPECT().FindActive("auth0|support", impersonationNow).Return(nil, nil)
+
+	assert.NoError(t, useCase.StopImpersonation("auth0|support"))
+	assert.ErrorIs(t, useCase.StopImpersonation("auth0|support"), ErrNoImpersonation)
+	_, err := useCase.CurrentImpersonation("auth0|support")
+	assert.ErrorIs(t, err, ErrNoImpersonation)
+}
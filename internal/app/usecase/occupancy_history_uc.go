@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+)
+
+// MaxOccupancyHistoryRange caps the period of a single history request.
+const MaxOccupancyHistoryRange = 31 * 24 * time.Hour
+
+var ErrInvalidHistoryRange = errors.New("from must be before to and the range at most 31 days")
+
+type IOccupancyHistoryUseCase interface {
+	GetHistory(parkingLotID uint, from, to time.Time, public bool) ([]domain.OccupancySample, error)
+}
+
+// OccupancyHistoryUseCase serves the recorded occupancy of parking lots to integrators.
+type OccupancyHistoryUseCase struct {
+	ParkingLotRepository      repository.IParkingLotRepository
+	OccupancySampleRepository repository.IOccupancySampleRepository
+}
+
+// NewOccupancyHistoryUseCase creates a new instance of OccupancyHistoryUseCase.
+func NewOccupancyHistoryUseCase(parkingLotRepo repository.IParkingLotRepository, occupancySampleRepo repository.IOccupancySampleRepository) IOccupancyHistoryUseCase {
+	return &OccupancyHistoryUseCase{
+		ParkingLotRepository:      parkingLotRepo,
+		OccupancySampleRepository: occupancySampleRepo,
+	}
+}
+
+// GetHistory retrieves the occupancy samples of the lot recorded between from and to. With public
+// set, only the lots shown to drivers are served, the way they are to integrators with an API key;
+// other lots are not found.
+func (uc *OccupancyHistoryUseCase) GetHistory(parkingLotID uint, from, to time.Time, public bool) ([]domain.OccupancySample, error) {
+	if !from.Before(to) || to.Sub(from) > MaxOccupancyHistoryRange {
+		return nil, ErrInvalidHistoryRange
+	}
+	getParkingLot := uc.ParkingLotRepository.GetByID
+	if public {
+		getParkingLot = uc.ParkingLotRepository.GetPublicByID
+	}
+	if _, err := getParkingLot(parkingLotID); err != nil {
+		return nil, err
+	}
+
+	samples := []domain.OccupancySample{}
+	err := uc.OccupancySampleRepository.StreamByParkingLotBetween(parkingLotID, from, to, func(sample domain.OccupancySample) error {
+		samples = append(samples, sample)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
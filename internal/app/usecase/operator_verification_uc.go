@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/storage"
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+)
+
+// MaxDocumentSize is the largest document operators may upload, in bytes.
+const MaxDocumentSize = 10 << 20
+
+// documentContentTypes are the formats operators may upload their documents in.
+var documentContentTypes = []string{"application/pdf", "image/jpeg", "image/png"}
+
+var (
+	ErrInvalidNIT                    = errors.New("nit must be a valid Colombian NIT with its check digit")
+	ErrInvalidDocumentKind           = errors.New("kind must be rut, chamber_of_commerce or identity")
+	ErrDocumentTooLarge              = errors.New("documents cannot exceed 10 MB")
+	ErrUnsupportedDocumentType       = errors.New("documents must be PDF, JPEG or PNG files")
+	ErrDocumentNotFound              = errors.New("document not found")
+	ErrRUTRequired                   = errors.New("a RUT document is required before submitting for review")
+	ErrVerificationLocked            = errors.New("documents and NIT cannot change while under review or once verified")
+	ErrInvalidVerificationTransition = errors.New("the operator cannot change to that verification status from its current one")
+)
+
+type IOperatorVerificationUseCase interface {
+	GetVerification(adminUUID string) (*VerificationDetail, error)
+	UploadDocument(adminUUID string, upload DocumentUpload) (*domain.OperatorDocument, error)
+	SubmitForReview(adminUUID string) (*VerificationDetail, error)
+	GetOperatorVerification(adminID uint) (*VerificationDetail, error)
+	OpenDocument(adminID, documentID uint) (*domain.OperatorDocument, io.ReadCloser, error)
+	VerifyOperator(actorUUID string, adminID uint, reason string) (*VerificationDetail, error)
+	RejectOperator(actorUUID string, adminID uint, reason string) (*VerificationDetail, error)
+}
+
+// DocumentUpload is a document an operator uploads; Size is the size they declared, checked
+// again while reading the content.
+type DocumentUpload struct {
+	Kind     string
+	FileName string
+	Size     int64
+	Content  io.Reader
+}
+
+// VerificationDetail is where an operator stands in the verification, with their documents and
+// the history of the verification.
+type VerificationDetail struct {
+	AdminID    uint                            `json:"admin_id"`
+	NIT        string                          `json:"nit"`
+	Status     string                          `json:"status"`
+	Reason     string                          `json:"reason,omitempty"`
+	VerifiedAt *time.Time                      `json:"verified_at,omitempty"`
+	Documents  []domain.OperatorDocument       `json:"documents"`
+	Events     []domain.AdminVerificationEvent `json:"events"`
+}
+
+// OperatorVerificationUseCase verifies operators: they upload their documents and submit them
+// for review, then a global admin verifies or rejects them. Rejected operators may upload new
+// documents and submit them again.
+type OperatorVerificationUseCase struct {
+	AdminRepository            repository.IAdminRepository
+	OperatorDocumentRepository repository.IOperatorDocumentRepository
+	DocumentStore              storage.DocumentStore
+	now                        func() time.Time
+}
+
+// NewOperatorVerificationUseCase creates a new instance of OperatorVerificationUseCase.
+func NewOperatorVerificationUseCase(adminRepo repository.IAdminRepository, documentRepo repository.IOperatorDocumentRepository, store storage.DocumentStore) IOperatorVerificationUseCase {
+	return &OperatorVerificationUseCase{
+		AdminRepository:            adminRepo,
+		OperatorDocumentRepository: documentRepo,
+		DocumentStore:              store,
+		now:                        time.Now,
+	}
+}
+
+// GetVerification returns where the operator stands in the verification.
+func (uc *OperatorVerificationUseCase) GetVerification(adminUUID string) (*VerificationDetail, error) {
+	admin, err := uc.AdminRepository.FindByAuth0UUID(adminUUID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.detail(admin)
+}
+
+// UploadDocument stores a document of the operator, as long as their documents are not under
+// review or verified.
+func (uc *OperatorVerificationUseCase) UploadDocument(adminUUID string, upload DocumentUpload) (*domain.OperatorDocument, error) {
+	if !domain.ValidDocumentKind(upload.Kind) {
+		return nil, ErrInvalidDocumentKind
+	}
+	if upload.Size > MaxDocumentSize {
+		return nil, ErrDocumentTooLarge
+	}
+
+	admin, err := uc.AdminRepository.FindByAuth0UUID(adminUUID)
+	if err != nil {
+		return nil, err
+	}
+	if !acceptsDocuments(admin) {
+		return nil, ErrVerificationLocked
+	}
+
+	content, err := io.ReadAll(io.LimitReader(upload.Content, MaxDocumentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > MaxDocumentSize {
+		return nil, ErrDocumentTooLarge
+	}
+	contentType := http.DetectContentType(content)
+	if !slices.Contains(documentContentTypes, contentType) {
+		return nil, ErrUnsupportedDocumentType
+	}
+
+	key, err := uc.DocumentStore.Save(upload.FileName, bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	document := &domain.OperatorDocument{
+		AdminID:     admin.ID,
+		Kind:        upload.Kind,
+		FileName:    strings.TrimSpace(upload.FileName),
+		ContentType: contentType,
+		Size:        int64(len(content)),
+		StorageKey:  key,
+	}
+	if err := uc.OperatorDocumentRepository.Create(document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// SubmitForReview puts the operator under review once they have a valid NIT and uploaded
+// their RUT.
+func (uc *OperatorVerificationUseCase) SubmitForReview(adminUUID string) (*VerificationDetail, error) {
+	admin, err := uc.AdminRepository.FindByAuth0UUID(adminUUID)
+	if err != nil {
+		return nil, err
+	}
+	if !acceptsDocuments(admin) {
+		return nil, ErrInvalidVerificationTransition
+	}
+	if _, ok := domain.NormalizeNIT(admin.NIT); !ok {
+		return nil, ErrInvalidNIT
+	}
+
+	documents, err := uc.OperatorDocumentRepository.ListByAdmin(admin.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(documents, func(document domain.OperatorDocument) bool {
+		return document.Kind == domain.DocumentKindRUT
+	}) {
+		return nil, ErrRUTRequired
+	}
+
+	if err := uc.changeVerification(admin, adminUUID, domain.VerificationUnderReview, ""); err != nil {
+		return nil, err
+	}
+	return uc.detail(admin)
+}
+
+// GetOperatorVerification returns where an operator stands in the verification, for global
+// admins to review.
+func (uc *OperatorVerificationUseCase) GetOperatorVerification(adminID uint) (*VerificationDetail, error) {
+	admin, err := findAdminByID(uc.AdminRepository, adminID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.detail(admin)
+}
+
+// OpenDocument opens a document of the operator for global admins to review it. The caller
+// closes the content.
+func (uc *OperatorVerificationUseCase) OpenDocument(adminID, documentID uint) (*domain.OperatorDocument, io.ReadCloser, error) {
+	document, err := uc.OperatorDocumentRepository.FindByAdmin(adminID, documentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if document == nil {
+		return nil, nil, ErrDocumentNotFound
+	}
+	content, err := uc.DocumentStore.Open(document.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return document, content, nil
+}
+
+// VerifyOperator verifies an operator under review, letting them publish parking lots.
+func (uc *OperatorVerificationUseCase) VerifyOperator(actorUUID string, adminID uint, reason string) (*VerificationDetail, error) {
+	return uc.review(actorUUID, adminID, domain.VerificationVerified, strings.TrimSpace(reason))
+}
+
+// RejectOperator rejects an operator under review; the reason tells them what to fix.
+func (uc *OperatorVerificationUseCase) RejectOperator(actorUUID string, adminID uint, reason string) (*VerificationDetail, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrStatusReasonRequired
+	}
+	return uc.review(actorUUID, adminID, domain.VerificationRejected, reason)
+}
+
+func (uc *OperatorVerificationUseCase) review(actorUUID string, adminID uint, status, reason string) (*VerificationDetail, error) {
+	admin, err := findAdminByID(uc.AdminRepository, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin.VerificationStatus != domain.VerificationUnderReview {
+		return nil, ErrInvalidVerificationTransition
+	}
+	if err := uc.changeVerification(admin, actorUUID, status, reason); err != nil {
+		return nil, err
+	}
+	return uc.detail(admin)
+}
+
+func (uc *OperatorVerificationUseCase) changeVerification(admin *domain.Admin, actorUUID, status, reason string) error {
+	previous := admin.VerificationStatus
+	admin.VerificationStatus = status
+	admin.VerificationReason = reason
+	admin.VerifiedAt = nil
+	if status == domain.VerificationVerified {
+		now := uc.now()
+		admin.VerifiedAt = &now
+	}
+	return uc.AdminRepository.ChangeVerification(admin, &domain.AdminVerificationEvent{
+		FromStatus: previous,
+		ToStatus:   status,
+		Reason:     reason,
+		Actor:      actorUUID,
+	})
+}
+
+func (uc *OperatorVerificationUseCase) detail(admin *domain.Admin) (*VerificationDetail, error) {
+	documents, err := uc.OperatorDocumentRepository.ListByAdmin(admin.ID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := uc.AdminRepository.ListVerificationEvents(admin.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &VerificationDetail{
+		AdminID:    admin.ID,
+		NIT:        admin.NIT,
+		Status:     admin.VerificationStatus,
+		Reason:     admin.VerificationReason,
+		VerifiedAt: admin.VerifiedAt,
+		Documents:  documents,
+		Events:     events,
+	}, nil
+}
+
+// acceptsDocuments reports whether the operator may still change their documents and NIT.
+func acceptsDocuments(admin *domain.Admin) bool {
+	return admin.VerificationStatus == domain.VerificationPendingDocuments || admin.VerificationStatus == domain.VerificationRejected
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/storage"
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var verificationNow = time.Date(2024, time.December, 16, 10, 0, 0, 0, time.UTC)
+
+func setupVerificationTest(t *testing.T) (*gomock.Controller, *mockgen.MockIAdminRepository, *mockgen.MockIOperatorDocumentRepository, *OperatorVerificationUseCase) {
+	ctrl := gomock.NewController(t)
+	adminRepo := mockgen.NewMockIAdminRepository(ctrl)
+	documentRepo := mockgen.NewMockIOperatorDocumentRepository(ctrl)
+	useCase := NewOperatorVerificationUseCase(adminRepo, documentRepo, storage.NewLocalStore(t.TempDir())).(*OperatorVerificationUseCase)
+	useCase.now = func() time.Time { return verificationNow }
+	return ctrl, adminRepo, documentRepo, useCase
+}
+
+func TestUploadDocumentStoresPDFs(t *testing.T) {
+	ctrl, adminRepo, documentRepo, useCase := setupVerificationTest(t)
+	defer ctrl.Finish()
+
+	adminRepo.EXPECT().FindByAuth0UUID("auth0|operator").Return(&domain.Admin{ID: 4, VerificationStatus: domain.VerificationPendingDocuments}, nil).Times(2)
+	var stored *domain.OperatorDocument
+	documentRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(document *domain.OperatorDocument) error {
+		stored = document
+		return nil
+	})
+
+	document, err := useCase.UploadDocument("auth0|operator", DocumentUpload{Kind: domain.DocumentKindRUT, FileName: "rut.pdf", Content: strings.NewReader("%PDF-1.7\nRUT")})
+	assert.NoError(t, err)
+	assert.Equal(t, "application/pdf", document.ContentType)
+	assert.Equal(t, int64(12), document.Size)
+
+	documentRepo.EXPECT().FindByAdmin(uint(4), uint(1)).Return(stored, nil)
+	_, content, err := useCase.OpenDocument(4, 1)
+	assert.NoError(t, err)
+	defer content.Close()
+	data, _ := io.ReadAll(content)
+	assert.Equal(t, "%PDF-1.7\nRUT", string(data))
+
+	_, err = useCase.UploadDocument("auth0|operator", DocumentUpload{Kind: domain.DocumentKindRUT, FileName: "rut.txt", Content: strings.NewReader("plain text")})
+	assert.ErrorIs(t, err, ErrUnsupportedDocumentType)
+	_, err = useCase.UploadDocument("auth0|operator", DocumentUpload{Kind: "passport", Content: strings.NewReader("%PDF-1.7")})
+	assert.ErrorIs(t, err, ErrInvalidDocumentKind)
+	_, err = useCase.UploadDocument("auth0|operator", DocumentUpload{Kind: domain.DocumentKindRUT, Size: MaxDocumentSize + 1})
+	assert.ErrorIs(t, err, ErrDocumentTooLarge)
+}
+
+func TestUploadDocumentIsLockedUnderReview(t *testing.T) {
+	ctrl, adminRepo, _, useCase := setupVerificationTest(t)
+	defer ctrl.Finish()
+
+	adminRepo.EXPECT().FindByAuth0UUID("auth0|operator").Return(&domain.Admin{ID: 4, VerificationStatus: domain.VerificationUnderReview}, nil)
+
+	_, err := useCase.UploadDocument("auth0|operator", DocumentUpload{Kind: domain.DocumentKindRUT, Content: strings.NewReader("%PDF-1.7")})
+	assert.ErrorIs(t, err, ErrVerificationLocked)
+}
+
+func TestSubmitForReviewRequiresNITAndRUT(t *testing.T) {
+	ctrl, adminRepo, documentRepo, useCase := setupVerificationTest(t)
+	defer ctrl.Finish()
+
+	adminRepo.EXPECT().FindByAuth0UUID("auth0|no-nit").Return(&domain.Admin{ID: 3, VerificationStatus: domain.VerificationPendingDocuments}, nil)
+	_, err := useCase.SubmitForReview("auth0|no-nit")
+	assert.ErrorIs(t, err, ErrInvalidNIT)
+
+	operator := &domain.Admin{ID: 4, NIT: "800197268-4", VerificationStatus: domain.VerificationRejected}
+	adminRepo.EXPECT().FindByAuth0UUID("auth0|operator").Return(operator, nil).Times(2)
+	documentRepo.EXPECT().ListByAdmin(uint(4)).Return([]domain.OperatorDocument{{Kind: domain.DocumentKindIdentity}}, nil)
+	_, err = useCase.SubmitForReview("auth0|operator")
+	assert.ErrorIs(t, err, ErrRUTRequired)
+
+	documentRepo.EXPECT().ListByAdmin(uint(4)).Return([]domain.OperatorDocument{{Kind: domain.DocumentKindRUT}}, nil).Times(2)
+	adminRepo.EXPECT().ChangeVerification(operator, &domain.AdminVerificationEvent{
+		FromStatus: domain.VerificationRejected,
+		ToStatus:   domain.VerificationUnderReview,
+		Actor:      "auth0|operator",
+	}).Return(nil)
+	adminRepo.EXPECT().ListVerificationEvents(uint(4)).Return(nil, nil)
+
+	verification, err := useCase.SubmitForReview("auth0|operator")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.VerificationUnderReview, verification.Status)
+	assert.Empty(t, verification.Reason)
+}
+
+func TestReviewOperator(t *testing.T) {
+	ctrl, adminRepo, documentRepo, useCase := setupVerificationTest(t)
+	defer ctrl.Finish()
+
+	_, err := useCase.RejectOperator("auth0|global", 4, " ")
+	assert.ErrorIs(t, err, ErrStatusReasonRequired)
+
+	adminRepo.EXPECT().FindByID(uint(5)).Return(&domain.Admin{ID: 5, VerificationStatus: domain.VerificationPendingDocuments}, nil)
+	_, err = useCase.VerifyOperator("auth0|global", 5, "")
+	assert.ErrorIs(t, err, ErrInvalidVerificationTransition)
+
+	adminRepo.EXPECT().FindByID(uint(4)).Return(&domain.Admin{ID: 4, VerificationStatus: domain.VerificationUnderReview}, nil)
+	adminRepo.EXPECT().ChangeVerification(gomock.Any(), &domain.AdminVerificationEvent{
+		FromStatus: domain.VerificationUnderReview,
+		ToStatus:   domain.VerificationVerified,
+		Reason:     "RUT matches the NIT",
+		Actor:      "auth0|global",
+	}).Return(nil)
+	documentRepo.EXPECT().ListByAdmin(uint(4)).Return(nil, nil)
+	adminRepo.EXPECT().ListVerificationEvents(uint(4)).Return(nil, nil)
+
+	verification, err := useCase.VerifyOperator("auth0|global", 4, "RUT matches the NIT")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.VerificationVerified, verification.Status)
+	assert.Equal(t, verificationNow, *verification.VerifiedAt)
+}
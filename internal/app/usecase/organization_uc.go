@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrOrganizationNotFound      = errors.New("organization not found")
+	ErrOrganizationOwnerRequired = errors.New("only owners of the organization can manage its members")
+	ErrInvalidOrganizationName   = errors.New("organization name is required")
+	ErrInvalidMembershipRole     = errors.New("role must be owner, manager, attendant or technician")
+	ErrLastOrganizationOwner     = errors.New("the organization must keep at least one owner")
+	ErrLotOutsideOrganization    = errors.New("parking lot does not belong to the organization")
+	ErrMemberNotFound            = errors.New("admin is not registered or not a member of the organization")
+)
+
+type IOrganizationUseCase interface {
+	CreateOrganization(adminUUID string, name string) (*domain.Organization, error)
+	ListOrganizations(adminUUID string) ([]domain.Organization, error)
+	ListMembers(adminUUID string, organizationID uint) ([]domain.OrganizationMembership, error)
+	SetMember(adminUUID string, organizationID uint, req MembershipRequest) (*domain.OrganizationMembership, error)
+	RemoveMember(adminUUID string, organizationID uint, memberAdminID uint) error
+}
+
+// MembershipRequest gives the admin registered as AdminUUID a role in the organization. Listing
+// ParkingLotIDs restricts the membership to those lots.
+type MembershipRequest struct {
+	AdminUUID     string `json:"admin_uuid"`
+	Role          string `json:"role"`
+	ParkingLotIDs []uint `json:"parking_lot_ids"`
+}
+
+// OrganizationUseCase manages the operators owning parking lots and the admins working for them.
+type OrganizationUseCase struct {
+	OrganizationRepository repository.IOrganizationRepository
+	AdminRepository        repository.IAdminRepository
+	ParkingLotRepository   repository.IParkingLotRepository
+}
+
+// NewOrganizationUseCase creates a new instance of OrganizationUseCase.
+func NewOrganizationUseCase(organizationRepo repository.IOrganizationRepository, adminRepo repository.IAdminRepository, parkingLotRepo repository.IParkingLotRepository) IOrganizationUseCase {
+	return &OrganizationUseCase{
+		OrganizationRepository: organizationRepo,
+		AdminRepository:        adminRepo,
+		ParkingLotRepository:   parkingLotRepo,
+	}
+}
+
+// CreateOrganization creates an organization owned by the admin.
+func (uc *OrganizationUseCase) CreateOrganization(adminUUID string, name string) (*domain.Organization, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrInvalidOrganizationName
+	}
+
+	admin, err := uc.AdminRepository.FindByAuth0UUID(adminUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	organization := domain.Organization{Name: name}
+	owner := domain.OrganizationMembership{AdminID: admin.ID, Role: domain.MembershipRoleOwner}
+	if err := uc.OrganizationRepository.Create(&organization, &owner); err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// ListOrganizations lists the organizations the admin is a member of.
+func (uc *OrganizationUseCase) ListOrganizations(adminUUID string) ([]domain.Organization, error) {
+	admin, err := uc.AdminRepository.FindByAuth0UUID(adminUUID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.OrganizationRepository.ListByAdmin(admin.ID)
+}
+
+// ListMembers lists the memberships of an organization the admin is a member of.
+func (uc *OrganizationUseCase) ListMembers(adminUUID string, organizationID uint) ([]domain.OrganizationMembership, error) {
+	if _, err := uc.membership(adminUUID, organizationID); err != nil {
+		return nil, err
+	}
+	return uc.OrganizationRepository.ListMemberships(organizationID)
+}
+
+// SetMember adds an admin to the organization or changes their role and lots. Only owners may do
+// it, and the last owner cannot be demoted.
+func (uc *OrganizationUseCase) SetMember(adminUUID string, organizationID uint, req MembershipRequest) (*domain.OrganizationMembership, error) {
+	if !domain.ValidMembershipRole(req.Role) {
+		return nil, ErrInvalidMembershipRole
+	}
+	if _, err := uc.ownerMembership(adminUUID, organizationID); err != nil {
+		return nil, err
+	}
+
+	member, err := uc.AdminRepository.FindByAuth0UUID(req.AdminUUID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMemberNotFound
+		}
+		return nil, err
+	}
+
+	lots, err := uc.organizationLots(organizationID, req.ParkingLotIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	membership, err := uc.OrganizationRepository.FindMembership(organizationID, member.ID)
+	if err != nil {
+		return nil, err
+	}
+	if membership == nil {
+		membership = &domain.OrganizationMembership{OrganizationID: organizationID, AdminID: member.ID}
+	} else if membership.Role == domain.MembershipRoleOwner && req.Role != domain.MembershipRoleOwner {
+		if err := uc.ensureAnotherOwner(organizationID); err != nil {
+			return nil, err
+		}
+	}
+
+	membership.Role = req.Role
+	membership.Lots = lots
+	if err := uc.OrganizationRepository.SaveMembership(membership); err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+// RemoveMember removes an admin from the organization. Owners may remove anyone and members may
+// leave, as long as the organization keeps an owner.
+func (uc *OrganizationUseCase) RemoveMember(adminUUID string, organizationID uint, memberAdminID uint) error {
+	current, err := uc.membership(adminUUID, organizationID)
+	if err != nil {
+		return err
+	}
+	if current.AdminID != memberAdminID && current.Role != domain.MembershipRoleOwner {
+		return ErrOrganizationOwnerRequired
+	}
+
+	membership, err := uc.OrganizationRepository.FindMembership(organizationID, memberAdminID)
+	if err != nil {
+		return err
+	}
+	if membership == nil {
+		return ErrMemberNotFound
+	}
+	if membership.Role == domain.MembershipRoleOwner {
+		if err := uc.ensureAnotherOwner(organizationID); err != nil {
+			return err
+		}
+	}
+	return uc.OrganizationRepository.DeleteMembership(membership.ID)
+}
+
+// membership returns the membership of the admin, reporting organizations they do not belong to
+// as not found.
+func (uc *OrganizationUseCase) membership(adminUUID string, organizationID uint) (*domain.OrganizationMembership, error) {
+	admin, err := uc.AdminRepository.FindByAuth0UUID(adminUUID)
+	if err != nil {
+		return nil, err
+	}
+	membership, err := uc.OrganizationRepository.FindMembership(organizationID, admin.ID)
+	if err != nil {
+		return nil, err
+	}
+	if membership == nil {
+		return nil, ErrOrganizationNotFound
+	}
+	return membership, nil
+}
+
+func (uc *OrganizationUseCase) ownerMembership(adminUUID string, organizationID uint) (*domain.OrganizationMembership, error) {
+	membership, err := uc.membership(adminUUID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if membership.Role != domain.MembershipRoleOwner {
+		return nil, ErrOrganizationOwnerRequired
+	}
+	return membership, nil
+}
+
+func (uc *OrganizationUseCase) ensureAnotherOwner(organizationID uint) error {
+	owners, err := uc.OrganizationRepository.CountOwners(organizationID)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return ErrLastOrganizationOwner
+	}
+	return nil
+}
+
+// organizationLots checks that every lot belongs to the organization.
+func (uc *OrganizationUseCase) organizationLots(organizationID uint, parkingLotIDs []uint) ([]domain.OrganizationMembershipLot, error) {
+	if len(parkingLotIDs) == 0 {
+		return nil, nil
+	}
+
+	parkingLots, err := uc.ParkingLotRepository.FindByOrganizationID(organizationID)
+	if err != nil {
+		return nil, err
+	}
+	owned := make(map[uint]bool, len(parkingLots))
+	for _, lot := range parkingLots {
+		owned[lot.ID] = true
+	}
+
+	lots := []domain.OrganizationMembershipLot{}
+	seen := make(map[uint]bool, len(parkingLotIDs))
+	for _, id := range parkingLotIDs {
+		if !owned[id] {
+			return nil, ErrLotOutsideOrganization
+		}
+		if !seen[id] {
+			seen[id] = true
+			lots = append(lots, domain.OrganizationMembershipLot{ParkingLotID: id})
+		}
+	}
+	return lots, nil
+}
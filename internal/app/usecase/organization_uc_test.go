@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type organizationMocks struct {
+	organization *mockgen.MockIOrganizationRepository
+	admin        *mockgen.MockIAdminRepository
+	parkingLot   *mockgen.MockIParkingLotRepository
+}
+
+func setupOrganizationTest(t *testing.T) (*gomock.Controller, organizationMocks, IOrganizationUseCase) {
+	ctrl := gomock.NewController(t)
+	m := organizationMocks{
+		organization: mockgen.NewMockIOrganizationRepository(ctrl),
+		admin:        mockgen.NewMockIAdminRepository(ctrl),
+		parkingLot:   mockgen.NewMockIParkingLotRepository(ctrl),
+	}
+	return ctrl, m, NewOrganizationUseCase(m.organization, m.admin, m.parkingLot)
+}
+
+func TestCreateOrganizationMakesTheAdminOwner(t *testing.T) {
+	ctrl, m, useCase := setupOrganizationTest(t)
+	defer ctrl.Finish()
+
+	m.admin.EXPECT().FindByAuth0UUID("auth0|owner").Return(&domain.Admin{ID: 1}, nil)
+	m.organization.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(organization *domain.Organization, owner *domain.OrganizationMembership) error {
+		assert.Equal(t, "Parqueaderos Centro", organization.Name)
+		assert.Equal(t, domain.OrganizationMembership{AdminID: 1, Role: domain.MembershipRoleOwner}, *owner)
+		return nil
+	})
+
+	_, err := useCase.CreateOrganization("auth0|owner", "  Parqueaderos Centro ")
+	assert.NoError(t, err)
+
+	_, err = useCase.CreateOrganization("auth0|owner", " ")
+	assert.ErrorIs(t, err, ErrInvalidOrganizationName)
+}
+
+func TestSetMemberRestrictsToOrganizationLots(t *testing.T) {
+	ctrl, m, useCase := setupOrganizationTest(t)
+	defer ctrl.Finish()
+
+	m.admin.EXPECT().FindByAuth0UUID("auth0|owner").Return(&domain.Admin{ID: 1}, nil).Times(2)
+	m.admin.EXPECT().FindByAuth0UUID("auth0|attendant").Return(&domain.Admin{ID: 2}, nil).Times(2)
+	m.organization.EXPECT().FindMembership(uint(3), uint(1)).Return(&domain.OrganizationMembership{ID: 10, OrganizationID: 3, AdminID: 1, Role: domain.MembershipRoleOwner}, nil).Times(2)
+	m.organization.EXPECT().FindMembership(uint(3), uint(2)).Return(nil, nil)
+	m.parkingLot.EXPECT().FindByOrganizationID(uint(3)).Return([]domain.ParkingLot{{ID: 7}, {ID: 8}}, nil).Times(2)
+	m.organization.EXPECT().SaveMembership(gomock.Any()).Return(nil)
+
+	membership, err := useCase.SetMember("auth0|owner", 3, MembershipRequest{
+		AdminUUID: "auth0|attendant", Role: domain.MembershipRoleAttendant, ParkingLotIDs: []uint{8, 8},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), membership.AdminID)
+	assert.Equal(t, []domain.OrganizationMembershipLot{{ParkingLotID: 8}}, membership.Lots)
+
+	_, err = useCase.SetMember("auth0|owner", 3, MembershipRequest{
+		AdminUUID: "auth0|attendant", Role: domain.MembershipRoleAttendant, ParkingLotIDs: []uint{9},
+	})
+	assert.ErrorIs(t, err, ErrLotOutsideOrganization)
+}
+
+func TestSetMemberRequiresOwner(t *testing.T) {
+	ctrl, m, useCase := setupOrganizationTest(t)
+	defer ctrl.Finish()
+
+	_, err := useCase.SetMember("auth0|manager", 3, MembershipRequest{AdminUUID: "auth0|other", Role: "cashier"})
+	assert.ErrorIs(t, err, ErrInvalidMembershipRole)
+
+	m.admin.EXPECT().FindByAuth0UUID("auth0|manager").Return(&domain.Admin{ID: 4}, nil).Times(2)
+	m.organization.EXPECT().FindMembership(uint(3), uint(4)).Return(&domain.OrganizationMembership{Role: domain.MembershipRoleManager}, nil)
+	m.organization.EXPECT().FindMembership(uint(5), uint(4)).Return(nil, nil)
+
+	_, err = useCase.SetMember("auth0|manager", 3, MembershipRequest{AdminUUID: "auth0|other", Role: domain.MembershipRoleOwner})
+	assert.ErrorIs(t, err, ErrOrganizationOwnerRequired)
+	_, err = useCase.SetMember("auth0|manager", 5, MembershipRequest{AdminUUID: "auth0|other", Role: domain.MembershipRoleOwner})
+	assert.ErrorIs(t, err, ErrOrganizationNotFound)
+}
+
+func TestSetMemberUnknownAdmin(t *testing.T) {
+	ctrl, m, useCase := setupOrganizationTest(t)
+	defer ctrl.Finish()
+
+	m.admin.EXPECT().FindByAuth0UUID("auth0|owner").Return(&domain.Admin{ID: 1}, nil)
+	m.organization.EXPECT().FindMembership(uint(3), uint(1)).Return(&domain.OrganizationMembership{Role: domain.MembershipRoleOwner}, nil)
+	m.admin.EXPECT().FindByAuth0UUID("auth0|missing").Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := useCase.SetMember("auth0|owner", 3, MembershipRequest{AdminUUID: "auth0|missing", Role: domain.MembershipRoleManager})
+	assert.ErrorIs(t, err, ErrMemberNotFound)
+}
+
+func TestRemoveMemberKeepsAnOwner(t *testing.T) {
+	ctrl, m, useCase := setupOrganizationTest(t)
+	defer ctrl.Finish()
+
+	owner := &domain.OrganizationMembership{ID: 10, OrganizationID: 3, AdminID: 1, Role: domain.MembershipRoleOwner}
+	m.admin.EXPECT().FindByAuth0UUID("auth0|owner").Return(&domain.Admin{ID: 1}, nil).Times(2)
+	m.organization.EXPECT().FindMembership(uint(3), uint(1)).Return(owner, nil).Times(3)
+	m.organization.EXPECT().CountOwners(uint(3)).Return(int64(1), nil)
+
+	assert.ErrorIs(t, useCase.RemoveMember("auth0|owner", 3, 1), ErrLastOrganizationOwner)
+
+	m.organization.EXPECT().FindMembership(uint(3), uint(2)).Return(&domain.OrganizationMembership{ID: 11, OrganizationID: 3, AdminID: 2, Role: domain.MembershipRoleTechnician}, nil)
+	m.organization.EXPECT().DeleteMembership(uint(11)).Return(nil)
+
+	assert.NoError(t, useCase.RemoveMember("auth0|owner", 3, 2))
+}
+
+func TestMembershipCoversLot(t *testing.T) {
+	everyLot := domain.OrganizationMembership{Role: domain.MembershipRoleManager}
+	assert.True(t, everyLot.CoversLot(5))
+
+	someLots := domain.OrganizationMembership{Role: domain.MembershipRoleAttendant, Lots: []domain.OrganizationMembershipLot{{ParkingLotID: 2}}}
+	assert.True(t, someLots.CoversLot(2))
+	assert.False(t, someLots.CoversLot(5))
+	assert.True(t, someLots.Can(domain.PermissionLotRead))
+	assert.False(t, someLots.Can(domain.PermissionLotWrite))
+}
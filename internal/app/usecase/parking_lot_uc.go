@@ -2,8 +2,12 @@ package usecase
 
 import (
 	"errors"
+	"sort"
+	"time"
+
 	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
 	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+	"gorm.io/gorm"
 )
 
 //go:generate mockgen -source=./parking_lot_uc.go -destination=./../../test/parking/mocks/mock_parking_lot_uc.go -package=mockgen
@@ -11,24 +15,42 @@ type IParkingLotUseCase interface {
 	CreateParkingLot(req CreateParkingLotRequest) (*ParkingLotResponse, error)
 	GetParkingLot(parkingLotID uint) (*ParkingLotResponse, error)
 	GetParkingLotWithOwnership(parkingLotID uint, adminUUID string) (*ParkingLotResponse, error)
+	AuthorizeParkingLot(parkingLotID uint, adminUUID string, permissions []string) error
 	UpdateParkingLot(parkingLotID uint, req UpdateParkingLotRequest, adminUUID string) error
 	DeleteParkingLot(parkingLotID uint, adminUUID string) error
-	ListParkingLots() ([]ParkingLotResponse, error)
+	ListParkingLots(query ParkingLotQuery) ([]ParkingLotResponse, error)
 }
 
 type ParkingLotUseCase struct {
-	ParkingLotRepository repository.IParkingLotRepository
-	SensorRepository     repository.ISensorRepository
-	AdminRepository      repository.IAdminRepository
+	ParkingLotRepository   repository.IParkingLotRepository
+	SensorRepository       repository.ISensorRepository
+	AdminRepository        repository.IAdminRepository
+	ReservationRepository  repository.IReservationRepository
+	ReviewRepository       repository.IReviewRepository
+	CrowdReportRepository  repository.ICrowdReportRepository
+	OrganizationRepository repository.IOrganizationRepository
 }
 
 type ParkingLotResponse struct {
-	ID              uint    `json:"id"`
-	Name            string  `json:"name"`
-	Address         string  `json:"address"`
-	Latitude        float64 `json:"latitude"`
-	Longitude       float64 `json:"longitude"`
-	AvailableSpaces uint    `json:"available_spaces"`
+	ID                     uint                  `json:"id"`
+	Name                   string                `json:"name"`
+	Address                string                `json:"address"`
+	Latitude               float64               `json:"latitude"`
+	Longitude              float64               `json:"longitude"`
+	AvailableSpaces        uint                  `json:"available_spaces"`
+	HourlyRate             uint                  `json:"hourly_rate"`
+	BillingFractionMinutes uint                  `json:"billing_fraction_minutes"`
+	Rating                 *domain.RatingSummary `json:"rating,omitempty"`
+	AvailabilitySource     string                `json:"availability_source,omitempty"`
+	CrowdEstimate          *CrowdEstimate        `json:"crowd_estimate,omitempty"`
+	VerifiedOperator       bool                  `json:"verified_operator"`
+}
+
+// ParkingLotQuery narrows and orders the parking lot list. Spots only counts the free spots
+// matching the filter; SortBy is ParkingLotSortRating to list the best rated lots first.
+type ParkingLotQuery struct {
+	Spots  *domain.SpotFilter
+	SortBy string
 }
 
 type CreateParkingLotRequest struct {
@@ -37,38 +59,76 @@ type CreateParkingLotRequest struct {
 	Latitude  float64 `json:"latitude"`
 	Longitude float64 `json:"longitude"`
 	AdminUUID string  `json:"admin_uuid"`
+	// OrganizationID is the organization that will own the lot. It may be left out when the admin
+	// belongs to a single organization; admins without one get their own.
+	OrganizationID uint `json:"organization_id"`
 }
 
 type UpdateParkingLotRequest struct {
-	Name      string  `json:"name"`
-	Address   string  `json:"address"`
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
+	Name                   string  `json:"name"`
+	Address                string  `json:"address"`
+	Latitude               float64 `json:"latitude"`
+	Longitude              float64 `json:"longitude"`
+	HourlyRate             *uint   `json:"hourly_rate"`
+	MotorcycleHourlyRate   *uint   `json:"motorcycle_hourly_rate"`
+	BillingFractionMinutes *uint   `json:"billing_fraction_minutes"`
+	Capacity               *uint   `json:"capacity"`
+	// OpensAt and ClosesAt set the opening hours as HH:MM; both empty means open around the clock.
+	OpensAt  *string `json:"opens_at"`
+	ClosesAt *string `json:"closes_at"`
 }
 
+const ParkingLotSortRating = "rating"
+
+var (
+	ErrInvalidBillingFraction = errors.New("billing_fraction_minutes must be at least 1")
+	ErrInvalidOpeningHours    = errors.New("opens_at and closes_at must both be HH:MM and differ, or both be empty")
+	ErrParkingLotForbidden    = errors.New("forbidden: you don't have access to this parking lot")
+	ErrOrganizationRequired   = errors.New("organization_id is required for admins of several organizations")
+	ErrOperatorNotVerified    = errors.New("only verified operators can publish parking lots")
+	ErrOperatorNotActive      = errors.New("only approved operators that are not suspended can publish parking lots")
+)
+
 // NewParkingLotUseCase creates a new instance of ParkingLotUseCase.
-func NewParkingLotUseCase(parkingLotRepo repository.IParkingLotRepository, sensorRepository repository.ISensorRepository, adminRepository repository.IAdminRepository) IParkingLotUseCase {
+func NewParkingLotUseCase(parkingLotRepo repository.IParkingLotRepository, sensorRepository repository.ISensorRepository, adminRepository repository.IAdminRepository, reservationRepository repository.IReservationRepository, reviewRepository repository.IReviewRepository, crowdReportRepository repository.ICrowdReportRepository, organizationRepository repository.IOrganizationRepository) IParkingLotUseCase {
 	return &ParkingLotUseCase{
-		ParkingLotRepository: parkingLotRepo,
-		SensorRepository:     sensorRepository,
-		AdminRepository:      adminRepository,
+		ParkingLotRepository:   parkingLotRepo,
+		SensorRepository:       sensorRepository,
+		AdminRepository:        adminRepository,
+		ReservationRepository:  reservationRepository,
+		ReviewRepository:       reviewRepository,
+		CrowdReportRepository:  crowdReportRepository,
+		OrganizationRepository: organizationRepository,
 	}
 }
 
-// CreateParkingLot creates a new parking lot.
+// CreateParkingLot creates a new parking lot owned by an organization of the admin, who must be
+// an active and verified operator.
 func (uc *ParkingLotUseCase) CreateParkingLot(req CreateParkingLotRequest) (*ParkingLotResponse, error) {
 
 	admin, err := uc.AdminRepository.FindByAuth0UUID(req.AdminUUID)
 	if err != nil {
 		return nil, err
 	}
+	if admin.Status != domain.AdminStatusActive {
+		return nil, ErrOperatorNotActive
+	}
+	if admin.VerificationStatus != domain.VerificationVerified {
+		return nil, ErrOperatorNotVerified
+	}
+
+	organizationID, err := uc.owningOrganization(admin, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
 
 	parkingLot := domain.ParkingLot{
-		Name:      req.Name,
-		Address:   req.Address,
-		Latitude:  req.Latitude,
-		Longitude: req.Longitude,
-		AdminID:   admin.ID,
+		Name:           req.Name,
+		Address:        req.Address,
+		Latitude:       req.Latitude,
+		Longitude:      req.Longitude,
+		AdminID:        admin.ID,
+		OrganizationID: organizationID,
 	}
 
 	if err := uc.ParkingLotRepository.Create(&parkingLot); err != nil {
@@ -96,56 +156,69 @@ func (uc *ParkingLotUseCase) GetParkingLot(parkingLotID uint) (*ParkingLotRespon
 		return nil, err
 	}
 
-	availableSpaces := countAvailableSpaces(sensors)
+	availableSpaces, err := uc.availableSpaces(parkingLotID, sensors)
+	if err != nil {
+		return nil, err
+	}
+
+	rating, err := uc.ReviewRepository.GetRatingSummary(parkingLotID)
+	if err != nil {
+		return nil, err
+	}
 
 	return &ParkingLotResponse{
-		ID:              parkingLot.ID,
-		Name:            parkingLot.Name,
-		Address:         parkingLot.Address,
-		Latitude:        parkingLot.Latitude,
-		Longitude:       parkingLot.Longitude,
-		AvailableSpaces: availableSpaces,
+		ID:                     parkingLot.ID,
+		Name:                   parkingLot.Name,
+		Address:                parkingLot.Address,
+		Latitude:               parkingLot.Latitude,
+		Longitude:              parkingLot.Longitude,
+		AvailableSpaces:        availableSpaces,
+		HourlyRate:             parkingLot.HourlyRate,
+		BillingFractionMinutes: parkingLot.BillingFractionMinutes,
+		Rating:                 &rating,
 	}, nil
 }
 
-// GetParkingLotWithOwnership retrieves a parking lot if it belongs to the admin.
+// GetParkingLotWithOwnership retrieves a parking lot if a membership of the admin covers it.
 func (uc *ParkingLotUseCase) GetParkingLotWithOwnership(parkingLotID uint, adminUUID string) (*ParkingLotResponse, error) {
-	admin, err := uc.AdminRepository.FindByAuth0UUID(adminUUID)
+	admin, parkingLot, err := uc.memberParkingLot(parkingLotID, adminUUID, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	parkingLot, err := uc.ParkingLotRepository.GetByIDWithAdmin(parkingLotID, admin.ID)
+	sensors, err := uc.SensorRepository.ListByParkingLotForMember(parkingLotID, admin.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	sensors, err := uc.SensorRepository.ListByParkingLot(parkingLotID)
+	availableSpaces, err := uc.availableSpaces(parkingLotID, sensors)
 	if err != nil {
 		return nil, err
 	}
 
-	availableSpaces := countAvailableSpaces(sensors)
-
 	return &ParkingLotResponse{
-		ID:              parkingLot.ID,
-		Name:            parkingLot.Name,
-		Address:         parkingLot.Address,
-		Latitude:        parkingLot.Latitude,
-		Longitude:       parkingLot.Longitude,
-		AvailableSpaces: availableSpaces,
+		ID:                     parkingLot.ID,
+		Name:                   parkingLot.Name,
+		Address:                parkingLot.Address,
+		Latitude:               parkingLot.Latitude,
+		Longitude:              parkingLot.Longitude,
+		AvailableSpaces:        availableSpaces,
+		HourlyRate:             parkingLot.HourlyRate,
+		BillingFractionMinutes: parkingLot.BillingFractionMinutes,
 	}, nil
 }
 
+// AuthorizeParkingLot checks that a membership of the admin covers the parking lot and that its
+// role grants every permission, returning ErrParkingLotForbidden otherwise.
+func (uc *ParkingLotUseCase) AuthorizeParkingLot(parkingLotID uint, adminUUID string, permissions []string) error {
+	_, _, err := uc.memberParkingLot(parkingLotID, adminUUID, permissions)
+	return err
+}
+
 // UpdateParkingLot updates a parking lot.
 func (uc *ParkingLotUseCase) UpdateParkingLot(parkingLotID uint, req UpdateParkingLotRequest, adminUUID string) error {
 
-	admin, err := uc.AdminRepository.FindByAuth0UUID(adminUUID)
-	if err != nil {
-		return err
-	}
-
-	parkingLot, err := uc.ParkingLotRepository.GetByIDWithAdmin(parkingLotID, admin.ID)
+	_, parkingLot, err := uc.memberParkingLot(parkingLotID, adminUUID, []string{domain.PermissionLotWrite})
 	if err != nil {
 		return err
 	}
@@ -154,6 +227,34 @@ func (uc *ParkingLotUseCase) UpdateParkingLot(parkingLotID uint, req UpdateParki
 	parkingLot.Address = req.Address
 	parkingLot.Latitude = req.Latitude
 	parkingLot.Longitude = req.Longitude
+	if req.HourlyRate != nil {
+		parkingLot.HourlyRate = *req.HourlyRate
+	}
+	if req.MotorcycleHourlyRate != nil {
+		parkingLot.MotorcycleHourlyRate = *req.MotorcycleHourlyRate
+	}
+	if req.Capacity != nil {
+		parkingLot.Capacity = *req.Capacity
+	}
+	if req.BillingFractionMinutes != nil {
+		if *req.BillingFractionMinutes == 0 {
+			return ErrInvalidBillingFraction
+		}
+		parkingLot.BillingFractionMinutes = *req.BillingFractionMinutes
+	}
+	if req.OpensAt != nil || req.ClosesAt != nil {
+		opensAt, closesAt := parkingLot.OpensAt, parkingLot.ClosesAt
+		if req.OpensAt != nil {
+			opensAt = *req.OpensAt
+		}
+		if req.ClosesAt != nil {
+			closesAt = *req.ClosesAt
+		}
+		if !validOpeningHours(opensAt, closesAt) {
+			return ErrInvalidOpeningHours
+		}
+		parkingLot.OpensAt, parkingLot.ClosesAt = opensAt, closesAt
+	}
 
 	return uc.ParkingLotRepository.Update(parkingLot)
 }
@@ -161,51 +262,193 @@ func (uc *ParkingLotUseCase) UpdateParkingLot(parkingLotID uint, req UpdateParki
 // DeleteParkingLot deletes a parking lot with ownership validation.
 func (uc *ParkingLotUseCase) DeleteParkingLot(parkingLotID uint, adminUUID string) error {
 
+	if _, _, err := uc.memberParkingLot(parkingLotID, adminUUID, []string{domain.PermissionLotWrite}); err != nil {
+		return err
+	}
+	return uc.ParkingLotRepository.Delete(parkingLotID)
+}
+
+// memberParkingLot retrieves the parking lot when a membership of the admin covers it and its role
+// grants every permission.
+func (uc *ParkingLotUseCase) memberParkingLot(parkingLotID uint, adminUUID string, permissions []string) (*domain.Admin, *domain.ParkingLot, error) {
 	admin, err := uc.AdminRepository.FindByAuth0UUID(adminUUID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, ErrParkingLotForbidden
+	}
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	if _, err := uc.ParkingLotRepository.GetByIDWithAdmin(parkingLotID, admin.ID); err != nil {
-		return errors.New("forbidden: you don't have access to this parking lot")
+	parkingLot, membership, err := uc.ParkingLotRepository.GetByIDForMember(parkingLotID, admin.ID)
+	if err != nil {
+		return nil, nil, err
 	}
-	return uc.ParkingLotRepository.Delete(parkingLotID)
+	if parkingLot == nil {
+		return nil, nil, ErrParkingLotForbidden
+	}
+	for _, permission := range permissions {
+		if !membership.Can(permission) {
+			return nil, nil, ErrParkingLotForbidden
+		}
+	}
+	return admin, parkingLot, nil
+}
+
+// owningOrganization picks the organization a new lot of the admin belongs to. The admin needs a
+// membership allowed to write every lot of it. Admins without any membership get an organization
+// of their own.
+func (uc *ParkingLotUseCase) owningOrganization(admin *domain.Admin, organizationID uint) (uint, error) {
+	memberships, err := uc.OrganizationRepository.ListMembershipsByAdmin(admin.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	if organizationID == 0 {
+		switch len(memberships) {
+		case 0:
+			return uc.createPersonalOrganization(admin)
+		case 1:
+			organizationID = memberships[0].OrganizationID
+		default:
+			return 0, ErrOrganizationRequired
+		}
+	}
+
+	for _, membership := range memberships {
+		if membership.OrganizationID == organizationID {
+			if !membership.Can(domain.PermissionLotWrite) || len(membership.Lots) > 0 {
+				break
+			}
+			return organizationID, nil
+		}
+	}
+	return 0, ErrParkingLotForbidden
 }
 
-// ListParkingLots retrieves all parking lots with their available spaces.
-func (uc *ParkingLotUseCase) ListParkingLots() ([]ParkingLotResponse, error) {
-	parkingLots, err := uc.ParkingLotRepository.List()
+func (uc *ParkingLotUseCase) createPersonalOrganization(admin *domain.Admin) (uint, error) {
+	organization := domain.Organization{Name: domain.DefaultOrganizationName(*admin)}
+	owner := domain.OrganizationMembership{AdminID: admin.ID, Role: domain.MembershipRoleOwner}
+	if err := uc.OrganizationRepository.Create(&organization, &owner); err != nil {
+		return 0, err
+	}
+	return organization.ID, nil
+}
+
+// ListParkingLots retrieves the parking lots of active operators with their available spaces,
+// rating and whether their operator is verified. Lots without sensors report the availability estimated from recent crowd reports
+// instead, flagged with AvailabilitySourceCrowd.
+func (uc *ParkingLotUseCase) ListParkingLots(query ParkingLotQuery) ([]ParkingLotResponse, error) {
+	parkingLots, err := uc.ParkingLotRepository.ListPublic()
+	if err != nil {
+		return nil, err
+	}
+
+	sensorMap, err := freeSpacesByParkingLot(uc.SensorRepository, query.Spots)
+	if err != nil {
+		return nil, err
+	}
+
+	sensorTotals, err := uc.SensorRepository.CountGroupedByParkingLot()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	crowdMap, err := uc.CrowdReportRepository.ListGroupedByParkingLotSince(now.Add(-crowdReportMaxAge))
+	if err != nil {
+		return nil, err
+	}
+
+	ratingMap, err := uc.ReviewRepository.ListRatingSummaries()
 	if err != nil {
 		return nil, err
 	}
 
-	sensorMap, err := uc.SensorRepository.ListGroupedByParkingLot()
+	heldMap, err := uc.ReservationRepository.CountActiveGroupedByParkingLot(now)
 	if err != nil {
 		return nil, err
 	}
 
 	var response []ParkingLotResponse
 	for _, lot := range parkingLots {
-		availableSpaces := sensorMap[lot.ID]
+		rating := ratingMap[lot.ID]
+		item := ParkingLotResponse{
+			ID:                     lot.ID,
+			Name:                   lot.Name,
+			Address:                lot.Address,
+			Latitude:               lot.Latitude,
+			Longitude:              lot.Longitude,
+			HourlyRate:             lot.HourlyRate,
+			BillingFractionMinutes: lot.BillingFractionMinutes,
+			Rating:                 &rating,
+			AvailabilitySource:     AvailabilitySourceSensors,
+			VerifiedOperator:       lot.Admin.VerificationStatus == domain.VerificationVerified,
+		}
+
+		if sensorTotals[lot.ID] > 0 {
+			item.AvailableSpaces = excludeHeldSpaces(sensorMap[lot.ID], heldMap[lot.ID])
+		} else if estimate := estimateCrowdAvailability(crowdMap[lot.ID], lot.Capacity, now); estimate != nil {
+			item.AvailabilitySource = AvailabilitySourceCrowd
+			item.CrowdEstimate = estimate
+			if estimate.EstimatedSpaces != nil {
+				item.AvailableSpaces = *estimate.EstimatedSpaces
+			}
+		} else {
+			item.AvailabilitySource = AvailabilitySourceNone
+		}
 
-		response = append(response, ParkingLotResponse{
-			ID:              lot.ID,
-			Name:            lot.Name,
-			Address:         lot.Address,
-			Latitude:        lot.Latitude,
-			Longitude:       lot.Longitude,
-			AvailableSpaces: availableSpaces,
-		})
+		response = append(response, item)
+	}
+
+	if query.SortBy == ParkingLotSortRating {
+		sortByRating(response)
 	}
 
 	return response, nil
 }
 
+// availableSpaces counts the free spots of the lot that are not held by a reservation.
+func (uc *ParkingLotUseCase) availableSpaces(parkingLotID uint, sensors []domain.Sensor) (uint, error) {
+	held, err := uc.ReservationRepository.ListActiveByParkingLot(parkingLotID, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	return excludeHeldSpaces(countAvailableSpaces(sensors), uint(len(held))), nil
+}
+
+// sortByRating orders the lots by overall rating, then by number of reviews. Lots without
+// reviews go last.
+func sortByRating(parkingLots []ParkingLotResponse) {
+	sort.SliceStable(parkingLots, func(i, j int) bool {
+		a, b := parkingLots[i].Rating, parkingLots[j].Rating
+		if a.Overall != b.Overall {
+			return a.Overall > b.Overall
+		}
+		return a.Reviews > b.Reviews
+	})
+}
+
+// freeSpacesByParkingLot counts the free spots of every lot, restricted to the spot filter when given.
+func freeSpacesByParkingLot(sensorRepo repository.ISensorRepository, spots *domain.SpotFilter) (map[uint]uint, error) {
+	if spots == nil {
+		return sensorRepo.ListGroupedByParkingLot()
+	}
+	return sensorRepo.ListGroupedByParkingLotForSpots(*spots)
+}
+
+// excludeHeldSpaces removes the spots held by reservations from the free spaces of a lot.
+func excludeHeldSpaces(free, held uint) uint {
+	if held >= free {
+		return 0
+	}
+	return free - held
+}
+
 // Helper function to count available spaces.
 func countAvailableSpaces(sensors []domain.Sensor) uint {
 	var availableSpaces uint
 	for _, sensor := range sensors {
-		if sensor.Status == "free" {
+		if sensor.Status == domain.SensorStatusFree {
 			availableSpaces++
 		}
 	}
@@ -2,25 +2,41 @@ package usecase
 
 import (
 	"testing"
+	"time"
 
 	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
 	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
 // Helper to set up common dependencies for tests.
 func setupTest(t *testing.T) (*gomock.Controller, *mockgen.MockIParkingLotRepository, *mockgen.MockISensorRepository, *mockgen.MockIAdminRepository, IParkingLotUseCase) {
+	ctrl, mockRepo, sensorRepo, adminRepo, _, useCase := setupTestWithReservations(t)
+	return ctrl, mockRepo, sensorRepo, adminRepo, useCase
+}
+
+func setupTestWithReservations(t *testing.T) (*gomock.Controller, *mockgen.MockIParkingLotRepository, *mockgen.MockISensorRepository, *mockgen.MockIAdminRepository, *mockgen.MockIReservationRepository, IParkingLotUseCase) {
+	ctrl, mockRepo, sensorRepo, adminRepo, reservationRepo, _, useCase := setupTestWithOrganizations(t)
+	return ctrl, mockRepo, sensorRepo, adminRepo, reservationRepo, useCase
+}
+
+func setupTestWithOrganizations(t *testing.T) (*gomock.Controller, *mockgen.MockIParkingLotRepository, *mockgen.MockISensorRepository, *mockgen.MockIAdminRepository, *mockgen.MockIReservationRepository, *mockgen.MockIOrganizationRepository, IParkingLotUseCase) {
 	ctrl := gomock.NewController(t)
 	mockRepo := mockgen.NewMockIParkingLotRepository(ctrl)
 	sensorRepo := mockgen.NewMockISensorRepository(ctrl)
 	adminRepo := mockgen.NewMockIAdminRepository(ctrl)
-	useCase := NewParkingLotUseCase(mockRepo, sensorRepo, adminRepo)
-	return ctrl, mockRepo, sensorRepo, adminRepo, useCase
+	reservationRepo := mockgen.NewMockIReservationRepository(ctrl)
+	reviewRepo := mockgen.NewMockIReviewRepository(ctrl)
+	organizationRepo := mockgen.NewMockIOrganizationRepository(ctrl)
+	reviewRepo.EXPECT().GetRatingSummary(gomock.Any()).Return(domain.RatingSummary{}, nil).AnyTimes()
+	useCase := NewParkingLotUseCase(mockRepo, sensorRepo, adminRepo, reservationRepo, reviewRepo, mockgen.NewMockICrowdReportRepository(ctrl), organizationRepo)
+	return ctrl, mockRepo, sensorRepo, adminRepo, reservationRepo, organizationRepo, useCase
 }
 
 func TestCreateParkingLot(t *testing.T) {
-	ctrl, mockRepo, _, adminRepo, useCase := setupTest(t)
+	ctrl, mockRepo, _, adminRepo, _, organizationRepo, useCase := setupTestWithOrganizations(t)
 	defer ctrl.Finish()
 
 	req := CreateParkingLotRequest{
@@ -31,28 +47,96 @@ func TestCreateParkingLot(t *testing.T) {
 		AdminUUID: "admin123",
 	}
 
-	adminRepo.EXPECT().FindByAuth0UUID(req.AdminUUID).Return(&domain.Admin{ID: 123}, nil)
-	mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+	adminRepo.EXPECT().FindByAuth0UUID(req.AdminUUID).Return(&domain.Admin{ID: 123, Status: domain.AdminStatusActive, VerificationStatus: domain.VerificationVerified}, nil)
+	organizationRepo.EXPECT().ListMembershipsByAdmin(uint(123)).Return([]domain.OrganizationMembership{
+		{OrganizationID: 4, AdminID: 123, Role: domain.MembershipRoleManager},
+	}, nil)
+	mockRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(parkingLot *domain.ParkingLot) error {
+		assert.Equal(t, uint(4), parkingLot.OrganizationID)
+		return nil
+	})
 
 	_, err := useCase.CreateParkingLot(req)
 	assert.NoError(t, err)
 }
 
+func TestCreateParkingLotCreatesOrganizationForNewOperators(t *testing.T) {
+	ctrl, mockRepo, _, adminRepo, _, organizationRepo, useCase := setupTestWithOrganizations(t)
+	defer ctrl.Finish()
+
+	adminRepo.EXPECT().FindByAuth0UUID("admin123").Return(&domain.Admin{ID: 123, NIT: "900123456-7", Status: domain.AdminStatusActive, VerificationStatus: domain.VerificationVerified}, nil)
+	organizationRepo.EXPECT().ListMembershipsByAdmin(uint(123)).Return(nil, nil)
+	organizationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(organization *domain.Organization, owner *domain.OrganizationMembership) error {
+		assert.Equal(t, "NIT 900123456-7", organization.Name)
+		assert.Equal(t, domain.MembershipRoleOwner, owner.Role)
+		organization.ID = 9
+		return nil
+	})
+	mockRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(parkingLot *domain.ParkingLot) error {
+		assert.Equal(t, uint(9), parkingLot.OrganizationID)
+		return nil
+	})
+
+	_, err := useCase.CreateParkingLot(CreateParkingLotRequest{Name: "Centro", AdminUUID: "admin123"})
+	assert.NoError(t, err)
+}
+
+func TestCreateParkingLotRequiresVerifiedOperator(t *testing.T) {
+	ctrl, _, _, adminRepo, _, _, useCase := setupTestWithOrganizations(t)
+	defer ctrl.Finish()
+
+	adminRepo.EXPECT().FindByAuth0UUID("admin123").Return(&domain.Admin{ID: 123, Status: domain.AdminStatusActive, VerificationStatus: domain.VerificationUnderReview}, nil)
+
+	_, err := useCase.CreateParkingLot(CreateParkingLotRequest{Name: "Centro", AdminUUID: "admin123"})
+	assert.ErrorIs(t, err, ErrOperatorNotVerified)
+}
+
+func TestCreateParkingLotRequiresActiveOperator(t *testing.T) {
+	ctrl, _, _, adminRepo, _, _, useCase := setupTestWithOrganizations(t)
+	defer ctrl.Finish()
+
+	for _, status := range []string{domain.AdminStatusPending, domain.AdminStatusSuspended} {
+		adminRepo.EXPECT().FindByAuth0UUID("admin123").Return(&domain.Admin{ID: 123, Status: status, VerificationStatus: domain.VerificationVerified}, nil)
+
+		_, err := useCase.CreateParkingLot(CreateParkingLotRequest{Name: "Centro", AdminUUID: "admin123"})
+		assert.ErrorIs(t, err, ErrOperatorNotActive)
+	}
+}
+
+func TestCreateParkingLotRejectsRestrictedMemberships(t *testing.T) {
+	ctrl, _, _, adminRepo, _, organizationRepo, useCase := setupTestWithOrganizations(t)
+	defer ctrl.Finish()
+
+	adminRepo.EXPECT().FindByAuth0UUID("admin123").Return(&domain.Admin{ID: 123, Status: domain.AdminStatusActive, VerificationStatus: domain.VerificationVerified}, nil).Times(3)
+	organizationRepo.EXPECT().ListMembershipsByAdmin(uint(123)).Return([]domain.OrganizationMembership{
+		{OrganizationID: 4, Role: domain.MembershipRoleAttendant},
+		{OrganizationID: 5, Role: domain.MembershipRoleManager, Lots: []domain.OrganizationMembershipLot{{ParkingLotID: 1}}},
+	}, nil).Times(3)
+
+	_, err := useCase.CreateParkingLot(CreateParkingLotRequest{AdminUUID: "admin123"})
+	assert.ErrorIs(t, err, ErrOrganizationRequired)
+	_, err = useCase.CreateParkingLot(CreateParkingLotRequest{AdminUUID: "admin123", OrganizationID: 4})
+	assert.ErrorIs(t, err, ErrParkingLotForbidden)
+	_, err = useCase.CreateParkingLot(CreateParkingLotRequest{AdminUUID: "admin123", OrganizationID: 5})
+	assert.ErrorIs(t, err, ErrParkingLotForbidden)
+}
+
 func TestGetParkingLotWithOwnership(t *testing.T) {
-	ctrl, mockRepo, sensorRepo, adminRepo, useCase := setupTest(t)
+	ctrl, mockRepo, sensorRepo, adminRepo, reservationRepo, useCase := setupTestWithReservations(t)
 	defer ctrl.Finish()
 
 	adminID := "admin123"
 	parkingLotID := uint(1)
 
-	adminRepo.EXPECT().FindByAuth0UUID(adminID).Return(&domain.Admin{ID: 123}, nil)
-	mockRepo.EXPECT().GetByIDWithAdmin(parkingLotID, uint(123)).Return(&domain.ParkingLot{
+	adminRepo.EXPECT().FindByAuth0UUID(adminID).Return(&domain.Admin{ID: 123, Status: domain.AdminStatusActive, VerificationStatus: domain.VerificationVerified}, nil)
+	mockRepo.EXPECT().GetByIDForMember(parkingLotID, uint(123)).Return(&domain.ParkingLot{
 		ID: 1, Name: "Test Lot", Address: "123 Test St", Latitude: 40.7128, Longitude: -74.0060,
-	}, nil)
-	sensorRepo.EXPECT().ListByParkingLot(parkingLotID).Return([]domain.Sensor{
+	}, &domain.OrganizationMembership{Role: domain.MembershipRoleAttendant}, nil)
+	sensorRepo.EXPECT().ListByParkingLotForMember(parkingLotID, uint(123)).Return([]domain.Sensor{
 		{Status: "free"},
 		{Status: "busy"},
 	}, nil)
+	reservationRepo.EXPECT().ListActiveByParkingLot(parkingLotID, gomock.Any()).Return(nil, nil)
 
 	response, err := useCase.GetParkingLotWithOwnership(parkingLotID, adminID)
 	assert.NoError(t, err)
@@ -60,8 +144,31 @@ func TestGetParkingLotWithOwnership(t *testing.T) {
 	assert.Equal(t, uint(1), response.AvailableSpaces)
 }
 
+func TestAuthorizeParkingLotForbidsUnknownAdmin(t *testing.T) {
+	ctrl, _, _, adminRepo, useCase := setupTest(t)
+	defer ctrl.Finish()
+
+	adminRepo.EXPECT().FindByAuth0UUID("unknown").Return(nil, gorm.ErrRecordNotFound)
+
+	assert.ErrorIs(t, useCase.AuthorizeParkingLot(1, "unknown", nil), ErrParkingLotForbidden)
+}
+
+func TestAuthorizeParkingLotChecksMembershipRole(t *testing.T) {
+	ctrl, mockRepo, _, adminRepo, useCase := setupTest(t)
+	defer ctrl.Finish()
+
+	adminRepo.EXPECT().FindByAuth0UUID("admin123").Return(&domain.Admin{ID: 123, Status: domain.AdminStatusActive, VerificationStatus: domain.VerificationVerified}, nil).Times(3)
+	technician := &domain.OrganizationMembership{Role: domain.MembershipRoleTechnician}
+	mockRepo.EXPECT().GetByIDForMember(uint(1), uint(123)).Return(&domain.ParkingLot{ID: 1}, technician, nil).Times(2)
+	mockRepo.EXPECT().GetByIDForMember(uint(2), uint(123)).Return(nil, nil, nil)
+
+	assert.NoError(t, useCase.AuthorizeParkingLot(1, "admin123", []string{domain.PermissionLotRead, domain.PermissionSensorWrite}))
+	assert.ErrorIs(t, useCase.AuthorizeParkingLot(1, "admin123", []string{domain.PermissionPaymentRefund}), ErrParkingLotForbidden)
+	assert.ErrorIs(t, useCase.AuthorizeParkingLot(2, "admin123", nil), ErrParkingLotForbidden)
+}
+
 func TestGetParkingLot(t *testing.T) {
-	ctrl, mockRepo, sensorRepo, _, useCase := setupTest(t)
+	ctrl, mockRepo, sensorRepo, _, reservationRepo, useCase := setupTestWithReservations(t)
 	defer ctrl.Finish()
 
 	parkingLotID := uint(1)
@@ -73,9 +180,98 @@ func TestGetParkingLot(t *testing.T) {
 		{Status: "free"},
 		{Status: "free"},
 	}, nil)
+	reservationRepo.EXPECT().ListActiveByParkingLot(parkingLotID, gomock.Any()).Return(nil, nil)
 
 	response, err := useCase.GetParkingLot(parkingLotID)
 	assert.NoError(t, err)
 	assert.Equal(t, uint(1), response.ID)
 	assert.Equal(t, uint(2), response.AvailableSpaces)
 }
+
+func TestGetParkingLotExcludesHeldSpots(t *testing.T) {
+	ctrl, mockRepo, sensorRepo, _, reservationRepo, useCase := setupTestWithReservations(t)
+	defer ctrl.Finish()
+
+	parkingLotID := uint(1)
+
+	mockRepo.EXPECT().GetByID(parkingLotID).Return(&domain.ParkingLot{ID: 1}, nil)
+	sensorRepo.EXPECT().ListByParkingLot(parkingLotID).Return([]domain.Sensor{
+		{ID: 1, Status: domain.SensorStatusFree},
+		{ID: 2, Status: domain.SensorStatusFree},
+	}, nil)
+	reservationRepo.EXPECT().ListActiveByParkingLot(parkingLotID, gomock.Any()).Return([]domain.Reservation{
+		{ID: 1, ParkingLotID: 1, SensorID: 2, Status: domain.ReservationStatusHeld},
+	}, nil)
+
+	response, err := useCase.GetParkingLot(parkingLotID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), response.AvailableSpaces)
+}
+
+func TestListParkingLotsSortedByRating(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	parkingLotRepo := mockgen.NewMockIParkingLotRepository(ctrl)
+	sensorRepo := mockgen.NewMockISensorRepository(ctrl)
+	reservationRepo := mockgen.NewMockIReservationRepository(ctrl)
+	reviewRepo := mockgen.NewMockIReviewRepository(ctrl)
+	crowdReportRepo := mockgen.NewMockICrowdReportRepository(ctrl)
+	useCase := NewParkingLotUseCase(parkingLotRepo, sensorRepo, mockgen.NewMockIAdminRepository(ctrl), reservationRepo, reviewRepo, crowdReportRepo, mockgen.NewMockIOrganizationRepository(ctrl))
+
+	parkingLotRepo.EXPECT().ListPublic().Return([]domain.ParkingLot{
+		{ID: 1, Admin: domain.Admin{VerificationStatus: domain.VerificationVerified}},
+		{ID: 2},
+		{ID: 3},
+	}, nil)
+	sensorRepo.EXPECT().ListGroupedByParkingLot().Return(map[uint]uint{1: 4, 2: 1}, nil)
+	sensorRepo.EXPECT().CountGroupedByParkingLot().Return(map[uint]uint{1: 6, 2: 3, 3: 0}, nil)
+	crowdReportRepo.EXPECT().ListGroupedByParkingLotSince(gomock.Any()).Return(map[uint][]domain.CrowdReport{}, nil)
+	reservationRepo.EXPECT().CountActiveGroupedByParkingLot(gomock.Any()).Return(map[uint]uint{}, nil)
+	reviewRepo.EXPECT().ListRatingSummaries().Return(map[uint]domain.RatingSummary{
+		1: {Reviews: 2, Overall: 3.5},
+		3: {Reviews: 8, Overall: 4.2},
+	}, nil)
+
+	response, err := useCase.ListParkingLots(ParkingLotQuery{SortBy: ParkingLotSortRating})
+	assert.NoError(t, err)
+	assert.Len(t, response, 3)
+	assert.Equal(t, []uint{3, 1, 2}, []uint{response[0].ID, response[1].ID, response[2].ID})
+	assert.Equal(t, 4.2, response[0].Rating.Overall)
+	assert.Equal(t, uint(0), response[2].Rating.Reviews)
+	assert.Equal(t, AvailabilitySourceNone, response[0].AvailabilitySource)
+	assert.Equal(t, AvailabilitySourceSensors, response[1].AvailabilitySource)
+	assert.True(t, response[1].VerifiedOperator)
+	assert.False(t, response[0].VerifiedOperator)
+}
+
+func TestListParkingLotsUsesCrowdEstimateWithoutSensors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	parkingLotRepo := mockgen.NewMockIParkingLotRepository(ctrl)
+	sensorRepo := mockgen.NewMockISensorRepository(ctrl)
+	reservationRepo := mockgen.NewMockIReservationRepository(ctrl)
+	reviewRepo := mockgen.NewMockIReviewRepository(ctrl)
+	crowdReportRepo := mockgen.NewMockICrowdReportRepository(ctrl)
+	useCase := NewParkingLotUseCase(parkingLotRepo, sensorRepo, mockgen.NewMockIAdminRepository(ctrl), reservationRepo, reviewRepo, crowdReportRepo, mockgen.NewMockIOrganizationRepository(ctrl))
+
+	parkingLotRepo.EXPECT().ListPublic().Return([]domain.ParkingLot{{ID: 1}, {ID: 2, Capacity: 40}}, nil)
+	sensorRepo.EXPECT().ListGroupedByParkingLot().Return(map[uint]uint{1: 2}, nil)
+	sensorRepo.EXPECT().CountGroupedByParkingLot().Return(map[uint]uint{1: 5}, nil)
+	crowdReportRepo.EXPECT().ListGroupedByParkingLotSince(gomock.Any()).Return(map[uint][]domain.CrowdReport{
+		2: {{ParkingLotID: 2, Level: domain.CrowdLevelPlenty, Weight: 0.5, ReportedAt: time.Now()}},
+	}, nil)
+	reservationRepo.EXPECT().CountActiveGroupedByParkingLot(gomock.Any()).Return(map[uint]uint{}, nil)
+	reviewRepo.EXPECT().ListRatingSummaries().Return(map[uint]domain.RatingSummary{}, nil)
+
+	response, err := useCase.ListParkingLots(ParkingLotQuery{})
+	assert.NoError(t, err)
+	assert.Len(t, response, 2)
+	assert.Equal(t, AvailabilitySourceSensors, response[0].AvailabilitySource)
+	assert.Equal(t, uint(2), response[0].AvailableSpaces)
+	assert.Nil(t, response[0].CrowdEstimate)
+	assert.Equal(t, AvailabilitySourceCrowd, response[1].AvailabilitySource)
+	assert.Equal(t, domain.CrowdLevelPlenty, response[1].CrowdEstimate.Level)
+	assert.Equal(t, uint(28), response[1].AvailableSpaces)
+}
@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	SessionEventStarted = "parking-session-started"
+	SessionEventUpdated = "parking-session-updated"
+	SessionEventEnded   = "parking-session-ended"
+)
+
+var (
+	ErrSessionAlreadyActive = errors.New("driver already has an active parking session")
+	ErrSessionNotActive     = errors.New("parking session is not active")
+	ErrSensorNotInLot       = errors.New("sensor does not belong to the parking lot")
+)
+
+type IParkingSessionUseCase interface {
+	StartSession(userID uint, req StartSessionRequest, source string) (*domain.ParkingSession, error)
+	StopSession(userID uint, sessionID uint) (*domain.ParkingSession, error)
+	ListByUser(userID uint, status string) ([]domain.ParkingSession, error)
+	ListByParkingLot(parkingLotID uint, status string) ([]domain.ParkingSession, error)
+	OnSensorChange(change SensorChange)
+}
+
+// SessionPublisher delivers session events to the driver that owns the session only.
+type SessionPublisher interface {
+	SendToUser(userID uint, message interface{})
+}
+
+type ParkingSessionUseCase struct {
+	ParkingSessionRepository repository.IParkingSessionRepository
+	ParkingLotRepository     repository.IParkingLotRepository
+	SensorRepository         repository.ISensorRepository
+	VehicleRepository        repository.IVehicleRepository
+	Publisher                SessionPublisher
+	now                      func() time.Time
+}
+
+type StartSessionRequest struct {
+	ParkingLotID uint `json:"parking_lot_id" binding:"required"`
+	SensorID     uint `json:"sensor_id"`
+}
+
+type SessionEvent struct {
+	Type    string                `json:"type"`
+	Payload domain.ParkingSession `json:"payload"`
+}
+
+// NewParkingSessionUseCase creates a new instance of ParkingSessionUseCase.
+func NewParkingSessionUseCase(sessionRepo repository.IParkingSessionRepository, parkingLotRepo repository.IParkingLotRepository, sensorRepo repository.ISensorRepository, vehicleRepo repository.IVehicleRepository, publisher SessionPublisher) IParkingSessionUseCase {
+	return &ParkingSessionUseCase{
+		ParkingSessionRepository: sessionRepo,
+		ParkingLotRepository:     parkingLotRepo,
+		SensorRepository:         sensorRepo,
+		VehicleRepository:        vehicleRepo,
+		Publisher:                publisher,
+		now:                      time.Now,
+	}
+}
+
+// StartSession starts a session for the driver from the app or a QR check-in. When the spot's
+// sensor already started an anonymous session, the driver claims it instead.
+func (uc *ParkingSessionUseCase) StartSession(userID uint, req StartSessionRequest, source string) (*domain.ParkingSession, error) {
+	active, err := uc.ParkingSessionRepository.FindActiveByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return nil, ErrSessionAlreadyActive
+	}
+
+	parkingLot, err := uc.ParkingLotRepository.GetByID(req.ParkingLotID)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicle, err := uc.VehicleRepository.FindDefault(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var sensorID *uint
+	if req.SensorID != 0 {
+		sensor, err := uc.SensorRepository.GetByID(req.SensorID)
+		if err != nil {
+			return nil, err
+		}
+		if sensor.ParkingLotID != parkingLot.ID {
+			return nil, ErrSensorNotInLot
+		}
+
+		running, err := uc.ParkingSessionRepository.FindActiveBySensor(sensor.ID)
+		if err != nil {
+			return nil, err
+		}
+		if running != nil {
+			if running.UserID != nil {
+				return nil, domain.ErrSpotInSession
+			}
+			running.UserID = &userID
+			chargeVehicle(running, parkingLot, vehicle)
+			claimed, err := uc.ParkingSessionRepository.Claim(running)
+			if err != nil {
+				return nil, err
+			}
+			if !claimed {
+				// Another driver claimed it, or the spot freed up, since it was read.
+				return nil, domain.ErrSpotInSession
+			}
+			uc.publish(SessionEventUpdated, running)
+			return running, nil
+		}
+		sensorID = &sensor.ID
+	}
+
+	session := newParkingSession(parkingLot, sensorID, source, uc.now())
+	session.UserID = &userID
+	chargeVehicle(session, parkingLot, vehicle)
+	if err := uc.ParkingSessionRepository.Create(session); err != nil {
+		return nil, err
+	}
+	uc.publish(SessionEventStarted, session)
+	return session, nil
+}
+
+// StopSession ends a session of the driver and computes its cost.
+func (uc *ParkingSessionUseCase) StopSession(userID uint, sessionID uint) (*domain.ParkingSession, error) {
+	session, err := uc.ParkingSessionRepository.GetByID(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.UserID == nil || *session.UserID != userID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if session.Status != domain.ParkingSessionActive {
+		return nil, ErrSessionNotActive
+	}
+
+	if err := uc.complete(session, uc.now()); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// ListByUser retrieves the sessions of the driver, newest first.
+func (uc *ParkingSessionUseCase) ListByUser(userID uint, status string) ([]domain.ParkingSession, error) {
+	return uc.ParkingSessionRepository.ListByUser(userID, status)
+}
+
+// ListByParkingLot retrieves the sessions of a parking lot, newest first.
+func (uc *ParkingSessionUseCase) ListByParkingLot(parkingLotID uint, status string) ([]domain.ParkingSession, error) {
+	return uc.ParkingSessionRepository.ListByParkingLot(parkingLotID, status)
+}
+
+// OnSensorChange starts an anonymous session when a spot becomes occupied and stops the session
+// of the spot when it frees up.
+func (uc *ParkingSessionUseCase) OnSensorChange(change SensorChange) {
+	if change.Sensor.Status == change.PreviousStatus {
+		return
+	}
+
+	switch change.Sensor.Status {
+	case domain.SensorStatusOccupied:
+		running, err := uc.ParkingSessionRepository.FindActiveBySensor(change.Sensor.ID)
+		if err != nil {
+			log.Println("Error loading parking session of sensor:", err)
+			return
+		}
+		if running != nil {
+			return
+		}
+
+		parkingLot, err := uc.ParkingLotRepository.GetByID(change.Sensor.ParkingLotID)
+		if err != nil {
+			log.Println("Error loading parking lot for parking session:", err)
+			return
+		}
+		sensorID := change.Sensor.ID
+		session := newParkingSession(parkingLot, &sensorID, domain.ParkingSessionSourceSensor, change.OccurredAt)
+		if err := uc.ParkingSessionRepository.Create(session); err != nil {
+			log.Println("Error starting parking session:", err)
+		}
+
+	case domain.SensorStatusFree:
+		running, err := uc.ParkingSessionRepository.FindActiveBySensor(change.Sensor.ID)
+		if err != nil {
+			log.Println("Error loading parking session of sensor:", err)
+			return
+		}
+		if running == nil {
+			return
+		}
+		if err := uc.complete(running, change.OccurredAt); err != nil && !errors.Is(err, ErrSessionNotActive) {
+			log.Println("Error stopping parking session:", err)
+		}
+	}
+}
+
+// complete ends an active session. It returns ErrSessionNotActive when the session was ended
+// since it was read, by its driver or by its sensor.
+func (uc *ParkingSessionUseCase) complete(session *domain.ParkingSession, endedAt time.Time) error {
+	duration := endedAt.Sub(session.StartedAt)
+	if duration < 0 {
+		duration = 0
+	}
+
+	session.Status = domain.ParkingSessionCompleted
+	session.EndedAt = &endedAt
+	session.DurationSeconds = int64(duration / time.Second)
+	session.Cost = sessionCost(session.HourlyRate, session.BillingFractionMinutes, duration)
+	completed, err := uc.ParkingSessionRepository.Complete(session)
+	if err != nil {
+		return err
+	}
+	if !completed {
+		return ErrSessionNotActive
+	}
+
+	uc.publish(SessionEventEnded, session)
+	return nil
+}
+
+// publish sends the session to its driver; anonymous sessions are not published.
+func (uc *ParkingSessionUseCase) publish(eventType string, session *domain.ParkingSession) {
+	if session.UserID == nil || uc.Publisher == nil {
+		return
+	}
+	uc.Publisher.SendToUser(*session.UserID, SessionEvent{Type: eventType, Payload: *session})
+}
+
+func newParkingSession(parkingLot *domain.ParkingLot, sensorID *uint, source string, startedAt time.Time) *domain.ParkingSession {
+	return &domain.ParkingSession{
+		ParkingLotID:           parkingLot.ID,
+		SensorID:               sensorID,
+		Source:                 source,
+		Status:                 domain.ParkingSessionActive,
+		HourlyRate:             parkingLot.HourlyRate,
+		BillingFractionMinutes: parkingLot.BillingFractionMinutes,
+		StartedAt:              startedAt,
+	}
+}
+
+// chargeVehicle applies the lot's tariff for the driver's vehicle; sessions of drivers without a
+// registered vehicle keep the car tariff.
+func chargeVehicle(session *domain.ParkingSession, parkingLot *domain.ParkingLot, vehicle *domain.Vehicle) {
+	if vehicle == nil {
+		return
+	}
+	session.VehicleID = &vehicle.ID
+	session.HourlyRate = parkingLot.HourlyRateFor(vehicle.Type)
+}
+
+// sessionCost charges every started billing fraction at the hourly rate, rounded to the peso.
+func sessionCost(hourlyRate, fractionMinutes uint, duration time.Duration) uint {
+	if fractionMinutes == 0 {
+		fractionMinutes = 1
+	}
+	fraction := time.Duration(fractionMinutes) * time.Minute
+	fractions := (duration + fraction - 1) / fraction
+	minutes := float64(fractions) * float64(fractionMinutes)
+	return uint(minutes*float64(hourlyRate)/60 + 0.5)
+}
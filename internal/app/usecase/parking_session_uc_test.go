@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingPublisher struct {
+	userIDs []uint
+	events  []SessionEvent
+}
+
+func (p *recordingPublisher) SendToUser(userID uint, message interface{}) {
+	p.userIDs = append(p.userIDs, userID)
+	p.events = append(p.events, message.(SessionEvent))
+}
+
+func setupSessionTest(t *testing.T, now time.Time) (*gomock.Controller, *mockgen.MockIParkingSessionRepository, *mockgen.MockIParkingLotRepository, *mockgen.MockISensorRepository, *recordingPublisher, *ParkingSessionUseCase) {
+	ctrl := gomock.NewController(t)
+	sessionRepo := mockgen.NewMockIParkingSessionRepository(ctrl)
+	parkingLotRepo := mockgen.NewMockIParkingLotRepository(ctrl)
+	sensorRepo := mockgen.NewMockISensorRepository(ctrl)
+	vehicleRepo := mockgen.NewMockIVehicleRepository(ctrl)
+	vehicleRepo.EXPECT().FindDefault(gomock.Any()).Return(nil, nil).AnyTimes()
+	publisher := &recordingPublisher{}
+	useCase := NewParkingSessionUseCase(sessionRepo, parkingLotRepo, sensorRepo, vehicleRepo, publisher).(*ParkingSessionUseCase)
+	useCase.now = func() time.Time { return now }
+	return ctrl, sessionRepo, parkingLotRepo, sensorRepo, publisher, useCase
+}
+
+func TestSessionCostChargesStartedFractions(t *testing.T) {
+	assert.Equal(t, uint(1500), sessionCost(6000, 15, 1*time.Minute))
+	assert.Equal(t, uint(3000), sessionCost(6000, 15, 16*time.Minute))
+	assert.Equal(t, uint(6000), sessionCost(6000, 15, time.Hour))
+	assert.Equal(t, uint(100), sessionCost(6000, 0, 30*time.Second))
+	assert.Equal(t, uint(0), sessionCost(6000, 15, 0))
+}
+
+func TestCheckInClaimsSensorSessionAndPublishesToDriver(t *testing.T) {
+	now := time.Date(2024, time.September, 10, 8, 0, 0, 0, time.UTC)
+	ctrl, sessionRepo, parkingLotRepo, sensorRepo, publisher, useCase := setupSessionTest(t, now)
+	defer ctrl.Finish()
+
+	sensorID := uint(10)
+	sessionRepo.EXPECT().FindActiveByUser(uint(7)).Return(nil, nil)
+	parkingLotRepo.EXPECT().GetByID(uint(1)).Return(&domain.ParkingLot{ID: 1, HourlyRate: 6000, BillingFractionMinutes: 15}, nil)
+	sensorRepo.EXPECT().GetByID(sensorID).Return(&domain.Sensor{ID: sensorID, ParkingLotID: 1}, nil)
+	sessionRepo.EXPECT().FindActiveBySensor(sensorID).Return(&domain.ParkingSession{
+		ID: 4, ParkingLotID: 1, SensorID: &sensorID, Source: domain.ParkingSessionSourceSensor,
+		Status: domain.ParkingSessionActive, StartedAt: now.Add(-2 * time.Minute),
+	}, nil)
+	sessionRepo.EXPECT().Claim(gomock.Any()).Return(true, nil)
+
+	session, err := useCase.StartSession(7, StartSessionRequest{ParkingLotID: 1, SensorID: sensorID}, domain.ParkingSessionSourceQR)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4), session.ID)
+	assert.Equal(t, uint(7), *session.UserID)
+	assert.Equal(t, []uint{7}, publisher.userIDs)
+	assert.Equal(t, SessionEventUpdated, publisher.events[0].Type)
+}
+
+func TestSensorFreeStopsSessionWithCost(t *testing.T) {
+	now := time.Date(2024, time.September, 10, 8, 0, 0, 0, time.UTC)
+	ctrl, sessionRepo, _, _, publisher, useCase := setupSessionTest(t, now)
+	defer ctrl.Finish()
+
+	userID := uint(7)
+	sensorID := uint(10)
+	sessionRepo.EXPECT().FindActiveBySensor(sensorID).Return(&domain.ParkingSession{
+		ID: 4, UserID: &userID, ParkingLotID: 1, SensorID: &sensorID, Status: domain.ParkingSessionActive,
+		HourlyRate: 6000, BillingFractionMinutes: 15, StartedAt: now.Add(-40 * time.Minute),
+	}, nil)
+	sessionRepo.EXPECT().Complete(gomock.Any()).Return(true, nil)
+
+	useCase.OnSensorChange(SensorChange{
+		Sensor:         domain.Sensor{ID: sensorID, ParkingLotID: 1, Status: domain.SensorStatusFree},
+		PreviousStatus: domain.SensorStatusOccupied,
+		OccurredAt:     now,
+	})
+
+	assert.Len(t, publisher.events, 1)
+	ended := publisher.events[0].Payload
+	assert.Equal(t, SessionEventEnded, publisher.events[0].Type)
+	assert.Equal(t, domain.ParkingSessionCompleted, ended.Status)
+	assert.Equal(t, int64(2400), ended.DurationSeconds)
+	assert.Equal(t, uint(4500), ended.Cost)
+}
+
+func TestCheckInFailsWhenAnotherDriverClaimedTheSessionFirst(t *testing.T) {
+	now := time.Date(2024, time.September, 10, 8, 0, 0, 0, time.UTC)
+	ctrl, sessionRepo, parkingLotRepo, sensorRepo, publisher, useCase := setupSessionTest(t, now)
+	defer ctrl.Finish()
+
+	sensorID := uint(10)
+	sessionRepo.EXPECT().FindActiveByUser(uint(7)).Return(nil, nil)
+	parkingLotRepo.EXPECT().GetByID(uint(1)).Return(&domain.ParkingLot{ID: 1, HourlyRate: 6000}, nil)
+	sensorRepo.EXPECT().GetByID(sensorID).Return(&domain.Sensor{ID: sensorID, ParkingLotID: 1}, nil)
+	sessionRepo.EXPECT().FindActiveBySensor(sensorID).Return(&domain.ParkingSession{
+		ID: 4, ParkingLotID: 1, SensorID: &sensorID, Status: domain.ParkingSessionActive, StartedAt: now.Add(-time.Minute),
+	}, nil)
+	sessionRepo.EXPECT().Claim(gomock.Any()).Return(false, nil)
+
+	_, err := useCase.StartSession(7, StartSessionRequest{ParkingLotID: 1, SensorID: sensorID}, domain.ParkingSessionSourceQR)
+	assert.ErrorIs(t, err, domain.ErrSpotInSession)
+	assert.Empty(t, publisher.events)
+}
+
+func TestStopSessionFailsWhenTheSensorEndedItFirst(t *testing.T) {
+	now := time.Date(2024, time.September, 10, 8, 0, 0, 0, time.UTC)
+	ctrl, sessionRepo, _, _, publisher, useCase := setupSessionTest(t, now)
+	defer ctrl.Finish()
+
+	userID := uint(7)
+	sessionRepo.EXPECT().GetByID(uint(4)).Return(&domain.ParkingSession{
+		ID: 4, UserID: &userID, ParkingLotID: 1, Status: domain.ParkingSessionActive, HourlyRate: 6000, StartedAt: now.Add(-time.Hour),
+	}, nil)
+	sessionRepo.EXPECT().Complete(gomock.Any()).Return(false, nil)
+
+	_, err := useCase.StopSession(7, 4)
+	assert.ErrorIs(t, err, ErrSessionNotActive)
+	assert.Empty(t, publisher.events)
+}
+
+func TestStartSessionChargesDefaultVehicleTariff(t *testing.T) {
+	now := time.Date(2024, time.September, 10, 8, 0, 0, 0, time.UTC)
+	ctrl, sessionRepo, parkingLotRepo, _, _, useCase := setupSessionTest(t, now)
+	defer ctrl.Finish()
+
+	vehicleRepo := mockgen.NewMockIVehicleRepository(ctrl)
+	useCase.VehicleRepository = vehicleRepo
+	vehicleRepo.EXPECT().FindDefault(uint(7)).Return(&domain.Vehicle{ID: 3, UserID: 7, Type: domain.VehicleTypeMotorcycle}, nil)
+	sessionRepo.EXPECT().FindActiveByUser(uint(7)).Return(nil, nil)
+	parkingLotRepo.EXPECT().GetByID(uint(1)).Return(&domain.ParkingLot{ID: 1, HourlyRate: 6000, MotorcycleHourlyRate: 2400, BillingFractionMinutes: 15}, nil)
+	sessionRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	session, err := useCase.StartSession(7, StartSessionRequest{ParkingLotID: 1}, domain.ParkingSessionSourceApp)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2400), session.HourlyRate)
+	assert.Equal(t, uint(3), *session.VehicleID)
+}
@@ -0,0 +1,463 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/payment"
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+	"gorm.io/gorm"
+)
+
+const paymentCurrency = "COP"
+
+var (
+	ErrInvalidPaymentTarget     = errors.New("pay either a parking_session_id or a reservation_id")
+	ErrNothingToPay             = errors.New("there is nothing to pay yet")
+	ErrUnknownPaymentProvider   = errors.New("unknown payment provider")
+	ErrInvalidPaymentTransition = errors.New("invalid payment state change")
+	ErrInvalidRefundAmount      = errors.New("refund amount exceeds the refundable amount")
+)
+
+// paymentTransitions lists the states each payment state can move to.
+var paymentTransitions = map[string][]string{
+	"":                                    {domain.PaymentStatusPending},
+	domain.PaymentStatusPending:           {domain.PaymentStatusAuthorized, domain.PaymentStatusFailed},
+	domain.PaymentStatusAuthorized:        {domain.PaymentStatusCaptured, domain.PaymentStatusFailed},
+	domain.PaymentStatusCaptured:          {domain.PaymentStatusPartiallyRefunded, domain.PaymentStatusRefunded},
+	domain.PaymentStatusPartiallyRefunded: {domain.PaymentStatusPartiallyRefunded, domain.PaymentStatusRefunded},
+}
+
+type IPaymentUseCase interface {
+	CreatePayment(userID uint, req CreatePaymentRequest, idempotencyKey string) (*domain.Payment, error)
+	ListByUser(userID uint) ([]domain.Payment, error)
+	HandleWebhook(providerName string, payload []byte, signature string) error
+	Refund(parkingLotID uint, paymentID uint, amount uint, actor string, idempotencyKey string) (*domain.Payment, error)
+	ListEvents(parkingLotID uint, paymentID uint) ([]domain.PaymentEvent, error)
+	GetRevenue(parkingLotID uint, period ReportPeriod) (*RevenueResponse, error)
+}
+
+type PaymentUseCase struct {
+	PaymentRepository        repository.IPaymentRepository
+	ParkingSessionRepository repository.IParkingSessionRepository
+	ReservationRepository    repository.IReservationRepository
+	ParkingLotRepository     repository.IParkingLotRepository
+	Providers                map[string]payment.PaymentProvider
+	defaultProvider          string
+	now                      func() time.Time
+}
+
+type CreatePaymentRequest struct {
+	ParkingSessionID uint `json:"parking_session_id"`
+	ReservationID    uint `json:"reservation_id"`
+}
+
+type RevenueResponse struct {
+	ParkingLotID uint      `json:"parking_lot_id"`
+	From         time.Time `json:"from"`
+	To           time.Time `json:"to"`
+	Currency     string    `json:"currency"`
+	Payments     uint      `json:"payments"`
+	Gross        uint      `json:"gross"`
+	Refunded     uint      `json:"refunded"`
+	Net          uint      `json:"net"`
+}
+
+// NewPaymentUseCase creates a new instance of PaymentUseCase. New payments go to the first
+// provider; webhooks are accepted from all of them.
+func NewPaymentUseCase(paymentRepo repository.IPaymentRepository, sessionRepo repository.IParkingSessionRepository, reservationRepo repository.IReservationRepository, parkingLotRepo repository.IParkingLotRepository, providers ...payment.PaymentProvider) IPaymentUseCase {
+	uc := &PaymentUseCase{
+		PaymentRepository:        paymentRepo,
+		ParkingSessionRepository: sessionRepo,
+		ReservationRepository:    reservationRepo,
+		ParkingLotRepository:     parkingLotRepo,
+		Providers:                make(map[string]payment.PaymentProvider),
+		now:                      time.Now,
+	}
+	for i, provider := range providers {
+		if i == 0 {
+			uc.defaultProvider = provider.Name()
+		}
+		uc.Providers[provider.Name()] = provider
+	}
+	return uc
+}
+
+// CreatePayment opens a payment intent for a completed parking session or a reservation of the
+// driver. Retrying with the same idempotency key returns the payment created the first time.
+func (uc *PaymentUseCase) CreatePayment(userID uint, req CreatePaymentRequest, idempotencyKey string) (*domain.Payment, error) {
+	if idempotencyKey != "" {
+		existing, err := uc.PaymentRepository.FindByIdempotencyKey(userID, idempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return existing, nil
+		}
+	}
+
+	newPayment, description, err := uc.paymentFor(userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, ok := uc.Providers[uc.defaultProvider]
+	if !ok {
+		return nil, ErrUnknownPaymentProvider
+	}
+	intentKey := ""
+	if idempotencyKey != "" {
+		intentKey = fmt.Sprintf("intent:%d:%s", userID, idempotencyKey)
+	}
+	intent, err := provider.CreateIntent(payment.IntentRequest{
+		Reference:      fmt.Sprintf("user-%d-%d", userID, uc.now().UnixNano()),
+		IdempotencyKey: intentKey,
+		Amount:         newPayment.Amount,
+		Currency:       paymentCurrency,
+		Description:    description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	newPayment.UserID = userID
+	newPayment.Currency = paymentCurrency
+	newPayment.Provider = provider.Name()
+	newPayment.ProviderReference = intent.ProviderReference
+	newPayment.ClientSecret = intent.ClientSecret
+	newPayment.CheckoutURL = intent.CheckoutURL
+	newPayment.Status = domain.PaymentStatusPending
+	if idempotencyKey != "" {
+		newPayment.IdempotencyKey = &idempotencyKey
+	}
+
+	event := &domain.PaymentEvent{
+		ToStatus: domain.PaymentStatusPending,
+		Amount:   newPayment.Amount,
+		Source:   domain.PaymentEventSourceAPI,
+		Actor:    userActor(userID),
+	}
+	if err := uc.PaymentRepository.Create(newPayment, event); err != nil {
+		// A concurrent retry with the same key created the payment between the lookup and now.
+		if errors.Is(err, domain.ErrDuplicatePayment) {
+			existing, findErr := uc.PaymentRepository.FindByIdempotencyKey(userID, idempotencyKey)
+			if findErr != nil {
+				return nil, findErr
+			}
+			if existing != nil {
+				return existing, nil
+			}
+		}
+		return nil, err
+	}
+	return newPayment, nil
+}
+
+// paymentFor prices the session or reservation to pay, checking that it belongs to the driver
+// and has not been paid already.
+func (uc *PaymentUseCase) paymentFor(userID uint, req CreatePaymentRequest) (*domain.Payment, string, error) {
+	if (req.ParkingSessionID == 0) == (req.ReservationID == 0) {
+		return nil, "", ErrInvalidPaymentTarget
+	}
+
+	if req.ParkingSessionID != 0 {
+		session, err := uc.ParkingSessionRepository.GetByID(req.ParkingSessionID)
+		if err != nil {
+			return nil, "", err
+		}
+		if session.UserID == nil || *session.UserID != userID {
+			return nil, "", gorm.ErrRecordNotFound
+		}
+		if session.Status != domain.ParkingSessionCompleted || session.Cost == 0 {
+			return nil, "", ErrNothingToPay
+		}
+		open, err := uc.PaymentRepository.FindOpenByParkingSession(session.ID)
+		if err != nil {
+			return nil, "", err
+		}
+		if open != nil {
+			return nil, "", domain.ErrAlreadyPaid
+		}
+		return &domain.Payment{
+			ParkingLotID:     session.ParkingLotID,
+			ParkingSessionID: &session.ID,
+			Amount:           session.Cost,
+		}, fmt.Sprintf("Parking session %d", session.ID), nil
+	}
+
+	reservation, err := uc.ReservationRepository.GetByID(req.ReservationID)
+	if err != nil {
+		return nil, "", err
+	}
+	if reservation.UserID != userID {
+		return nil, "", gorm.ErrRecordNotFound
+	}
+	if reservation.Status != domain.ReservationStatusHeld && reservation.Status != domain.ReservationStatusConfirmed {
+		return nil, "", ErrNothingToPay
+	}
+	open, err := uc.PaymentRepository.FindOpenByReservation(reservation.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	if open != nil {
+		return nil, "", domain.ErrAlreadyPaid
+	}
+
+	parkingLot, err := uc.ParkingLotRepository.GetByID(reservation.ParkingLotID)
+	if err != nil {
+		return nil, "", err
+	}
+	amount := sessionCost(parkingLot.HourlyRate, parkingLot.BillingFractionMinutes, reservation.HeldUntil.Sub(reservation.CreatedAt))
+	if amount == 0 {
+		return nil, "", ErrNothingToPay
+	}
+	return &domain.Payment{
+		ParkingLotID:  reservation.ParkingLotID,
+		ReservationID: &reservation.ID,
+		Amount:        amount,
+	}, fmt.Sprintf("Reservation %d", reservation.ID), nil
+}
+
+// ListByUser retrieves the payments of the driver, newest first.
+func (uc *PaymentUseCase) ListByUser(userID uint) ([]domain.Payment, error) {
+	return uc.PaymentRepository.ListByUser(userID)
+}
+
+// HandleWebhook applies a status change notified by a provider. Each provider event is applied
+// once; an authorized payment is captured right away.
+func (uc *PaymentUseCase) HandleWebhook(providerName string, payload []byte, signature string) error {
+	provider, ok := uc.Providers[providerName]
+	if !ok {
+		return ErrUnknownPaymentProvider
+	}
+
+	event, err := provider.ParseWebhook(payload, signature)
+	if err != nil {
+		return err
+	}
+
+	current, err := uc.PaymentRepository.FindByProviderReference(providerName, event.ProviderReference)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return payment.ErrUnknownPayment
+	}
+
+	dedupKey := fmt.Sprintf("webhook:%s:%s", providerName, event.ID)
+	applied, err := uc.PaymentRepository.HasEvent(dedupKey)
+	if err != nil {
+		return err
+	}
+
+	if !applied {
+		actor := "provider:" + providerName
+		switch event.Status {
+		case payment.StatusAuthorized:
+			if current.Status == domain.PaymentStatusPending {
+				if err := uc.transition(current, domain.PaymentStatusAuthorized, 0, domain.PaymentEventSourceWebhook, actor, dedupKey); err != nil {
+					return err
+				}
+			}
+		case payment.StatusFailed:
+			if current.Status == domain.PaymentStatusPending || current.Status == domain.PaymentStatusAuthorized {
+				return uc.transition(current, domain.PaymentStatusFailed, 0, domain.PaymentEventSourceWebhook, actor, dedupKey)
+			}
+		default:
+			log.Printf("Ignoring %s webhook with status %s for payment %d", providerName, event.Status, current.ID)
+		}
+	}
+
+	if current.Status == domain.PaymentStatusAuthorized {
+		return uc.capture(provider, current)
+	}
+	return nil
+}
+
+// capture collects an authorized payment in full. The capture is claimed first so that a webhook
+// delivered twice at the same time captures once.
+func (uc *PaymentUseCase) capture(provider payment.PaymentProvider, current *domain.Payment) error {
+	dedupKey := fmt.Sprintf("capture:%d", current.ID)
+	event, claimed, err := uc.claim(current, domain.PaymentStatusCaptured, current.Amount, domain.PaymentEventSourceAPI, "system", dedupKey)
+	if err != nil || !claimed {
+		return err
+	}
+	if err := provider.Capture(current.ProviderReference, current.Amount, dedupKey); err != nil {
+		return uc.release(event, err)
+	}
+
+	capturedAt := uc.now()
+	current.CapturedAmount = current.Amount
+	current.CapturedAt = &capturedAt
+	current.Status = domain.PaymentStatusCaptured
+	return uc.PaymentRepository.ApplyEvent(current, event)
+}
+
+// Refund returns part of a captured payment of the lot, or all that is left when amount is 0.
+// Retrying with the same idempotency key does not refund twice: the key is claimed before
+// calling the provider, which receives it too.
+func (uc *PaymentUseCase) Refund(parkingLotID uint, paymentID uint, amount uint, actor string, idempotencyKey string) (*domain.Payment, error) {
+	current, err := uc.lotPayment(parkingLotID, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	dedupKey := ""
+	if idempotencyKey != "" {
+		dedupKey = fmt.Sprintf("refund:%d:%s", current.ID, idempotencyKey)
+		applied, err := uc.PaymentRepository.HasEvent(dedupKey)
+		if err != nil {
+			return nil, err
+		}
+		if applied {
+			return current, nil
+		}
+	}
+
+	if current.Status != domain.PaymentStatusCaptured && current.Status != domain.PaymentStatusPartiallyRefunded {
+		return nil, ErrInvalidPaymentTransition
+	}
+	refundable := current.CapturedAmount - current.RefundedAmount
+	if amount == 0 {
+		amount = refundable
+	}
+	if amount > refundable {
+		return nil, ErrInvalidRefundAmount
+	}
+
+	provider, ok := uc.Providers[current.Provider]
+	if !ok {
+		return nil, ErrUnknownPaymentProvider
+	}
+
+	status := domain.PaymentStatusPartiallyRefunded
+	if current.RefundedAmount+amount == current.CapturedAmount {
+		status = domain.PaymentStatusRefunded
+	}
+	event, claimed, err := uc.claim(current, status, amount, domain.PaymentEventSourceAPI, actor, dedupKey)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return uc.PaymentRepository.GetByID(current.ID)
+	}
+
+	providerKey := dedupKey
+	if providerKey == "" {
+		providerKey = fmt.Sprintf("refund:%d:event:%d", current.ID, event.ID)
+	}
+	if err := provider.Refund(current.ProviderReference, amount, providerKey); err != nil {
+		return nil, uc.release(event, err)
+	}
+
+	current.RefundedAmount += amount
+	current.Status = status
+	if err := uc.PaymentRepository.ApplyEvent(current, event); err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+// ListEvents retrieves the audit trail of a payment of the lot.
+func (uc *PaymentUseCase) ListEvents(parkingLotID uint, paymentID uint) ([]domain.PaymentEvent, error) {
+	if _, err := uc.lotPayment(parkingLotID, paymentID); err != nil {
+		return nil, err
+	}
+	return uc.PaymentRepository.ListEvents(paymentID)
+}
+
+// GetRevenue sums the payments of the lot captured in the period.
+func (uc *PaymentUseCase) GetRevenue(parkingLotID uint, period ReportPeriod) (*RevenueResponse, error) {
+	revenue, err := uc.PaymentRepository.RevenueByParkingLot(parkingLotID, period.From, period.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RevenueResponse{
+		ParkingLotID: parkingLotID,
+		From:         period.From,
+		To:           period.To,
+		Currency:     paymentCurrency,
+		Payments:     revenue.Payments,
+		Gross:        revenue.Captured,
+		Refunded:     revenue.Refunded,
+		Net:          revenue.Captured - revenue.Refunded,
+	}, nil
+}
+
+func (uc *PaymentUseCase) lotPayment(parkingLotID uint, paymentID uint) (*domain.Payment, error) {
+	current, err := uc.PaymentRepository.GetByID(paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if current.ParkingLotID != parkingLotID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return current, nil
+}
+
+// transition moves the payment to a new state and records who did it.
+func (uc *PaymentUseCase) transition(current *domain.Payment, to string, amount uint, source, actor, dedupKey string) error {
+	event, err := newPaymentEvent(current, to, amount, source, actor, dedupKey)
+	if err != nil {
+		return err
+	}
+	current.Status = to
+	return uc.PaymentRepository.ApplyEvent(current, event)
+}
+
+// claim reserves a transition that needs the provider before calling it, so that no other change
+// of the payment runs at the same time and the dedup key is applied once. It reports false when
+// the dedup key was already claimed.
+func (uc *PaymentUseCase) claim(current *domain.Payment, to string, amount uint, source, actor, dedupKey string) (*domain.PaymentEvent, bool, error) {
+	event, err := newPaymentEvent(current, to, amount, source, actor, dedupKey)
+	if err != nil {
+		return nil, false, err
+	}
+	claimed, err := uc.PaymentRepository.ClaimEvent(current, event)
+	if err != nil {
+		return nil, false, err
+	}
+	return event, claimed, nil
+}
+
+// release gives up a claimed event after the provider call failed, returning that failure.
+func (uc *PaymentUseCase) release(event *domain.PaymentEvent, cause error) error {
+	if err := uc.PaymentRepository.ReleaseEvent(event); err != nil {
+		log.Printf("Error releasing payment event %d: %v", event.ID, err)
+	}
+	return cause
+}
+
+func newPaymentEvent(current *domain.Payment, to string, amount uint, source, actor, dedupKey string) (*domain.PaymentEvent, error) {
+	if !paymentTransitionAllowed(current.Status, to) {
+		return nil, ErrInvalidPaymentTransition
+	}
+	event := &domain.PaymentEvent{
+		FromStatus: current.Status,
+		ToStatus:   to,
+		Amount:     amount,
+		Source:     source,
+		Actor:      actor,
+	}
+	if dedupKey != "" {
+		event.DedupKey = &dedupKey
+	}
+	return event, nil
+}
+
+func paymentTransitionAllowed(from, to string) bool {
+	for _, allowed := range paymentTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func userActor(userID uint) string {
+	return fmt.Sprintf("user:%d", userID)
+}
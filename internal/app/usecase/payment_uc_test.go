@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/payment"
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupPaymentTest(t *testing.T) (*gomock.Controller, *mockgen.MockIPaymentRepository, *mockgen.MockIParkingSessionRepository, *payment.FakeProvider, *PaymentUseCase) {
+	ctrl := gomock.NewController(t)
+	paymentRepo := mockgen.NewMockIPaymentRepository(ctrl)
+	sessionRepo := mockgen.NewMockIParkingSessionRepository(ctrl)
+	reservationRepo := mockgen.NewMockIReservationRepository(ctrl)
+	parkingLotRepo := mockgen.NewMockIParkingLotRepository(ctrl)
+	provider := payment.NewFakeProvider([]byte("secret"))
+	useCase := NewPaymentUseCase(paymentRepo, sessionRepo, reservationRepo, parkingLotRepo, provider).(*PaymentUseCase)
+	useCase.now = func() time.Time { return time.Date(2024, time.September, 10, 8, 0, 0, 0, time.UTC) }
+	return ctrl, paymentRepo, sessionRepo, provider, useCase
+}
+
+func TestCreatePaymentForCompletedSession(t *testing.T) {
+	ctrl, paymentRepo, sessionRepo, _, useCase := setupPaymentTest(t)
+	defer ctrl.Finish()
+
+	userID := uint(7)
+	paymentRepo.EXPECT().FindByIdempotencyKey(userID, "key-1").Return(nil, nil)
+	sessionRepo.EXPECT().GetByID(uint(3)).Return(&domain.ParkingSession{
+		ID: 3, UserID: &userID, ParkingLotID: 1, Status: domain.ParkingSessionCompleted, Cost: 4500,
+	}, nil)
+	paymentRepo.EXPECT().FindOpenByParkingSession(uint(3)).Return(nil, nil)
+	paymentRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(p *domain.Payment, e *domain.PaymentEvent) error {
+		assert.Equal(t, domain.PaymentStatusPending, e.ToStatus)
+		assert.Equal(t, "user:7", e.Actor)
+		return nil
+	})
+
+	created, err := useCase.CreatePayment(userID, CreatePaymentRequest{ParkingSessionID: 3}, "key-1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4500), created.Amount)
+	assert.Equal(t, payment.FakeProviderName, created.Provider)
+	assert.Equal(t, domain.PaymentStatusPending, created.Status)
+	assert.Equal(t, "key-1", *created.IdempotencyKey)
+	assert.NotEmpty(t, created.ProviderReference)
+}
+
+func TestCreatePaymentReturnsExistingForSameIdempotencyKey(t *testing.T) {
+	ctrl, paymentRepo, _, _, useCase := setupPaymentTest(t)
+	defer ctrl.Finish()
+
+	existing := &domain.Payment{ID: 9, UserID: 7, Amount: 4500}
+	paymentRepo.EXPECT().FindByIdempotencyKey(uint(7), "key-1").Return(existing, nil)
+
+	created, err := useCase.CreatePayment(7, CreatePaymentRequest{ParkingSessionID: 3}, "key-1")
+	assert.NoError(t, err)
+	assert.Same(t, existing, created)
+}
+
+func TestCreatePaymentReturnsThePaymentOfAConcurrentRetry(t *testing.T) {
+	ctrl, paymentRepo, sessionRepo, _, useCase := setupPaymentTest(t)
+	defer ctrl.Finish()
+
+	userID := uint(7)
+	existing := &domain.Payment{ID: 9, UserID: userID, Amount: 4500}
+	gomock.InOrder(
+		paymentRepo.EXPECT().FindByIdempotencyKey(userID, "key-1").Return(nil, nil),
+		paymentRepo.EXPECT().FindOpenByParkingSession(uint(3)).Return(nil, nil),
+		paymentRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(domain.ErrDuplicatePayment),
+		paymentRepo.EXPECT().FindByIdempotencyKey(userID, "key-1").Return(existing, nil),
+	)
+	sessionRepo.EXPECT().GetByID(uint(3)).Return(&domain.ParkingSession{
+		ID: 3, UserID: &userID, ParkingLotID: 1, Status: domain.ParkingSessionCompleted, Cost: 4500,
+	}, nil)
+
+	created, err := useCase.CreatePayment(userID, CreatePaymentRequest{ParkingSessionID: 3}, "key-1")
+	assert.NoError(t, err)
+	assert.Same(t, existing, created)
+}
+
+func TestCreatePaymentRejectsActiveSession(t *testing.T) {
+	ctrl, paymentRepo, sessionRepo, _, useCase := setupPaymentTest(t)
+	defer ctrl.Finish()
+
+	userID := uint(7)
+	paymentRepo.EXPECT().FindByIdempotencyKey(userID, "key-1").Return(nil, nil)
+	sessionRepo.EXPECT().GetByID(uint(3)).Return(&domain.ParkingSession{
+		ID: 3, UserID: &userID, Status: domain.ParkingSessionActive,
+	}, nil)
+
+	_, err := useCase.CreatePayment(userID, CreatePaymentRequest{ParkingSessionID: 3}, "key-1")
+	assert.ErrorIs(t, err, ErrNothingToPay)
+}
+
+func TestHandleWebhookAuthorizesAndCaptures(t *testing.T) {
+	ctrl, paymentRepo, _, provider, useCase := setupPaymentTest(t)
+	defer ctrl.Finish()
+
+	intent, err := provider.CreateIntent(payment.IntentRequest{Reference: "r", Amount: 4500, Currency: paymentCurrency})
+	assert.NoError(t, err)
+	payload, signature, err := provider.Authorize(intent.ProviderReference)
+	assert.NoError(t, err)
+
+	current := &domain.Payment{ID: 9, Amount: 4500, Provider: payment.FakeProviderName, ProviderReference: intent.ProviderReference, Status: domain.PaymentStatusPending}
+	paymentRepo.EXPECT().FindByProviderReference(payment.FakeProviderName, intent.ProviderReference).Return(current, nil)
+	paymentRepo.EXPECT().HasEvent(gomock.Any()).Return(false, nil)
+
+	var applied []string
+	paymentRepo.EXPECT().ClaimEvent(current, gomock.Any()).DoAndReturn(func(p *domain.Payment, e *domain.PaymentEvent) (bool, error) {
+		assert.Equal(t, "capture:9", *e.DedupKey)
+		return true, nil
+	})
+	paymentRepo.EXPECT().ApplyEvent(current, gomock.Any()).Times(2).DoAndReturn(func(p *domain.Payment, e *domain.PaymentEvent) error {
+		applied = append(applied, e.FromStatus+"->"+e.ToStatus)
+		return nil
+	})
+
+	assert.NoError(t, useCase.HandleWebhook(payment.FakeProviderName, payload, signature))
+	assert.Equal(t, []string{"pending->authorized", "authorized->captured"}, applied)
+	assert.Equal(t, domain.PaymentStatusCaptured, current.Status)
+	assert.Equal(t, uint(4500), current.CapturedAmount)
+}
+
+func TestHandleWebhookCapturesOnceWhenAlreadyClaimed(t *testing.T) {
+	ctrl, paymentRepo, _, provider, useCase := setupPaymentTest(t)
+	defer ctrl.Finish()
+
+	payload, signature, err := provider.SignedWebhook(payment.WebhookEvent{ID: "evt_2", ProviderReference: "fake_ref", Status: payment.StatusAuthorized, Amount: 4500})
+	assert.NoError(t, err)
+
+	current := &domain.Payment{ID: 9, Amount: 4500, Provider: payment.FakeProviderName, ProviderReference: "fake_ref", Status: domain.PaymentStatusAuthorized}
+	paymentRepo.EXPECT().FindByProviderReference(payment.FakeProviderName, "fake_ref").Return(current, nil)
+	paymentRepo.EXPECT().HasEvent("webhook:fake:evt_2").Return(true, nil)
+	paymentRepo.EXPECT().ClaimEvent(current, gomock.Any()).Return(false, nil)
+
+	assert.NoError(t, useCase.HandleWebhook(payment.FakeProviderName, payload, signature))
+	assert.Equal(t, domain.PaymentStatusAuthorized, current.Status)
+}
+
+func TestHandleWebhookIgnoresDuplicateEvent(t *testing.T) {
+	ctrl, paymentRepo, _, provider, useCase := setupPaymentTest(t)
+	defer ctrl.Finish()
+
+	payload, signature, err := provider.SignedWebhook(payment.WebhookEvent{ID: "evt_1", ProviderReference: "fake_ref", Status: payment.StatusAuthorized, Amount: 4500})
+	assert.NoError(t, err)
+
+	current := &domain.Payment{ID: 9, Amount: 4500, CapturedAmount: 4500, Provider: payment.FakeProviderName, ProviderReference: "fake_ref", Status: domain.PaymentStatusCaptured}
+	paymentRepo.EXPECT().FindByProviderReference(payment.FakeProviderName, "fake_ref").Return(current, nil)
+	paymentRepo.EXPECT().HasEvent("webhook:fake:evt_1").Return(true, nil)
+
+	assert.NoError(t, useCase.HandleWebhook(payment.FakeProviderName, payload, signature))
+	assert.Equal(t, domain.PaymentStatusCaptured, current.Status)
+}
+
+func TestHandleWebhookRejectsInvalidSignature(t *testing.T) {
+	ctrl, _, _, provider, useCase := setupPaymentTest(t)
+	defer ctrl.Finish()
+
+	payload, _, err := provider.SignedWebhook(payment.WebhookEvent{ID: "evt_1", ProviderReference: "fake_ref", Status: payment.StatusAuthorized})
+	assert.NoError(t, err)
+
+	err = useCase.HandleWebhook(payment.FakeProviderName, payload, "forged")
+	assert.ErrorIs(t, err, payment.ErrInvalidSignature)
+}
+
+func TestRefundPartiallyThenFully(t *testing.T) {
+	ctrl, paymentRepo, _, provider, useCase := setupPaymentTest(t)
+	defer ctrl.Finish()
+
+	intent, err := provider.CreateIntent(payment.IntentRequest{Reference: "r", Amount: 4500, Currency: paymentCurrency})
+	assert.NoError(t, err)
+	_, _, err = provider.Authorize(intent.ProviderReference)
+	assert.NoError(t, err)
+	assert.NoError(t, provider.Capture(intent.ProviderReference, 4500, "capture:9"))
+
+	current := &domain.Payment{ID: 9, ParkingLotID: 1, Amount: 4500, CapturedAmount: 4500, Provider: payment.FakeProviderName, ProviderReference: intent.ProviderReference, Status: domain.PaymentStatusCaptured}
+	paymentRepo.EXPECT().GetByID(uint(9)).Return(current, nil).Times(2)
+	paymentRepo.EXPECT().HasEvent("refund:9:r1").Return(false, nil)
+	paymentRepo.EXPECT().ClaimEvent(current, gomock.Any()).Times(2).DoAndReturn(func(p *domain.Payment, e *domain.PaymentEvent) (bool, error) {
+		e.ID = 20
+		return true, nil
+	})
+	paymentRepo.EXPECT().ApplyEvent(current, gomock.Any()).Return(nil).Times(2)
+
+	refunded, err := useCase.Refund(1, 9, 1500, "admin:a", "r1")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusPartiallyRefunded, refunded.Status)
+	assert.Equal(t, uint(1500), refunded.RefundedAmount)
+
+	refunded, err = useCase.Refund(1, 9, 0, "admin:a", "")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusRefunded, refunded.Status)
+	assert.Equal(t, uint(4500), refunded.RefundedAmount)
+}
+
+func TestRefundDoesNotCallTheProviderForAClaimedKey(t *testing.T) {
+	ctrl, paymentRepo, _, _, useCase := setupPaymentTest(t)
+	defer ctrl.Finish()
+
+	current := &domain.Payment{ID: 9, ParkingLotID: 1, Amount: 4500, CapturedAmount: 4500, Provider: payment.FakeProviderName, ProviderReference: "fake_unknown", Status: domain.PaymentStatusCaptured}
+	refunded := &domain.Payment{ID: 9, ParkingLotID: 1, Amount: 4500, CapturedAmount: 4500, RefundedAmount: 4500, Status: domain.PaymentStatusRefunded}
+	gomock.InOrder(
+		paymentRepo.EXPECT().GetByID(uint(9)).Return(current, nil),
+		paymentRepo.EXPECT().HasEvent("refund:9:r1").Return(false, nil),
+		paymentRepo.EXPECT().ClaimEvent(current, gomock.Any()).Return(false, nil),
+		paymentRepo.EXPECT().GetByID(uint(9)).Return(refunded, nil),
+	)
+
+	result, err := useCase.Refund(1, 9, 0, "admin:a", "r1")
+	assert.NoError(t, err)
+	assert.Same(t, refunded, result)
+}
+
+func TestRefundReleasesTheClaimWhenTheProviderFails(t *testing.T) {
+	ctrl, paymentRepo, _, _, useCase := setupPaymentTest(t)
+	defer ctrl.Finish()
+
+	current := &domain.Payment{ID: 9, ParkingLotID: 1, Amount: 4500, CapturedAmount: 4500, Provider: payment.FakeProviderName, ProviderReference: "fake_unknown", Status: domain.PaymentStatusCaptured}
+	paymentRepo.EXPECT().GetByID(uint(9)).Return(current, nil)
+	paymentRepo.EXPECT().ClaimEvent(current, gomock.Any()).Return(true, nil)
+	paymentRepo.EXPECT().ReleaseEvent(gomock.Any()).Return(nil)
+
+	_, err := useCase.Refund(1, 9, 0, "admin:a", "")
+	assert.ErrorIs(t, err, payment.ErrUnknownPayment)
+	assert.Equal(t, domain.PaymentStatusCaptured, current.Status)
+	assert.Zero(t, current.RefundedAmount)
+}
+
+func TestRefundRejectsAmountAboveCaptured(t *testing.T) {
+	ctrl, paymentRepo, _, _, useCase := setupPaymentTest(t)
+	defer ctrl.Finish()
+
+	current := &domain.Payment{ID: 9, ParkingLotID: 1, Amount: 4500, CapturedAmount: 4500, RefundedAmount: 4000, Provider: payment.FakeProviderName, Status: domain.PaymentStatusPartiallyRefunded}
+	paymentRepo.EXPECT().GetByID(uint(9)).Return(current, nil)
+
+	_, err := useCase.Refund(1, 9, 1000, "admin:a", "")
+	assert.ErrorIs(t, err, ErrInvalidRefundAmount)
+}
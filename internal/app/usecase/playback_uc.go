@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"sort"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+)
+
+const (
+	SensorStatusUnknown = "unknown"
+
+	PlaybackFrameState  = "state"
+	PlaybackFrameChange = "change"
+)
+
+type IPlaybackUseCase interface {
+	GetStateAt(parkingLotID uint, at time.Time) (*LotStateResponse, error)
+	Replay(parkingLotID uint, from, to time.Time, fn func(PlaybackFrame) error) error
+}
+
+type PlaybackUseCase struct {
+	SensorRepository      repository.ISensorRepository
+	SensorEventRepository repository.ISensorEventRepository
+	LotSnapshotRepository repository.ILotSnapshotRepository
+}
+
+type SensorStateResponse struct {
+	SensorID         uint      `json:"sensor_id"`
+	SensorNumber     int       `json:"sensor_number"`
+	DeviceIdentifier string    `json:"device_identifier"`
+	Status           string    `json:"status"`
+	Since            time.Time `json:"since"`
+}
+
+type LotStateResponse struct {
+	ParkingLotID    uint                  `json:"parking_lot_id"`
+	At              time.Time             `json:"at"`
+	AvailableSpaces uint                  `json:"available_spaces"`
+	Sensors         []SensorStateResponse `json:"sensors"`
+}
+
+// PlaybackFrame is one element of a replay: the initial state, then one frame per change.
+type PlaybackFrame struct {
+	Type            string              `json:"type"`
+	At              time.Time           `json:"at"`
+	State           *LotStateResponse   `json:"state,omitempty"`
+	Event           *domain.SensorEvent `json:"event,omitempty"`
+	AvailableSpaces uint                `json:"available_spaces"`
+}
+
+// lotState is the mutable state of a lot while events are being replayed. sensors holds every
+// sensor the lot had during the period; only the ones installed at the instant `at` count.
+type lotState struct {
+	at      time.Time
+	sensors map[uint]domain.Sensor
+	status  map[uint]domain.SensorState
+}
+
+// NewPlaybackUseCase creates a new instance of PlaybackUseCase.
+func NewPlaybackUseCase(sensorRepo repository.ISensorRepository, eventRepo repository.ISensorEventRepository, snapshotRepo repository.ILotSnapshotRepository) IPlaybackUseCase {
+	return &PlaybackUseCase{
+		SensorRepository:      sensorRepo,
+		SensorEventRepository: eventRepo,
+		LotSnapshotRepository: snapshotRepo,
+	}
+}
+
+// GetStateAt rebuilds the status of every sensor of a parking lot at the given instant from the
+// latest snapshot taken before it plus the events recorded after that snapshot.
+func (uc *PlaybackUseCase) GetStateAt(parkingLotID uint, at time.Time) (*LotStateResponse, error) {
+	state, err := uc.rebuild(parkingLotID, at, at)
+	if err != nil {
+		return nil, err
+	}
+	return state.response(parkingLotID, at), nil
+}
+
+// Replay sends the state of the lot at `from` followed by every change recorded until `to`.
+func (uc *PlaybackUseCase) Replay(parkingLotID uint, from, to time.Time, fn func(PlaybackFrame) error) error {
+	state, err := uc.rebuild(parkingLotID, from, to)
+	if err != nil {
+		return err
+	}
+
+	initial := state.response(parkingLotID, from)
+	if err := fn(PlaybackFrame{Type: PlaybackFrameState, At: from, State: initial, AvailableSpaces: initial.AvailableSpaces}); err != nil {
+		return err
+	}
+
+	return uc.SensorEventRepository.StreamByParkingLotBetween(parkingLotID, from, to, func(event domain.SensorEvent) error {
+		state.apply(event)
+		return fn(PlaybackFrame{
+			Type:            PlaybackFrameChange,
+			At:              event.OccurredAt,
+			Event:           &event,
+			AvailableSpaces: state.available(),
+		})
+	})
+}
+
+// rebuild returns the state of the lot at `at`, knowing the sensors it has until `until` so that
+// a replay can carry on from there.
+func (uc *PlaybackUseCase) rebuild(parkingLotID uint, at, until time.Time) (*lotState, error) {
+	state := &lotState{
+		sensors: make(map[uint]domain.Sensor),
+		status:  make(map[uint]domain.SensorState),
+	}
+
+	var since time.Time
+	snapshot, err := uc.LotSnapshotRepository.FindLatestBefore(parkingLotID, at)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot != nil {
+		since = snapshot.TakenAt
+		for _, s := range snapshot.Sensors {
+			state.status[s.SensorID] = s
+		}
+	}
+
+	// Sensors deleted after the snapshot are listed too, so that their events do not install them.
+	sensors, err := uc.SensorRepository.ListByParkingLotBetween(parkingLotID, since, until)
+	if err != nil {
+		return nil, err
+	}
+	for _, sensor := range sensors {
+		state.sensors[sensor.ID] = sensor
+	}
+
+	if err := uc.SensorEventRepository.StreamByParkingLotBetween(parkingLotID, since, at, func(event domain.SensorEvent) error {
+		state.apply(event)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	state.at = at
+	return state, nil
+}
+
+// apply moves the lot to the instant of the event. A sensor that reports before it was listed
+// is installed from its first event on.
+func (s *lotState) apply(event domain.SensorEvent) {
+	if _, ok := s.sensors[event.SensorID]; !ok {
+		s.sensors[event.SensorID] = domain.Sensor{ID: event.SensorID, ParkingLotID: event.ParkingLotID, CreatedAt: event.OccurredAt}
+	}
+	s.status[event.SensorID] = domain.SensorState{SensorID: event.SensorID, Status: event.Status, Since: event.OccurredAt}
+	s.at = event.OccurredAt
+}
+
+// installed reports whether the sensor was created and not yet deleted at the current instant.
+func (s *lotState) installed(sensor domain.Sensor) bool {
+	return !sensor.CreatedAt.After(s.at) && (!sensor.DeletedAt.Valid || sensor.DeletedAt.Time.After(s.at))
+}
+
+func (s *lotState) available() uint {
+	var available uint
+	for sensorID, sensor := range s.sensors {
+		if s.installed(sensor) && s.status[sensorID].Status == domain.SensorStatusFree {
+			available++
+		}
+	}
+	return available
+}
+
+func (s *lotState) response(parkingLotID uint, at time.Time) *LotStateResponse {
+	response := &LotStateResponse{
+		ParkingLotID:    parkingLotID,
+		At:              at,
+		AvailableSpaces: s.available(),
+		Sensors:         []SensorStateResponse{},
+	}
+
+	for sensorID, sensor := range s.sensors {
+		if !s.installed(sensor) {
+			continue
+		}
+		item := SensorStateResponse{
+			SensorID:         sensorID,
+			SensorNumber:     sensor.SensorNumber,
+			DeviceIdentifier: sensor.DeviceIdentifier,
+			Status:           SensorStatusUnknown,
+		}
+		if known, ok := s.status[sensorID]; ok {
+			item.Status = known.Status
+			item.Since = known.Since
+		}
+		response.Sensors = append(response.Sensors, item)
+	}
+
+	sort.Slice(response.Sensors, func(i, j int) bool {
+		return response.Sensors[i].SensorID < response.Sensors[j].SensorID
+	})
+	return response
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestGetStateAtReplaysEventsAfterSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sensorRepo := mockgen.NewMockISensorRepository(ctrl)
+	eventRepo := mockgen.NewMockISensorEventRepository(ctrl)
+	snapshotRepo := mockgen.NewMockILotSnapshotRepository(ctrl)
+	useCase := NewPlaybackUseCase(sensorRepo, eventRepo, snapshotRepo)
+
+	base := time.Date(2024, time.May, 2, 18, 0, 0, 0, time.UTC)
+	at := base.Add(15 * time.Minute)
+
+	sensorRepo.EXPECT().ListByParkingLotBetween(uint(1), base, at).Return([]domain.Sensor{
+		{ID: 1, SensorNumber: 1, CreatedAt: base.Add(-time.Hour)},
+		{ID: 2, SensorNumber: 2, CreatedAt: base.Add(-time.Hour)},
+		{ID: 3, SensorNumber: 3, CreatedAt: base.Add(-time.Hour)},
+		{ID: 5, SensorNumber: 5, CreatedAt: base.Add(-time.Hour), DeletedAt: gorm.DeletedAt{Time: base.Add(12 * time.Minute), Valid: true}},
+	}, nil)
+	snapshotRepo.EXPECT().FindLatestBefore(uint(1), at).Return(&domain.LotSnapshot{
+		ParkingLotID: 1,
+		TakenAt:      base,
+		Sensors: []domain.SensorState{
+			{SensorID: 1, Status: domain.SensorStatusFree, Since: base},
+			{SensorID: 2, Status: domain.SensorStatusOccupied, Since: base},
+		},
+	}, nil)
+	eventRepo.EXPECT().StreamByParkingLotBetween(uint(1), base, at, gomock.Any()).
+		DoAndReturn(func(_ uint, _, _ time.Time, fn func(domain.SensorEvent) error) error {
+			_ = fn(domain.SensorEvent{SensorID: 1, Status: domain.SensorStatusOccupied, OccurredAt: base.Add(5 * time.Minute)})
+			_ = fn(domain.SensorEvent{SensorID: 5, Status: domain.SensorStatusFree, OccurredAt: base.Add(6 * time.Minute)})
+			return fn(domain.SensorEvent{SensorID: 2, Status: domain.SensorStatusFree, OccurredAt: base.Add(10 * time.Minute)})
+		})
+
+	state, err := useCase.GetStateAt(1, at)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), state.AvailableSpaces)
+	assert.Len(t, state.Sensors, 3)
+	assert.Equal(t, domain.SensorStatusOccupied, state.Sensors[0].Status)
+	assert.Equal(t, domain.SensorStatusFree, state.Sensors[1].Status)
+	assert.Equal(t, base.Add(10*time.Minute), state.Sensors[1].Since)
+	assert.Equal(t, SensorStatusUnknown, state.Sensors[2].Status)
+}
+
+func TestReplayInstallsSensorsFromTheirFirstEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sensorRepo := mockgen.NewMockISensorRepository(ctrl)
+	eventRepo := mockgen.NewMockISensorEventRepository(ctrl)
+	snapshotRepo := mockgen.NewMockILotSnapshotRepository(ctrl)
+	useCase := NewPlaybackUseCase(sensorRepo, eventRepo, snapshotRepo)
+
+	from := time.Date(2024, time.May, 2, 18, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	snapshotRepo.EXPECT().FindLatestBefore(uint(1), from).Return(nil, nil)
+	sensorRepo.EXPECT().ListByParkingLotBetween(uint(1), time.Time{}, to).Return([]domain.Sensor{
+		{ID: 1, CreatedAt: from.Add(-time.Hour)},
+		{ID: 2, CreatedAt: from.Add(-time.Hour), DeletedAt: gorm.DeletedAt{Time: from.Add(30 * time.Minute), Valid: true}},
+	}, nil)
+	eventRepo.EXPECT().StreamByParkingLotBetween(uint(1), time.Time{}, from, gomock.Any()).
+		DoAndReturn(func(_ uint, _, _ time.Time, fn func(domain.SensorEvent) error) error {
+			_ = fn(domain.SensorEvent{SensorID: 1, Status: domain.SensorStatusFree, OccurredAt: from.Add(-time.Minute)})
+			return fn(domain.SensorEvent{SensorID: 2, Status: domain.SensorStatusFree, OccurredAt: from.Add(-time.Minute)})
+		})
+	eventRepo.EXPECT().StreamByParkingLotBetween(uint(1), from, to, gomock.Any()).
+		DoAndReturn(func(_ uint, _, _ time.Time, fn func(domain.SensorEvent) error) error {
+			_ = fn(domain.SensorEvent{SensorID: 3, Status: domain.SensorStatusFree, OccurredAt: from.Add(10 * time.Minute)})
+			return fn(domain.SensorEvent{SensorID: 1, Status: domain.SensorStatusOccupied, OccurredAt: from.Add(40 * time.Minute)})
+		})
+
+	var available []uint
+	err := useCase.Replay(1, from, to, func(frame PlaybackFrame) error {
+		available = append(available, frame.AvailableSpaces)
+		return nil
+	})
+	assert.NoError(t, err)
+	// Sensor 3 counts from its first event; sensor 2 stops counting once deleted.
+	assert.Equal(t, []uint{2, 3, 1}, available)
+}
@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/storage"
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+)
+
+// IPrivacyUseCase honors the access and deletion requests of Ley 1581 for drivers and admins.
+type IPrivacyUseCase interface {
+	ExportUser(userID uint) (*UserDataExport, error)
+	EraseUser(userID uint) error
+	ExportAdmin(adminUUID string) (*AdminDataExport, error)
+	EraseAdmin(adminUUID string) error
+}
+
+type PrivacyUseCase struct {
+	PrivacyRepository        repository.IPrivacyRepository
+	UserRepository           repository.IUserRepository
+	VehicleRepository        repository.IVehicleRepository
+	ParkingSessionRepository repository.IParkingSessionRepository
+	ReservationRepository    repository.IReservationRepository
+	PaymentRepository        repository.IPaymentRepository
+	ReviewRepository         repository.IReviewRepository
+	FavoriteRepository       repository.IFavoriteRepository
+	AlertRepository          repository.IAvailabilityAlertRepository
+	CrowdReportRepository    repository.ICrowdReportRepository
+	AdminRepository          repository.IAdminRepository
+	ParkingLotRepository     repository.IParkingLotRepository
+	DocumentRepository       repository.IOperatorDocumentRepository
+	DocumentStore            storage.DocumentStore
+	now                      func() time.Time
+}
+
+// UserDataExport is every piece of personal data stored about a driver.
+type UserDataExport struct {
+	ExportedAt      time.Time                   `json:"exported_at"`
+	Profile         domain.User                 `json:"profile"`
+	Vehicles        []domain.Vehicle            `json:"vehicles"`
+	ParkingSessions []domain.ParkingSession     `json:"parking_sessions"`
+	Reservations    []domain.Reservation        `json:"reservations"`
+	Payments        []domain.Payment            `json:"payments"`
+	Reviews         []domain.Review             `json:"reviews"`
+	Favorites       []domain.FavoriteParkingLot `json:"favorites"`
+	Alerts          []domain.AvailabilityAlert  `json:"alerts"`
+	CrowdReports    []domain.CrowdReport        `json:"crowd_reports"`
+}
+
+// AdminDataExport is the profile of an admin, the operator documents they uploaded and the
+// parking lots registered under it.
+type AdminDataExport struct {
+	ExportedAt  time.Time                 `json:"exported_at"`
+	Profile     domain.Admin              `json:"profile"`
+	Documents   []domain.OperatorDocument `json:"documents"`
+	ParkingLots []domain.ParkingLot       `json:"parking_lots"`
+}
+
+// NewPrivacyUseCase creates a new instance of PrivacyUseCase.
+func NewPrivacyUseCase(privacyRepo repository.IPrivacyRepository, userRepo repository.IUserRepository, vehicleRepo repository.IVehicleRepository,
+	sessionRepo repository.IParkingSessionRepository, reservationRepo repository.IReservationRepository, paymentRepo repository.IPaymentRepository,
+	reviewRepo repository.IReviewRepository, favoriteRepo repository.IFavoriteRepository, alertRepo repository.IAvailabilityAlertRepository,
+	crowdReportRepo repository.ICrowdReportRepository, adminRepo repository.IAdminRepository, parkingLotRepo repository.IParkingLotRepository,
+	documentRepo repository.IOperatorDocumentRepository, store storage.DocumentStore) IPrivacyUseCase {
+	return &PrivacyUseCase{
+		PrivacyRepository:        privacyRepo,
+		UserRepository:           userRepo,
+		VehicleRepository:        vehicleRepo,
+		ParkingSessionRepository: sessionRepo,
+		ReservationRepository:    reservationRepo,
+		PaymentRepository:        paymentRepo,
+		ReviewRepository:         reviewRepo,
+		FavoriteRepository:       favoriteRepo,
+		AlertRepository:          alertRepo,
+		CrowdReportRepository:    crowdReportRepo,
+		AdminRepository:          adminRepo,
+		ParkingLotRepository:     parkingLotRepo,
+		DocumentRepository:       documentRepo,
+		DocumentStore:            store,
+		now:                      time.Now,
+	}
+}
+
+// ExportUser gathers the personal data of the driver into a single archive.
+func (uc *PrivacyUseCase) ExportUser(userID uint) (*UserDataExport, error) {
+	user, err := uc.UserRepository.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &UserDataExport{ExportedAt: uc.now(), Profile: *user}
+	if export.Vehicles, err = uc.VehicleRepository.ListByUser(userID); err != nil {
+		return nil, err
+	}
+	if export.ParkingSessions, err = uc.ParkingSessionRepository.ListByUser(userID, ""); err != nil {
+		return nil, err
+	}
+	if export.Reservations, err = uc.ReservationRepository.ListByUser(userID); err != nil {
+		return nil, err
+	}
+	if export.Payments, err = uc.PaymentRepository.ListByUser(userID); err != nil {
+		return nil, err
+	}
+	if export.Reviews, err = uc.ReviewRepository.ListByUser(userID); err != nil {
+		return nil, err
+	}
+	if export.Favorites, err = uc.FavoriteRepository.ListByUser(userID); err != nil {
+		return nil, err
+	}
+	if export.Alerts, err = uc.AlertRepository.ListByUser(userID); err != nil {
+		return nil, err
+	}
+	if export.CrowdReports, err = uc.CrowdReportRepository.ListByUser(userID); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// EraseUser anonymizes the driver and closes every session of their account.
+func (uc *PrivacyUseCase) EraseUser(userID uint) error {
+	if _, err := uc.UserRepository.FindByID(userID); err != nil {
+		return err
+	}
+	return uc.PrivacyRepository.AnonymizeUser(userID, uc.now())
+}
+
+// ExportAdmin gathers the profile of the admin, the operator documents they uploaded and the
+// parking lots their memberships cover.
+func (uc *PrivacyUseCase) ExportAdmin(adminUUID string) (*AdminDataExport, error) {
+	admin, err := uc.AdminRepository.FindByAuth0UUID(adminUUID)
+	if err != nil {
+		return nil, err
+	}
+	documents, err := uc.DocumentRepository.ListByAdmin(admin.ID)
+	if err != nil {
+		return nil, err
+	}
+	parkingLots, err := uc.ParkingLotRepository.FindByMember(admin.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := *admin
+	profile.ParkingLots = nil
+	return &AdminDataExport{ExportedAt: uc.now(), Profile: profile, Documents: documents, ParkingLots: parkingLots}, nil
+}
+
+// EraseAdmin deletes the operator documents of the admin and anonymizes their profile. Their
+// parking lots keep operating. The files go first so that a failed erasure can be retried
+// without leaving documents behind that no row points to.
+func (uc *PrivacyUseCase) EraseAdmin(adminUUID string) error {
+	admin, err := uc.AdminRepository.FindByAuth0UUID(adminUUID)
+	if err != nil {
+		return err
+	}
+	documents, err := uc.DocumentRepository.ListByAdmin(admin.ID)
+	if err != nil {
+		return err
+	}
+	for _, document := range documents {
+		if err := uc.DocumentStore.Delete(document.StorageKey); err != nil {
+			return err
+		}
+	}
+	return uc.PrivacyRepository.AnonymizeAdmin(admin.ID, uc.now())
+}
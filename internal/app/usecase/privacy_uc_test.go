@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/storage"
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var privacyNow = time.Date(2024, time.September, 10, 8, 0, 0, 0, time.UTC)
+
+type privacyMocks struct {
+	privacy     *mockgen.MockIPrivacyRepository
+	user        *mockgen.MockIUserRepository
+	vehicle     *mockgen.MockIVehicleRepository
+	session     *mockgen.MockIParkingSessionRepository
+	reservation *mockgen.MockIReservationRepository
+	payment     *mockgen.MockIPaymentRepository
+	review      *mockgen.MockIReviewRepository
+	favorite    *mockgen.MockIFavoriteRepository
+	alert       *mockgen.MockIAvailabilityAlertRepository
+	crowdReport *mockgen.MockICrowdReportRepository
+	admin       *mockgen.MockIAdminRepository
+	parkingLot  *mockgen.MockIParkingLotRepository
+	document    *mockgen.MockIOperatorDocumentRepository
+	store       *storage.LocalStore
+}
+
+func setupPrivacyTest(t *testing.T) (*gomock.Controller, privacyMocks, IPrivacyUseCase) {
+	ctrl := gomock.NewController(t)
+	m := privacyMocks{
+		privacy:     mockgen.NewMockIPrivacyRepository(ctrl),
+		user:        mockgen.NewMockIUserRepository(ctrl),
+		vehicle:     mockgen.NewMockIVehicleRepository(ctrl),
+		session:     mockgen.NewMockIParkingSessionRepository(ctrl),
+		reservation: mockgen.NewMockIReservationRepository(ctrl),
+		payment:     mockgen.NewMockIPaymentRepository(ctrl),
+		review:      mockgen.NewMockIReviewRepository(ctrl),
+		favorite:    mockgen.NewMockIFavoriteRepository(ctrl),
+		alert:       mockgen.NewMockIAvailabilityAlertRepository(ctrl),
+		crowdReport: mockgen.NewMockICrowdReportRepository(ctrl),
+		admin:       mockgen.NewMockIAdminRepository(ctrl),
+		parkingLot:  mockgen.NewMockIParkingLotRepository(ctrl),
+		document:    mockgen.NewMockIOperatorDocumentRepository(ctrl),
+		store:       storage.NewLocalStore(t.TempDir()),
+	}
+	useCase := NewPrivacyUseCase(m.privacy, m.user, m.vehicle, m.session, m.reservation, m.payment, m.review, m.favorite, m.alert, m.crowdReport, m.admin, m.parkingLot,
+		m.document, m.store)
+	useCase.(*PrivacyUseCase).now = func() time.Time { return privacyNow }
+	return ctrl, m, useCase
+}
+
+func TestExportUserGathersPersonalData(t *testing.T) {
+	ctrl, m, useCase := setupPrivacyTest(t)
+	defer ctrl.Finish()
+
+	m.user.EXPECT().FindByID(uint(7)).Return(&domain.User{ID: 7, Username: "driver", Email: "driver@example.com"}, nil)
+	m.vehicle.EXPECT().ListByUser(uint(7)).Return([]domain.Vehicle{{ID: 1, Plate: "ABC123"}}, nil)
+	m.session.EXPECT().ListByUser(uint(7), "").Return([]domain.ParkingSession{{ID: 2}}, nil)
+	m.reservation.EXPECT().ListByUser(uint(7)).Return(nil, nil)
+	m.payment.EXPECT().ListByUser(uint(7)).Return([]domain.Payment{{ID: 3, Amount: 4500}}, nil)
+	m.review.EXPECT().ListByUser(uint(7)).Return([]domain.Review{{ID: 4, Comment: "Well lit"}}, nil)
+	m.favorite.EXPECT().ListByUser(uint(7)).Return(nil, nil)
+	m.alert.EXPECT().ListByUser(uint(7)).Return(nil, nil)
+	m.crowdReport.EXPECT().ListByUser(uint(7)).Return([]domain.CrowdReport{{ID: 5, Level: domain.CrowdLevelFew}}, nil)
+
+	export, err := useCase.ExportUser(7)
+	assert.NoError(t, err)
+	assert.Equal(t, privacyNow, export.ExportedAt)
+	assert.Equal(t, "driver@example.com", export.Profile.Email)
+	assert.Equal(t, "ABC123", export.Vehicles[0].Plate)
+	assert.Len(t, export.ParkingSessions, 1)
+	assert.Len(t, export.Payments, 1)
+	assert.Equal(t, "Well lit", export.Reviews[0].Comment)
+	assert.Len(t, export.CrowdReports, 1)
+}
+
+func TestEraseUserAnonymizesExistingUser(t *testing.T) {
+	ctrl, m, useCase := setupPrivacyTest(t)
+	defer ctrl.Finish()
+
+	m.user.EXPECT().FindByID(uint(7)).Return(&domain.User{ID: 7}, nil)
+	m.privacy.EXPECT().AnonymizeUser(uint(7), privacyNow).Return(nil)
+	assert.NoError(t, useCase.EraseUser(7))
+
+	m.user.EXPECT().FindByID(uint(8)).Return(nil, gorm.ErrRecordNotFound)
+	assert.ErrorIs(t, useCase.EraseUser(8), gorm.ErrRecordNotFound)
+}
+
+func TestExportAndEraseAdmin(t *testing.T) {
+	ctrl, m, useCase := setupPrivacyTest(t)
+	defer ctrl.Finish()
+
+	admin := &domain.Admin{ID: 2, Auth0UUID: "auth0|abc", NIT: "900123456-7", ContactPhone: "3001234567", ParkingLots: []domain.ParkingLot{{ID: 1}}}
+	key, err := m.store.Save("rut.pdf", strings.NewReader("%PDF-1.4"))
+	assert.NoError(t, err)
+	documents := []domain.OperatorDocument{{ID: 5, AdminID: 2, Kind: "rut", FileName: "rut.pdf", StorageKey: key}}
+	m.admin.EXPECT().FindByAuth0UUID("auth0|abc").Return(admin, nil).Times(2)
+	m.document.EXPECT().ListByAdmin(uint(2)).Return(documents, nil).Times(2)
+	m.parkingLot.EXPECT().FindByMember(uint(2)).Return([]domain.ParkingLot{{ID: 1, Name: "Centro"}}, nil)
+
+	export, err := useCase.ExportAdmin("auth0|abc")
+	assert.NoError(t, err)
+	assert.Equal(t, "900123456-7", export.Profile.NIT)
+	assert.Nil(t, export.Profile.ParkingLots)
+	assert.Equal(t, "rut.pdf", export.Documents[0].FileName)
+	assert.Equal(t, "Centro", export.ParkingLots[0].Name)
+
+	m.privacy.EXPECT().AnonymizeAdmin(uint(2), privacyNow).Return(nil)
+	assert.NoError(t, useCase.EraseAdmin("auth0|abc"))
+	_, err = m.store.Open(key)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
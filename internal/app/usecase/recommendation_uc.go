@@ -0,0 +1,375 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+)
+
+const (
+	// DefaultMaxWalkingKm is how far from the destination a lot may be to be recommended.
+	DefaultMaxWalkingKm = 1.5
+	// DefaultStayDuration is the expected stay when the driver does not give one.
+	DefaultStayDuration = time.Hour
+	// walkingSpeedKmh converts straight-line distances to walking minutes.
+	walkingSpeedKmh = 4.8
+	// availabilityHalfSaturation is the number of predicted free spaces scoring 0.5.
+	availabilityHalfSaturation = 3.0
+	// neutralScore is used for a criterion the lot has no data for.
+	neutralScore = 0.5
+)
+
+var ErrInvalidRecommendationWeights = errors.New("recommendation weights must not be negative and must not all be zero")
+
+// RecommendationWeights sets how much each criterion counts in the score of a lot. Weights are
+// relative: the score divides by their sum.
+type RecommendationWeights struct {
+	Distance     float64 `json:"distance"`
+	Availability float64 `json:"availability"`
+	Price        float64 `json:"price"`
+	Rating       float64 `json:"rating"`
+	Open         float64 `json:"open"`
+}
+
+// DefaultRecommendationWeights favors short walks and free spaces over price and rating, and
+// weighs opening hours enough that a closed lot with unknown availability ranks below open ones.
+var DefaultRecommendationWeights = RecommendationWeights{
+	Distance:     0.3,
+	Availability: 0.25,
+	Price:        0.15,
+	Rating:       0.1,
+	Open:         0.2,
+}
+
+// Validate checks that the weights can be used to score lots.
+func (w RecommendationWeights) Validate() error {
+	if w.Distance < 0 || w.Availability < 0 || w.Price < 0 || w.Rating < 0 || w.Open < 0 {
+		return ErrInvalidRecommendationWeights
+	}
+	if w.sum() == 0 {
+		return ErrInvalidRecommendationWeights
+	}
+	return nil
+}
+
+func (w RecommendationWeights) sum() float64 {
+	return w.Distance + w.Availability + w.Price + w.Rating + w.Open
+}
+
+// ParseRecommendationWeights reads weights written as "distance=0.5,price=0.2". Criteria left
+// out keep their weight in base.
+func ParseRecommendationWeights(value string, base RecommendationWeights) (RecommendationWeights, error) {
+	weights := base
+	fields := map[string]*float64{
+		"distance":     &weights.Distance,
+		"availability": &weights.Availability,
+		"price":        &weights.Price,
+		"rating":       &weights.Rating,
+		"open":         &weights.Open,
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		name, raw, found := strings.Cut(pair, "=")
+		field, known := fields[strings.TrimSpace(name)]
+		if !found || !known {
+			return base, fmt.Errorf("%w: unknown criterion in %q", ErrInvalidRecommendationWeights, pair)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return base, fmt.Errorf("%w: invalid number in %q", ErrInvalidRecommendationWeights, pair)
+		}
+		*field = weight
+	}
+
+	if err := weights.Validate(); err != nil {
+		return base, err
+	}
+	return weights, nil
+}
+
+type IRecommendationUseCase interface {
+	Recommend(req RecommendationRequest) ([]RecommendationResponse, error)
+}
+
+type RecommendationUseCase struct {
+	ParkingLotRepository repository.IParkingLotRepository
+	SensorRepository     repository.ISensorRepository
+	ReviewRepository     repository.IReviewRepository
+	ForecastUseCase      IForecastUseCase
+	weights              RecommendationWeights
+	now                  func() time.Time
+}
+
+// RecommendationRequest describes where and when the driver is going. VehicleType selects the
+// tariff and Spots the spots that count as available. Weights replaces the configured weights.
+type RecommendationRequest struct {
+	DestinationLatitude  float64
+	DestinationLongitude float64
+	ArriveAt             *time.Time
+	Duration             time.Duration
+	VehicleType          string
+	Spots                *domain.SpotFilter
+	MaxWalkingKm         float64
+	Weights              *RecommendationWeights
+}
+
+// ScoreComponent is the contribution of a criterion to the score of a lot: Score is in [0, 1]
+// and Contribution is Score times the normalized Weight.
+type ScoreComponent struct {
+	Score        float64 `json:"score"`
+	Weight       float64 `json:"weight"`
+	Contribution float64 `json:"contribution"`
+}
+
+type ScoreBreakdown struct {
+	Distance     ScoreComponent `json:"distance"`
+	Availability ScoreComponent `json:"availability"`
+	Price        ScoreComponent `json:"price"`
+	Rating       ScoreComponent `json:"rating"`
+	Open         ScoreComponent `json:"open"`
+}
+
+type RecommendationResponse struct {
+	ParkingLotID        uint                  `json:"parking_lot_id"`
+	Name                string                `json:"name"`
+	Address             string                `json:"address"`
+	Latitude            float64               `json:"latitude"`
+	Longitude           float64               `json:"longitude"`
+	WalkingDistanceKm   float64               `json:"walking_distance_km"`
+	WalkingMinutes      int                   `json:"walking_minutes"`
+	PredictedFreeSpaces *float64              `json:"predicted_free_spaces,omitempty"`
+	EstimatedCost       *uint                 `json:"estimated_cost,omitempty"`
+	Rating              *domain.RatingSummary `json:"rating,omitempty"`
+	Open                bool                  `json:"open"`
+	Score               float64               `json:"score"`
+	Breakdown           ScoreBreakdown        `json:"breakdown"`
+}
+
+// NewRecommendationUseCase creates a new instance of RecommendationUseCase scoring lots with weights.
+func NewRecommendationUseCase(parkingLotRepo repository.IParkingLotRepository, sensorRepo repository.ISensorRepository, reviewRepo repository.IReviewRepository, forecastUseCase IForecastUseCase, weights RecommendationWeights) IRecommendationUseCase {
+	return &RecommendationUseCase{
+		ParkingLotRepository: parkingLotRepo,
+		SensorRepository:     sensorRepo,
+		ReviewRepository:     reviewRepo,
+		ForecastUseCase:      forecastUseCase,
+		weights:              weights,
+		now:                  time.Now,
+	}
+}
+
+// Recommend ranks the lots within walking distance of the destination by a weighted score of
+// walking distance, predicted availability at arrival, price of the stay, rating and whether
+// the lot is open at arrival. Lots without a single spot for the vehicle are left out.
+func (uc *RecommendationUseCase) Recommend(req RecommendationRequest) ([]RecommendationResponse, error) {
+	maxWalking := req.MaxWalkingKm
+	if maxWalking <= 0 {
+		maxWalking = DefaultMaxWalkingKm
+	}
+	duration := req.Duration
+	if duration <= 0 {
+		duration = DefaultStayDuration
+	}
+	arriveAt := uc.now()
+	if req.ArriveAt != nil {
+		arriveAt = *req.ArriveAt
+	}
+
+	parkingLots, err := uc.ParkingLotRepository.ListPublic()
+	if err != nil {
+		return nil, err
+	}
+	ratingMap, err := uc.ReviewRepository.ListRatingSummaries()
+	if err != nil {
+		return nil, err
+	}
+
+	response := []RecommendationResponse{}
+	var costs []uint
+	for _, lot := range parkingLots {
+		distance := haversineKm(req.DestinationLatitude, req.DestinationLongitude, lot.Latitude, lot.Longitude)
+		if distance > maxWalking {
+			continue
+		}
+
+		predicted, known, err := uc.predictFreeSpaces(lot.ID, arriveAt, req.Spots)
+		if err != nil {
+			return nil, err
+		}
+		if known && predicted == nil {
+			continue
+		}
+
+		item := RecommendationResponse{
+			ParkingLotID:        lot.ID,
+			Name:                lot.Name,
+			Address:             lot.Address,
+			Latitude:            lot.Latitude,
+			Longitude:           lot.Longitude,
+			WalkingDistanceKm:   roundTo(distance, 3),
+			WalkingMinutes:      int(math.Ceil(distance / walkingSpeedKmh * 60)),
+			PredictedFreeSpaces: predicted,
+			Open:                lotOpenAt(lot, arriveAt),
+		}
+		if rate := lot.HourlyRateFor(req.VehicleType); rate > 0 {
+			cost := sessionCost(rate, lot.BillingFractionMinutes, duration)
+			item.EstimatedCost = &cost
+			costs = append(costs, cost)
+		}
+		if rating, ok := ratingMap[lot.ID]; ok && rating.Reviews > 0 {
+			item.Rating = &rating
+		}
+
+		item.Breakdown.Distance.Score = 1 - distance/maxWalking
+		item.Breakdown.Availability.Score = neutralScore
+		if predicted != nil {
+			item.Breakdown.Availability.Score = 1 - math.Pow(0.5, *predicted/availabilityHalfSaturation)
+		}
+		item.Breakdown.Rating.Score = neutralScore
+		if item.Rating != nil {
+			item.Breakdown.Rating.Score = item.Rating.Overall / 5
+		}
+		if item.Open {
+			item.Breakdown.Open.Score = 1
+		}
+
+		response = append(response, item)
+	}
+
+	weights := uc.weights
+	if req.Weights != nil {
+		weights = *req.Weights
+	}
+	minCost, maxCost := costRange(costs)
+	for i := range response {
+		item := &response[i]
+		item.Breakdown.Price.Score = neutralScore
+		if item.EstimatedCost != nil {
+			item.Breakdown.Price.Score = 1
+			if maxCost > minCost {
+				item.Breakdown.Price.Score = float64(maxCost-*item.EstimatedCost) / float64(maxCost-minCost)
+			}
+		}
+		item.Score = applyWeights(&item.Breakdown, weights)
+	}
+
+	sort.SliceStable(response, func(i, j int) bool {
+		if response[i].Score != response[j].Score {
+			return response[i].Score > response[j].Score
+		}
+		return response[i].WalkingDistanceKm < response[j].WalkingDistanceKm
+	})
+	return response, nil
+}
+
+// predictFreeSpaces forecasts the free spaces of the lot at arrival. With a spot filter only the
+// matching share of the lot counts; predicted is nil when no spot matches. known is false for
+// lots without sensors, whose availability cannot be predicted.
+func (uc *RecommendationUseCase) predictFreeSpaces(parkingLotID uint, at time.Time, spots *domain.SpotFilter) (predicted *float64, known bool, err error) {
+	forecast, err := uc.ForecastUseCase.ForecastAvailability(parkingLotID, at)
+	if err != nil {
+		return nil, false, err
+	}
+	if forecast.TotalSpaces == 0 {
+		return nil, false, nil
+	}
+
+	share := 1.0
+	if spots != nil {
+		sensors, err := uc.SensorRepository.ListByParkingLot(parkingLotID)
+		if err != nil {
+			return nil, false, err
+		}
+		var matching int
+		for _, sensor := range sensors {
+			if spots.Matches(sensor) {
+				matching++
+			}
+		}
+		if matching == 0 {
+			return nil, true, nil
+		}
+		share = float64(matching) / float64(len(sensors))
+	}
+
+	expected := roundTo(forecast.ExpectedFreeSpaces*share, 2)
+	return &expected, true, nil
+}
+
+// applyWeights fills the weights and contributions of the breakdown and returns the score.
+func applyWeights(breakdown *ScoreBreakdown, weights RecommendationWeights) float64 {
+	total := weights.sum()
+	components := []struct {
+		component *ScoreComponent
+		weight    float64
+	}{
+		{&breakdown.Distance, weights.Distance},
+		{&breakdown.Availability, weights.Availability},
+		{&breakdown.Price, weights.Price},
+		{&breakdown.Rating, weights.Rating},
+		{&breakdown.Open, weights.Open},
+	}
+
+	var score float64
+	for _, c := range components {
+		weight := c.weight / total
+		contribution := c.component.Score * weight
+		score += contribution
+		c.component.Score = roundTo(c.component.Score, 3)
+		c.component.Weight = roundTo(weight, 3)
+		c.component.Contribution = roundTo(contribution, 3)
+	}
+	return roundTo(score, 3)
+}
+
+func costRange(costs []uint) (uint, uint) {
+	if len(costs) == 0 {
+		return 0, 0
+	}
+	minCost, maxCost := costs[0], costs[0]
+	for _, cost := range costs[1:] {
+		minCost = min(minCost, cost)
+		maxCost = max(maxCost, cost)
+	}
+	return minCost, maxCost
+}
+
+// validOpeningHours accepts either no opening hours or two different HH:MM times.
+func validOpeningHours(opensAt, closesAt string) bool {
+	if opensAt == "" && closesAt == "" {
+		return true
+	}
+	opens, errOpens := parseMinuteOfDay(opensAt)
+	closes, errCloses := parseMinuteOfDay(closesAt)
+	return errOpens == nil && errCloses == nil && opens != closes
+}
+
+// lotOpenAt reports whether the lot is open at the instant in Bogotá time. Closing before
+// opening means the lot stays open past midnight.
+func lotOpenAt(lot domain.ParkingLot, at time.Time) bool {
+	if lot.OpensAt == "" || lot.ClosesAt == "" {
+		return true
+	}
+	opens, errOpens := parseMinuteOfDay(lot.OpensAt)
+	closes, errCloses := parseMinuteOfDay(lot.ClosesAt)
+	if errOpens != nil || errCloses != nil {
+		return true
+	}
+
+	local := at.In(helpers.BogotaLocation)
+	minute := local.Hour()*60 + local.Minute()
+	if opens < closes {
+		return minute >= opens && minute < closes
+	}
+	return minute >= opens || minute < closes
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// recommendationArrival is in the past, so forecasts use the current state of the sensors.
+var recommendationArrival = time.Date(2024, time.September, 10, 13, 0, 0, 0, time.UTC)
+
+func setupRecommendationTest(t *testing.T) (*gomock.Controller, *mockgen.MockIParkingLotRepository, *mockgen.MockISensorRepository, *mockgen.MockIReviewRepository, IRecommendationUseCase) {
+	ctrl := gomock.NewController(t)
+	parkingLotRepo := mockgen.NewMockIParkingLotRepository(ctrl)
+	sensorRepo := mockgen.NewMockISensorRepository(ctrl)
+	reviewRepo := mockgen.NewMockIReviewRepository(ctrl)
+	forecastUseCase := NewForecastUseCase(parkingLotRepo, sensorRepo, mockgen.NewMockIOccupancySampleRepository(ctrl), mockgen.NewMockIReservationRepository(ctrl))
+	useCase := NewRecommendationUseCase(parkingLotRepo, sensorRepo, reviewRepo, forecastUseCase, DefaultRecommendationWeights)
+	return ctrl, parkingLotRepo, sensorRepo, reviewRepo, useCase
+}
+
+func sensorsWithStatus(parkingLotID uint, spotType string, statuses ...string) []domain.Sensor {
+	sensors := make([]domain.Sensor, len(statuses))
+	for i, status := range statuses {
+		sensors[i] = domain.Sensor{ID: uint(i + 1), ParkingLotID: parkingLotID, Status: status, SpotType: spotType}
+	}
+	return sensors
+}
+
+func TestRecommendRanksLotsWithScoreBreakdown(t *testing.T) {
+	ctrl, parkingLotRepo, sensorRepo, reviewRepo, useCase := setupRecommendationTest(t)
+	defer ctrl.Finish()
+
+	free, occupied := domain.SensorStatusFree, domain.SensorStatusOccupied
+	lots := []domain.ParkingLot{
+		{ID: 1, Name: "Near but busy", Latitude: 4.652, Longitude: -74.05, HourlyRate: 6000, BillingFractionMinutes: 15},
+		{ID: 2, Name: "Farther and free", Latitude: 4.661, Longitude: -74.05, HourlyRate: 3000, BillingFractionMinutes: 15},
+		{ID: 3, Name: "Too far", Latitude: 4.68, Longitude: -74.05, HourlyRate: 1000},
+		{ID: 4, Name: "Closed", Latitude: 4.651, Longitude: -74.05, OpensAt: "06:00", ClosesAt: "07:00"},
+	}
+	parkingLotRepo.EXPECT().ListPublic().Return(lots, nil)
+	reviewRepo.EXPECT().ListRatingSummaries().Return(map[uint]domain.RatingSummary{2: {Reviews: 3, Overall: 4.5}}, nil)
+	for _, lot := range []domain.ParkingLot{lots[0], lots[1], lots[3]} {
+		parkingLotRepo.EXPECT().GetPublicByID(lot.ID).Return(&lot, nil)
+	}
+	sensorRepo.EXPECT().ListByParkingLot(uint(1)).Return(sensorsWithStatus(1, domain.SpotTypeCar, occupied, occupied, occupied, free), nil)
+	sensorRepo.EXPECT().ListByParkingLot(uint(2)).Return(sensorsWithStatus(2, domain.SpotTypeCar, free, free, free, free, free, free), nil)
+	sensorRepo.EXPECT().ListByParkingLot(uint(4)).Return(nil, nil)
+
+	recommendations, err := useCase.Recommend(RecommendationRequest{
+		DestinationLatitude:  4.65,
+		DestinationLongitude: -74.05,
+		ArriveAt:             &recommendationArrival,
+		Duration:             90 * time.Minute,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, recommendations, 3)
+	assert.Equal(t, []uint{2, 1, 4}, []uint{recommendations[0].ParkingLotID, recommendations[1].ParkingLotID, recommendations[2].ParkingLotID})
+
+	best := recommendations[0]
+	assert.Equal(t, uint(4500), *best.EstimatedCost)
+	assert.Equal(t, 6.0, *best.PredictedFreeSpaces)
+	assert.Equal(t, 1.0, best.Breakdown.Price.Score)
+	assert.Equal(t, 0.9, best.Breakdown.Rating.Score)
+	assert.True(t, best.Open)
+	breakdown := best.Breakdown
+	assert.InDelta(t, best.Score, breakdown.Distance.Contribution+breakdown.Availability.Contribution+breakdown.Price.Contribution+breakdown.Rating.Contribution+breakdown.Open.Contribution, 0.002)
+
+	closed := recommendations[2]
+	assert.False(t, closed.Open)
+	assert.Nil(t, closed.PredictedFreeSpaces)
+	assert.Equal(t, neutralScore, closed.Breakdown.Availability.Score)
+	assert.Equal(t, 0.0, closed.Breakdown.Open.Score)
+}
+
+func TestRecommendOnlyCountsSpotsOfTheVehicle(t *testing.T) {
+	ctrl, parkingLotRepo, sensorRepo, reviewRepo, useCase := setupRecommendationTest(t)
+	defer ctrl.Finish()
+
+	free := domain.SensorStatusFree
+	lots := []domain.ParkingLot{
+		{ID: 1, Latitude: 4.651, Longitude: -74.05, HourlyRate: 4000, MotorcycleHourlyRate: 1200},
+		{ID: 2, Latitude: 4.652, Longitude: -74.05, HourlyRate: 4000},
+	}
+	parkingLotRepo.EXPECT().ListPublic().Return(lots, nil)
+	reviewRepo.EXPECT().ListRatingSummaries().Return(map[uint]domain.RatingSummary{}, nil)
+	parkingLotRepo.EXPECT().GetPublicByID(uint(1)).Return(&lots[0], nil)
+	parkingLotRepo.EXPECT().GetPublicByID(uint(2)).Return(&lots[1], nil)
+	lotOneSensors := append(sensorsWithStatus(1, domain.SpotTypeCar, free, free), sensorsWithStatus(1, domain.SpotTypeMotorcycle, free, free)...)
+	sensorRepo.EXPECT().ListByParkingLot(uint(1)).Return(lotOneSensors, nil).Times(2)
+	sensorRepo.EXPECT().ListByParkingLot(uint(2)).Return(sensorsWithStatus(2, domain.SpotTypeCar, free, free), nil).Times(2)
+
+	spots := domain.Vehicle{Type: domain.VehicleTypeMotorcycle}.SpotFilter()
+	recommendations, err := useCase.Recommend(RecommendationRequest{
+		DestinationLatitude:  4.65,
+		DestinationLongitude: -74.05,
+		ArriveAt:             &recommendationArrival,
+		VehicleType:          domain.VehicleTypeMotorcycle,
+		Spots:                &spots,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, recommendations, 1)
+	assert.Equal(t, uint(1), recommendations[0].ParkingLotID)
+	assert.Equal(t, 2.0, *recommendations[0].PredictedFreeSpaces)
+	assert.Equal(t, uint(1200), *recommendations[0].EstimatedCost)
+}
+
+func TestParseRecommendationWeights(t *testing.T) {
+	weights, err := ParseRecommendationWeights("price=0.6, distance=0.1", DefaultRecommendationWeights)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.6, weights.Price)
+	assert.Equal(t, 0.1, weights.Distance)
+	assert.Equal(t, DefaultRecommendationWeights.Rating, weights.Rating)
+
+	_, err = ParseRecommendationWeights("speed=1", DefaultRecommendationWeights)
+	assert.ErrorIs(t, err, ErrInvalidRecommendationWeights)
+	_, err = ParseRecommendationWeights("distance=0,availability=0,price=0,rating=0,open=0", DefaultRecommendationWeights)
+	assert.ErrorIs(t, err, ErrInvalidRecommendationWeights)
+}
+
+func TestLotOpenAtHandlesOvernightHours(t *testing.T) {
+	night := domain.ParkingLot{OpensAt: "18:00", ClosesAt: "02:00"}
+	// 23:30 and 08:00 in Bogotá (UTC-5).
+	assert.True(t, lotOpenAt(night, time.Date(2024, time.September, 11, 4, 30, 0, 0, time.UTC)))
+	assert.False(t, lotOpenAt(night, time.Date(2024, time.September, 11, 13, 0, 0, 0, time.UTC)))
+	assert.True(t, lotOpenAt(domain.ParkingLot{}, time.Date(2024, time.September, 11, 13, 0, 0, 0, time.UTC)))
+}
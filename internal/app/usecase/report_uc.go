@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/report"
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+)
+
+type IReportUseCase interface {
+	ExportOccupancy(adminUUID string, period ReportPeriod, w report.Writer) error
+	ExportDevices(adminUUID string, period ReportPeriod, w report.Writer) error
+}
+
+type ReportUseCase struct {
+	AdminRepository           repository.IAdminRepository
+	ParkingLotRepository      repository.IParkingLotRepository
+	SensorRepository          repository.ISensorRepository
+	Esp32DeviceRepository     repository.IEsp32DeviceRepository
+	OccupancySampleRepository repository.IOccupancySampleRepository
+}
+
+// ReportPeriod is the half-open interval [From, To) covered by a report.
+type ReportPeriod struct {
+	From time.Time
+	To   time.Time
+}
+
+// NewReportUseCase creates a new instance of ReportUseCase.
+func NewReportUseCase(adminRepo repository.IAdminRepository, parkingLotRepo repository.IParkingLotRepository, sensorRepo repository.ISensorRepository, esp32DeviceRepo repository.IEsp32DeviceRepository, sampleRepo repository.IOccupancySampleRepository) IReportUseCase {
+	return &ReportUseCase{
+		AdminRepository:           adminRepo,
+		ParkingLotRepository:      parkingLotRepo,
+		SensorRepository:          sensorRepo,
+		Esp32DeviceRepository:     esp32DeviceRepo,
+		OccupancySampleRepository: sampleRepo,
+	}
+}
+
+// CurrentMonthPeriod returns the calendar month containing t, in Bogota time.
+func CurrentMonthPeriod(t time.Time) ReportPeriod {
+	local := t.In(helpers.BogotaLocation)
+	from := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, helpers.BogotaLocation)
+	return ReportPeriod{From: from, To: from.AddDate(0, 1, 0)}
+}
+
+// ExportOccupancy writes one sheet per parking lot of the admin with every occupancy sample
+// recorded in the period. Timestamps are expressed in America/Bogota.
+func (uc *ReportUseCase) ExportOccupancy(adminUUID string, period ReportPeriod, w report.Writer) error {
+	admin, parkingLots, err := uc.adminParkingLots(adminUUID)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"parking_lot_id", "parking_lot", "recorded_at", "free_spaces", "total_spaces", "occupancy_pct"}
+	for _, lot := range parkingLots {
+		if err := w.StartSheet(lot.Name, headers); err != nil {
+			return err
+		}
+
+		err := uc.OccupancySampleRepository.StreamByParkingLotBetweenForMember(lot.ID, admin.ID, period.From, period.To, func(sample domain.OccupancySample) error {
+			var occupancy float64
+			if sample.TotalSpaces > 0 {
+				occupancy = roundTo(100*float64(sample.TotalSpaces-sample.FreeSpaces)/float64(sample.TotalSpaces), 2)
+			}
+			return w.WriteRow(lot.ID, lot.Name, sample.RecordedAt.In(helpers.BogotaLocation),
+				sample.FreeSpaces, sample.TotalSpaces, occupancy)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.Close()
+}
+
+// ExportDevices writes one sheet per parking lot of the admin listing its ESP32 devices, the
+// state of their sensors and whether they reported during the period.
+func (uc *ReportUseCase) ExportDevices(adminUUID string, period ReportPeriod, w report.Writer) error {
+	admin, parkingLots, err := uc.adminParkingLots(adminUUID)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"parking_lot_id", "parking_lot", "device_identifier", "sensors", "free_sensors", "last_communication", "reported_in_period"}
+	for _, lot := range parkingLots {
+		if err := w.StartSheet(lot.Name, headers); err != nil {
+			return err
+		}
+
+		sensors, err := uc.SensorRepository.ListByParkingLotForMember(lot.ID, admin.ID)
+		if err != nil {
+			return err
+		}
+
+		byDevice := make(map[uint][]domain.Sensor)
+		var deviceIDs []uint
+		for _, sensor := range sensors {
+			if _, ok := byDevice[sensor.Esp32DeviceID]; !ok {
+				deviceIDs = append(deviceIDs, sensor.Esp32DeviceID)
+			}
+			byDevice[sensor.Esp32DeviceID] = append(byDevice[sensor.Esp32DeviceID], sensor)
+		}
+		sort.Slice(deviceIDs, func(i, j int) bool { return deviceIDs[i] < deviceIDs[j] })
+
+		for _, deviceID := range deviceIDs {
+			device, err := uc.Esp32DeviceRepository.GetByID(uint64(deviceID))
+			if err != nil {
+				return err
+			}
+			if device == nil {
+				return fmt.Errorf("esp32 device %d not found", deviceID)
+			}
+
+			var lastCommunication time.Time
+			if !device.LastCommunication.IsZero() {
+				lastCommunication = device.LastCommunication.In(helpers.BogotaLocation)
+			}
+			reported := !device.LastCommunication.Before(period.From) && device.LastCommunication.Before(period.To)
+
+			deviceSensors := byDevice[deviceID]
+			if err := w.WriteRow(lot.ID, lot.Name, device.DeviceIdentifier, len(deviceSensors),
+				countAvailableSpaces(deviceSensors), lastCommunication, reported); err != nil {
+				return err
+			}
+		}
+	}
+
+	return w.Close()
+}
+
+func (uc *ReportUseCase) adminParkingLots(adminUUID string) (*domain.Admin, []domain.ParkingLot, error) {
+	admin, err := uc.AdminRepository.FindByAuth0UUID(adminUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+	parkingLots, err := uc.ParkingLotRepository.FindByMember(admin.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return admin, parkingLots, nil
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/report"
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type reportMocks struct {
+	admins      *mockgen.MockIAdminRepository
+	parkingLots *mockgen.MockIParkingLotRepository
+	sensors     *mockgen.MockISensorRepository
+	devices     *mockgen.MockIEsp32DeviceRepository
+	samples     *mockgen.MockIOccupancySampleRepository
+}
+
+func newTestReportUseCase(ctrl *gomock.Controller) (IReportUseCase, reportMocks) {
+	mocks := reportMocks{
+		admins:      mockgen.NewMockIAdminRepository(ctrl),
+		parkingLots: mockgen.NewMockIParkingLotRepository(ctrl),
+		sensors:     mockgen.NewMockISensorRepository(ctrl),
+		devices:     mockgen.NewMockIEsp32DeviceRepository(ctrl),
+		samples:     mockgen.NewMockIOccupancySampleRepository(ctrl),
+	}
+	mocks.admins.EXPECT().FindByAuth0UUID("operator").Return(&domain.Admin{ID: 7}, nil)
+	mocks.parkingLots.EXPECT().FindByMember(uint(7)).Return([]domain.ParkingLot{{ID: 3, Name: "Norte"}}, nil)
+	return NewReportUseCase(mocks.admins, mocks.parkingLots, mocks.sensors, mocks.devices, mocks.samples), mocks
+}
+
+func TestCurrentMonthPeriodFollowsTheBogotaCalendar(t *testing.T) {
+	// 03:00 UTC on October 1st is still September 30th in Bogota.
+	period := CurrentMonthPeriod(time.Date(2024, time.October, 1, 3, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, time.Date(2024, time.September, 1, 0, 0, 0, 0, helpers.BogotaLocation), period.From)
+	assert.Equal(t, time.Date(2024, time.October, 1, 0, 0, 0, 0, helpers.BogotaLocation), period.To)
+}
+
+func TestExportOccupancyWritesTheSamplesOfThePeriodInBogotaTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase, mocks := newTestReportUseCase(ctrl)
+	period := CurrentMonthPeriod(time.Date(2024, time.September, 15, 12, 0, 0, 0, helpers.BogotaLocation))
+
+	mocks.samples.EXPECT().StreamByParkingLotBetweenForMember(uint(3), uint(7), period.From, period.To, gomock.Any()).
+		DoAndReturn(func(_ uint, _ uint, _, _ time.Time, fn func(domain.OccupancySample) error) error {
+			if err := fn(domain.OccupancySample{ParkingLotID: 3, FreeSpaces: 1, TotalSpaces: 3, RecordedAt: period.From.UTC()}); err != nil {
+				return err
+			}
+			return fn(domain.OccupancySample{ParkingLotID: 3, FreeSpaces: 0, TotalSpaces: 0, RecordedAt: period.To.Add(-time.Second).UTC()})
+		})
+
+	var buf bytes.Buffer
+	assert.NoError(t, useCase.ExportOccupancy("operator", period, report.NewCSVWriter(&buf)))
+	assert.Equal(t, "parking_lot_id,parking_lot,recorded_at,free_spaces,total_spaces,occupancy_pct\n"+
+		"3,Norte,2024-09-01T00:00:00-05:00,1,3,66.67\n"+
+		"3,Norte,2024-09-30T23:59:59-05:00,0,0,0\n", buf.String())
+}
+
+func TestExportDevicesReportsCommunicationWithinTheHalfOpenPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase, mocks := newTestReportUseCase(ctrl)
+	period := CurrentMonthPeriod(time.Date(2024, time.September, 15, 12, 0, 0, 0, helpers.BogotaLocation))
+
+	mocks.sensors.EXPECT().ListByParkingLotForMember(uint(3), uint(7)).Return([]domain.Sensor{
+		{ID: 1, Esp32DeviceID: 6, Status: domain.SensorStatusOccupied},
+		{ID: 2, Esp32DeviceID: 5, Status: domain.SensorStatusFree},
+		{ID: 3, Esp32DeviceID: 5, Status: domain.SensorStatusOccupied},
+		{ID: 4, Esp32DeviceID: 8, Status: domain.SensorStatusFree},
+	}, nil)
+	mocks.devices.EXPECT().GetByID(uint64(5)).Return(&domain.Esp32Device{ID: 5, DeviceIdentifier: "esp-5", LastCommunication: period.From}, nil)
+	mocks.devices.EXPECT().GetByID(uint64(6)).Return(&domain.Esp32Device{ID: 6, DeviceIdentifier: "esp-6", LastCommunication: period.To}, nil)
+	mocks.devices.EXPECT().GetByID(uint64(8)).Return(&domain.Esp32Device{ID: 8, DeviceIdentifier: "esp-8"}, nil)
+
+	var buf bytes.Buffer
+	assert.NoError(t, useCase.ExportDevices("operator", period, report.NewCSVWriter(&buf)))
+	assert.Equal(t, "parking_lot_id,parking_lot,device_identifier,sensors,free_sensors,last_communication,reported_in_period\n"+
+		"3,Norte,esp-5,2,1,2024-09-01T00:00:00-05:00,true\n"+
+		"3,Norte,esp-6,1,0,2024-10-01T00:00:00-05:00,false\n"+
+		"3,Norte,esp-8,1,1,,false\n", buf.String())
+}
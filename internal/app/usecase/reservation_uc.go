@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultReservationHold = 15 * time.Minute
+	MaxReservationHold     = 30 * time.Minute
+)
+
+var (
+	ErrNoFreeSpots            = errors.New("no free spots to reserve")
+	ErrSpotNotFree            = errors.New("spot is not free")
+	ErrInvalidHoldWindow      = errors.New("hold_minutes must be between 1 and 30")
+	ErrReservationNotHeld     = errors.New("reservation is no longer held")
+	ErrTooManyReservableSpots = errors.New("reservable spots exceed the spots of the parking lot")
+)
+
+type IReservationUseCase interface {
+	Reserve(userID uint, req CreateReservationRequest) (*domain.Reservation, error)
+	Cancel(userID uint, reservationID uint) error
+	ListByUser(userID uint) ([]domain.Reservation, error)
+	ListByParkingLot(parkingLotID uint) ([]domain.Reservation, error)
+	SetReservableSpots(parkingLotID uint, spots uint) error
+	ExpireHolds() (int64, error)
+	OnSensorChange(change SensorChange)
+}
+
+type ReservationUseCase struct {
+	ReservationRepository repository.IReservationRepository
+	ParkingLotRepository  repository.IParkingLotRepository
+	SensorRepository      repository.ISensorRepository
+	now                   func() time.Time
+}
+
+type CreateReservationRequest struct {
+	ParkingLotID uint `json:"parking_lot_id" binding:"required"`
+	// SensorID selects a specific spot; when empty the first free spot is held.
+	SensorID    uint `json:"sensor_id"`
+	HoldMinutes int  `json:"hold_minutes"`
+}
+
+// NewReservationUseCase creates a new instance of ReservationUseCase.
+func NewReservationUseCase(reservationRepo repository.IReservationRepository, parkingLotRepo repository.IParkingLotRepository, sensorRepo repository.ISensorRepository) IReservationUseCase {
+	return &ReservationUseCase{
+		ReservationRepository: reservationRepo,
+		ParkingLotRepository:  parkingLotRepo,
+		SensorRepository:      sensorRepo,
+		now:                   time.Now,
+	}
+}
+
+// Reserve holds a free spot of the parking lot for the driver. The lot's reservable spots are
+// enforced by the repository inside a transaction, so concurrent requests cannot overbook it.
+func (uc *ReservationUseCase) Reserve(userID uint, req CreateReservationRequest) (*domain.Reservation, error) {
+	hold := DefaultReservationHold
+	if req.HoldMinutes != 0 {
+		hold = time.Duration(req.HoldMinutes) * time.Minute
+		if hold < time.Minute || hold > MaxReservationHold {
+			return nil, ErrInvalidHoldWindow
+		}
+	}
+
+	parkingLot, err := uc.ParkingLotRepository.GetByID(req.ParkingLotID)
+	if err != nil {
+		return nil, err
+	}
+	if parkingLot.ReservableSpots == 0 {
+		return nil, domain.ErrNoReservableSpots
+	}
+
+	now := uc.now()
+	candidates, err := uc.candidateSpots(req, now)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sensor := range candidates {
+		reservation := &domain.Reservation{
+			UserID:       userID,
+			ParkingLotID: req.ParkingLotID,
+			SensorID:     sensor.ID,
+			Status:       domain.ReservationStatusHeld,
+			HeldUntil:    now.Add(hold),
+		}
+		err := uc.ReservationRepository.CreateHold(reservation, now)
+		if err == nil {
+			return reservation, nil
+		}
+		// Another driver took this spot in the meantime, try the next one.
+		if errors.Is(err, domain.ErrSpotAlreadyReserved) && req.SensorID == 0 {
+			continue
+		}
+		return nil, err
+	}
+
+	return nil, ErrNoFreeSpots
+}
+
+// candidateSpots lists the free spots not already held, ordered by sensor number, or only the
+// requested spot.
+func (uc *ReservationUseCase) candidateSpots(req CreateReservationRequest, now time.Time) ([]domain.Sensor, error) {
+	sensors, err := uc.SensorRepository.ListByParkingLot(req.ParkingLotID)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := uc.ReservationRepository.ListActiveByParkingLot(req.ParkingLotID, now)
+	if err != nil {
+		return nil, err
+	}
+	held := make(map[uint]bool)
+	for _, reservation := range active {
+		held[reservation.SensorID] = true
+	}
+
+	if req.SensorID != 0 {
+		for _, sensor := range sensors {
+			if sensor.ID != req.SensorID {
+				continue
+			}
+			if held[sensor.ID] {
+				return nil, domain.ErrSpotAlreadyReserved
+			}
+			if sensor.Status != domain.SensorStatusFree {
+				return nil, ErrSpotNotFree
+			}
+			return []domain.Sensor{sensor}, nil
+		}
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	var candidates []domain.Sensor
+	for _, sensor := range sensors {
+		if sensor.Status == domain.SensorStatusFree && !held[sensor.ID] {
+			candidates = append(candidates, sensor)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].SensorNumber < candidates[j].SensorNumber
+	})
+	return candidates, nil
+}
+
+// Cancel releases a held reservation of the driver.
+func (uc *ReservationUseCase) Cancel(userID uint, reservationID uint) error {
+	reservation, err := uc.ReservationRepository.GetByID(reservationID)
+	if err != nil {
+		return err
+	}
+	if reservation.UserID != userID {
+		return gorm.ErrRecordNotFound
+	}
+	if reservation.Status != domain.ReservationStatusHeld || !uc.now().Before(reservation.HeldUntil) {
+		return ErrReservationNotHeld
+	}
+
+	reservation.Status = domain.ReservationStatusCancelled
+	return uc.ReservationRepository.Update(reservation)
+}
+
+// ListByUser retrieves the reservations of the driver, newest first.
+func (uc *ReservationUseCase) ListByUser(userID uint) ([]domain.Reservation, error) {
+	return uc.ReservationRepository.ListByUser(userID)
+}
+
+// ListByParkingLot retrieves the reservations of a parking lot, newest first.
+func (uc *ReservationUseCase) ListByParkingLot(parkingLotID uint) ([]domain.Reservation, error) {
+	return uc.ReservationRepository.ListByParkingLot(parkingLotID)
+}
+
+// SetReservableSpots configures how many spots of the lot can be held at the same time. Holds
+// already granted are kept when the limit is lowered.
+func (uc *ReservationUseCase) SetReservableSpots(parkingLotID uint, spots uint) error {
+	parkingLot, err := uc.ParkingLotRepository.GetByID(parkingLotID)
+	if err != nil {
+		return err
+	}
+
+	sensors, err := uc.SensorRepository.ListByParkingLot(parkingLotID)
+	if err != nil {
+		return err
+	}
+	if spots > uint(len(sensors)) {
+		return ErrTooManyReservableSpots
+	}
+
+	parkingLot.ReservableSpots = spots
+	return uc.ParkingLotRepository.Update(parkingLot)
+}
+
+// ExpireHolds marks as expired the holds of drivers that did not arrive in time.
+func (uc *ReservationUseCase) ExpireHolds() (int64, error) {
+	return uc.ReservationRepository.ExpireHolds(uc.now())
+}
+
+// OnSensorChange confirms the hold on a spot when its sensor reports it occupied.
+func (uc *ReservationUseCase) OnSensorChange(change SensorChange) {
+	if change.Sensor.Status != domain.SensorStatusOccupied || change.PreviousStatus == domain.SensorStatusOccupied {
+		return
+	}
+
+	reservation, err := uc.ReservationRepository.FindActiveBySensor(change.Sensor.ID, change.OccurredAt)
+	if err != nil {
+		log.Println("Error loading reservation of sensor:", err)
+		return
+	}
+	if reservation == nil {
+		return
+	}
+
+	confirmedAt := change.OccurredAt
+	reservation.Status = domain.ReservationStatusConfirmed
+	reservation.ConfirmedAt = &confirmedAt
+	if err := uc.ReservationRepository.Update(reservation); err != nil {
+		log.Println("Error confirming reservation:", err)
+	}
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupReservationTest(t *testing.T, now time.Time) (*gomock.Controller, *mockgen.MockIReservationRepository, *mockgen.MockIParkingLotRepository, *mockgen.MockISensorRepository, *ReservationUseCase) {
+	ctrl := gomock.NewController(t)
+	reservationRepo := mockgen.NewMockIReservationRepository(ctrl)
+	parkingLotRepo := mockgen.NewMockIParkingLotRepository(ctrl)
+	sensorRepo := mockgen.NewMockISensorRepository(ctrl)
+	useCase := NewReservationUseCase(reservationRepo, parkingLotRepo, sensorRepo).(*ReservationUseCase)
+	useCase.now = func() time.Time { return now }
+	return ctrl, reservationRepo, parkingLotRepo, sensorRepo, useCase
+}
+
+func TestReserveHoldsFirstFreeUnheldSpot(t *testing.T) {
+	now := time.Date(2024, time.September, 10, 8, 0, 0, 0, time.UTC)
+	ctrl, reservationRepo, parkingLotRepo, sensorRepo, useCase := setupReservationTest(t, now)
+	defer ctrl.Finish()
+
+	parkingLotRepo.EXPECT().GetByID(uint(1)).Return(&domain.ParkingLot{ID: 1, ReservableSpots: 2}, nil)
+	sensorRepo.EXPECT().ListByParkingLot(uint(1)).Return([]domain.Sensor{
+		{ID: 10, SensorNumber: 3, Status: domain.SensorStatusFree},
+		{ID: 11, SensorNumber: 1, Status: domain.SensorStatusOccupied},
+		{ID: 12, SensorNumber: 2, Status: domain.SensorStatusFree},
+		{ID: 13, SensorNumber: 0, Status: domain.SensorStatusFree},
+	}, nil)
+	reservationRepo.EXPECT().ListActiveByParkingLot(uint(1), now).Return([]domain.Reservation{
+		{SensorID: 13, Status: domain.ReservationStatusHeld},
+	}, nil)
+	// Spot 2 is taken concurrently, so spot 3 is held instead.
+	gomock.InOrder(
+		reservationRepo.EXPECT().CreateHold(gomock.Any(), now).DoAndReturn(func(r *domain.Reservation, _ time.Time) error {
+			assert.Equal(t, uint(12), r.SensorID)
+			return domain.ErrSpotAlreadyReserved
+		}),
+		reservationRepo.EXPECT().CreateHold(gomock.Any(), now).Return(nil),
+	)
+
+	reservation, err := useCase.Reserve(7, CreateReservationRequest{ParkingLotID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(10), reservation.SensorID)
+	assert.Equal(t, domain.ReservationStatusHeld, reservation.Status)
+	assert.Equal(t, now.Add(DefaultReservationHold), reservation.HeldUntil)
+}
+
+func TestReserveStopsWhenLotLimitReached(t *testing.T) {
+	now := time.Date(2024, time.September, 10, 8, 0, 0, 0, time.UTC)
+	ctrl, reservationRepo, parkingLotRepo, sensorRepo, useCase := setupReservationTest(t, now)
+	defer ctrl.Finish()
+
+	parkingLotRepo.EXPECT().GetByID(uint(1)).Return(&domain.ParkingLot{ID: 1, ReservableSpots: 1}, nil)
+	sensorRepo.EXPECT().ListByParkingLot(uint(1)).Return([]domain.Sensor{
+		{ID: 10, SensorNumber: 1, Status: domain.SensorStatusFree},
+		{ID: 12, SensorNumber: 2, Status: domain.SensorStatusFree},
+	}, nil)
+	reservationRepo.EXPECT().ListActiveByParkingLot(uint(1), now).Return(nil, nil)
+	reservationRepo.EXPECT().CreateHold(gomock.Any(), now).Return(domain.ErrNoReservableSpots)
+
+	_, err := useCase.Reserve(7, CreateReservationRequest{ParkingLotID: 1})
+	assert.ErrorIs(t, err, domain.ErrNoReservableSpots)
+}
+
+func TestOnSensorChangeConfirmsHeldReservation(t *testing.T) {
+	now := time.Date(2024, time.September, 10, 8, 0, 0, 0, time.UTC)
+	ctrl, reservationRepo, _, _, useCase := setupReservationTest(t, now)
+	defer ctrl.Finish()
+
+	occurredAt := now.Add(5 * time.Minute)
+	reservationRepo.EXPECT().FindActiveBySensor(uint(10), occurredAt).Return(&domain.Reservation{
+		ID: 3, SensorID: 10, Status: domain.ReservationStatusHeld, HeldUntil: now.Add(DefaultReservationHold),
+	}, nil)
+	reservationRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(r *domain.Reservation) error {
+		assert.Equal(t, domain.ReservationStatusConfirmed, r.Status)
+		assert.Equal(t, occurredAt, *r.ConfirmedAt)
+		return nil
+	})
+
+	useCase.OnSensorChange(SensorChange{
+		Sensor:         domain.Sensor{ID: 10, ParkingLotID: 1, Status: domain.SensorStatusOccupied},
+		PreviousStatus: domain.SensorStatusFree,
+		OccurredAt:     occurredAt,
+	})
+}
@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxReviewsPerDay is how many new reviews a driver may write in 24 hours.
+	MaxReviewsPerDay = 5
+	// MaxReviewReportsPerDay is how many reviews a driver may report in 24 hours.
+	MaxReviewReportsPerDay = 20
+	// ReviewReportThreshold is the number of reports that sends a published review back to moderation.
+	ReviewReportThreshold = 3
+	// reviewBurstThreshold is how many reviews a lot may receive within reviewBurstWindow before
+	// new ones are held for moderation.
+	reviewBurstThreshold = 10
+	reviewBurstWindow    = time.Hour
+	reviewRateWindow     = 24 * time.Hour
+	maxReviewTextLength  = 1000
+)
+
+var (
+	ErrInvalidRating         = errors.New("ratings must be between 1 and 5")
+	ErrInvalidReviewText     = errors.New("review texts must have at most 1000 characters")
+	ErrReviewRateLimited     = errors.New("too many reviews, try again later")
+	ErrReviewReportLimited   = errors.New("too many reports, try again later")
+	ErrCannotReportOwnReview = errors.New("you cannot report your own review")
+)
+
+type IReviewUseCase interface {
+	SubmitReview(userID uint, req ReviewRequest) (*domain.Review, error)
+	DeleteReview(userID uint, reviewID uint) error
+	ListMyReviews(userID uint) ([]domain.Review, error)
+	ListParkingLotReviews(parkingLotID uint) (*ParkingLotReviewsResponse, error)
+	ReportReview(userID uint, reviewID uint, reason string) error
+	ReplyToReview(parkingLotID uint, reviewID uint, adminUUID string, reply string) (*domain.Review, error)
+	ListModerationQueue() ([]domain.Review, error)
+	HideReview(reviewID uint) (*domain.Review, error)
+	RestoreReview(reviewID uint) (*domain.Review, error)
+}
+
+type ReviewUseCase struct {
+	ReviewRepository     repository.IReviewRepository
+	ParkingLotRepository repository.IParkingLotRepository
+	AdminRepository      repository.IAdminRepository
+	now                  func() time.Time
+}
+
+type ReviewRequest struct {
+	ParkingLotID  uint   `json:"parking_lot_id" binding:"required"`
+	Safety        uint   `json:"safety" binding:"required"`
+	Cleanliness   uint   `json:"cleanliness" binding:"required"`
+	PriceAccuracy uint   `json:"price_accuracy" binding:"required"`
+	Comment       string `json:"comment"`
+}
+
+type ParkingLotReviewsResponse struct {
+	ParkingLotID uint                 `json:"parking_lot_id"`
+	Rating       domain.RatingSummary `json:"rating"`
+	Reviews      []domain.Review      `json:"reviews"`
+}
+
+// NewReviewUseCase creates a new instance of ReviewUseCase.
+func NewReviewUseCase(reviewRepo repository.IReviewRepository, parkingLotRepo repository.IParkingLotRepository, adminRepo repository.IAdminRepository) IReviewUseCase {
+	return &ReviewUseCase{
+		ReviewRepository:     reviewRepo,
+		ParkingLotRepository: parkingLotRepo,
+		AdminRepository:      adminRepo,
+		now:                  time.Now,
+	}
+}
+
+// SubmitReview creates the driver's review of a lot, or updates it when they already reviewed
+// the lot. New reviews are rate limited per driver, and held for moderation while the lot is
+// receiving an unusual burst of reviews.
+func (uc *ReviewUseCase) SubmitReview(userID uint, req ReviewRequest) (*domain.Review, error) {
+	if !validRating(req.Safety) || !validRating(req.Cleanliness) || !validRating(req.PriceAccuracy) {
+		return nil, ErrInvalidRating
+	}
+	if len(req.Comment) > maxReviewTextLength {
+		return nil, ErrInvalidReviewText
+	}
+
+	existing, err := uc.ReviewRepository.FindByUserAndParkingLot(userID, req.ParkingLotID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && !existing.DeletedAt.Valid {
+		existing.Safety = req.Safety
+		existing.Cleanliness = req.Cleanliness
+		existing.PriceAccuracy = req.PriceAccuracy
+		existing.Comment = req.Comment
+		if err := uc.ReviewRepository.Update(existing); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	}
+
+	if _, err := uc.ParkingLotRepository.GetByID(req.ParkingLotID); err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	written, err := uc.ReviewRepository.CountByUserSince(userID, now.Add(-reviewRateWindow))
+	if err != nil {
+		return nil, err
+	}
+	if written >= MaxReviewsPerDay {
+		return nil, ErrReviewRateLimited
+	}
+
+	status := domain.ReviewStatusPublished
+	recent, err := uc.ReviewRepository.CountByParkingLotSince(req.ParkingLotID, now.Add(-reviewBurstWindow))
+	if err != nil {
+		return nil, err
+	}
+	if recent >= reviewBurstThreshold {
+		status = domain.ReviewStatusPending
+	}
+
+	// Resubmitting a deleted review restores it with its reports, and a hidden one stays hidden, so
+	// that deleting a review does not escape its moderation.
+	if existing != nil {
+		existing.Safety = req.Safety
+		existing.Cleanliness = req.Cleanliness
+		existing.PriceAccuracy = req.PriceAccuracy
+		existing.Comment = req.Comment
+		if existing.Status != domain.ReviewStatusHidden {
+			existing.Status = status
+		}
+		if err := uc.ReviewRepository.Restore(existing); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	}
+
+	review := &domain.Review{
+		UserID:        userID,
+		ParkingLotID:  req.ParkingLotID,
+		Safety:        req.Safety,
+		Cleanliness:   req.Cleanliness,
+		PriceAccuracy: req.PriceAccuracy,
+		Comment:       req.Comment,
+		Status:        status,
+	}
+	if err := uc.ReviewRepository.Create(review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// DeleteReview removes a review of the driver. It keeps counting toward the limits on writing
+// reviews.
+func (uc *ReviewUseCase) DeleteReview(userID uint, reviewID uint) error {
+	return uc.ReviewRepository.Delete(userID, reviewID)
+}
+
+// ListMyReviews retrieves the reviews of the driver, including the ones under moderation.
+func (uc *ReviewUseCase) ListMyReviews(userID uint) ([]domain.Review, error) {
+	return uc.ReviewRepository.ListByUser(userID)
+}
+
+// ListParkingLotReviews retrieves the published reviews of a lot with its rating.
+func (uc *ReviewUseCase) ListParkingLotReviews(parkingLotID uint) (*ParkingLotReviewsResponse, error) {
+	if _, err := uc.ParkingLotRepository.GetByID(parkingLotID); err != nil {
+		return nil, err
+	}
+
+	rating, err := uc.ReviewRepository.GetRatingSummary(parkingLotID)
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := uc.ReviewRepository.ListPublishedByParkingLot(parkingLotID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ParkingLotReviewsResponse{ParkingLotID: parkingLotID, Rating: rating, Reviews: reviews}, nil
+}
+
+// ReportReview flags a published review. Once it collects ReviewReportThreshold reports it is
+// hidden until a global admin moderates it.
+func (uc *ReviewUseCase) ReportReview(userID uint, reviewID uint, reason string) error {
+	if len(reason) > maxReviewTextLength {
+		return ErrInvalidReviewText
+	}
+
+	review, err := uc.ReviewRepository.GetByID(reviewID)
+	if err != nil {
+		return err
+	}
+	if review.Status != domain.ReviewStatusPublished {
+		return gorm.ErrRecordNotFound
+	}
+	if review.UserID == userID {
+		return ErrCannotReportOwnReview
+	}
+
+	reported, err := uc.ReviewRepository.CountReportsByUserSince(userID, uc.now().Add(-reviewRateWindow))
+	if err != nil {
+		return err
+	}
+	if reported >= MaxReviewReportsPerDay {
+		return ErrReviewReportLimited
+	}
+
+	created, err := uc.ReviewRepository.CreateReport(&domain.ReviewReport{ReviewID: reviewID, UserID: userID, Reason: reason})
+	if err != nil || !created {
+		return err
+	}
+
+	// Reviews a moderator already restored stay visible; new reports only list them in the queue.
+	review.ReportCount++
+	if review.ModeratedAt == nil && review.ReportCount >= ReviewReportThreshold {
+		review.Status = domain.ReviewStatusPending
+		return uc.ReviewRepository.Update(review)
+	}
+	return nil
+}
+
+// ReplyToReview stores the answer of the lot's admin to a review of the lot.
+func (uc *ReviewUseCase) ReplyToReview(parkingLotID uint, reviewID uint, adminUUID string, reply string) (*domain.Review, error) {
+	if reply == "" || len(reply) > maxReviewTextLength {
+		return nil, ErrInvalidReviewText
+	}
+
+	review, err := uc.ReviewRepository.GetByID(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.ParkingLotID != parkingLotID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	// Global admins may not have an admin profile; their replies are not attributed.
+	if admin, err := uc.AdminRepository.FindByAuth0UUID(adminUUID); err == nil && admin != nil {
+		review.ReplyAdminID = &admin.ID
+	}
+	repliedAt := uc.now()
+	review.Reply = reply
+	review.RepliedAt = &repliedAt
+	if err := uc.ReviewRepository.Update(review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// ListModerationQueue retrieves the reviews waiting for a global admin, most reported first.
+func (uc *ReviewUseCase) ListModerationQueue() ([]domain.Review, error) {
+	return uc.ReviewRepository.ListForModeration()
+}
+
+// HideReview removes a review from the lot's page and rating.
+func (uc *ReviewUseCase) HideReview(reviewID uint) (*domain.Review, error) {
+	return uc.moderate(reviewID, domain.ReviewStatusHidden)
+}
+
+// RestoreReview publishes a review again and clears its reports.
+func (uc *ReviewUseCase) RestoreReview(reviewID uint) (*domain.Review, error) {
+	return uc.moderate(reviewID, domain.ReviewStatusPublished)
+}
+
+func (uc *ReviewUseCase) moderate(reviewID uint, status string) (*domain.Review, error) {
+	review, err := uc.ReviewRepository.GetByID(reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	moderatedAt := uc.now()
+	review.Status = status
+	review.ReportCount = 0
+	review.ModeratedAt = &moderatedAt
+	if err := uc.ReviewRepository.Update(review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+func validRating(value uint) bool {
+	return value >= 1 && value <= 5
+}
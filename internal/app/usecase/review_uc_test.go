@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupReviewTest(t *testing.T) (*gomock.Controller, *mockgen.MockIReviewRepository, *mockgen.MockIParkingLotRepository, *mockgen.MockIAdminRepository, *ReviewUseCase) {
+	ctrl := gomock.NewController(t)
+	reviewRepo := mockgen.NewMockIReviewRepository(ctrl)
+	parkingLotRepo := mockgen.NewMockIParkingLotRepository(ctrl)
+	adminRepo := mockgen.NewMockIAdminRepository(ctrl)
+	useCase := NewReviewUseCase(reviewRepo, parkingLotRepo, adminRepo).(*ReviewUseCase)
+	useCase.now = func() time.Time { return time.Date(2024, time.September, 10, 8, 0, 0, 0, time.UTC) }
+	return ctrl, reviewRepo, parkingLotRepo, adminRepo, useCase
+}
+
+func TestSubmitReviewPublishesNewReview(t *testing.T) {
+	ctrl, reviewRepo, parkingLotRepo, _, useCase := setupReviewTest(t)
+	defer ctrl.Finish()
+
+	reviewRepo.EXPECT().FindByUserAndParkingLot(uint(7), uint(1)).Return(nil, nil)
+	parkingLotRepo.EXPECT().GetByID(uint(1)).Return(&domain.ParkingLot{ID: 1}, nil)
+	reviewRepo.EXPECT().CountByUserSince(uint(7), gomock.Any()).Return(int64(0), nil)
+	reviewRepo.EXPECT().CountByParkingLotSince(uint(1), gomock.Any()).Return(int64(2), nil)
+	reviewRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	review, err := useCase.SubmitReview(7, ReviewRequest{ParkingLotID: 1, Safety: 5, Cleanliness: 4, PriceAccuracy: 3, Comment: "Well lit"})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ReviewStatusPublished, review.Status)
+}
+
+func TestSubmitReviewHoldsReviewsDuringBurst(t *testing.T) {
+	ctrl, reviewRepo, parkingLotRepo, _, useCase := setupReviewTest(t)
+	defer ctrl.Finish()
+
+	reviewRepo.EXPECT().FindByUserAndParkingLot(uint(7), uint(1)).Return(nil, nil)
+	parkingLotRepo.EXPECT().GetByID(uint(1)).Return(&domain.ParkingLot{ID: 1}, nil)
+	reviewRepo.EXPECT().CountByUserSince(uint(7), gomock.Any()).Return(int64(0), nil)
+	reviewRepo.EXPECT().CountByParkingLotSince(uint(1), gomock.Any()).Return(int64(reviewBurstThreshold), nil)
+	reviewRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	review, err := useCase.SubmitReview(7, ReviewRequest{ParkingLotID: 1, Safety: 1, Cleanliness: 1, PriceAccuracy: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ReviewStatusPending, review.Status)
+}
+
+func TestSubmitReviewRateLimitsDriver(t *testing.T) {
+	ctrl, reviewRepo, parkingLotRepo, _, useCase := setupReviewTest(t)
+	defer ctrl.Finish()
+
+	reviewRepo.EXPECT().FindByUserAndParkingLot(uint(7), uint(1)).Return(nil, nil)
+	parkingLotRepo.EXPECT().GetByID(uint(1)).Return(&domain.ParkingLot{ID: 1}, nil)
+	reviewRepo.EXPECT().CountByUserSince(uint(7), gomock.Any()).Return(int64(MaxReviewsPerDay), nil)
+
+	_, err := useCase.SubmitReview(7, ReviewRequest{ParkingLotID: 1, Safety: 1, Cleanliness: 1, PriceAccuracy: 1})
+	assert.ErrorIs(t, err, ErrReviewRateLimited)
+}
+
+func TestSubmitReviewUpdatesExistingReview(t *testing.T) {
+	ctrl, reviewRepo, _, _, useCase := setupReviewTest(t)
+	defer ctrl.Finish()
+
+	existing := &domain.Review{ID: 4, UserID: 7, ParkingLotID: 1, Safety: 2, Status: domain.ReviewStatusHidden}
+	reviewRepo.EXPECT().FindByUserAndParkingLot(uint(7), uint(1)).Return(existing, nil)
+	reviewRepo.EXPECT().Update(existing).Return(nil)
+
+	review, err := useCase.SubmitReview(7, ReviewRequest{ParkingLotID: 1, Safety: 4, Cleanliness: 4, PriceAccuracy: 4})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4), review.Safety)
+	assert.Equal(t, domain.ReviewStatusHidden, review.Status)
+}
+
+func TestSubmitReviewRestoresADeletedReviewKeepingItHidden(t *testing.T) {
+	ctrl, reviewRepo, parkingLotRepo, _, useCase := setupReviewTest(t)
+	defer ctrl.Finish()
+
+	deleted := &domain.Review{
+		ID: 4, UserID: 7, ParkingLotID: 1, Status: domain.ReviewStatusHidden, ReportCount: 5,
+		DeletedAt: gorm.DeletedAt{Time: time.Date(2024, time.September, 9, 8, 0, 0, 0, time.UTC), Valid: true},
+	}
+	reviewRepo.EXPECT().FindByUserAndParkingLot(uint(7), uint(1)).Return(deleted, nil)
+	parkingLotRepo.EXPECT().GetByID(uint(1)).Return(&domain.ParkingLot{ID: 1}, nil)
+	reviewRepo.EXPECT().CountByUserSince(uint(7), gomock.Any()).Return(int64(1), nil)
+	reviewRepo.EXPECT().CountByParkingLotSince(uint(1), gomock.Any()).Return(int64(0), nil)
+	reviewRepo.EXPECT().Restore(deleted).Return(nil)
+
+	review, err := useCase.SubmitReview(7, ReviewRequest{ParkingLotID: 1, Safety: 5, Cleanliness: 5, PriceAccuracy: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4), review.ID)
+	assert.Equal(t, domain.ReviewStatusHidden, review.Status)
+	assert.Equal(t, uint(5), review.ReportCount)
+}
+
+func TestSubmitReviewCountsDeletedReviewsTowardTheLimit(t *testing.T) {
+	ctrl, reviewRepo, parkingLotRepo, _, useCase := setupReviewTest(t)
+	defer ctrl.Finish()
+
+	deleted := &domain.Review{ID: 4, UserID: 7, ParkingLotID: 1, Status: domain.ReviewStatusPublished, DeletedAt: gorm.DeletedAt{Valid: true}}
+	reviewRepo.EXPECT().FindByUserAndParkingLot(uint(7), uint(1)).Return(deleted, nil)
+	parkingLotRepo.EXPECT().GetByID(uint(1)).Return(&domain.ParkingLot{ID: 1}, nil)
+	reviewRepo.EXPECT().CountByUserSince(uint(7), gomock.Any()).Return(int64(MaxReviewsPerDay), nil)
+
+	_, err := useCase.SubmitReview(7, ReviewRequest{ParkingLotID: 1, Safety: 1, Cleanliness: 1, PriceAccuracy: 1})
+	assert.ErrorIs(t, err, ErrReviewRateLimited)
+}
+
+func TestSubmitReviewRejectsOutOfRangeRatings(t *testing.T) {
+	ctrl, _, _, _, useCase := setupReviewTest(t)
+	defer ctrl.Finish()
+
+	_, err := useCase.SubmitReview(7, ReviewRequest{ParkingLotID: 1, Safety: 6, Cleanliness: 4, PriceAccuracy: 4})
+	assert.ErrorIs(t, err, ErrInvalidRating)
+}
+
+func TestReportReviewSendsItToModerationAtThreshold(t *testing.T) {
+	ctrl, reviewRepo, _, _, useCase := setupReviewTest(t)
+	defer ctrl.Finish()
+
+	review := &domain.Review{ID: 4, UserID: 7, Status: domain.ReviewStatusPublished, ReportCount: ReviewReportThreshold - 1}
+	reviewRepo.EXPECT().GetByID(uint(4)).Return(review, nil)
+	reviewRepo.EXPECT().CountReportsByUserSince(uint(8), gomock.Any()).Return(int64(0), nil)
+	reviewRepo.EXPECT().CreateReport(gomock.Any()).Return(true, nil)
+	reviewRepo.EXPECT().Update(review).Return(nil)
+
+	assert.NoError(t, useCase.ReportReview(8, 4, "spam"))
+	assert.Equal(t, domain.ReviewStatusPending, review.Status)
+}
+
+func TestReportOwnReviewIsRejected(t *testing.T) {
+	ctrl, reviewRepo, _, _, useCase := setupReviewTest(t)
+	defer ctrl.Finish()
+
+	reviewRepo.EXPECT().GetByID(uint(4)).Return(&domain.Review{ID: 4, UserID: 7, Status: domain.ReviewStatusPublished}, nil)
+
+	assert.ErrorIs(t, useCase.ReportReview(7, 4, ""), ErrCannotReportOwnReview)
+}
+
+func TestReplyToReviewOfAnotherLotIsNotFound(t *testing.T) {
+	ctrl, reviewRepo, _, _, useCase := setupReviewTest(t)
+	defer ctrl.Finish()
+
+	reviewRepo.EXPECT().GetByID(uint(4)).Return(&domain.Review{ID: 4, ParkingLotID: 2}, nil)
+
+	_, err := useCase.ReplyToReview(1, 4, "admin-uuid", "Thanks")
+	assert.Error(t, err)
+}
+
+func TestRestoreReviewClearsReports(t *testing.T) {
+	ctrl, reviewRepo, _, _, useCase := setupReviewTest(t)
+	defer ctrl.Finish()
+
+	review := &domain.Review{ID: 4, Status: domain.ReviewStatusPending, ReportCount: 3}
+	reviewRepo.EXPECT().GetByID(uint(4)).Return(review, nil)
+	reviewRepo.EXPECT().Update(review).Return(nil)
+
+	restored, err := useCase.RestoreReview(4)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ReviewStatusPublished, restored.Status)
+	assert.Equal(t, uint(0), restored.ReportCount)
+	assert.NotNil(t, restored.ModeratedAt)
+}
@@ -2,6 +2,9 @@ package usecase
 
 import (
 	"errors"
+	"log"
+	"time"
+
 	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
 	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
 )
@@ -16,15 +19,44 @@ type ISensorUseCase interface {
 }
 
 type SensorUseCase struct {
-	SensorRepository      repository.ISensorRepository
-	Esp32DeviceRepository repository.IEsp32DeviceRepository
+	SensorRepository          repository.ISensorRepository
+	Esp32DeviceRepository     repository.IEsp32DeviceRepository
+	OccupancySampleRepository repository.IOccupancySampleRepository
+	SensorEventRepository     repository.ISensorEventRepository
+	LotSnapshotRepository     repository.ILotSnapshotRepository
+	ParkingLotRepository      repository.IParkingLotRepository
+	Listeners                 []SensorChangeListener
+}
+
+// SensorChange describes a status reported by a sensor.
+type SensorChange struct {
+	Sensor         domain.Sensor
+	PreviousStatus string
+	OccurredAt     time.Time
 }
 
+// SensorChangeListener is notified after every sensor update, alongside the WebSocket broadcast.
+type SensorChangeListener interface {
+	OnSensorChange(change SensorChange)
+}
+
+var (
+	ErrInvalidSpotType   = errors.New("spot type must be one of car, motorcycle, ev or van")
+	ErrOperatorSuspended = errors.New("the operator of the parking lot is suspended")
+	ErrDeviceNotFound    = errors.New("device not found")
+	ErrDeviceParkingLot  = errors.New("the device is registered on another parking lot")
+)
+
+// lotSnapshotInterval is the maximum age of a lot's latest snapshot before a new one is taken.
+const lotSnapshotInterval = time.Hour
+
 type CreateSensorRequest struct {
 	ParkingLotID     uint   `json:"parking_lot_id"`
 	DeviceIdentifier string `json:"device_identifier"` // Dirección MAC
 	SensorNumber     int    `json:"sensor_number"`
 	Status           string `json:"status"`
+	SpotType         string `json:"spot_type"`
+	Accessible       bool   `json:"accessible"`
 }
 
 type UpdateSensorRequest struct {
@@ -38,19 +70,37 @@ type SensorResponse struct {
 	ParkingLotID     uint   `json:"parking_lot_id"`
 	DeviceIdentifier string `json:"device_identifier"`
 	Status           string `json:"status"`
+	SpotType         string `json:"spot_type"`
+	Accessible       bool   `json:"accessible"`
 }
 
-func NewSensorUseCase(sensorRepo repository.ISensorRepository, esp32DeviceRepo repository.IEsp32DeviceRepository) ISensorUseCase {
+func NewSensorUseCase(sensorRepo repository.ISensorRepository, esp32DeviceRepo repository.IEsp32DeviceRepository, sampleRepo repository.IOccupancySampleRepository, eventRepo repository.ISensorEventRepository, snapshotRepo repository.ILotSnapshotRepository, parkingLotRepo repository.IParkingLotRepository, listeners ...SensorChangeListener) ISensorUseCase {
 	return &SensorUseCase{
-		SensorRepository:      sensorRepo,
-		Esp32DeviceRepository: esp32DeviceRepo,
+		SensorRepository:          sensorRepo,
+		Esp32DeviceRepository:     esp32DeviceRepo,
+		OccupancySampleRepository: sampleRepo,
+		SensorEventRepository:     eventRepo,
+		LotSnapshotRepository:     snapshotRepo,
+		ParkingLotRepository:      parkingLotRepo,
+		Listeners:                 listeners,
 	}
 }
 
 func (uc *SensorUseCase) CreateSensor(req CreateSensorRequest) error {
 	device, err := uc.Esp32DeviceRepository.GetByDeviceIdentifier(req.DeviceIdentifier)
-	if err != nil {
-		return errors.New("device not found")
+	if err != nil || device == nil {
+		return ErrDeviceNotFound
+	}
+	if device.ParkingLotID != req.ParkingLotID {
+		return ErrDeviceParkingLot
+	}
+
+	spotType := req.SpotType
+	if spotType == "" {
+		spotType = domain.SpotTypeCar
+	}
+	if !domain.ValidSpotType(spotType) {
+		return ErrInvalidSpotType
 	}
 
 	sensor := domain.Sensor{
@@ -59,6 +109,8 @@ func (uc *SensorUseCase) CreateSensor(req CreateSensorRequest) error {
 		Status:           req.Status,
 		SensorNumber:     req.SensorNumber,
 		DeviceIdentifier: device.DeviceIdentifier,
+		SpotType:         spotType,
+		Accessible:       req.Accessible,
 	}
 
 	return uc.SensorRepository.Create(&sensor)
@@ -75,19 +127,110 @@ func (uc *SensorUseCase) GetSensor(sensorID uint) (*SensorResponse, error) {
 		ParkingLotID:     sensor.ParkingLotID,
 		DeviceIdentifier: sensor.DeviceIdentifier,
 		Status:           sensor.Status,
+		SpotType:         sensor.SpotType,
+		Accessible:       sensor.Accessible,
 	}
 
 	return response, nil
 }
 
+// UpdateSensor applies a status reported by a device. Reports for lots of suspended operators are
+// rejected with ErrOperatorSuspended.
 func (uc *SensorUseCase) UpdateSensor(sensorID uint, req UpdateSensorRequest) error {
 	sensor, err := uc.SensorRepository.GetByID(sensorID)
 	if err != nil {
 		return err
 	}
 
+	operatorStatus, err := uc.ParkingLotRepository.OperatorStatus(sensor.ParkingLotID)
+	if err != nil {
+		return err
+	}
+	if operatorStatus == domain.AdminStatusSuspended {
+		return ErrOperatorSuspended
+	}
+
+	previousStatus := sensor.Status
 	sensor.Status = req.Status
-	return uc.SensorRepository.Update(sensor)
+	if err := uc.SensorRepository.Update(sensor); err != nil {
+		return err
+	}
+
+	uc.touchDevice(uint64(sensor.Esp32DeviceID))
+	uc.recordHistory(sensor, previousStatus)
+
+	change := SensorChange{Sensor: *sensor, PreviousStatus: previousStatus, OccurredAt: time.Now()}
+	for _, listener := range uc.Listeners {
+		listener.OnSensorChange(change)
+	}
+	return nil
+}
+
+// touchDevice records that the sensor's ESP32 device has just communicated.
+func (uc *SensorUseCase) touchDevice(deviceID uint64) {
+	device, err := uc.Esp32DeviceRepository.GetByID(deviceID)
+	if err != nil || device == nil {
+		log.Println("Error loading device for last communication:", err)
+		return
+	}
+
+	device.LastCommunication = time.Now()
+	if err := uc.Esp32DeviceRepository.Update(device); err != nil {
+		log.Println("Error updating device last communication:", err)
+	}
+}
+
+// recordHistory stores the status change event, an occupancy sample for forecasting and, when
+// the latest one is too old, a snapshot of the lot for playback. Failures are logged so that a
+// history write never rejects a sensor update.
+func (uc *SensorUseCase) recordHistory(sensor *domain.Sensor, previousStatus string) {
+	now := time.Now()
+
+	if previousStatus != sensor.Status {
+		event := domain.SensorEvent{
+			SensorID:       sensor.ID,
+			ParkingLotID:   sensor.ParkingLotID,
+			PreviousStatus: previousStatus,
+			Status:         sensor.Status,
+			OccurredAt:     now,
+		}
+		if err := uc.SensorEventRepository.Create(&event); err != nil {
+			log.Println("Error recording sensor event:", err)
+		}
+	}
+
+	sensors, err := uc.SensorRepository.ListByParkingLot(sensor.ParkingLotID)
+	if err != nil {
+		log.Println("Error loading sensors for occupancy history:", err)
+		return
+	}
+
+	sample := domain.OccupancySample{
+		ParkingLotID: sensor.ParkingLotID,
+		FreeSpaces:   countAvailableSpaces(sensors),
+		TotalSpaces:  uint(len(sensors)),
+		RecordedAt:   now,
+	}
+	if err := uc.OccupancySampleRepository.Create(&sample); err != nil {
+		log.Println("Error recording occupancy sample:", err)
+	}
+
+	latest, err := uc.LotSnapshotRepository.FindLatestBefore(sensor.ParkingLotID, now)
+	if err != nil {
+		log.Println("Error loading latest lot snapshot:", err)
+		return
+	}
+	if latest != nil && now.Sub(latest.TakenAt) < lotSnapshotInterval {
+		return
+	}
+
+	snapshot := domain.LotSnapshot{ParkingLotID: sensor.ParkingLotID, TakenAt: now}
+	for _, s := range sensors {
+		snapshot.Sensors = append(snapshot.Sensors, domain.SensorState{SensorID: s.ID, Status: s.Status, Since: s.UpdatedAt})
+	}
+	if err := uc.LotSnapshotRepository.Create(&snapshot); err != nil {
+		log.Println("Error recording lot snapshot:", err)
+	}
 }
 
 func (uc *SensorUseCase) DeleteSensor(sensorID uint) error {
@@ -124,6 +267,8 @@ func (uc *SensorUseCase) GetSensorByDeviceAndNumber(deviceIdentifier string, sen
 		ParkingLotID:     sensor.ParkingLotID,
 		DeviceIdentifier: sensor.DeviceIdentifier,
 		Status:           sensor.Status,
+		SpotType:         sensor.SpotType,
+		Accessible:       sensor.Accessible,
 	}
 
 	return response, nil
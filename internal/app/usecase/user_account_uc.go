@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/mailer"
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	EmailVerificationTTL = 24 * time.Hour
+	PasswordResetTTL     = time.Hour
+	// MaxAccountEmailsPerHour caps the verification or reset e-mails sent to one driver.
+	MaxAccountEmailsPerHour = 3
+	MinPasswordLength       = 8
+)
+
+var (
+	ErrEmailAlreadyVerified = errors.New("email address is already verified")
+	ErrAccountEmailLimited  = errors.New("too many e-mails requested, try again later")
+	ErrWeakPassword         = fmt.Errorf("password must be at least %d characters long", MinPasswordLength)
+)
+
+type IAccountUseCase interface {
+	SendVerification(userID uint) error
+	VerifyEmail(token string) error
+	RequestPasswordReset(email string) error
+	ResetPassword(token, password string) error
+	IsEmailVerified(userID uint) (bool, error)
+}
+
+// AccountUseCase e-mails drivers signed, single-use and expiring tokens to verify their address
+// and to reset a forgotten password. Links in the e-mails point to the app at baseURL.
+type AccountUseCase struct {
+	UserRepository         repository.IUserRepository
+	UserTokenRepository    repository.IUserTokenRepository
+	RefreshTokenRepository repository.IRefreshTokenRepository
+	Mailer                 mailer.Mailer
+	secret                 []byte
+	baseURL                string
+	now                    func() time.Time
+}
+
+// NewAccountUseCase creates a new instance of AccountUseCase signing tokens with secret.
+func NewAccountUseCase(userRepo repository.IUserRepository, userTokenRepo repository.IUserTokenRepository, refreshTokenRepo repository.IRefreshTokenRepository, m mailer.Mailer, secret []byte, baseURL string) IAccountUseCase {
+	return &AccountUseCase{
+		UserRepository:         userRepo,
+		UserTokenRepository:    userTokenRepo,
+		RefreshTokenRepository: refreshTokenRepo,
+		Mailer:                 m,
+		secret:                 secret,
+		baseURL:                strings.TrimRight(baseURL, "/"),
+		now:                    time.Now,
+	}
+}
+
+// SendVerification e-mails the driver a link to verify their address. Earlier verification links
+// stop working.
+func (uc *AccountUseCase) SendVerification(userID uint) error {
+	user, err := uc.UserRepository.FindByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified() {
+		return ErrEmailAlreadyVerified
+	}
+
+	token, err := uc.issueToken(user, domain.UserTokenEmailVerification, EmailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return uc.Mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your Parking Radar e-mail",
+		Body: fmt.Sprintf("Hi %s,\n\nConfirm your e-mail address by opening this link within %s:\n\n%s\n",
+			user.Username, EmailVerificationTTL, uc.link("verify-email", token)),
+	})
+}
+
+// VerifyEmail redeems a verification token and marks the address of its driver as verified. A
+// token sent to an address the driver has since replaced is rejected.
+func (uc *AccountUseCase) VerifyEmail(token string) error {
+	stored, err := uc.redeemToken(token, domain.UserTokenEmailVerification)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.UserRepository.FindByID(stored.UserID)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(stored.Email, user.Email) {
+		return ErrInvalidToken
+	}
+	if user.EmailVerified() {
+		return nil
+	}
+	verifiedAt := uc.now()
+	user.EmailVerifiedAt = &verifiedAt
+	return uc.UserRepository.Update(user)
+}
+
+// RequestPasswordReset e-mails a reset link to the driver registered with the address. Unknown
+// addresses and drivers over the hourly limit are ignored silently, so the response does not
+// reveal which addresses are registered.
+func (uc *AccountUseCase) RequestPasswordReset(email string) error {
+	user, err := uc.UserRepository.FindByEmail(strings.TrimSpace(email))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	token, err := uc.issueToken(user, domain.UserTokenPasswordReset, PasswordResetTTL)
+	if err != nil {
+		if errors.Is(err, ErrAccountEmailLimited) {
+			return nil
+		}
+		return err
+	}
+
+	return uc.Mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your Parking Radar password",
+		Body: fmt.Sprintf("Hi %s,\n\nChoose a new password by opening this link within %s:\n\n%s\n\nIf you did not ask for it, ignore this e-mail.\n",
+			user.Username, PasswordResetTTL, uc.link("reset-password", token)),
+	})
+}
+
+// ResetPassword redeems a reset token and replaces the password of its driver. Every session of
+// the driver is closed. Since the token was received by e-mail, the address becomes verified when
+// it is still the one the token was sent to.
+func (uc *AccountUseCase) ResetPassword(token, password string) error {
+	if len(password) < MinPasswordLength {
+		return ErrWeakPassword
+	}
+
+	stored, err := uc.redeemToken(token, domain.UserTokenPasswordReset)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.UserRepository.FindByID(stored.UserID)
+	if err != nil {
+		return err
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	now := uc.now()
+	user.PasswordHash = string(hashedPassword)
+	user.UpdatedAt = now
+	if !user.EmailVerified() && strings.EqualFold(stored.Email, user.Email) {
+		user.EmailVerifiedAt = &now
+	}
+	if err := uc.UserRepository.Update(user); err != nil {
+		return err
+	}
+
+	if err := uc.UserTokenRepository.InvalidateForUser(user.ID, domain.UserTokenPasswordReset, now); err != nil {
+		return err
+	}
+	return uc.RefreshTokenRepository.RevokeAllForUser(user.ID, now)
+}
+
+// IsEmailVerified reports whether the driver verified their e-mail address.
+func (uc *AccountUseCase) IsEmailVerified(userID uint) (bool, error) {
+	user, err := uc.UserRepository.FindByID(userID)
+	if err != nil {
+		return false, err
+	}
+	return user.EmailVerified(), nil
+}
+
+// issueToken stores a new token for the purpose, superseding the unused ones of the driver, along
+// with the address it is sent to. The token is a random value followed by its HMAC for the
+// purpose, so forged or mistyped tokens are rejected without a database lookup.
+func (uc *AccountUseCase) issueToken(user *domain.User, purpose string, ttl time.Duration) (string, error) {
+	now := uc.now()
+	userID := user.ID
+
+	sent, err := uc.UserTokenRepository.CountCreatedSince(userID, purpose, now.Add(-time.Hour))
+	if err != nil {
+		return "", err
+	}
+	if sent >= MaxAccountEmailsPerHour {
+		return "", ErrAccountEmailLimited
+	}
+
+	value, err := randomToken(32)
+	if err != nil {
+		return "", err
+	}
+	token := value + "." + uc.sign(purpose, value)
+
+	if err := uc.UserTokenRepository.InvalidateForUser(userID, purpose, now); err != nil {
+		return "", err
+	}
+	if err := uc.UserTokenRepository.Create(&domain.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashToken(token),
+		Email:     user.Email,
+		ExpiresAt: now.Add(ttl),
+	}); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// redeemToken checks the signature, purpose and expiry of a token and marks it as used.
+func (uc *AccountUseCase) redeemToken(token, purpose string) (*domain.UserToken, error) {
+	value, signature, found := strings.Cut(token, ".")
+	if !found || !hmac.Equal([]byte(signature), []byte(uc.sign(purpose, value))) {
+		return nil, ErrInvalidToken
+	}
+
+	stored, err := uc.UserTokenRepository.FindByHash(hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	now := uc.now()
+	if stored == nil || stored.Purpose != purpose || stored.UsedAt != nil || !now.Before(stored.ExpiresAt) {
+		return nil, ErrInvalidToken
+	}
+
+	redeemed, err := uc.UserTokenRepository.MarkUsed(stored.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !redeemed {
+		return nil, ErrInvalidToken
+	}
+	return stored, nil
+}
+
+func (uc *AccountUseCase) sign(purpose, value string) string {
+	mac := hmac.New(sha256.New, uc.secret)
+	mac.Write([]byte(purpose + ":" + value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (uc *AccountUseCase) link(path, token string) string {
+	return fmt.Sprintf("%s/%s?token=%s", uc.baseURL, path, url.QueryEscape(token))
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/mailer"
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+var accountNow = time.Date(2024, time.September, 10, 8, 0, 0, 0, time.UTC)
+
+func setupAccountTest(t *testing.T) (*gomock.Controller, *mockgen.MockIUserRepository, *mockgen.MockIUserTokenRepository, *mockgen.MockIRefreshTokenRepository, *mailer.MemoryMailer, *AccountUseCase) {
+	ctrl := gomock.NewController(t)
+	userRepo := mockgen.NewMockIUserRepository(ctrl)
+	userTokenRepo := mockgen.NewMockIUserTokenRepository(ctrl)
+	refreshTokenRepo := mockgen.NewMockIRefreshTokenRepository(ctrl)
+	m := mailer.NewMemoryMailer()
+	useCase := NewAccountUseCase(userRepo, userTokenRepo, refreshTokenRepo, m, []byte("test-secret"), "https://app.example.com/").(*AccountUseCase)
+	useCase.now = func() time.Time { return accountNow }
+	return ctrl, userRepo, userTokenRepo, refreshTokenRepo, m, useCase
+}
+
+// tokenFromMail extracts the token of the link in the e-mail.
+func tokenFromMail(t *testing.T, message mailer.Message) string {
+	start := strings.Index(message.Body, "https://")
+	assert.GreaterOrEqual(t, start, 0)
+	link, err := url.Parse(strings.Fields(message.Body[start:])[0])
+	assert.NoError(t, err)
+	return link.Query().Get("token")
+}
+
+func TestSendVerificationAndVerifyEmail(t *testing.T) {
+	ctrl, userRepo, userTokenRepo, _, m, useCase := setupAccountTest(t)
+	defer ctrl.Finish()
+
+	user := &domain.User{ID: 7, Username: "driver", Email: "driver@example.com"}
+	userRepo.EXPECT().FindByID(uint(7)).Return(user, nil).Times(2)
+	userTokenRepo.EXPECT().CountCreatedSince(uint(7), domain.UserTokenEmailVerification, accountNow.Add(-time.Hour)).Return(int64(0), nil)
+	userTokenRepo.EXPECT().InvalidateForUser(uint(7), domain.UserTokenEmailVerification, accountNow).Return(nil)
+	var stored domain.UserToken
+	userTokenRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(token *domain.UserToken) error {
+		token.ID = 3
+		stored = *token
+		return nil
+	})
+
+	assert.NoError(t, useCase.SendVerification(7))
+	assert.Len(t, m.Sent(), 1)
+	assert.Equal(t, "driver@example.com", m.Sent()[0].To)
+	assert.Contains(t, m.Sent()[0].Body, "https://app.example.com/verify-email?token=")
+	assert.Equal(t, accountNow.Add(EmailVerificationTTL), stored.ExpiresAt)
+	assert.Equal(t, "driver@example.com", stored.Email)
+
+	token := tokenFromMail(t, m.Sent()[0])
+	userTokenRepo.EXPECT().FindByHash(hashToken(token)).Return(&stored, nil)
+	userTokenRepo.EXPECT().MarkUsed(uint(3), accountNow).Return(true, nil)
+	userRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(updated *domain.User) error {
+		assert.True(t, updated.EmailVerified())
+		return nil
+	})
+
+	assert.NoError(t, useCase.VerifyEmail(token))
+}
+
+func TestVerifyEmailRejectsForgedAndReusedTokens(t *testing.T) {
+	ctrl, _, userTokenRepo, _, _, useCase := setupAccountTest(t)
+	defer ctrl.Finish()
+
+	assert.ErrorIs(t, useCase.VerifyEmail("abc.def"), ErrInvalidToken)
+
+	value := "0123456789abcdef"
+	resetToken := value + "." + useCase.sign(domain.UserTokenPasswordReset, value)
+	assert.ErrorIs(t, useCase.VerifyEmail(resetToken), ErrInvalidToken, "tokens are signed for their purpose")
+
+	token := value + "." + useCase.sign(domain.UserTokenEmailVerification, value)
+	userTokenRepo.EXPECT().FindByHash(hashToken(token)).Return(&domain.UserToken{ID: 3, UserID: 7, Purpose: domain.UserTokenEmailVerification, ExpiresAt: accountNow.Add(time.Hour)}, nil)
+	userTokenRepo.EXPECT().MarkUsed(uint(3), accountNow).Return(false, nil)
+	assert.ErrorIs(t, useCase.VerifyEmail(token), ErrInvalidToken)
+
+	userTokenRepo.EXPECT().FindByHash(hashToken(token)).Return(&domain.UserToken{ID: 3, UserID: 7, Purpose: domain.UserTokenEmailVerification, ExpiresAt: accountNow}, nil)
+	assert.ErrorIs(t, useCase.VerifyEmail(token), ErrInvalidToken, "expired")
+}
+
+func TestVerifyEmailRejectsTokensSentToAReplacedAddress(t *testing.T) {
+	ctrl, userRepo, userTokenRepo, _, _, useCase := setupAccountTest(t)
+	defer ctrl.Finish()
+
+	value := "0123456789abcdef"
+	token := value + "." + useCase.sign(domain.UserTokenEmailVerification, value)
+	userTokenRepo.EXPECT().FindByHash(hashToken(token)).Return(&domain.UserToken{
+		ID: 3, UserID: 7, Purpose: domain.UserTokenEmailVerification, Email: "old@example.com", ExpiresAt: accountNow.Add(time.Hour),
+	}, nil)
+	userTokenRepo.EXPECT().MarkUsed(uint(3), accountNow).Return(true, nil)
+	userRepo.EXPECT().FindByID(uint(7)).Return(&domain.User{ID: 7, Email: "new@example.com"}, nil)
+
+	assert.ErrorIs(t, useCase.VerifyEmail(token), ErrInvalidToken)
+}
+
+func TestRequestPasswordResetDoesNotRevealUnknownOrLimitedAddresses(t *testing.T) {
+	ctrl, userRepo, userTokenRepo, _, m, useCase := setupAccountTest(t)
+	defer ctrl.Finish()
+
+	userRepo.EXPECT().FindByEmail("nobody@example.com").Return(nil, gorm.ErrRecordNotFound)
+	assert.NoError(t, useCase.RequestPasswordReset("nobody@example.com"))
+
+	userRepo.EXPECT().FindByEmail("driver@example.com").Return(&domain.User{ID: 7, Email: "driver@example.com"}, nil)
+	userTokenRepo.EXPECT().CountCreatedSince(uint(7), domain.UserTokenPasswordReset, gomock.Any()).Return(int64(MaxAccountEmailsPerHour), nil)
+	assert.NoError(t, useCase.RequestPasswordReset("driver@example.com"))
+
+	assert.Empty(t, m.Sent())
+}
+
+func TestResetPasswordReplacesHashAndClosesSessions(t *testing.T) {
+	ctrl, userRepo, userTokenRepo, refreshTokenRepo, _, useCase := setupAccountTest(t)
+	defer ctrl.Finish()
+
+	assert.ErrorIs(t, useCase.ResetPassword("irrelevant", "short"), ErrWeakPassword)
+
+	value := "fedcba9876543210"
+	token := value + "." + useCase.sign(domain.UserTokenPasswordReset, value)
+	userTokenRepo.EXPECT().FindByHash(hashToken(token)).Return(&domain.UserToken{ID: 4, UserID: 7, Purpose: domain.UserTokenPasswordReset, ExpiresAt: accountNow.Add(time.Minute)}, nil)
+	userTokenRepo.EXPECT().MarkUsed(uint(4), accountNow).Return(true, nil)
+	userRepo.EXPECT().FindByID(uint(7)).Return(&domain.User{ID: 7, PasswordHash: "old"}, nil)
+	userRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(user *domain.User) error {
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("new-password")))
+		assert.True(t, user.EmailVerified())
+		return nil
+	})
+	userTokenRepo.EXPECT().InvalidateForUser(uint(7), domain.UserTokenPasswordReset, accountNow).Return(nil)
+	refreshTokenRepo.EXPECT().RevokeAllForUser(uint(7), accountNow).Return(nil)
+
+	assert.NoError(t, useCase.ResetPassword(token, "new-password"))
+}
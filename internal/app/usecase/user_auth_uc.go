@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	userTokenIssuer       = "parking-radar"
+	userAccessTokenType   = "access"
+	DefaultAccessTokenTTL = 15 * time.Minute
+	DefaultRefreshTTL     = 30 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrInvalidToken       = errors.New("invalid or expired token")
+)
+
+// dummyPasswordHash is compared against when the user does not exist so that a failed login
+// takes the same time whether or not the username is registered.
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("parking-radar"), bcrypt.DefaultCost)
+
+//go:generate mockgen -source=./user_auth_uc.go -destination=./../../test/parking/mockgen/mock_user_auth_uc.go -package=mockgen
+type IUserAuthUseCase interface {
+	Login(username, password string) (*TokenPair, error)
+	Refresh(refreshToken string) (*TokenPair, error)
+	Logout(refreshToken string) error
+	LogoutAll(userID uint) error
+	Authenticate(accessToken string) (uint, error)
+}
+
+type UserAuthUseCase struct {
+	UserRepository         repository.IUserRepository
+	RefreshTokenRepository repository.IRefreshTokenRepository
+	secret                 []byte
+	accessTTL              time.Duration
+	refreshTTL             time.Duration
+	now                    func() time.Time
+}
+
+type TokenPair struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	RefreshToken string    `json:"refresh_token"`
+}
+
+type userAccessClaims struct {
+	SessionID string `json:"sid"`
+	TokenType string `json:"typ"`
+	jwt.RegisteredClaims
+}
+
+// NewUserAuthUseCase creates a new instance of UserAuthUseCase signing access tokens with secret.
+func NewUserAuthUseCase(userRepo repository.IUserRepository, refreshTokenRepo repository.IRefreshTokenRepository, secret []byte, accessTTL, refreshTTL time.Duration) IUserAuthUseCase {
+	return &UserAuthUseCase{
+		UserRepository:         userRepo,
+		RefreshTokenRepository: refreshTokenRepo,
+		secret:                 secret,
+		accessTTL:              accessTTL,
+		refreshTTL:             refreshTTL,
+		now:                    time.Now,
+	}
+}
+
+// Login verifies the password against the stored bcrypt hash and opens a new session.
+func (uc *UserAuthUseCase) Login(username, password string) (*TokenPair, error) {
+	user, err := uc.UserRepository.FindByUserName(username)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	familyID, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	return uc.issueTokens(user.ID, familyID)
+}
+
+// Refresh rotates a refresh token: the presented token is revoked and a new pair is issued in
+// the same session. Presenting an already rotated token revokes the whole session, since it
+// means the token was stolen; so does losing a race to rotate the same token.
+func (uc *UserAuthUseCase) Refresh(refreshToken string) (*TokenPair, error) {
+	now := uc.now()
+
+	stored, err := uc.RefreshTokenRepository.FindByHash(hashToken(refreshToken))
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, ErrInvalidToken
+	}
+
+	if stored.RevokedAt != nil {
+		return nil, uc.revokeReusedFamily(stored.FamilyID, now)
+	}
+	if !now.Before(stored.ExpiresAt) {
+		return nil, ErrInvalidToken
+	}
+
+	revoked, err := uc.RefreshTokenRepository.Revoke(stored.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !revoked {
+		return nil, uc.revokeReusedFamily(stored.FamilyID, now)
+	}
+
+	return uc.issueTokens(stored.UserID, stored.FamilyID)
+}
+
+// revokeReusedFamily revokes the session of a refresh token presented after its rotation.
+func (uc *UserAuthUseCase) revokeReusedFamily(familyID string, now time.Time) error {
+	if err := uc.RefreshTokenRepository.RevokeFamily(familyID, now); err != nil {
+		return err
+	}
+	return ErrInvalidToken
+}
+
+// Logout revokes the session the refresh token belongs to, including its access tokens.
+func (uc *UserAuthUseCase) Logout(refreshToken string) error {
+	stored, err := uc.RefreshTokenRepository.FindByHash(hashToken(refreshToken))
+	if err != nil {
+		return err
+	}
+	if stored == nil {
+		return ErrInvalidToken
+	}
+	return uc.RefreshTokenRepository.RevokeFamily(stored.FamilyID, uc.now())
+}
+
+// LogoutAll revokes every session of the user.
+func (uc *UserAuthUseCase) LogoutAll(userID uint) error {
+	return uc.RefreshTokenRepository.RevokeAllForUser(userID, uc.now())
+}
+
+// Authenticate validates an access token and returns the ID of its user. Tokens of revoked
+// sessions are rejected even before they expire.
+func (uc *UserAuthUseCase) Authenticate(accessToken string) (uint, error) {
+	claims := &userAccessClaims{}
+	token, err := jwt.ParseWithClaims(accessToken, claims, func(t *jwt.Token) (interface{}, error) {
+		return uc.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(userTokenIssuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(uc.now),
+	)
+	if err != nil || !token.Valid || claims.TokenType != userAccessTokenType {
+		return 0, ErrInvalidToken
+	}
+
+	userID, err := strconv.ParseUint(claims.Subject, 10, 32)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+
+	active, err := uc.RefreshTokenRepository.HasActiveInFamily(claims.SessionID, uc.now())
+	if err != nil {
+		return 0, err
+	}
+	if !active {
+		return 0, ErrInvalidToken
+	}
+
+	return uint(userID), nil
+}
+
+func (uc *UserAuthUseCase) issueTokens(userID uint, familyID string) (*TokenPair, error) {
+	now := uc.now()
+	expiresAt := now.Add(uc.accessTTL)
+
+	tokenID, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, userAccessClaims{
+		SessionID: familyID,
+		TokenType: userAccessTokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    userTokenIssuer,
+			Subject:   strconv.FormatUint(uint64(userID), 10),
+			ID:        tokenID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}).SignedString(uc.secret)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.RefreshTokenRepository.Create(&domain.RefreshToken{
+		UserID:    userID,
+		TokenHash: hashToken(refreshToken),
+		FamilyID:  familyID,
+		ExpiresAt: now.Add(uc.refreshTTL),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresAt:    expiresAt,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// randomToken returns n random bytes encoded as hex.
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
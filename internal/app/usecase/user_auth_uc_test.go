@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+func setupUserAuthTest(t *testing.T) (*gomock.Controller, *mockgen.MockIUserRepository, *mockgen.MockIRefreshTokenRepository, IUserAuthUseCase) {
+	ctrl := gomock.NewController(t)
+	userRepo := mockgen.NewMockIUserRepository(ctrl)
+	tokenRepo := mockgen.NewMockIRefreshTokenRepository(ctrl)
+	useCase := NewUserAuthUseCase(userRepo, tokenRepo, []byte("test-secret"), DefaultAccessTokenTTL, DefaultRefreshTTL)
+	return ctrl, userRepo, tokenRepo, useCase
+}
+
+func TestLoginIssuesTokensForValidPassword(t *testing.T) {
+	ctrl, userRepo, tokenRepo, useCase := setupUserAuthTest(t)
+	defer ctrl.Finish()
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	userRepo.EXPECT().FindByUserName("driver").Return(&domain.User{ID: 7, PasswordHash: string(hash)}, nil)
+
+	var stored *domain.RefreshToken
+	tokenRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(token *domain.RefreshToken) error {
+		stored = token
+		return nil
+	})
+
+	tokens, err := useCase.Login("driver", "secret")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tokens.AccessToken)
+	assert.Equal(t, hashToken(tokens.RefreshToken), stored.TokenHash)
+
+	tokenRepo.EXPECT().HasActiveInFamily(stored.FamilyID, gomock.Any()).Return(true, nil)
+	userID, err := useCase.Authenticate(tokens.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), userID)
+}
+
+func TestLoginRejectsWrongPasswordAndUnknownUser(t *testing.T) {
+	ctrl, userRepo, _, useCase := setupUserAuthTest(t)
+	defer ctrl.Finish()
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	userRepo.EXPECT().FindByUserName("driver").Return(&domain.User{ID: 7, PasswordHash: string(hash)}, nil)
+	userRepo.EXPECT().FindByUserName("ghost").Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := useCase.Login("driver", "wrong")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = useCase.Login("ghost", "secret")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestRefreshReuseRevokesSession(t *testing.T) {
+	ctrl, _, tokenRepo, useCase := setupUserAuthTest(t)
+	defer ctrl.Finish()
+
+	revokedAt := time.Now().Add(-time.Minute)
+	tokenRepo.EXPECT().FindByHash(hashToken("stolen")).Return(&domain.RefreshToken{
+		UserID: 7, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt,
+	}, nil)
+	tokenRepo.EXPECT().RevokeFamily("family", gomock.Any()).Return(nil)
+
+	_, err := useCase.Refresh("stolen")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestRefreshRevokesSessionWhenAConcurrentRotationWon(t *testing.T) {
+	ctrl, _, tokenRepo, useCase := setupUserAuthTest(t)
+	defer ctrl.Finish()
+
+	tokenRepo.EXPECT().FindByHash(hashToken("raced")).Return(&domain.RefreshToken{
+		ID: 3, UserID: 7, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+	tokenRepo.EXPECT().Revoke(uint(3), gomock.Any()).Return(false, nil)
+	tokenRepo.EXPECT().RevokeFamily("family", gomock.Any()).Return(nil)
+
+	_, err := useCase.Refresh("raced")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestAuthenticateRejectsRevokedSession(t *testing.T) {
+	ctrl, userRepo, tokenRepo, useCase := setupUserAuthTest(t)
+	defer ctrl.Finish()
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	userRepo.EXPECT().FindByUserName("driver").Return(&domain.User{ID: 7, PasswordHash: string(hash)}, nil)
+	tokenRepo.EXPECT().Create(gomock.Any()).Return(nil)
+	tokenRepo.EXPECT().HasActiveInFamily(gomock.Any(), gomock.Any()).Return(false, nil)
+
+	tokens, err := useCase.Login("driver", "secret")
+	assert.NoError(t, err)
+
+	_, err = useCase.Authenticate(tokens.AccessToken)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
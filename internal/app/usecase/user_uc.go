@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"log"
+	"strings"
 	"time"
 
 	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
@@ -16,13 +18,22 @@ type IUserUseCase interface {
 	DeleteUser(id uint) error
 }
 
+// EmailVerificationSender e-mails a driver the link to verify their address.
+type EmailVerificationSender interface {
+	SendVerification(userID uint) error
+}
+
 type UserUseCase struct {
-	UserRepository repository.IUserRepository
+	UserRepository      repository.IUserRepository
+	UserTokenRepository repository.IUserTokenRepository
+	Verifier            EmailVerificationSender
 }
 
-func NewUserUseCase(userRepo repository.IUserRepository) IUserUseCase {
+func NewUserUseCase(userRepo repository.IUserRepository, userTokenRepo repository.IUserTokenRepository, verifier EmailVerificationSender) IUserUseCase {
 	return &UserUseCase{
-		UserRepository: userRepo,
+		UserRepository:      userRepo,
+		UserTokenRepository: userTokenRepo,
+		Verifier:            verifier,
 	}
 }
 
@@ -44,6 +55,7 @@ func (u *UserUseCase) Register(username, password, email string) (*domain.User,
 		return nil, err
 	}
 
+	u.sendVerification(user.ID)
 	return user, nil
 }
 
@@ -57,9 +69,13 @@ func (u *UserUseCase) UpdateUser(id uint, username, email, password string) (*do
 		return nil, err
 	}
 
+	emailChanged := !strings.EqualFold(user.Email, email)
 	user.Username = username
 	user.Email = email
 	user.UpdatedAt = time.Now()
+	if emailChanged {
+		user.EmailVerifiedAt = nil
+	}
 
 	if password != "" {
 		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -73,9 +89,25 @@ func (u *UserUseCase) UpdateUser(id uint, username, email, password string) (*do
 		return nil, err
 	}
 
+	// Links sent to the previous address must not verify the new one, even when no new link
+	// can be sent right now.
+	if emailChanged {
+		if err := u.UserTokenRepository.InvalidateForUser(user.ID, domain.UserTokenEmailVerification, user.UpdatedAt); err != nil {
+			return nil, err
+		}
+		u.sendVerification(user.ID)
+	}
 	return user, nil
 }
 
 func (u *UserUseCase) DeleteUser(id uint) error {
 	return u.UserRepository.Delete(id)
 }
+
+// sendVerification e-mails the verification link. A failure does not undo the registration,
+// since the driver can ask for the link again.
+func (u *UserUseCase) sendVerification(userID uint) {
+	if err := u.Verifier.SendVerification(userID); err != nil {
+		log.Println("Error sending verification e-mail:", err)
+	}
+}
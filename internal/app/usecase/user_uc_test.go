@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingVerifier fails to send verification e-mails, as when the driver hit the hourly limit.
+type failingVerifier struct {
+	sent int
+}
+
+func (v *failingVerifier) SendVerification(uint) error {
+	v.sent++
+	return ErrAccountEmailLimited
+}
+
+func TestUpdateUserInvalidatesVerificationLinksWhenTheAddressChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mockgen.NewMockIUserRepository(ctrl)
+	userTokenRepo := mockgen.NewMockIUserTokenRepository(ctrl)
+	verifier := &failingVerifier{}
+	useCase := NewUserUseCase(userRepo, userTokenRepo, verifier)
+
+	userRepo.EXPECT().FindByID(uint(7)).Return(&domain.User{ID: 7, Username: "driver", Email: "old@example.com"}, nil)
+	userRepo.EXPECT().Update(gomock.Any()).Return(nil)
+	userTokenRepo.EXPECT().InvalidateForUser(uint(7), domain.UserTokenEmailVerification, gomock.Any()).Return(nil)
+
+	user, err := useCase.UpdateUser(7, "driver", "new@example.com", "")
+	assert.NoError(t, err)
+	assert.False(t, user.EmailVerified())
+	assert.Equal(t, 1, verifier.sent)
+}
+
+func TestUpdateUserKeepsVerificationLinksOfAnUnchangedAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mockgen.NewMockIUserRepository(ctrl)
+	verifier := &failingVerifier{}
+	useCase := NewUserUseCase(userRepo, mockgen.NewMockIUserTokenRepository(ctrl), verifier)
+
+	userRepo.EXPECT().FindByID(uint(7)).Return(&domain.User{ID: 7, Username: "driver", Email: "driver@example.com"}, nil)
+	userRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	_, err := useCase.UpdateUser(7, "renamed", "Driver@example.com", "")
+	assert.NoError(t, err)
+	assert.Zero(t, verifier.sent)
+}
@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/repository"
+)
+
+const defaultQuoteMinutes = 60
+
+var (
+	ErrInvalidVehicleType = errors.New("vehicle type must be one of car, motorcycle, ev or van")
+	ErrInvalidPlate       = errors.New("plate does not match the Colombian format for the vehicle type")
+	ErrDuplicatePlate     = errors.New("a vehicle with this plate is already registered")
+)
+
+type IVehicleUseCase interface {
+	CreateVehicle(userID uint, req VehicleRequest) (*domain.Vehicle, error)
+	UpdateVehicle(userID uint, vehicleID uint, req VehicleRequest) (*domain.Vehicle, error)
+	DeleteVehicle(userID uint, vehicleID uint) error
+	SetDefaultVehicle(userID uint, vehicleID uint) error
+	ListVehicles(userID uint) ([]domain.Vehicle, error)
+	DefaultSpotFilter(userID uint) (*domain.SpotFilter, error)
+	Quote(userID uint, parkingLotID uint, req QuoteRequest) (*QuoteResponse, error)
+}
+
+type VehicleUseCase struct {
+	VehicleRepository     repository.IVehicleRepository
+	ParkingLotRepository  repository.IParkingLotRepository
+	SensorRepository      repository.ISensorRepository
+	ReservationRepository repository.IReservationRepository
+	now                   func() time.Time
+}
+
+type VehicleRequest struct {
+	Plate               string `json:"plate" binding:"required"`
+	Type                string `json:"type" binding:"required"`
+	AccessibilityPermit bool   `json:"accessibility_permit"`
+}
+
+// QuoteRequest selects the vehicle to quote: a vehicle of the driver, their default vehicle, or
+// just a vehicle type for anonymous drivers.
+type QuoteRequest struct {
+	Minutes     uint
+	VehicleID   uint
+	VehicleType string
+}
+
+type QuoteResponse struct {
+	ParkingLotID           uint   `json:"parking_lot_id"`
+	VehicleID              *uint  `json:"vehicle_id,omitempty"`
+	VehicleType            string `json:"vehicle_type"`
+	Minutes                uint   `json:"minutes"`
+	HourlyRate             uint   `json:"hourly_rate"`
+	BillingFractionMinutes uint   `json:"billing_fraction_minutes"`
+	Amount                 uint   `json:"amount"`
+	Currency               string `json:"currency"`
+	AvailableSpaces        uint   `json:"available_spaces"`
+}
+
+// NewVehicleUseCase creates a new instance of VehicleUseCase.
+func NewVehicleUseCase(vehicleRepo repository.IVehicleRepository, parkingLotRepo repository.IParkingLotRepository, sensorRepo repository.ISensorRepository, reservationRepo repository.IReservationRepository) IVehicleUseCase {
+	return &VehicleUseCase{
+		VehicleRepository:     vehicleRepo,
+		ParkingLotRepository:  parkingLotRepo,
+		SensorRepository:      sensorRepo,
+		ReservationRepository: reservationRepo,
+		now:                   time.Now,
+	}
+}
+
+// CreateVehicle registers a vehicle of the driver. Their first vehicle becomes the default one.
+func (uc *VehicleUseCase) CreateVehicle(userID uint, req VehicleRequest) (*domain.Vehicle, error) {
+	plate, err := validateVehicle(req)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.VehicleRepository.FindByUserAndPlate(userID, plate)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrDuplicatePlate
+	}
+
+	current, err := uc.VehicleRepository.FindDefault(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicle := &domain.Vehicle{
+		UserID:              userID,
+		Plate:               plate,
+		Type:                req.Type,
+		AccessibilityPermit: req.AccessibilityPermit,
+		IsDefault:           current == nil,
+	}
+	if err := uc.VehicleRepository.Create(vehicle); err != nil {
+		return nil, err
+	}
+	return vehicle, nil
+}
+
+// UpdateVehicle changes the plate, type or accessibility permit of a vehicle of the driver.
+func (uc *VehicleUseCase) UpdateVehicle(userID uint, vehicleID uint, req VehicleRequest) (*domain.Vehicle, error) {
+	plate, err := validateVehicle(req)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicle, err := uc.VehicleRepository.GetByID(userID, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if plate != vehicle.Plate {
+		existing, err := uc.VehicleRepository.FindByUserAndPlate(userID, plate)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, ErrDuplicatePlate
+		}
+	}
+
+	vehicle.Plate = plate
+	vehicle.Type = req.Type
+	vehicle.AccessibilityPermit = req.AccessibilityPermit
+	if err := uc.VehicleRepository.Update(vehicle); err != nil {
+		return nil, err
+	}
+	return vehicle, nil
+}
+
+// DeleteVehicle removes a vehicle of the driver. When it was the default vehicle, the oldest
+// remaining one takes its place.
+func (uc *VehicleUseCase) DeleteVehicle(userID uint, vehicleID uint) error {
+	vehicle, err := uc.VehicleRepository.GetByID(userID, vehicleID)
+	if err != nil {
+		return err
+	}
+	if err := uc.VehicleRepository.Delete(userID, vehicleID); err != nil {
+		return err
+	}
+	if !vehicle.IsDefault {
+		return nil
+	}
+
+	remaining, err := uc.VehicleRepository.ListByUser(userID)
+	if err != nil || len(remaining) == 0 {
+		return err
+	}
+	return uc.VehicleRepository.SetDefault(userID, remaining[0].ID)
+}
+
+// SetDefaultVehicle makes a vehicle the default vehicle of the driver.
+func (uc *VehicleUseCase) SetDefaultVehicle(userID uint, vehicleID uint) error {
+	return uc.VehicleRepository.SetDefault(userID, vehicleID)
+}
+
+// ListVehicles retrieves the vehicles of the driver, the default one first.
+func (uc *VehicleUseCase) ListVehicles(userID uint) ([]domain.Vehicle, error) {
+	return uc.VehicleRepository.ListByUser(userID)
+}
+
+// DefaultSpotFilter returns the spots the default vehicle of the driver can use, or nil when
+// they have not registered a vehicle.
+func (uc *VehicleUseCase) DefaultSpotFilter(userID uint) (*domain.SpotFilter, error) {
+	vehicle, err := uc.VehicleRepository.FindDefault(userID)
+	if err != nil || vehicle == nil {
+		return nil, err
+	}
+	filter := vehicle.SpotFilter()
+	return &filter, nil
+}
+
+// Quote prices a stay in the parking lot for the vehicle and counts the free spots it can use.
+func (uc *VehicleUseCase) Quote(userID uint, parkingLotID uint, req QuoteRequest) (*QuoteResponse, error) {
+	vehicle, err := uc.quotedVehicle(userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	parkingLot, err := uc.ParkingLotRepository.GetByID(parkingLotID)
+	if err != nil {
+		return nil, err
+	}
+
+	sensors, err := uc.SensorRepository.ListByParkingLot(parkingLotID)
+	if err != nil {
+		return nil, err
+	}
+	held, err := uc.ReservationRepository.ListActiveByParkingLot(parkingLotID, uc.now())
+	if err != nil {
+		return nil, err
+	}
+	heldSensors := make(map[uint]bool)
+	for _, reservation := range held {
+		heldSensors[reservation.SensorID] = true
+	}
+
+	filter := vehicle.SpotFilter()
+	var available uint
+	for _, sensor := range sensors {
+		if sensor.Status == domain.SensorStatusFree && !heldSensors[sensor.ID] && filter.Matches(sensor) {
+			available++
+		}
+	}
+
+	minutes := req.Minutes
+	if minutes == 0 {
+		minutes = defaultQuoteMinutes
+	}
+	rate := parkingLot.HourlyRateFor(vehicle.Type)
+
+	response := &QuoteResponse{
+		ParkingLotID:           parkingLot.ID,
+		VehicleType:            vehicle.Type,
+		Minutes:                minutes,
+		HourlyRate:             rate,
+		BillingFractionMinutes: parkingLot.BillingFractionMinutes,
+		Amount:                 sessionCost(rate, parkingLot.BillingFractionMinutes, time.Duration(minutes)*time.Minute),
+		Currency:               paymentCurrency,
+		AvailableSpaces:        available,
+	}
+	if vehicle.ID != 0 {
+		response.VehicleID = &vehicle.ID
+	}
+	return response, nil
+}
+
+// quotedVehicle resolves the vehicle of a quote, defaulting to a car without permit.
+func (uc *VehicleUseCase) quotedVehicle(userID uint, req QuoteRequest) (*domain.Vehicle, error) {
+	if userID != 0 && req.VehicleID != 0 {
+		return uc.VehicleRepository.GetByID(userID, req.VehicleID)
+	}
+	if req.VehicleType != "" {
+		if !domain.ValidVehicleType(req.VehicleType) {
+			return nil, ErrInvalidVehicleType
+		}
+		return &domain.Vehicle{Type: req.VehicleType}, nil
+	}
+	if userID != 0 {
+		vehicle, err := uc.VehicleRepository.FindDefault(userID)
+		if err != nil {
+			return nil, err
+		}
+		if vehicle != nil {
+			return vehicle, nil
+		}
+	}
+	return &domain.Vehicle{Type: domain.VehicleTypeCar}, nil
+}
+
+// validateVehicle checks the type and plate of the request and returns the normalized plate.
+func validateVehicle(req VehicleRequest) (string, error) {
+	if !domain.ValidVehicleType(req.Type) {
+		return "", ErrInvalidVehicleType
+	}
+	plate := domain.NormalizePlate(req.Plate)
+	if !domain.ValidPlate(plate, req.Type) {
+		return "", ErrInvalidPlate
+	}
+	return plate, nil
+}
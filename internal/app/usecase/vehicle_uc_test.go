@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/test/shared/mockgen"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupVehicleTest(t *testing.T) (*gomock.Controller, *mockgen.MockIVehicleRepository, *mockgen.MockIParkingLotRepository, *mockgen.MockISensorRepository, *mockgen.MockIReservationRepository, *VehicleUseCase) {
+	ctrl := gomock.NewController(t)
+	vehicleRepo := mockgen.NewMockIVehicleRepository(ctrl)
+	parkingLotRepo := mockgen.NewMockIParkingLotRepository(ctrl)
+	sensorRepo := mockgen.NewMockISensorRepository(ctrl)
+	reservationRepo := mockgen.NewMockIReservationRepository(ctrl)
+	useCase := NewVehicleUseCase(vehicleRepo, parkingLotRepo, sensorRepo, reservationRepo).(*VehicleUseCase)
+	useCase.now = func() time.Time { return time.Date(2024, time.September, 10, 8, 0, 0, 0, time.UTC) }
+	return ctrl, vehicleRepo, parkingLotRepo, sensorRepo, reservationRepo, useCase
+}
+
+func TestNormalizeAndValidatePlates(t *testing.T) {
+	assert.Equal(t, "ABC123", domain.NormalizePlate(" abc-123 "))
+	assert.Equal(t, "XYZ12D", domain.NormalizePlate("xyz 12d"))
+
+	assert.True(t, domain.ValidPlate("ABC123", domain.VehicleTypeCar))
+	assert.True(t, domain.ValidPlate("ABC123", domain.VehicleTypeVan))
+	assert.True(t, domain.ValidPlate("ABC12D", domain.VehicleTypeMotorcycle))
+	assert.False(t, domain.ValidPlate("ABC12D", domain.VehicleTypeCar))
+	assert.False(t, domain.ValidPlate("ABC123", domain.VehicleTypeMotorcycle))
+	assert.False(t, domain.ValidPlate("AB1234", domain.VehicleTypeCar))
+}
+
+func TestCreateVehicleNormalizesPlateAndMakesFirstDefault(t *testing.T) {
+	ctrl, vehicleRepo, _, _, _, useCase := setupVehicleTest(t)
+	defer ctrl.Finish()
+
+	vehicleRepo.EXPECT().FindByUserAndPlate(uint(7), "ABC123").Return(nil, nil)
+	vehicleRepo.EXPECT().FindDefault(uint(7)).Return(nil, nil)
+	vehicleRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	vehicle, err := useCase.CreateVehicle(7, VehicleRequest{Plate: "abc-123", Type: domain.VehicleTypeEV})
+	assert.NoError(t, err)
+	assert.Equal(t, "ABC123", vehicle.Plate)
+	assert.True(t, vehicle.IsDefault)
+}
+
+func TestCreateVehicleRejectsInvalidPlateAndDuplicates(t *testing.T) {
+	ctrl, vehicleRepo, _, _, _, useCase := setupVehicleTest(t)
+	defer ctrl.Finish()
+
+	_, err := useCase.CreateVehicle(7, VehicleRequest{Plate: "ABC123", Type: domain.VehicleTypeMotorcycle})
+	assert.ErrorIs(t, err, ErrInvalidPlate)
+
+	_, err = useCase.CreateVehicle(7, VehicleRequest{Plate: "ABC123", Type: "truck"})
+	assert.ErrorIs(t, err, ErrInvalidVehicleType)
+
+	vehicleRepo.EXPECT().FindByUserAndPlate(uint(7), "ABC123").Return(&domain.Vehicle{ID: 1}, nil)
+	_, err = useCase.CreateVehicle(7, VehicleRequest{Plate: "ABC 123", Type: domain.VehicleTypeCar})
+	assert.ErrorIs(t, err, ErrDuplicatePlate)
+}
+
+func TestDeleteDefaultVehiclePromotesNextOne(t *testing.T) {
+	ctrl, vehicleRepo, _, _, _, useCase := setupVehicleTest(t)
+	defer ctrl.Finish()
+
+	vehicleRepo.EXPECT().GetByID(uint(7), uint(1)).Return(&domain.Vehicle{ID: 1, UserID: 7, IsDefault: true}, nil)
+	vehicleRepo.EXPECT().Delete(uint(7), uint(1)).Return(nil)
+	vehicleRepo.EXPECT().ListByUser(uint(7)).Return([]domain.Vehicle{{ID: 2, UserID: 7}}, nil)
+	vehicleRepo.EXPECT().SetDefault(uint(7), uint(2)).Return(nil)
+
+	assert.NoError(t, useCase.DeleteVehicle(7, 1))
+}
+
+func TestQuoteUsesDefaultVehicleSpotsAndTariff(t *testing.T) {
+	ctrl, vehicleRepo, parkingLotRepo, sensorRepo, reservationRepo, useCase := setupVehicleTest(t)
+	defer ctrl.Finish()
+
+	vehicleRepo.EXPECT().FindDefault(uint(7)).Return(&domain.Vehicle{ID: 3, UserID: 7, Type: domain.VehicleTypeMotorcycle}, nil)
+	parkingLotRepo.EXPECT().GetByID(uint(1)).Return(&domain.ParkingLot{ID: 1, HourlyRate: 6000, MotorcycleHourlyRate: 2400, BillingFractionMinutes: 15}, nil)
+	sensorRepo.EXPECT().ListByParkingLot(uint(1)).Return([]domain.Sensor{
+		{ID: 1, Status: domain.SensorStatusFree, SpotType: domain.SpotTypeCar},
+		{ID: 2, Status: domain.SensorStatusFree, SpotType: domain.SpotTypeMotorcycle},
+		{ID: 3, Status: domain.SensorStatusFree, SpotType: domain.SpotTypeMotorcycle},
+		{ID: 4, Status: domain.SensorStatusFree, SpotType: domain.SpotTypeMotorcycle, Accessible: true},
+		{ID: 5, Status: domain.SensorStatusOccupied, SpotType: domain.SpotTypeMotorcycle},
+	}, nil)
+	reservationRepo.EXPECT().ListActiveByParkingLot(uint(1), gomock.Any()).Return([]domain.Reservation{{SensorID: 3}}, nil)
+
+	quote, err := useCase.Quote(7, 1, QuoteRequest{Minutes: 50})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.VehicleTypeMotorcycle, quote.VehicleType)
+	assert.Equal(t, uint(3), *quote.VehicleID)
+	assert.Equal(t, uint(2400), quote.HourlyRate)
+	assert.Equal(t, uint(2400), quote.Amount)
+	assert.Equal(t, uint(1), quote.AvailableSpaces)
+}
+
+func TestSpotFilterForEVIncludesCarSpots(t *testing.T) {
+	filter := domain.Vehicle{Type: domain.VehicleTypeEV, AccessibilityPermit: true}.SpotFilter()
+
+	assert.True(t, filter.Matches(domain.Sensor{SpotType: domain.SpotTypeEV}))
+	assert.True(t, filter.Matches(domain.Sensor{SpotType: domain.SpotTypeCar, Accessible: true}))
+	assert.False(t, filter.Matches(domain.Sensor{SpotType: domain.SpotTypeVan}))
+}
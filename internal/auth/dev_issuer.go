@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+)
+
+const (
+	// DevIssuerName is the issuer of the tokens minted by DevIssuer.
+	DevIssuerName = "parking-radar-dev"
+	// DefaultDevKeyFile is where the dev issuer key is kept when no path is configured.
+	DefaultDevKeyFile = ".dev-issuer-key.pem"
+	devKeyBits        = 2048
+)
+
+var ErrInvalidDevKey = errors.New("dev issuer key file does not hold an RSA private key")
+
+// DevIssuer mints admin tokens with any roles and serves the key they are verified with. It
+// replaces Auth0 on laptops and in tests; it must never be enabled in production.
+type DevIssuer struct {
+	Audience   string
+	RolesClaim string
+	key        *rsa.PrivateKey
+	keyID      string
+	set        jwk.Set
+	now        func() time.Time
+}
+
+// NewDevIssuer creates a new instance of DevIssuer signing with key.
+func NewDevIssuer(key *rsa.PrivateKey, audience string) (*DevIssuer, error) {
+	public, err := jwk.New(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := jwk.AssignKeyID(public); err != nil {
+		return nil, err
+	}
+	if err := public.Set(jwk.AlgorithmKey, jwa.RS256); err != nil {
+		return nil, err
+	}
+
+	set := jwk.NewSet()
+	set.Add(public)
+	return &DevIssuer{
+		Audience:   audience,
+		RolesClaim: DefaultRolesClaim,
+		key:        key,
+		keyID:      public.KeyID(),
+		set:        set,
+		now:        time.Now,
+	}, nil
+}
+
+// GenerateDevIssuer creates a DevIssuer with a new random key.
+func GenerateDevIssuer(audience string) (*DevIssuer, error) {
+	key, err := rsa.GenerateKey(rand.Reader, devKeyBits)
+	if err != nil {
+		return nil, err
+	}
+	return NewDevIssuer(key, audience)
+}
+
+// LoadOrCreateDevIssuer creates a DevIssuer with the PEM key at path, generating the file when it
+// does not exist. Sharing the file lets the server verify tokens minted by the CLI.
+func LoadOrCreateDevIssuer(path, audience string) (*DevIssuer, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		key, err := rsa.GenerateKey(rand.Reader, devKeyBits)
+		if err != nil {
+			return nil, err
+		}
+		block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+		if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+			return nil, err
+		}
+		return NewDevIssuer(key, audience)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidDevKey
+	}
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		parsed, errPKCS8 := x509.ParsePKCS8PrivateKey(block.Bytes)
+		rsaKey, ok := parsed.(*rsa.PrivateKey)
+		if errPKCS8 != nil || !ok {
+			return nil, ErrInvalidDevKey
+		}
+		key = rsaKey
+	}
+	return NewDevIssuer(key, audience)
+}
+
+func (d *DevIssuer) KeySet(context.Context) (jwk.Set, error) {
+	return d.set, nil
+}
+
+// WriteJWKS writes the public key of the issuer as a JWKS document, to be served by FileJWKS.
+func (d *DevIssuer) WriteJWKS(path string) error {
+	data, err := json.MarshalIndent(d.set, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Mint signs a token for the admin subject with the given roles, valid for ttl.
+func (d *DevIssuer) Mint(subject string, roles []string, ttl time.Duration) (string, time.Time, error) {
+	if subject == "" {
+		return "", time.Time{}, errors.New("subject is required")
+	}
+	if ttl <= 0 {
+		return "", time.Time{}, fmt.Errorf("invalid token lifetime %s", ttl)
+	}
+
+	now := d.now()
+	expiresAt := now.Add(ttl)
+	claims := jwt.MapClaims{
+		"iss":        DevIssuerName,
+		"sub":        subject,
+		"iat":        now.Unix(),
+		"exp":        expiresAt.Unix(),
+		d.RolesClaim: append([]string{}, roles...),
+	}
+	if d.Audience != "" {
+		claims["aud"] = d.Audience
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = d.keyID
+	signed, err := token.SignedString(d.key)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func parseWith(t *testing.T, source KeySource, token string) (jwt.MapClaims, error) {
+	set, err := source.KeySet(context.Background())
+	assert.NoError(t, err)
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		key, found := set.LookupKeyID(token.Header["kid"].(string))
+		if !found {
+			return nil, jwt.ErrTokenUnverifiable
+		}
+		var raw interface{}
+		if err := key.Raw(&raw); err != nil {
+			return nil, err
+		}
+		return raw, nil
+	})
+	return claims, err
+}
+
+func TestDevIssuerMintsTokensVerifiableWithItsKeySet(t *testing.T) {
+	issuer, err := GenerateDevIssuer("https://api.parking-radar")
+	assert.NoError(t, err)
+
+	token, expiresAt, err := issuer.Mint("auth0|123", []string{"admin_global"}, time.Hour)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Minute)
+
+	claims, err := parseWith(t, issuer, token)
+	assert.NoError(t, err)
+	assert.Equal(t, "auth0|123", claims["sub"])
+	assert.Equal(t, DevIssuerName, claims["iss"])
+	assert.Equal(t, "https://api.parking-radar", claims["aud"])
+	assert.Equal(t, []interface{}{"admin_global"}, claims[DefaultRolesClaim])
+
+	other, err := GenerateDevIssuer("")
+	assert.NoError(t, err)
+	_, err = parseWith(t, other, token)
+	assert.Error(t, err)
+}
+
+func TestLoadOrCreateDevIssuerReusesTheKeyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dev-key.pem")
+
+	created, err := LoadOrCreateDevIssuer(path, "")
+	assert.NoError(t, err)
+	token, _, err := created.Mint("auth0|123", nil, time.Minute)
+	assert.NoError(t, err)
+
+	loaded, err := LoadOrCreateDevIssuer(path, "")
+	assert.NoError(t, err)
+	_, err = parseWith(t, loaded, token)
+	assert.NoError(t, err)
+}
+
+func TestFileJWKSServesTheKeysOfTheDocument(t *testing.T) {
+	issuer, err := GenerateDevIssuer("")
+	assert.NoError(t, err)
+	token, _, err := issuer.Mint("auth0|123", []string{"admin_local"}, time.Minute)
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	assert.NoError(t, issuer.WriteJWKS(path))
+
+	source, err := NewFileJWKS(path)
+	assert.NoError(t, err)
+	_, err = parseWith(t, source, token)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,45 @@
+package auth
+
+import "time"
+
+// Principal is the admin authenticated by a validated token, with the permissions granted by
+// their roles. While a global admin impersonates an admin, the principal is that admin and
+// ImpersonatedBy the subject of the global admin.
+type Principal struct {
+	Subject        string
+	Issuer         string
+	Roles          []string
+	Permissions    []string
+	ExpiresAt      time.Time
+	ImpersonatedBy string
+}
+
+// HasRole reports whether the principal holds the role.
+func (p *Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAnyRole reports whether the principal holds at least one of the roles.
+func (p *Principal) HasAnyRole(roles ...string) bool {
+	for _, role := range roles {
+		if p.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// Can reports whether the principal was granted the permission.
+func (p *Principal) Can(permission string) bool {
+	for _, granted := range p.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,302 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwk"
+)
+
+const (
+	DefaultMinJWKSRefresh        = 5 * time.Minute
+	DefaultMaxJWKSRefresh        = 24 * time.Hour
+	DefaultUnknownKeyRefresh     = time.Minute
+	defaultJWKSFetchTimeout      = 10 * time.Second
+	maxJWKSResponseBytes         = 1 << 20
+	jwksRefreshRetryAfterFailure = 30 * time.Second
+)
+
+var ErrKeysUnavailable = errors.New("token signing keys are unavailable")
+
+// RemoteJWKS is a shared cache of the keys published at a JWKS endpoint such as Auth0's. Keys are
+// kept for as long as the Cache-Control or Expires headers of the endpoint allow, bounded by
+// MinRefresh and MaxRefresh, and are refreshed in the background by Run. A token signed with an
+// unknown key ID triggers a refresh, at most once per UnknownKeyRefresh.
+//
+// While the endpoint is unreachable and the keys have expired, a fail-open cache keeps verifying
+// with the last keys it fetched; a fail-closed cache rejects every token until a fetch succeeds.
+type RemoteJWKS struct {
+	URL               string
+	Client            *http.Client
+	MinRefresh        time.Duration
+	MaxRefresh        time.Duration
+	UnknownKeyRefresh time.Duration
+	FailOpen          bool
+
+	fetchMutex sync.Mutex
+	mutex      sync.RWMutex
+	set        jwk.Set
+	fetchedAt  time.Time
+	expiresAt  time.Time
+	retryAt    time.Time
+	lastError  error
+	lastForced time.Time
+	metrics    JWKSMetrics
+	now        func() time.Time
+}
+
+// JWKSMetrics is a snapshot of the activity of a RemoteJWKS cache.
+type JWKSMetrics struct {
+	URL                  string     `json:"url"`
+	FailOpen             bool       `json:"fail_open"`
+	Keys                 int        `json:"keys"`
+	Fetches              int64      `json:"fetches"`
+	FetchFailures        int64      `json:"fetch_failures"`
+	UnknownKeyRefreshes  int64      `json:"unknown_key_refreshes"`
+	RateLimitedRefreshes int64      `json:"rate_limited_refreshes"`
+	StaleServes          int64      `json:"stale_serves"`
+	Rejections           int64      `json:"rejections"`
+	LastFetchAt          *time.Time `json:"last_fetch_at,omitempty"`
+	ExpiresAt            *time.Time `json:"expires_at,omitempty"`
+	LastError            string     `json:"last_error,omitempty"`
+}
+
+// NewRemoteJWKS creates a new instance of RemoteJWKS for the JWKS at url, failing open with the
+// default refresh intervals.
+func NewRemoteJWKS(url string) *RemoteJWKS {
+	return &RemoteJWKS{
+		URL:               url,
+		Client:            &http.Client{Timeout: defaultJWKSFetchTimeout},
+		MinRefresh:        DefaultMinJWKSRefresh,
+		MaxRefresh:        DefaultMaxJWKSRefresh,
+		UnknownKeyRefresh: DefaultUnknownKeyRefresh,
+		FailOpen:          true,
+		now:               time.Now,
+	}
+}
+
+func (s *RemoteJWKS) KeySet(ctx context.Context) (jwk.Set, error) {
+	if set, ok := s.freshSet(); ok {
+		return set, nil
+	}
+
+	s.fetchMutex.Lock()
+	defer s.fetchMutex.Unlock()
+
+	// Another request may have refreshed the keys while this one waited.
+	if set, ok := s.freshSet(); ok {
+		return set, nil
+	}
+
+	s.mutex.RLock()
+	retryAt := s.retryAt
+	s.mutex.RUnlock()
+	if !s.now().Before(retryAt) {
+		if err := s.fetch(ctx); err == nil {
+			set, _ := s.freshSet()
+			return set, nil
+		}
+	}
+	return s.staleSet()
+}
+
+// RefreshUnknownKey fetches the keys again because a token names a key ID missing from the
+// cache. Refreshes are rate limited, so tokens with made-up key IDs cannot flood the provider.
+func (s *RemoteJWKS) RefreshUnknownKey(ctx context.Context, keyID string) (jwk.Set, error) {
+	s.fetchMutex.Lock()
+	defer s.fetchMutex.Unlock()
+
+	s.mutex.Lock()
+	if s.set != nil {
+		if _, found := s.set.LookupKeyID(keyID); found {
+			set := s.set
+			s.mutex.Unlock()
+			return set, nil
+		}
+	}
+	now := s.now()
+	if !s.lastForced.IsZero() && now.Before(s.lastForced.Add(s.UnknownKeyRefresh)) {
+		s.metrics.RateLimitedRefreshes++
+		s.mutex.Unlock()
+		return s.currentSet()
+	}
+	s.lastForced = now
+	s.metrics.UnknownKeyRefreshes++
+	s.mutex.Unlock()
+
+	if err := s.fetch(ctx); err != nil {
+		return s.staleSet()
+	}
+	return s.currentSet()
+}
+
+// Run refreshes the keys whenever they expire until ctx is done.
+func (s *RemoteJWKS) Run(ctx context.Context) {
+	for {
+		timer := time.NewTimer(s.nextRefreshIn())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.fetchMutex.Lock()
+		if err := s.fetch(ctx); err != nil {
+			log.Printf("Failed to refresh JWKS from %s: %v", s.URL, err)
+		}
+		s.fetchMutex.Unlock()
+	}
+}
+
+// Metrics returns a snapshot of the cache activity.
+func (s *RemoteJWKS) Metrics() JWKSMetrics {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	metrics := s.metrics
+	metrics.URL = s.URL
+	metrics.FailOpen = s.FailOpen
+	if s.set != nil {
+		metrics.Keys = s.set.Len()
+		fetchedAt, expiresAt := s.fetchedAt, s.expiresAt
+		metrics.LastFetchAt = &fetchedAt
+		metrics.ExpiresAt = &expiresAt
+	}
+	if s.lastError != nil {
+		metrics.LastError = s.lastError.Error()
+	}
+	return metrics
+}
+
+func (s *RemoteJWKS) freshSet() (jwk.Set, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.set == nil || !s.now().Before(s.expiresAt) {
+		return nil, false
+	}
+	return s.set, true
+}
+
+func (s *RemoteJWKS) currentSet() (jwk.Set, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.set == nil {
+		return nil, fmt.Errorf("%w: %v", ErrKeysUnavailable, s.lastError)
+	}
+	return s.set, nil
+}
+
+// staleSet returns the expired keys when failing open, or an error when failing closed.
+func (s *RemoteJWKS) staleSet() (jwk.Set, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.set != nil && s.FailOpen {
+		s.metrics.StaleServes++
+		return s.set, nil
+	}
+	s.metrics.Rejections++
+	return nil, fmt.Errorf("%w: %v", ErrKeysUnavailable, s.lastError)
+}
+
+func (s *RemoteJWKS) nextRefreshIn() time.Duration {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	now := s.now()
+	next := s.expiresAt
+	if s.retryAt.After(next) {
+		next = s.retryAt
+	}
+	if !next.After(now) {
+		return 0
+	}
+	return next.Sub(now)
+}
+
+// fetch downloads the keys and stores them with their lifetime. Callers hold fetchMutex.
+func (s *RemoteJWKS) fetch(ctx context.Context) error {
+	set, ttl, err := s.download(ctx)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	s.metrics.Fetches++
+	if err != nil {
+		s.metrics.FetchFailures++
+		s.lastError = err
+		s.retryAt = now.Add(min(jwksRefreshRetryAfterFailure, s.MinRefresh))
+		return err
+	}
+	s.set = set
+	s.fetchedAt = now
+	s.expiresAt = now.Add(ttl)
+	s.retryAt = time.Time{}
+	s.lastError = nil
+	return nil
+}
+
+func (s *RemoteJWKS) download(ctx context.Context) (jwk.Set, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	res, err := s.Client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("unexpected JWKS response status %d", res.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxJWKSResponseBytes))
+	if err != nil {
+		return nil, 0, err
+	}
+	set, err := jwk.Parse(body)
+	if err != nil {
+		return nil, 0, err
+	}
+	if set.Len() == 0 {
+		return nil, 0, errors.New("JWKS has no keys")
+	}
+	return set, s.cacheLifetime(res.Header), nil
+}
+
+// cacheLifetime reads how long the keys may be cached from the max-age directive or the Expires
+// header, clamped between MinRefresh and MaxRefresh.
+func (s *RemoteJWKS) cacheLifetime(header http.Header) time.Duration {
+	ttl := s.MaxRefresh
+	if maxAge, ok := cacheControlMaxAge(header.Get("Cache-Control")); ok {
+		ttl = maxAge
+	} else if expires, err := http.ParseTime(header.Get("Expires")); err == nil {
+		ttl = expires.Sub(s.now())
+	}
+	return min(max(ttl, s.MinRefresh), s.MaxRefresh)
+}
+
+func cacheControlMaxAge(value string) (time.Duration, bool) {
+	for _, directive := range strings.Split(value, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-cache", "no-store":
+			return 0, true
+		case "max-age":
+			seconds, err := strconv.Atoi(strings.Trim(arg, `"`))
+			if err == nil && seconds >= 0 {
+				return time.Duration(seconds) * time.Second, true
+			}
+		}
+	}
+	return 0, false
+}
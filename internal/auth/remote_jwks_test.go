@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// jwksServer publishes the keys of the current issuer, or fails while down.
+type jwksServer struct {
+	mutex        sync.Mutex
+	issuer       *DevIssuer
+	cacheControl string
+	down         bool
+}
+
+func (s *jwksServer) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.down {
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+	if s.cacheControl != "" {
+		w.Header().Set("Cache-Control", s.cacheControl)
+	}
+	json.NewEncoder(w).Encode(s.issuer.set)
+}
+
+func (s *jwksServer) rotate(t *testing.T) *DevIssuer {
+	issuer, err := GenerateDevIssuer("")
+	assert.NoError(t, err)
+	s.mutex.Lock()
+	s.issuer = issuer
+	s.mutex.Unlock()
+	return issuer
+}
+
+func (s *jwksServer) setDown(down bool) {
+	s.mutex.Lock()
+	s.down = down
+	s.mutex.Unlock()
+}
+
+func newTestRemoteJWKS(t *testing.T, cacheControl string) (*RemoteJWKS, *jwksServer, *time.Time) {
+	server := &jwksServer{cacheControl: cacheControl}
+	server.rotate(t)
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewRemoteJWKS(httpServer.URL)
+	cache.now = func() time.Time { return now }
+	return cache, server, &now
+}
+
+func TestRemoteJWKSCachesKeysForTheirMaxAge(t *testing.T) {
+	cache, _, now := newTestRemoteJWKS(t, "public, max-age=600")
+
+	_, err := cache.KeySet(context.Background())
+	assert.NoError(t, err)
+	*now = now.Add(9 * time.Minute)
+	_, err = cache.KeySet(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), cache.Metrics().Fetches)
+
+	*now = now.Add(2 * time.Minute)
+	_, err = cache.KeySet(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), cache.Metrics().Fetches)
+}
+
+func TestRemoteJWKSClampsCacheLifetime(t *testing.T) {
+	cache, _, now := newTestRemoteJWKS(t, "max-age=15")
+
+	_, err := cache.KeySet(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(DefaultMinJWKSRefresh), *cache.Metrics().ExpiresAt)
+}
+
+func TestRemoteJWKSRefreshesOnUnknownKeyWithRateLimit(t *testing.T) {
+	cache, server, now := newTestRemoteJWKS(t, "max-age=3600")
+	_, err := cache.KeySet(context.Background())
+	assert.NoError(t, err)
+
+	rotated := server.rotate(t)
+	set, err := cache.RefreshUnknownKey(context.Background(), rotated.keyID)
+	assert.NoError(t, err)
+	_, found := set.LookupKeyID(rotated.keyID)
+	assert.True(t, found)
+
+	_, err = cache.RefreshUnknownKey(context.Background(), "made-up")
+	assert.NoError(t, err)
+	metrics := cache.Metrics()
+	assert.Equal(t, int64(2), metrics.Fetches)
+	assert.Equal(t, int64(1), metrics.UnknownKeyRefreshes)
+	assert.Equal(t, int64(1), metrics.RateLimitedRefreshes)
+
+	*now = now.Add(DefaultUnknownKeyRefresh)
+	_, err = cache.RefreshUnknownKey(context.Background(), "made-up")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), cache.Metrics().Fetches)
+}
+
+func TestRemoteJWKSFailOpenServesExpiredKeys(t *testing.T) {
+	cache, server, now := newTestRemoteJWKS(t, "max-age=600")
+	fetched, err := cache.KeySet(context.Background())
+	assert.NoError(t, err)
+
+	server.setDown(true)
+	*now = now.Add(time.Hour)
+	set, err := cache.KeySet(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, fetched, set)
+
+	metrics := cache.Metrics()
+	assert.Equal(t, int64(1), metrics.FetchFailures)
+	assert.Equal(t, int64(1), metrics.StaleServes)
+	assert.NotEmpty(t, metrics.LastError)
+}
+
+func TestRemoteJWKSFailClosedRejectsExpiredKeys(t *testing.T) {
+	cache, server, now := newTestRemoteJWKS(t, "max-age=600")
+	cache.FailOpen = false
+	_, err := cache.KeySet(context.Background())
+	assert.NoError(t, err)
+
+	server.setDown(true)
+	*now = now.Add(time.Hour)
+	_, err = cache.KeySet(context.Background())
+	assert.True(t, errors.Is(err, ErrKeysUnavailable))
+
+	// Retries wait before reaching the provider again.
+	_, err = cache.KeySet(context.Background())
+	assert.True(t, errors.Is(err, ErrKeysUnavailable))
+	assert.Equal(t, int64(2), cache.Metrics().Fetches)
+
+	server.setDown(false)
+	*now = now.Add(time.Minute)
+	_, err = cache.KeySet(context.Background())
+	assert.NoError(t, err)
+}
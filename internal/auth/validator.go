@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// DefaultRolesClaim is the custom claim listing the roles of an admin.
+	DefaultRolesClaim = "https://parkiu.com/roles"
+	DefaultLeeway     = 30 * time.Second
+)
+
+// DefaultAlgorithms are the signing algorithms accepted when none are configured.
+var DefaultAlgorithms = []string{"RS256"}
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// Validator verifies admin tokens: their signature against the key source, their algorithm,
+// issuer, audience and lifetime, allowing Leeway of clock skew. Issuer and audience are only
+// checked when configured.
+type Validator struct {
+	Source     KeySource
+	Issuer     string
+	Audience   string
+	Algorithms []string
+	Leeway     time.Duration
+	RolesClaim string
+}
+
+// NewValidator creates a new instance of Validator with the default algorithms, leeway and
+// roles claim.
+func NewValidator(source KeySource, issuer, audience string) *Validator {
+	return &Validator{
+		Source:     source,
+		Issuer:     issuer,
+		Audience:   audience,
+		Algorithms: DefaultAlgorithms,
+		Leeway:     DefaultLeeway,
+		RolesClaim: DefaultRolesClaim,
+	}
+}
+
+// Validate verifies the token and returns the admin it authenticates. Errors wrap
+// ErrKeysUnavailable when the keys could not be loaded, and ErrInvalidToken otherwise.
+func (v *Validator) Validate(ctx context.Context, tokenString string) (*Principal, error) {
+	set, err := v.Source.KeySet(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(v.Algorithms),
+		jwt.WithLeeway(v.Leeway),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	}
+	if v.Issuer != "" {
+		options = append(options, jwt.WithIssuer(v.Issuer))
+	}
+	if v.Audience != "" {
+		options = append(options, jwt.WithAudience(v.Audience))
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.NewParser(options...).ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		keyID, ok := t.Header["kid"].(string)
+		if !ok {
+			return nil, errors.New("missing key ID (kid) in token header")
+		}
+
+		key, found := set.LookupKeyID(keyID)
+		if refresher, ok := v.Source.(UnknownKeyRefresher); !found && ok {
+			// The provider may have rotated its keys since they were cached.
+			if refreshed, err := refresher.RefreshUnknownKey(ctx, keyID); err == nil {
+				key, found = refreshed.LookupKeyID(keyID)
+			}
+		}
+		if !found {
+			return nil, errors.New("unable to find matching key")
+		}
+		if alg := key.Algorithm(); alg != "" && alg != t.Method.Alg() {
+			return nil, fmt.Errorf("key %s is not used with %s", keyID, t.Method.Alg())
+		}
+
+		var rawKey interface{}
+		if err := key.Raw(&rawKey); err != nil {
+			return nil, errors.New("failed to parse key")
+		}
+		return rawKey, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+	issuer, _ := claims.GetIssuer()
+	principal := &Principal{
+		Subject: subject,
+		Issuer:  issuer,
+		Roles:   rolesFromClaim(claims[v.RolesClaim]),
+	}
+	if expiresAt, err := claims.GetExpirationTime(); err == nil && expiresAt != nil {
+		principal.ExpiresAt = expiresAt.Time
+	}
+	return principal, nil
+}
+
+// rolesFromClaim reads the roles claim, which holds one role or a list of them.
+func rolesFromClaim(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		roles := make([]string, 0, len(v))
+		for _, role := range v {
+			if roleStr, ok := role.(string); ok {
+				roles = append(roles, roleStr)
+			}
+		}
+		return roles
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestValidator(t *testing.T) (*Validator, *DevIssuer) {
+	issuer, err := GenerateDevIssuer("https://api.parking-radar")
+	assert.NoError(t, err)
+	return NewValidator(issuer, DevIssuerName, "https://api.parking-radar"), issuer
+}
+
+func TestValidatorReturnsPrincipal(t *testing.T) {
+	validator, issuer := newTestValidator(t)
+	token, expiresAt, err := issuer.Mint("auth0|123", []string{"admin_local", "admin_global"}, time.Hour)
+	assert.NoError(t, err)
+
+	principal, err := validator.Validate(context.Background(), token)
+	assert.NoError(t, err)
+	assert.Equal(t, "auth0|123", principal.Subject)
+	assert.Equal(t, DevIssuerName, principal.Issuer)
+	assert.Equal(t, []string{"admin_local", "admin_global"}, principal.Roles)
+	assert.Equal(t, expiresAt.Unix(), principal.ExpiresAt.Unix())
+	assert.True(t, principal.HasRole("admin_global"))
+}
+
+func TestValidatorReadsTheConfiguredRolesClaim(t *testing.T) {
+	validator, issuer := newTestValidator(t)
+	validator.RolesClaim = "https://example.com/roles"
+	issuer.RolesClaim = "https://example.com/roles"
+	token, _, err := issuer.Mint("auth0|123", []string{"admin_local"}, time.Hour)
+	assert.NoError(t, err)
+
+	principal, err := validator.Validate(context.Background(), token)
+	assert.NoError(t, err)
+	assert.True(t, principal.HasAnyRole("admin_default", "admin_local"))
+	assert.False(t, principal.HasRole("admin_global"))
+}
+
+func TestValidatorRejectsWrongIssuerAndAudience(t *testing.T) {
+	validator, issuer := newTestValidator(t)
+	token, _, err := issuer.Mint("auth0|123", nil, time.Hour)
+	assert.NoError(t, err)
+
+	validator.Issuer = "https://tenant.auth0.com/"
+	_, err = validator.Validate(context.Background(), token)
+	assert.True(t, errors.Is(err, ErrInvalidToken))
+
+	validator.Issuer = DevIssuerName
+	validator.Audience = "https://other-api"
+	_, err = validator.Validate(context.Background(), token)
+	assert.True(t, errors.Is(err, ErrInvalidToken))
+}
+
+func TestValidatorAllowsClockSkewWithinLeeway(t *testing.T) {
+	validator, issuer := newTestValidator(t)
+	issuer.now = func() time.Time { return time.Now().Add(-time.Hour - 10*time.Second) }
+	token, _, err := issuer.Mint("auth0|123", nil, time.Hour)
+	assert.NoError(t, err)
+
+	_, err = validator.Validate(context.Background(), token)
+	assert.NoError(t, err)
+
+	validator.Leeway = 0
+	_, err = validator.Validate(context.Background(), token)
+	assert.True(t, errors.Is(err, ErrInvalidToken))
+}
+
+func TestValidatorRejectsAlgorithmsNotAllowed(t *testing.T) {
+	validator, issuer := newTestValidator(t)
+
+	// A token signed with HMAC, using the public key as the secret, must not be accepted.
+	claims := jwt.MapClaims{"iss": DevIssuerName, "aud": issuer.Audience, "sub": "auth0|123", "exp": time.Now().Add(time.Hour).Unix()}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = issuer.keyID
+	signed, err := token.SignedString([]byte("public-key"))
+	assert.NoError(t, err)
+
+	_, err = validator.Validate(context.Background(), signed)
+	assert.True(t, errors.Is(err, ErrInvalidToken))
+}
+
+func TestValidatorRejectsTokensWithoutExpiryOrSubject(t *testing.T) {
+	validator, issuer := newTestValidator(t)
+
+	for _, claims := range []jwt.MapClaims{
+		{"iss": DevIssuerName, "aud": issuer.Audience, "sub": "auth0|123"},
+		{"iss": DevIssuerName, "aud": issuer.Audience, "exp": time.Now().Add(time.Hour).Unix()},
+	} {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = issuer.keyID
+		signed, err := token.SignedString(issuer.key)
+		assert.NoError(t, err)
+
+		_, err = validator.Validate(context.Background(), signed)
+		assert.True(t, errors.Is(err, ErrInvalidToken))
+	}
+}
@@ -1,36 +1,309 @@
 package config
 
 import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/input/handler"
 	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/db"
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/mailer"
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/notifier"
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/payment"
+	"github.com/CamiloLeonP/parking-radar/internal/app/adapter/output/storage"
 	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/auth"
 	db2 "github.com/CamiloLeonP/parking-radar/internal/db"
 	"github.com/CamiloLeonP/parking-radar/internal/hub"
-	"log"
+	middlewares "github.com/CamiloLeonP/parking-radar/internal/middleware"
 )
 
+// reservationExpiryInterval is how often held reservations past their window are expired.
+const reservationExpiryInterval = time.Minute
+
 // Handlers stores all the handlers used in the application
 type Handlers struct {
-	UserHandler        *handler.UserHandler
-	ParkingLotHandler  *handler.ParkingLotHandler
-	SensorHandler      *handler.SensorHandler
-	Esp32DeviceHandler *handler.Esp32DeviceHandler
-	WebSocketHandler   *handler.WebSocketHandler
-	AdminHandler       *handler.AdminHandler
+	UserHandler           *handler.UserHandler
+	ParkingLotHandler     *handler.ParkingLotHandler
+	SensorHandler         *handler.SensorHandler
+	Esp32DeviceHandler    *handler.Esp32DeviceHandler
+	WebSocketHandler      *handler.WebSocketHandler
+	AdminHandler          *handler.AdminHandler
+	ForecastHandler       *handler.ForecastHandler
+	ReportHandler         *handler.ReportHandler
+	DashboardHandler      *handler.DashboardHandler
+	PlaybackHandler       *handler.PlaybackHandler
+	UserAuthHandler       *handler.UserAuthHandler
+	FavoriteHandler       *handler.FavoriteHandler
+	AlertHandler          *handler.AlertHandler
+	ReservationHandler    *handler.ReservationHandler
+	SessionHandler        *handler.ParkingSessionHandler
+	PaymentHandler        *handler.PaymentHandler
+	VehicleHandler        *handler.VehicleHandler
+	ReviewHandler         *handler.ReviewHandler
+	CrowdReportHandler    *handler.CrowdReportHandler
+	AccountHandler        *handler.AccountHandler
+	PrivacyHandler        *handler.PrivacyHandler
+	RecommendationHandler *handler.RecommendationHandler
+	AuthorizationHandler  *handler.AuthorizationHandler
+	APIKeyHandler         *handler.APIKeyHandler
+	IntegrationHandler    *handler.IntegrationHandler
+	OrganizationHandler   *handler.OrganizationHandler
+	InvitationHandler     *handler.AdminInvitationHandler
+	GlobalAdminHandler    *handler.GlobalAdminHandler
+	VerificationHandler   *handler.OperatorVerificationHandler
+	ImpersonationHandler  *handler.ImpersonationHandler
+	// AuthKeysHandler is nil unless admin tokens are verified with the Auth0 JWKS
+	AuthKeysHandler *handler.AuthKeysHandler
+	// DevTokenHandler is nil unless admin tokens come from the dev issuer
+	DevTokenHandler *handler.DevTokenHandler
 }
 
 // SetupDependencies initializes all dependencies and returns the handlers
 func SetupDependencies() *Handlers {
 	wsHub := setupWebSocketHub() // Initialize WebSocket hub
+	alertUseCase := setupAlertUseCase()
+	reservationUseCase := setupReservationUseCase()
+	sessionUseCase := setupParkingSessionUseCase(wsHub)
+	userAuthUseCase := setupUserAuthUseCase()
+	vehicleUseCase := setupVehicleUseCase()
+	parkingLotUseCase := setupParkingLotUseCase()
+	accountUseCase := setupAccountUseCase()
+	keySource := setupAuthKeySource()
+	authorizationUseCase := setupAuthorizationUseCase()
+	apiKeyUseCase := setupAPIKeyUseCase()
+	impersonationUseCase := setupImpersonationUseCase()
 
 	return &Handlers{
-		UserHandler:        setupUserHandler(),
-		ParkingLotHandler:  setupParkingLotHandler(wsHub),
-		SensorHandler:      setupSensorHandler(wsHub),
-		Esp32DeviceHandler: setupEsp32DeviceHandler(),
-		WebSocketHandler:   setupWebSocketHandler(wsHub),
-		AdminHandler:       setupAdminHandler(),
+		UserHandler:           setupUserHandler(accountUseCase),
+		ParkingLotHandler:     setupParkingLotHandler(wsHub, parkingLotUseCase, vehicleUseCase),
+		SensorHandler:         setupSensorHandler(wsHub, parkingLotUseCase, alertUseCase, reservationUseCase, sessionUseCase),
+		Esp32DeviceHandler:    setupEsp32DeviceHandler(parkingLotUseCase),
+		WebSocketHandler:      setupWebSocketHandler(wsHub, userAuthUseCase),
+		AdminHandler:          setupAdminHandler(),
+		ForecastHandler:       setupForecastHandler(vehicleUseCase),
+		ReportHandler:         setupReportHandler(),
+		DashboardHandler:      setupDashboardHandler(),
+		PlaybackHandler:       setupPlaybackHandler(parkingLotUseCase),
+		UserAuthHandler:       handler.NewUserAuthHandler(userAuthUseCase),
+		FavoriteHandler:       setupFavoriteHandler(),
+		AlertHandler:          handler.NewAlertHandler(alertUseCase),
+		ReservationHandler:    setupReservationHandler(reservationUseCase, parkingLotUseCase),
+		SessionHandler:        setupParkingSessionHandler(sessionUseCase, parkingLotUseCase),
+		PaymentHandler:        setupPaymentHandler(parkingLotUseCase),
+		VehicleHandler:        handler.NewVehicleHandler(vehicleUseCase),
+		ReviewHandler:         setupReviewHandler(parkingLotUseCase),
+		CrowdReportHandler:    setupCrowdReportHandler(),
+		AccountHandler:        handler.NewAccountHandler(accountUseCase),
+		PrivacyHandler:        setupPrivacyHandler(),
+		RecommendationHandler: setupRecommendationHandler(vehicleUseCase),
+		AuthorizationHandler:  handler.NewAuthorizationHandler(authorizationUseCase),
+		APIKeyHandler:         handler.NewAPIKeyHandler(apiKeyUseCase),
+		IntegrationHandler:    setupIntegrationHandler(parkingLotUseCase),
+		OrganizationHandler:   setupOrganizationHandler(),
+		InvitationHandler:     setupAdminInvitationHandler(),
+		GlobalAdminHandler:    setupGlobalAdminHandler(),
+		VerificationHandler:   setupOperatorVerificationHandler(),
+		ImpersonationHandler:  handler.NewImpersonationHandler(impersonationUseCase),
+		AuthKeysHandler:       setupAuthKeysHandler(keySource),
+		DevTokenHandler:       setupDevTokenHandler(keySource),
+	}
+}
+
+// setupAuthKeySource selects where the keys verifying admin tokens come from, through
+// AUTH_KEY_SOURCE: the Auth0 JWKS (default), a JWKS file or the local dev issuer. Every admin
+// middleware shares the returned source through the token validator.
+func setupAuthKeySource() auth.KeySource {
+	var source auth.KeySource
+	issuer := os.Getenv("AUTH0_DOMAIN")
+	switch name := os.Getenv("AUTH_KEY_SOURCE"); name {
+	case "", "remote":
+		source = setupRemoteJWKS()
+	case "file":
+		fileSource, err := auth.NewFileJWKS(os.Getenv("AUTH_JWKS_FILE"))
+		if err != nil {
+			log.Fatalf("Failed to read AUTH_JWKS_FILE: %v", err)
+		}
+		source = fileSource
+	case "dev":
+		path := os.Getenv("AUTH_DEV_KEY_FILE")
+		if path == "" {
+			path = auth.DefaultDevKeyFile
+		}
+		devIssuer, err := auth.LoadOrCreateDevIssuer(path, os.Getenv("AUTH0_AUDIENCE"))
+		if err != nil {
+			log.Fatalf("Failed to load the dev issuer key: %v", err)
+		}
+		log.Printf("Admin tokens are verified with the dev issuer key %s, never use it in production", path)
+		devIssuer.RolesClaim = rolesClaim()
+		source = devIssuer
+		issuer = auth.DevIssuerName
+	default:
+		log.Fatalf("Unsupported AUTH_KEY_SOURCE %q", name)
+	}
+
+	middlewares.ConfigureValidator(setupTokenValidator(source, issuer))
+	return source
+}
+
+// setupTokenValidator initializes the validator of admin tokens. AUTH_ISSUER overrides the
+// expected issuer, AUTH_ALGORITHMS lists the accepted signing algorithms, AUTH_LEEWAY the
+// tolerated clock skew and AUTH_ROLES_CLAIM the claim holding the roles. The server refuses to
+// start without an issuer and audience to check, unless tokens come from the dev issuer.
+func setupTokenValidator(source auth.KeySource, issuer string) *auth.Validator {
+	if configured := os.Getenv("AUTH_ISSUER"); configured != "" {
+		issuer = configured
+	}
+	audience := os.Getenv("AUTH0_AUDIENCE")
+	if issuer == "" || audience == "" {
+		if _, dev := source.(*auth.DevIssuer); !dev {
+			log.Fatal("AUTH0_DOMAIN or AUTH_ISSUER, and AUTH0_AUDIENCE, must be set to verify admin tokens")
+		}
+		log.Println("Admin token issuer or audience is not configured, the dev issuer tokens will not be checked against them")
+	}
+
+	validator := auth.NewValidator(source, issuer, audience)
+	validator.Leeway = durationFromEnv("AUTH_LEEWAY", auth.DefaultLeeway)
+	validator.RolesClaim = rolesClaim()
+	if algorithms := os.Getenv("AUTH_ALGORITHMS"); algorithms != "" {
+		validator.Algorithms = nil
+		for _, algorithm := range strings.Split(algorithms, ",") {
+			if algorithm = strings.TrimSpace(algorithm); algorithm != "" {
+				validator.Algorithms = append(validator.Algorithms, algorithm)
+			}
+		}
+	}
+	return validator
+}
+
+// rolesClaim returns the claim holding the roles of an admin, AUTH_ROLES_CLAIM when set.
+func rolesClaim() string {
+	if claim := os.Getenv("AUTH_ROLES_CLAIM"); claim != "" {
+		return claim
+	}
+	return auth.DefaultRolesClaim
+}
+
+// setupRemoteJWKS initializes the cache of the Auth0 JWKS and refreshes it in the background.
+// AUTH_JWKS_MIN_REFRESH and AUTH_JWKS_MAX_REFRESH bound how long the keys are cached and
+// AUTH_JWKS_FAILURE_MODE (open or closed) decides whether expired keys keep being used while
+// Auth0 is unreachable.
+func setupRemoteJWKS() *auth.RemoteJWKS {
+	cache := auth.NewRemoteJWKS(fmt.Sprintf("%s.well-known/jwks.json", os.Getenv("AUTH0_DOMAIN")))
+	cache.MinRefresh = durationFromEnv("AUTH_JWKS_MIN_REFRESH", auth.DefaultMinJWKSRefresh)
+	cache.MaxRefresh = max(durationFromEnv("AUTH_JWKS_MAX_REFRESH", auth.DefaultMaxJWKSRefresh), cache.MinRefresh)
+
+	switch mode := os.Getenv("AUTH_JWKS_FAILURE_MODE"); mode {
+	case "", "open":
+		cache.FailOpen = true
+	case "closed":
+		cache.FailOpen = false
+	default:
+		log.Fatalf("Unsupported AUTH_JWKS_FAILURE_MODE %q", mode)
+	}
+
+	go cache.Run(context.Background())
+	return cache
+}
+
+// setupAuthorizationUseCase initializes the AuthorizationUseCase and makes the admin
+// middlewares resolve permissions with it
+func setupAuthorizationUseCase() usecase.IAuthorizationUseCase {
+	rolePermissionRepository := &db.RolePermissionRepositoryImpl{DB: db2.DB}
+	authorizationUseCase := usecase.NewAuthorizationUseCase(rolePermissionRepository)
+	middlewares.ConfigurePermissionResolver(authorizationUseCase)
+	return authorizationUseCase
+}
+
+// setupImpersonationUseCase initializes the ImpersonationUseCase and makes the admin
+// middlewares run the requests of impersonating global admins with it
+func setupImpersonationUseCase() usecase.IImpersonationUseCase {
+	impersonationRepository := &db.ImpersonationRepositoryImpl{DB: db2.DB}
+	adminRepository := &db.AdminRepositoryImpl{DB: db2.DB}
+	impersonationUseCase := usecase.NewImpersonationUseCase(impersonationRepository, adminRepository)
+	middlewares.ConfigureImpersonation(impersonationUseCase)
+	return impersonationUseCase
+}
+
+// setupAPIKeyUseCase initializes the APIKeyUseCase
+func setupAPIKeyUseCase() usecase.IAPIKeyUseCase {
+	apiKeyRepository := &db.APIKeyRepositoryImpl{DB: db2.DB}
+	return usecase.NewAPIKeyUseCase(apiKeyRepository)
+}
+
+// setupIntegrationHandler initializes the IntegrationHandler
+func setupIntegrationHandler(parkingLotUseCase usecase.IParkingLotUseCase) *handler.IntegrationHandler {
+	parkingLotRepository := &db.ParkingLotRepositoryImpl{DB: db2.DB}
+	occupancySampleRepository := &db.OccupancySampleRepositoryImpl{DB: db2.DB}
+	occupancyHistoryUseCase := usecase.NewOccupancyHistoryUseCase(parkingLotRepository, occupancySampleRepository)
+	return handler.NewIntegrationHandler(parkingLotUseCase, occupancyHistoryUseCase)
+}
+
+// setupOrganizationHandler initializes the OrganizationHandler
+func setupOrganizationHandler() *handler.OrganizationHandler {
+	organizationRepository := &db.OrganizationRepositoryImpl{DB: db2.DB}
+	adminRepository := &db.AdminRepositoryImpl{DB: db2.DB}
+	parkingLotRepository := &db.ParkingLotRepositoryImpl{DB: db2.DB}
+	organizationUseCase := usecase.NewOrganizationUseCase(organizationRepository, adminRepository, parkingLotRepository)
+	return handler.NewOrganizationHandler(organizationUseCase)
+}
+
+// setupAdminInvitationHandler initializes the AdminInvitationHandler, e-mailing invitations with
+// the account mailer
+func setupAdminInvitationHandler() *handler.AdminInvitationHandler {
+	invitationRepository := &db.AdminInvitationRepositoryImpl{DB: db2.DB}
+	adminRepository := &db.AdminRepositoryImpl{DB: db2.DB}
+	organizationRepository := &db.OrganizationRepositoryImpl{DB: db2.DB}
+	invitationUseCase := usecase.NewAdminInvitationUseCase(invitationRepository, adminRepository, organizationRepository, accountMailer(), appBaseURL())
+	return handler.NewAdminInvitationHandler(invitationUseCase)
+}
+
+// setupGlobalAdminHandler initializes the GlobalAdminHandler
+func setupGlobalAdminHandler() *handler.GlobalAdminHandler {
+	adminRepository := &db.AdminRepositoryImpl{DB: db2.DB}
+	parkingLotRepository := &db.ParkingLotRepositoryImpl{DB: db2.DB}
+	globalAdminUseCase := usecase.NewGlobalAdminUseCase(adminRepository, parkingLotRepository)
+	return handler.NewGlobalAdminHandler(globalAdminUseCase)
+}
+
+// setupOperatorVerificationHandler initializes the OperatorVerificationHandler
+func setupOperatorVerificationHandler() *handler.OperatorVerificationHandler {
+	adminRepository := &db.AdminRepositoryImpl{DB: db2.DB}
+	documentRepository := &db.OperatorDocumentRepositoryImpl{DB: db2.DB}
+	verificationUseCase := usecase.NewOperatorVerificationUseCase(adminRepository, documentRepository, setupDocumentStore())
+	return handler.NewOperatorVerificationHandler(verificationUseCase)
+}
+
+// setupDocumentStore keeps the uploaded operator documents in DOCUMENTS_DIR
+func setupDocumentStore() storage.DocumentStore {
+	dir := os.Getenv("DOCUMENTS_DIR")
+	if dir == "" {
+		dir = "documents"
 	}
+	return storage.NewLocalStore(dir)
+}
+
+// setupAuthKeysHandler initializes the AuthKeysHandler when the keys come from the Auth0 JWKS
+func setupAuthKeysHandler(source auth.KeySource) *handler.AuthKeysHandler {
+	cache, ok := source.(*auth.RemoteJWKS)
+	if !ok {
+		return nil
+	}
+	return handler.NewAuthKeysHandler(cache)
+}
+
+// setupDevTokenHandler initializes the DevTokenHandler when the dev issuer is enabled
+func setupDevTokenHandler(source auth.KeySource) *handler.DevTokenHandler {
+	issuer, ok := source.(*auth.DevIssuer)
+	if !ok {
+		return nil
+	}
+	return handler.NewDevTokenHandler(issuer)
 }
 
 // setupWebSocketHub initializes the WebSocket hub
@@ -44,14 +317,15 @@ func setupWebSocketHub() *hub.WebSocketHub {
 }
 
 // setupWebSocketHandler initializes the WebSocket handler with the hub
-func setupWebSocketHandler(wsHub *hub.WebSocketHub) *handler.WebSocketHandler {
-	return handler.NewWebSocketHandler(wsHub)
+func setupWebSocketHandler(wsHub *hub.WebSocketHub, userAuthUseCase usecase.IUserAuthUseCase) *handler.WebSocketHandler {
+	return handler.NewWebSocketHandler(wsHub, userAuthUseCase)
 }
 
 // setupUserHandler initializes the UserHandler
-func setupUserHandler() *handler.UserHandler {
+func setupUserHandler(accountUseCase usecase.IAccountUseCase) *handler.UserHandler {
 	userRepository := &db.UserRepositoryImpl{DB: db2.DB}
-	userUseCase := usecase.NewUserUseCase(userRepository)
+	userTokenRepository := &db.UserTokenRepositoryImpl{DB: db2.DB}
+	userUseCase := usecase.NewUserUseCase(userRepository, userTokenRepository, accountUseCase)
 	return handler.NewUserHandler(userUseCase)
 }
 
@@ -62,27 +336,325 @@ func setupAdminHandler() *handler.AdminHandler {
 	return handler.NewAdminHandler(adminUseCase)
 }
 
+// setupParkingLotUseCase initializes the parking lot use case shared by the handlers that check
+// access to a parking lot
+func setupParkingLotUseCase() usecase.IParkingLotUseCase {
+	return usecase.NewParkingLotUseCase(
+		&db.ParkingLotRepositoryImpl{DB: db2.DB},
+		&db.SensorRepositoryImpl{DB: db2.DB},
+		&db.AdminRepositoryImpl{DB: db2.DB},
+		&db.ReservationRepositoryImpl{DB: db2.DB},
+		&db.ReviewRepositoryImpl{DB: db2.DB},
+		&db.CrowdReportRepositoryImpl{DB: db2.DB},
+		&db.OrganizationRepositoryImpl{DB: db2.DB},
+	)
+}
+
 // setupParkingLotHandler initializes the ParkingLotHandler with the hub
-func setupParkingLotHandler(wsHub *hub.WebSocketHub) *handler.ParkingLotHandler {
+func setupParkingLotHandler(wsHub *hub.WebSocketHub, parkingLotUseCase usecase.IParkingLotUseCase, vehicleUseCase usecase.IVehicleUseCase) *handler.ParkingLotHandler {
+	return handler.NewParkingLotHandler(parkingLotUseCase, vehicleUseCase, wsHub)
+}
+
+// setupSensorHandler initializes the SensorHandler with the hub, notifying sensor changes to the given listeners
+func setupSensorHandler(wsHub *hub.WebSocketHub, parkingLotUseCase usecase.IParkingLotUseCase, listeners ...usecase.SensorChangeListener) *handler.SensorHandler {
 	sensorRepository := &db.SensorRepositoryImpl{DB: db2.DB}
+	esp32DeviceRepository := &db.Esp32DeviceRepositoryImpl{DB: db2.DB}
+	occupancySampleRepository := &db.OccupancySampleRepositoryImpl{DB: db2.DB}
+	sensorEventRepository := &db.SensorEventRepositoryImpl{DB: db2.DB}
+	lotSnapshotRepository := &db.LotSnapshotRepositoryImpl{DB: db2.DB}
 	parkingLotRepository := &db.ParkingLotRepositoryImpl{DB: db2.DB}
+	sensorUseCase := usecase.NewSensorUseCase(sensorRepository, esp32DeviceRepository, occupancySampleRepository, sensorEventRepository, lotSnapshotRepository, parkingLotRepository, listeners...)
+	return handler.NewSensorHandler(sensorUseCase, parkingLotUseCase, wsHub)
+}
+
+// setupEsp32DeviceHandler initializes the Esp32DeviceHandler
+func setupEsp32DeviceHandler(parkingLotUseCase usecase.IParkingLotUseCase) *handler.Esp32DeviceHandler {
+	esp32DeviceRepository := &db.Esp32DeviceRepositoryImpl{DB: db2.DB}
+	sensorRepository := &db.SensorRepositoryImpl{DB: db2.DB}
 	adminRepository := &db.AdminRepositoryImpl{DB: db2.DB}
-	parkingLotUseCase := usecase.NewParkingLotUseCase(parkingLotRepository, sensorRepository, adminRepository)
-	return handler.NewParkingLotHandler(parkingLotUseCase, wsHub)
+	esp32DeviceUseCase := usecase.NewEsp32DeviceUseCase(esp32DeviceRepository, sensorRepository, adminRepository)
+	return handler.NewEsp32DeviceHandler(esp32DeviceUseCase, parkingLotUseCase)
 }
 
-// setupSensorHandler initializes the SensorHandler with the hub
-func setupSensorHandler(wsHub *hub.WebSocketHub) *handler.SensorHandler {
+// setupForecastHandler initializes the ForecastHandler
+func setupForecastHandler(vehicleUseCase usecase.IVehicleUseCase) *handler.ForecastHandler {
+	parkingLotRepository := &db.ParkingLotRepositoryImpl{DB: db2.DB}
+	sensorRepository := &db.SensorRepositoryImpl{DB: db2.DB}
+	occupancySampleRepository := &db.OccupancySampleRepositoryImpl{DB: db2.DB}
+	reservationRepository := &db.ReservationRepositoryImpl{DB: db2.DB}
+	forecastUseCase := usecase.NewForecastUseCase(parkingLotRepository, sensorRepository, occupancySampleRepository, reservationRepository)
+	return handler.NewForecastHandler(forecastUseCase, vehicleUseCase)
+}
+
+// setupRecommendationHandler initializes the RecommendationHandler. RECOMMENDATION_WEIGHTS
+// overrides the default weights of the score, e.g. "distance=0.5,price=0.2".
+func setupRecommendationHandler(vehicleUseCase usecase.IVehicleUseCase) *handler.RecommendationHandler {
+	parkingLotRepository := &db.ParkingLotRepositoryImpl{DB: db2.DB}
+	sensorRepository := &db.SensorRepositoryImpl{DB: db2.DB}
+	occupancySampleRepository := &db.OccupancySampleRepositoryImpl{DB: db2.DB}
+	reservationRepository := &db.ReservationRepositoryImpl{DB: db2.DB}
+	reviewRepository := &db.ReviewRepositoryImpl{DB: db2.DB}
+	forecastUseCase := usecase.NewForecastUseCase(parkingLotRepository, sensorRepository, occupancySampleRepository, reservationRepository)
+
+	weights, err := usecase.ParseRecommendationWeights(os.Getenv("RECOMMENDATION_WEIGHTS"), usecase.DefaultRecommendationWeights)
+	if err != nil {
+		log.Fatalf("Invalid RECOMMENDATION_WEIGHTS: %v", err)
+	}
+	recommendationUseCase := usecase.NewRecommendationUseCase(parkingLotRepository, sensorRepository, reviewRepository, forecastUseCase, weights)
+	return handler.NewRecommendationHandler(recommendationUseCase, vehicleUseCase)
+}
+
+// setupReportHandler initializes the ReportHandler
+func setupReportHandler() *handler.ReportHandler {
+	adminRepository := &db.AdminRepositoryImpl{DB: db2.DB}
+	parkingLotRepository := &db.ParkingLotRepositoryImpl{DB: db2.DB}
 	sensorRepository := &db.SensorRepositoryImpl{DB: db2.DB}
 	esp32DeviceRepository := &db.Esp32DeviceRepositoryImpl{DB: db2.DB}
-	sensorUseCase := usecase.NewSensorUseCase(sensorRepository, esp32DeviceRepository)
-	return handler.NewSensorHandler(sensorUseCase, wsHub)
+	occupancySampleRepository := &db.OccupancySampleRepositoryImpl{DB: db2.DB}
+	reportUseCase := usecase.NewReportUseCase(adminRepository, parkingLotRepository, sensorRepository, esp32DeviceRepository, occupancySampleRepository)
+	return handler.NewReportHandler(reportUseCase)
 }
 
-// setupEsp32DeviceHandler initializes the Esp32DeviceHandler
-func setupEsp32DeviceHandler() *handler.Esp32DeviceHandler {
+// setupDashboardHandler initializes the DashboardHandler
+func setupDashboardHandler() *handler.DashboardHandler {
+	adminRepository := &db.AdminRepositoryImpl{DB: db2.DB}
+	parkingLotRepository := &db.ParkingLotRepositoryImpl{DB: db2.DB}
+	sensorRepository := &db.SensorRepositoryImpl{DB: db2.DB}
 	esp32DeviceRepository := &db.Esp32DeviceRepositoryImpl{DB: db2.DB}
+	occupancySampleRepository := &db.OccupancySampleRepositoryImpl{DB: db2.DB}
+	organizationRepository := &db.OrganizationRepositoryImpl{DB: db2.DB}
+	dashboardUseCase := usecase.NewDashboardUseCase(adminRepository, parkingLotRepository, sensorRepository, esp32DeviceRepository, occupancySampleRepository, organizationRepository)
+	return handler.NewDashboardHandler(dashboardUseCase)
+}
+
+// setupPlaybackHandler initializes the PlaybackHandler
+func setupPlaybackHandler(parkingLotUseCase usecase.IParkingLotUseCase) *handler.PlaybackHandler {
+	sensorRepository := &db.SensorRepositoryImpl{DB: db2.DB}
+	sensorEventRepository := &db.SensorEventRepositoryImpl{DB: db2.DB}
+	lotSnapshotRepository := &db.LotSnapshotRepositoryImpl{DB: db2.DB}
+	playbackUseCase := usecase.NewPlaybackUseCase(sensorRepository, sensorEventRepository, lotSnapshotRepository)
+	return handler.NewPlaybackHandler(playbackUseCase, parkingLotUseCase)
+}
+
+// setupUserAuthUseCase initializes the driver authentication use case. It is shared by the
+// UserAuthHandler and the WebSocket handler so that both verify tokens with the same key.
+func setupUserAuthUseCase() usecase.IUserAuthUseCase {
+	userRepository := &db.UserRepositoryImpl{DB: db2.DB}
+	refreshTokenRepository := &db.RefreshTokenRepositoryImpl{DB: db2.DB}
+	return usecase.NewUserAuthUseCase(userRepository, refreshTokenRepository, userTokenSecret(),
+		usecase.DefaultAccessTokenTTL, usecase.DefaultRefreshTTL)
+}
+
+// setupAccountUseCase initializes e-mail verification and password recovery. Links in the e-mails
+// point to APP_BASE_URL and tokens are signed with ACCOUNT_TOKEN_SECRET.
+func setupAccountUseCase() usecase.IAccountUseCase {
+	userRepository := &db.UserRepositoryImpl{DB: db2.DB}
+	userTokenRepository := &db.UserTokenRepositoryImpl{DB: db2.DB}
+	refreshTokenRepository := &db.RefreshTokenRepositoryImpl{DB: db2.DB}
+	return usecase.NewAccountUseCase(userRepository, userTokenRepository, refreshTokenRepository, accountMailer(),
+		secretFromEnv("ACCOUNT_TOKEN_SECRET"), appBaseURL())
+}
+
+// appBaseURL returns APP_BASE_URL, the address of the app that links in e-mails point to.
+func appBaseURL() string {
+	baseURL := os.Getenv("APP_BASE_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:3000"
+	}
+	return baseURL
+}
+
+// accountMailer returns the mailer selected by MAILER: "smtp" relays through SMTP_HOST, "file"
+// writes .eml files to MAILER_DIR and the default "log" writes e-mails to the application log.
+func accountMailer() mailer.Mailer {
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = "Parking Radar <no-reply@parking-radar.local>"
+	}
+
+	switch name := os.Getenv("MAILER"); name {
+	case "", "log":
+		return mailer.NewLogMailer()
+	case "file":
+		dir := os.Getenv("MAILER_DIR")
+		if dir == "" {
+			dir = "mail"
+		}
+		return mailer.NewFileMailer(dir, from)
+	case "smtp":
+		port, err := strconv.Atoi(os.Getenv("SMTP_PORT"))
+		if err != nil {
+			port = 587
+		}
+		return mailer.NewSMTPMailer(os.Getenv("SMTP_HOST"), port, os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), from)
+	default:
+		log.Fatalf("Unsupported MAILER %q", name)
+		return nil
+	}
+}
+
+// setupPaymentHandler initializes the PaymentHandler with the configured payment provider
+func setupPaymentHandler(parkingLotUseCase usecase.IParkingLotUseCase) *handler.PaymentHandler {
+	paymentRepository := &db.PaymentRepositoryImpl{DB: db2.DB}
+	sessionRepository := &db.ParkingSessionRepositoryImpl{DB: db2.DB}
+	reservationRepository := &db.ReservationRepositoryImpl{DB: db2.DB}
+	parkingLotRepository := &db.ParkingLotRepositoryImpl{DB: db2.DB}
+	paymentUseCase := usecase.NewPaymentUseCase(paymentRepository, sessionRepository, reservationRepository, parkingLotRepository, paymentProvider())
+	return handler.NewPaymentHandler(paymentUseCase, parkingLotUseCase)
+}
+
+// paymentProvider returns the gateway selected by PAYMENT_PROVIDER. Only the local fake provider
+// exists for now; its webhooks are signed with PAYMENT_WEBHOOK_SECRET.
+func paymentProvider() payment.PaymentProvider {
+	name := os.Getenv("PAYMENT_PROVIDER")
+	if name != "" && name != payment.FakeProviderName {
+		log.Fatalf("Unsupported PAYMENT_PROVIDER %q", name)
+	}
+	return payment.NewFakeProvider(secretFromEnv("PAYMENT_WEBHOOK_SECRET"))
+}
+
+// userTokenSecret returns the key used to sign driver access tokens. Without USER_JWT_SECRET a
+// random key is generated, so tokens do not survive a restart.
+func userTokenSecret() []byte {
+	return secretFromEnv("USER_JWT_SECRET")
+}
+
+// durationFromEnv parses the duration in variable, such as "10m", or returns fallback when unset.
+func durationFromEnv(variable string, fallback time.Duration) time.Duration {
+	value := os.Getenv(variable)
+	if value == "" {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Fatalf("Invalid %s %q", variable, value)
+	}
+	return duration
+}
+
+// secretFromEnv returns the value of the variable, or a random key when it is not set.
+func secretFromEnv(variable string) []byte {
+	if secret := os.Getenv(variable); secret != "" {
+		return []byte(secret)
+	}
+
+	log.Printf("%s is not set, using a random key", variable)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatalf("Failed to generate a key for %s: %v", variable, err)
+	}
+	return secret
+}
+
+// setupFavoriteHandler initializes the FavoriteHandler
+func setupFavoriteHandler() *handler.FavoriteHandler {
+	favoriteRepository := &db.FavoriteRepositoryImpl{DB: db2.DB}
+	parkingLotRepository := &db.ParkingLotRepositoryImpl{DB: db2.DB}
+	sensorRepository := &db.SensorRepositoryImpl{DB: db2.DB}
+	reservationRepository := &db.ReservationRepositoryImpl{DB: db2.DB}
+	favoriteUseCase := usecase.NewFavoriteUseCase(favoriteRepository, parkingLotRepository, sensorRepository, reservationRepository)
+	return handler.NewFavoriteHandler(favoriteUseCase)
+}
+
+// setupAlertUseCase initializes the availability alert use case. It is shared by the AlertHandler and
+// the sensor use case, which feeds it every sensor change.
+func setupAlertUseCase() usecase.IAlertUseCase {
+	alertRepository := &db.AvailabilityAlertRepositoryImpl{DB: db2.DB}
+	parkingLotRepository := &db.ParkingLotRepositoryImpl{DB: db2.DB}
+	sensorRepository := &db.SensorRepositoryImpl{DB: db2.DB}
+	return usecase.NewAlertUseCase(alertRepository, parkingLotRepository, sensorRepository, notifier.NewLogNotifier())
+}
+
+// setupReservationUseCase initializes the reservation use case and starts expiring the holds of
+// drivers that did not arrive in time.
+func setupReservationUseCase() usecase.IReservationUseCase {
+	reservationRepository := &db.ReservationRepositoryImpl{DB: db2.DB}
+	parkingLotRepository := &db.ParkingLotRepositoryImpl{DB: db2.DB}
+	sensorRepository := &db.SensorRepositoryImpl{DB: db2.DB}
+	reservationUseCase := usecase.NewReservationUseCase(reservationRepository, parkingLotRepository, sensorRepository)
+
+	go func() {
+		ticker := time.NewTicker(reservationExpiryInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			expired, err := reservationUseCase.ExpireHolds()
+			if err != nil {
+				log.Println("Error expiring reservations:", err)
+				continue
+			}
+			if expired > 0 {
+				log.Printf("Expired %d reservations", expired)
+			}
+		}
+	}()
+
+	return reservationUseCase
+}
+
+// setupReservationHandler initializes the ReservationHandler
+func setupReservationHandler(reservationUseCase usecase.IReservationUseCase, parkingLotUseCase usecase.IParkingLotUseCase) *handler.ReservationHandler {
+	return handler.NewReservationHandler(reservationUseCase, parkingLotUseCase)
+}
+
+// setupParkingSessionUseCase initializes the parking session use case, publishing session events
+// to their driver through the hub
+func setupParkingSessionUseCase(wsHub *hub.WebSocketHub) usecase.IParkingSessionUseCase {
+	sessionRepository := &db.ParkingSessionRepositoryImpl{DB: db2.DB}
+	parkingLotRepository := &db.ParkingLotRepositoryImpl{DB: db2.DB}
 	sensorRepository := &db.SensorRepositoryImpl{DB: db2.DB}
-	esp32DeviceUseCase := usecase.NewEsp32DeviceUseCase(esp32DeviceRepository, sensorRepository)
-	return handler.NewEsp32DeviceHandler(esp32DeviceUseCase)
+	vehicleRepository := &db.VehicleRepositoryImpl{DB: db2.DB}
+	return usecase.NewParkingSessionUseCase(sessionRepository, parkingLotRepository, sensorRepository, vehicleRepository, wsHub)
+}
+
+// setupReviewHandler initializes the ReviewHandler
+func setupReviewHandler(parkingLotUseCase usecase.IParkingLotUseCase) *handler.ReviewHandler {
+	reviewRepository := &db.ReviewRepositoryImpl{DB: db2.DB}
+	parkingLotRepository := &db.ParkingLotRepositoryImpl{DB: db2.DB}
+	adminRepository := &db.AdminRepositoryImpl{DB: db2.DB}
+	reviewUseCase := usecase.NewReviewUseCase(reviewRepository, parkingLotRepository, adminRepository)
+	return handler.NewReviewHandler(reviewUseCase, parkingLotUseCase)
+}
+
+// setupCrowdReportHandler initializes the CrowdReportHandler
+func setupCrowdReportHandler() *handler.CrowdReportHandler {
+	crowdReportRepository := &db.CrowdReportRepositoryImpl{DB: db2.DB}
+	parkingLotRepository := &db.ParkingLotRepositoryImpl{DB: db2.DB}
+	sensorRepository := &db.SensorRepositoryImpl{DB: db2.DB}
+	crowdReportUseCase := usecase.NewCrowdReportUseCase(crowdReportRepository, parkingLotRepository, sensorRepository)
+	return handler.NewCrowdReportHandler(crowdReportUseCase)
+}
+
+// setupPrivacyHandler initializes the PrivacyHandler
+func setupPrivacyHandler() *handler.PrivacyHandler {
+	privacyUseCase := usecase.NewPrivacyUseCase(
+		&db.PrivacyRepositoryImpl{DB: db2.DB},
+		&db.UserRepositoryImpl{DB: db2.DB},
+		&db.VehicleRepositoryImpl{DB: db2.DB},
+		&db.ParkingSessionRepositoryImpl{DB: db2.DB},
+		&db.ReservationRepositoryImpl{DB: db2.DB},
+		&db.PaymentRepositoryImpl{DB: db2.DB},
+		&db.ReviewRepositoryImpl{DB: db2.DB},
+		&db.FavoriteRepositoryImpl{DB: db2.DB},
+		&db.AvailabilityAlertRepositoryImpl{DB: db2.DB},
+		&db.CrowdReportRepositoryImpl{DB: db2.DB},
+		&db.AdminRepositoryImpl{DB: db2.DB},
+		&db.ParkingLotRepositoryImpl{DB: db2.DB},
+		&db.OperatorDocumentRepositoryImpl{DB: db2.DB},
+		setupDocumentStore(),
+	)
+	return handler.NewPrivacyHandler(privacyUseCase)
+}
+
+// setupVehicleUseCase initializes the vehicle use case shared by the vehicle, parking lot and forecast handlers
+func setupVehicleUseCase() usecase.IVehicleUseCase {
+	vehicleRepository := &db.VehicleRepositoryImpl{DB: db2.DB}
+	parkingLotRepository := &db.ParkingLotRepositoryImpl{DB: db2.DB}
+	sensorRepository := &db.SensorRepositoryImpl{DB: db2.DB}
+	reservationRepository := &db.ReservationRepositoryImpl{DB: db2.DB}
+	return usecase.NewVehicleUseCase(vehicleRepository, parkingLotRepository, sensorRepository, reservationRepository)
+}
+
+// setupParkingSessionHandler initializes the ParkingSessionHandler
+func setupParkingSessionHandler(sessionUseCase usecase.IParkingSessionUseCase, parkingLotUseCase usecase.IParkingLotUseCase) *handler.ParkingSessionHandler {
+	return handler.NewParkingSessionHandler(sessionUseCase, parkingLotUseCase)
 }
@@ -0,0 +1,17 @@
+package db
+
+import (
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+)
+
+// MigrateDeviceParkingLots assigns the devices registered before they belonged to a parking lot to
+// the lot of their sensors, so that the operators of that lot can manage them. Devices without
+// sensors stay unassigned and only global admins can manage them. Running it again only picks up
+// devices still unassigned.
+func MigrateDeviceParkingLots(database *gorm.DB) error {
+	return database.Model(&domain.Esp32Device{}).
+		Where("parking_lot_id = 0").
+		Where("EXISTS (SELECT 1 FROM sensors WHERE sensors.esp32_device_id = esp32_devices.id AND sensors.deleted_at IS NULL)").
+		Update("parking_lot_id", gorm.Expr("(SELECT MIN(sensors.parking_lot_id) FROM sensors WHERE sensors.esp32_device_id = esp32_devices.id AND sensors.deleted_at IS NULL)")).Error
+}
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+)
+
+// MigrateOrganizations moves the parking lots registered before organizations existed into one.
+// Each admin with such lots gets an organization they own, or reuses the first one they already
+// own, and their lots are assigned to it. Running it again only picks up lots still unassigned.
+func MigrateOrganizations(database *gorm.DB) error {
+	var adminIDs []uint
+	if err := database.Unscoped().Model(&domain.ParkingLot{}).
+		Where("organization_id = 0").
+		Distinct().Pluck("admin_id", &adminIDs).Error; err != nil {
+		return err
+	}
+
+	for _, adminID := range adminIDs {
+		if err := database.Transaction(func(tx *gorm.DB) error {
+			organizationID, err := ownedOrganization(tx, adminID)
+			if err != nil {
+				return err
+			}
+			return tx.Unscoped().Model(&domain.ParkingLot{}).
+				Where("admin_id = ? AND organization_id = 0", adminID).
+				Update("organization_id", organizationID).Error
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ownedOrganization returns the first organization owned by the admin, creating it when there is
+// none.
+func ownedOrganization(tx *gorm.DB, adminID uint) (uint, error) {
+	var owner domain.OrganizationMembership
+	err := tx.Where("admin_id = ? AND role = ?", adminID, domain.MembershipRoleOwner).Order("id").First(&owner).Error
+	if err == nil {
+		return owner.OrganizationID, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+
+	var admin domain.Admin
+	if err := tx.First(&admin, adminID).Error; err != nil {
+		return 0, err
+	}
+	organization := domain.Organization{Name: domain.DefaultOrganizationName(admin)}
+	if err := tx.Create(&organization).Error; err != nil {
+		return 0, err
+	}
+	owner = domain.OrganizationMembership{OrganizationID: organization.ID, AdminID: adminID, Role: domain.MembershipRoleOwner}
+	if err := tx.Create(&owner).Error; err != nil {
+		return 0, err
+	}
+	return organization.ID, nil
+}
@@ -0,0 +1,35 @@
+package db
+
+import (
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MigrateRolePermissions stores the default role to permission mapping when none exists yet.
+// Global admins can never drop admin:manage from their own role, so the mapping is only empty
+// before this first run; it also grants that permission back to a deployment that lost it.
+func MigrateRolePermissions(database *gorm.DB) error {
+	return database.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&domain.RolePermission{}).Count(&count).Error; err != nil {
+			return err
+		}
+
+		rolePermissions := []domain.RolePermission{{Role: domain.RoleAdminGlobal, Permission: domain.PermissionAdminManage}}
+		if count == 0 {
+			rolePermissions = defaultRolePermissions()
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rolePermissions).Error
+	})
+}
+
+func defaultRolePermissions() []domain.RolePermission {
+	var rolePermissions []domain.RolePermission
+	for role, permissions := range domain.DefaultRolePermissions {
+		for _, permission := range permissions {
+			rolePermissions = append(rolePermissions, domain.RolePermission{Role: role, Permission: permission})
+		}
+	}
+	return rolePermissions
+}
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"gorm.io/gorm"
+)
+
+// backfillActor is the actor recorded on the verification events written by migrations.
+const backfillActor = "system:migration"
+
+// MigrateOperatorVerification verifies the operators that registered parking lots before the
+// verification existed, so that they keep publishing lots. Since then only verified operators can
+// register lots, so an admin still waiting for documents who registered one predates it; running
+// it again finds nobody new.
+func MigrateOperatorVerification(database *gorm.DB) error {
+	var adminIDs []uint
+	if err := database.Model(&domain.Admin{}).
+		Where("verification_status = ?", domain.VerificationPendingDocuments).
+		Where("EXISTS (SELECT 1 FROM parking_lots WHERE parking_lots.admin_id = admins.id)").
+		Pluck("id", &adminIDs).Error; err != nil {
+		return err
+	}
+
+	for _, adminID := range adminIDs {
+		if err := database.Transaction(func(tx *gorm.DB) error {
+			now := time.Now()
+			result := tx.Model(&domain.Admin{}).
+				Where("id = ? AND verification_status = ?", adminID, domain.VerificationPendingDocuments).
+				Updates(map[string]interface{}{
+					"verification_status": domain.VerificationVerified,
+					"verification_reason": "",
+					"verified_at":         now,
+				})
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+			return tx.Create(&domain.AdminVerificationEvent{
+				AdminID:    adminID,
+				FromStatus: domain.VerificationPendingDocuments,
+				ToStatus:   domain.VerificationVerified,
+				Reason:     "operator registered parking lots before verification existed",
+				Actor:      backfillActor,
+			}).Error
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,33 +1,67 @@
 package helpers
 
 import (
+	"github.com/CamiloLeonP/parking-radar/internal/auth"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 )
 
-// ExtractAdminIDAndRole extracts the admin ID and role from the JWT token.
-func ExtractAdminIDAndRole(c *gin.Context) (string, bool) {
-	userClaims, ok := c.Get("user")
+// PrincipalKey is the context key under which the admin auth middleware stores the principal.
+const PrincipalKey = "principal"
+
+// ExtractPrincipal extracts the admin authenticated by the admin auth middleware.
+func ExtractPrincipal(c *gin.Context) (*auth.Principal, bool) {
+	value, ok := c.Get(PrincipalKey)
 	if !ok {
-		return "", false
+		return nil, false
 	}
+	principal, ok := value.(*auth.Principal)
+	return principal, ok
+}
 
-	claims, ok := userClaims.(jwt.MapClaims)
+// ExtractAdminID extracts the ID of the admin authenticated by the admin auth middleware.
+func ExtractAdminID(c *gin.Context) string {
+	principal, ok := ExtractPrincipal(c)
 	if !ok {
-		return "", false
+		return ""
 	}
+	return principal.Subject
+}
+
+// HasPermission reports whether the authenticated admin was granted the permission.
+func HasPermission(c *gin.Context, permission string) bool {
+	principal, ok := ExtractPrincipal(c)
+	return ok && principal.Can(permission)
+}
 
-	adminID, _ := claims["sub"].(string)
+// RequiredPermissionsKey is the context key under which the permission middleware stores the
+// permissions declared by the route.
+const RequiredPermissionsKey = "required_permissions"
 
-	roles, ok := claims["https://parkiu.com/roles"].([]interface{})
+// RequiredPermissions returns the permissions the route requires from the admin.
+func RequiredPermissions(c *gin.Context) []string {
+	value, ok := c.Get(RequiredPermissionsKey)
 	if !ok {
-		return adminID, false
+		return nil
 	}
+	permissions, _ := value.([]string)
+	return permissions
+}
+
+// APIKeyIDKey is the context key under which the API key middleware stores the key's ID.
+const APIKeyIDKey = "api_key_id"
+
+// UserIDKey is the context key under which the user auth middleware stores the driver's ID.
+const UserIDKey = "user_id"
 
-	for _, role := range roles {
-		if roleStr, ok := role.(string); ok && roleStr == "admin_global" {
-			return adminID, true
-		}
+// ExtractUserID extracts the ID of the driver authenticated by the user auth middleware.
+func ExtractUserID(c *gin.Context) (uint, bool) {
+	value, ok := c.Get(UserIDKey)
+	if !ok {
+		return 0, false
 	}
-	return adminID, false
+	userID, ok := value.(uint)
+	return userID, ok
 }
+
+// DeviceKey is the context key under which the device auth middleware stores the device.
+const DeviceKey = "device"
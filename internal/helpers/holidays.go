@@ -0,0 +1,94 @@
+package helpers
+
+import (
+	"time"
+)
+
+// BogotaLocation is the time zone used for every local-time calculation (Colombia has no DST).
+var BogotaLocation = loadBogotaLocation()
+
+func loadBogotaLocation() *time.Location {
+	loc, err := time.LoadLocation("America/Bogota")
+	if err != nil {
+		return time.FixedZone("COT", -5*60*60)
+	}
+	return loc
+}
+
+// IsColombianHoliday reports whether the given instant falls on a Colombian public holiday,
+// evaluated in Bogota local time.
+func IsColombianHoliday(t time.Time) bool {
+	local := t.In(BogotaLocation)
+	year, month, day := local.Date()
+	for _, holiday := range ColombianHolidays(year) {
+		if holiday.Month() == month && holiday.Day() == day {
+			return true
+		}
+	}
+	return false
+}
+
+// ColombianHolidays returns the public holidays of the given year, applying the
+// Ley Emiliani rule that moves most of them to the following Monday.
+func ColombianHolidays(year int) []time.Time {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, BogotaLocation)
+	}
+
+	holidays := []time.Time{
+		date(time.January, 1),
+		date(time.May, 1),
+		date(time.July, 20),
+		date(time.August, 7),
+		date(time.December, 8),
+		date(time.December, 25),
+	}
+
+	for _, d := range []time.Time{
+		date(time.January, 6),
+		date(time.March, 19),
+		date(time.June, 29),
+		date(time.August, 15),
+		date(time.October, 12),
+		date(time.November, 1),
+		date(time.November, 11),
+	} {
+		holidays = append(holidays, nextMonday(d))
+	}
+
+	easter := easterSunday(year)
+	holidays = append(holidays,
+		easter.AddDate(0, 0, -3), // Holy Thursday
+		easter.AddDate(0, 0, -2), // Good Friday
+		easter.AddDate(0, 0, 43), // Ascension
+		easter.AddDate(0, 0, 64), // Corpus Christi
+		easter.AddDate(0, 0, 71), // Sacred Heart
+	)
+
+	return holidays
+}
+
+// nextMonday returns the date itself when it is a Monday, otherwise the following Monday.
+func nextMonday(d time.Time) time.Time {
+	offset := (int(time.Monday) - int(d.Weekday()) + 7) % 7
+	return d.AddDate(0, 0, offset)
+}
+
+// easterSunday computes Easter Sunday for the Gregorian calendar (anonymous algorithm).
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, BogotaLocation)
+}
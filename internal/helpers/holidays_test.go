@@ -0,0 +1,40 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColombianHolidays(t *testing.T) {
+	holidays := []time.Time{
+		time.Date(2024, time.January, 1, 12, 0, 0, 0, BogotaLocation),
+		time.Date(2024, time.January, 8, 12, 0, 0, 0, BogotaLocation),  // Reyes moved to Monday
+		time.Date(2024, time.March, 28, 12, 0, 0, 0, BogotaLocation),   // Holy Thursday
+		time.Date(2024, time.March, 29, 12, 0, 0, 0, BogotaLocation),   // Good Friday
+		time.Date(2024, time.May, 13, 12, 0, 0, 0, BogotaLocation),     // Ascension
+		time.Date(2024, time.June, 3, 12, 0, 0, 0, BogotaLocation),     // Corpus Christi
+		time.Date(2024, time.June, 10, 12, 0, 0, 0, BogotaLocation),    // Sacred Heart
+		time.Date(2024, time.July, 1, 12, 0, 0, 0, BogotaLocation),     // San Pedro moved to Monday
+		time.Date(2024, time.October, 14, 12, 0, 0, 0, BogotaLocation), // Día de la Raza moved to Monday
+		time.Date(2024, time.November, 11, 12, 0, 0, 0, BogotaLocation),
+	}
+	for _, day := range holidays {
+		assert.True(t, IsColombianHoliday(day), day.Format("2006-01-02"))
+	}
+
+	workingDays := []time.Time{
+		time.Date(2024, time.January, 6, 12, 0, 0, 0, BogotaLocation),
+		time.Date(2024, time.March, 19, 12, 0, 0, 0, BogotaLocation),
+		time.Date(2024, time.October, 12, 12, 0, 0, 0, BogotaLocation),
+	}
+	for _, day := range workingDays {
+		assert.False(t, IsColombianHoliday(day), day.Format("2006-01-02"))
+	}
+}
+
+func TestIsColombianHolidayUsesBogotaTime(t *testing.T) {
+	// 2024-12-26 02:00 UTC is still Christmas evening in Bogota.
+	assert.True(t, IsColombianHoliday(time.Date(2024, time.December, 26, 2, 0, 0, 0, time.UTC)))
+}
@@ -9,16 +9,24 @@ import (
 
 // WebSocketHub handles broadcasting messages to connected clients
 type WebSocketHub struct {
-	clients   sync.Map         // Concurrent map for clients
+	clients   sync.Map         // Concurrent map for clients to the ID of their driver, 0 when anonymous
 	broadcast chan interface{} // Channel for broadcasting messages
+	direct    chan userMessage // Channel for messages to a single driver
 	stop      chan struct{}    // Channel to stop the hub
 	mutex     sync.Mutex       // Mutex for critical sections (if needed)
 }
 
+// userMessage is a message only delivered to the connections of one driver
+type userMessage struct {
+	userID  uint
+	message interface{}
+}
+
 // NewWebSocketHub initializes a new WebSocketHub
 func NewWebSocketHub() *WebSocketHub {
 	return &WebSocketHub{
 		broadcast: make(chan interface{}, 20),
+		direct:    make(chan userMessage, 20),
 		stop:      make(chan struct{}),
 	}
 }
@@ -30,6 +38,9 @@ func (hub *WebSocketHub) Run() {
 		case msg := <-hub.broadcast:
 			hub.broadcastMessage(msg)
 
+		case msg := <-hub.direct:
+			hub.sendToUser(msg)
+
 		case <-hub.stop:
 			log.Println("Stopping WebSocket hub")
 			return
@@ -40,21 +51,35 @@ func (hub *WebSocketHub) Run() {
 // broadcastMessage sends a message to all connected clients
 func (hub *WebSocketHub) broadcastMessage(msg interface{}) {
 	hub.clients.Range(func(key, value interface{}) bool {
-		client := key.(*websocket.Conn)
-		go func(c *websocket.Conn) {
-			if err := c.WriteJSON(msg); err != nil {
-				log.Println("Error sending message:", err)
-				err := c.Close()
-				if err != nil {
-					return
-				}
-				hub.RemoveClient(c)
-			}
-		}(client)
+		hub.write(key.(*websocket.Conn), msg)
 		return true
 	})
 }
 
+// sendToUser sends a message to the connections authenticated as the driver
+func (hub *WebSocketHub) sendToUser(msg userMessage) {
+	hub.clients.Range(func(key, value interface{}) bool {
+		if value.(uint) == msg.userID {
+			hub.write(key.(*websocket.Conn), msg.message)
+		}
+		return true
+	})
+}
+
+// write sends a message to a client, dropping the client when it fails
+func (hub *WebSocketHub) write(client *websocket.Conn, msg interface{}) {
+	go func(c *websocket.Conn) {
+		if err := c.WriteJSON(msg); err != nil {
+			log.Println("Error sending message:", err)
+			err := c.Close()
+			if err != nil {
+				return
+			}
+			hub.RemoveClient(c)
+		}
+	}(client)
+}
+
 // Stop sends a signal to stop the hub
 func (hub *WebSocketHub) Stop() {
 	close(hub.stop)
@@ -69,9 +94,26 @@ func (hub *WebSocketHub) Broadcast(message interface{}) {
 	}
 }
 
-// AddClient adds a new client to the hub
+// SendToUser sends a message only to the clients authenticated as the driver
+func (hub *WebSocketHub) SendToUser(userID uint, message interface{}) {
+	if userID == 0 {
+		return
+	}
+	select {
+	case hub.direct <- userMessage{userID: userID, message: message}:
+	default:
+		log.Println("Direct channel full, message dropped")
+	}
+}
+
+// AddClient adds a new anonymous client to the hub
 func (hub *WebSocketHub) AddClient(conn *websocket.Conn) {
-	hub.clients.Store(conn, true)
+	hub.AddUserClient(conn, 0)
+}
+
+// AddUserClient adds a new client authenticated as the driver to the hub
+func (hub *WebSocketHub) AddUserClient(conn *websocket.Conn, userID uint) {
+	hub.clients.Store(conn, userID)
 	log.Printf("Client added. Total clients: %d\n", hub.countClients())
 }
 
@@ -0,0 +1,68 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader is the header integrators send their API key in.
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthenticator validates API keys for a scope and counts their use.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(key string, scope string) (*domain.APIKey, error)
+}
+
+// APIKeyOrAuthMiddleware Middleware to accept either an API key granted the scope, sent in the
+// X-API-Key header, or an admin Bearer token granted the permission. Admin tokens honour
+// impersonation like AuthMiddleware, and the permission is kept in the context like
+// RequirePermission does.
+func APIKeyOrAuthMiddleware(authenticator APIKeyAuthenticator, scope string, permission string) gin.HandlerFunc {
+	v := currentValidator()
+	permissions := currentPermissionResolver()
+	sessions := currentImpersonationResolver()
+
+	return func(c *gin.Context) {
+		key := strings.TrimSpace(c.GetHeader(APIKeyHeader))
+		if key == "" {
+			if !authenticateAdmin(c, v, permissions) {
+				return
+			}
+			session, ok := impersonate(c, sessions, permissions)
+			if !ok || !checkPermissions(c, []string{permission}) {
+				return
+			}
+			c.Set(helpers.RequiredPermissionsKey, append(helpers.RequiredPermissions(c), permission))
+			nextAudited(c, sessions, session)
+			return
+		}
+
+		apiKey, err := authenticator.AuthenticateAPIKey(key, scope)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrInvalidAPIKey):
+				c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			case errors.Is(err, usecase.ErrAPIKeyScope):
+				c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			case errors.Is(err, usecase.ErrAPIKeyRateLimited):
+				c.Header("Retry-After", "60")
+				c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+			case errors.Is(err, usecase.ErrAPIKeyQuotaExceeded):
+				c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to authenticate api key"})
+			}
+			c.Abort()
+			return
+		}
+
+		c.Set(helpers.APIKeyIDKey, apiKey.ID)
+		c.Next()
+	}
+}
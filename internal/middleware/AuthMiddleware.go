@@ -1,120 +1,117 @@
 package middlewares
 
 import (
-	"context"
 	"errors"
 	"fmt"
-	"github.com/lestrrat-go/jwx/jwk"
+	"log"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 
+	"github.com/CamiloLeonP/parking-radar/internal/auth"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 )
 
 var (
-	domain   = os.Getenv("AUTH0_DOMAIN")
-	audience = os.Getenv("AUTH0_AUDIENCE")
+	validatorMutex sync.RWMutex
+	validator      = defaultValidator()
 )
 
-// AuthMiddleware Middleware to validate JWT token.
-func AuthMiddleware(allowedRoles ...string) gin.HandlerFunc {
-	jwksURL := fmt.Sprintf("%s.well-known/jwks.json", domain)
+// defaultValidator verifies tokens issued by AUTH0_DOMAIN for AUTH0_AUDIENCE with its JWKS.
+func defaultValidator() *auth.Validator {
+	domain := os.Getenv("AUTH0_DOMAIN")
+	source := auth.NewRemoteJWKS(fmt.Sprintf("%s.well-known/jwks.json", domain))
+	return auth.NewValidator(source, domain, os.Getenv("AUTH0_AUDIENCE"))
+}
 
-	ctx := context.Background()
-	set, err := jwk.Fetch(ctx, jwksURL)
-	if err != nil {
-		panic("failed to fetch JWKS: " + err.Error())
-	}
+// ConfigureValidator sets the validator of admin tokens. Middlewares built afterwards share it.
+func ConfigureValidator(v *auth.Validator) {
+	validatorMutex.Lock()
+	defer validatorMutex.Unlock()
+	validator = v
+}
+
+func currentValidator() *auth.Validator {
+	validatorMutex.RLock()
+	defer validatorMutex.RUnlock()
+	return validator
+}
+
+// AuthMiddleware Middleware to validate JWT token. The principal is stored with the permissions
+// of their roles, which RequirePermission checks. Global admins impersonating an admin are
+// stored as that admin, and their mutating requests are audited.
+func AuthMiddleware() gin.HandlerFunc {
+	v := currentValidator()
+	permissions := currentPermissionResolver()
+	sessions := currentImpersonationResolver()
 
 	return func(c *gin.Context) {
-		tokenString, err := extractToken(c)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
-			c.Abort()
+		if !authenticateAdmin(c, v, permissions) {
 			return
 		}
-
-		claims, err := validateToken(tokenString, set)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
-			c.Abort()
+		session, ok := impersonate(c, sessions, permissions)
+		if !ok {
 			return
 		}
+		nextAudited(c, sessions, session)
+	}
+}
 
-		if !hasAllowedRole(claims, allowedRoles) {
-			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden: insufficient privileges"})
-			c.Abort()
+// RealAdminAuthMiddleware Middleware to validate JWT token like AuthMiddleware, keeping the
+// global admin as the principal while they impersonate an admin, so that they can manage the
+// impersonation itself.
+func RealAdminAuthMiddleware() gin.HandlerFunc {
+	v := currentValidator()
+	permissions := currentPermissionResolver()
+
+	return func(c *gin.Context) {
+		if !authenticateAdmin(c, v, permissions) {
 			return
 		}
-
-		c.Set("user", claims)
 		c.Next()
 	}
 }
 
-func extractToken(c *gin.Context) (string, error) {
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-		return "", errors.New("missing or invalid Authorization header")
-	}
-	return strings.TrimPrefix(authHeader, "Bearer "), nil
-}
-
-func validateToken(tokenString string, set jwk.Set) (jwt.MapClaims, error) {
-	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
-		keyID, ok := t.Header["kid"].(string)
-		if !ok {
-			return nil, errors.New("missing key ID (kid) in token header")
-		}
-
-		key, found := set.LookupKeyID(keyID)
-		if !found {
-			return nil, errors.New("unable to find matching key")
-		}
-
-		var rawKey interface{}
-		if err := key.Raw(&rawKey); err != nil {
-			return nil, errors.New("failed to parse key")
-		}
-		return rawKey, nil
-	})
+// authenticateAdmin validates the Bearer token and stores its principal, writing the error
+// response and aborting when it is not valid.
+func authenticateAdmin(c *gin.Context, v *auth.Validator, permissions PermissionResolver) bool {
+	tokenString, err := extractToken(c)
 	if err != nil {
-		return nil, err
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		c.Abort()
+		return false
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
-		return nil, errors.New("invalid token claims")
+	principal, err := v.Validate(c.Request.Context(), tokenString)
+	if err != nil {
+		// The details of why the token was rejected are only logged, they help forging one.
+		log.Printf("Rejected admin token: %v", err)
+		if errors.Is(err, auth.ErrKeysUnavailable) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": auth.ErrKeysUnavailable.Error()})
+		} else {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": auth.ErrInvalidToken.Error()})
+		}
+		c.Abort()
+		return false
 	}
-	return claims, nil
-}
 
-func hasAllowedRole(claims jwt.MapClaims, allowedRoles []string) bool {
-	roles, ok := claims["https://parkiu.com/roles"]
-	if !ok {
+	principal.Permissions, err = permissions.PermissionsFor(principal.Roles)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve permissions"})
+		c.Abort()
 		return false
 	}
 
-	switch v := roles.(type) {
-	case string:
-		return contains(allowedRoles, v)
-	case []interface{}:
-		for _, role := range v {
-			if roleStr, ok := role.(string); ok && contains(allowedRoles, roleStr) {
-				return true
-			}
-		}
-	}
-	return false
+	c.Set(helpers.PrincipalKey, principal)
+	return true
 }
 
-func contains(slice []string, item string) bool {
-	for _, v := range slice {
-		if v == item {
-			return true
-		}
+func extractToken(c *gin.Context) (string, error) {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
+		return "", errors.New("missing or invalid Authorization header")
 	}
-	return false
+	return strings.TrimPrefix(authHeader, "Bearer "), nil
 }
@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/CamiloLeonP/parking-radar/internal/auth"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type noPermissions struct{}
+
+func (noPermissions) PermissionsFor([]string) ([]string, error) {
+	return nil, nil
+}
+
+func TestAuthenticateAdminHidesWhyTheTokenWasRejected(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	issuer, err := auth.GenerateDevIssuer("https://api.parking-radar")
+	assert.NoError(t, err)
+	v := auth.NewValidator(issuer, "https://other-issuer/", "https://api.parking-radar")
+	token, _, err := issuer.Mint("auth0|123", nil, time.Hour)
+	assert.NoError(t, err)
+
+	for _, bearer := range []string{token, "not-a-jwt"} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		c.Request.Header.Set("Authorization", "Bearer "+bearer)
+
+		assert.False(t, authenticateAdmin(c, v, noPermissions{}))
+		var response map[string]string
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, "invalid token", response["error"])
+	}
+}
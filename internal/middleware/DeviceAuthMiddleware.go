@@ -0,0 +1,47 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+// DeviceTokenHeader is the header devices send the token issued at their registration in.
+const DeviceTokenHeader = "X-Device-Token"
+
+// DeviceAuthenticator validates device tokens and returns the device they were issued to.
+type DeviceAuthenticator interface {
+	AuthenticateDevice(token string) (*domain.Esp32Device, error)
+}
+
+// DeviceAuthMiddleware Middleware to authenticate the devices reporting their sensors, with the
+// token sent in the X-Device-Token header.
+func DeviceAuthMiddleware(authenticator DeviceAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimSpace(c.GetHeader(DeviceTokenHeader))
+		if token == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing device token"})
+			c.Abort()
+			return
+		}
+
+		device, err := authenticator.AuthenticateDevice(token)
+		if err != nil {
+			if errors.Is(err, usecase.ErrInvalidDeviceToken) {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to authenticate device"})
+			}
+			c.Abort()
+			return
+		}
+
+		c.Set(helpers.DeviceKey, device)
+		c.Next()
+	}
+}
@@ -0,0 +1,119 @@
+package middlewares
+
+import (
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/auth"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+// ImpersonationResolver finds the admin a global admin impersonates and audits the requests they
+// make as that admin.
+type ImpersonationResolver interface {
+	ActiveImpersonation(actorSubject string) (*domain.ImpersonationSession, error)
+	RecordImpersonatedRequest(entry *domain.ImpersonationAuditEntry) error
+	CompleteImpersonatedRequest(entry *domain.ImpersonationAuditEntry) error
+}
+
+var (
+	impersonationMutex sync.RWMutex
+	impersonations     ImpersonationResolver
+)
+
+// ConfigureImpersonation sets where impersonation sessions come from. Middlewares built
+// afterwards run the requests of impersonating global admins as the impersonated admin; by
+// default nobody is impersonated.
+func ConfigureImpersonation(r ImpersonationResolver) {
+	impersonationMutex.Lock()
+	defer impersonationMutex.Unlock()
+	impersonations = r
+}
+
+func currentImpersonationResolver() ImpersonationResolver {
+	impersonationMutex.RLock()
+	defer impersonationMutex.RUnlock()
+	return impersonations
+}
+
+// impersonate replaces the authenticated principal with the admin they impersonate, if any,
+// holding the roles of an operator. It writes the error response and aborts when the session
+// cannot be resolved.
+func impersonate(c *gin.Context, sessions ImpersonationResolver, permissions PermissionResolver) (*domain.ImpersonationSession, bool) {
+	principal, ok := helpers.ExtractPrincipal(c)
+	if sessions == nil || !ok || !principal.Can(domain.PermissionAdminManage) {
+		return nil, true
+	}
+
+	session, err := sessions.ActiveImpersonation(principal.Subject)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve impersonation"})
+		c.Abort()
+		return nil, false
+	}
+	if session == nil {
+		return nil, true
+	}
+
+	roles := []string{domain.RoleAdminLocal}
+	granted, err := permissions.PermissionsFor(roles)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve permissions"})
+		c.Abort()
+		return nil, false
+	}
+	expiresAt := principal.ExpiresAt
+	if session.ExpiresAt.Before(expiresAt) {
+		expiresAt = session.ExpiresAt
+	}
+	c.Set(helpers.PrincipalKey, &auth.Principal{
+		Subject:        session.AdminSubject,
+		Issuer:         principal.Issuer,
+		Roles:          roles,
+		Permissions:    granted,
+		ExpiresAt:      expiresAt,
+		ImpersonatedBy: principal.Subject,
+	})
+	c.Header("X-Impersonating", session.AdminSubject)
+	return session, true
+}
+
+// nextAudited runs the rest of the chain. While impersonating, a request that may change anything
+// is recorded in the audit log of the session first and refused when it cannot be; its status is
+// filled in once the handler responded.
+func nextAudited(c *gin.Context, sessions ImpersonationResolver, session *domain.ImpersonationSession) {
+	if session == nil {
+		c.Next()
+		return
+	}
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		c.Next()
+		return
+	}
+
+	entry := &domain.ImpersonationAuditEntry{
+		SessionID:    session.ID,
+		ActorSubject: session.ActorSubject,
+		AdminSubject: session.AdminSubject,
+		Method:       c.Request.Method,
+		Route:        c.FullPath(),
+		Path:         c.Request.URL.Path,
+	}
+	if err := sessions.RecordImpersonatedRequest(entry); err != nil {
+		log.Printf("Failed to audit %s %s impersonating %s: %v", c.Request.Method, c.Request.URL.Path, session.AdminSubject, err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "failed to audit impersonated request"})
+		c.Abort()
+		return
+	}
+
+	c.Next()
+
+	entry.Status = c.Writer.Status()
+	if err := sessions.CompleteImpersonatedRequest(entry); err != nil {
+		log.Printf("Failed to record the status of audit entry %d: %v", entry.ID, err)
+	}
+}
@@ -0,0 +1,85 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingResolver struct {
+	recordErr error
+	recorded  []domain.ImpersonationAuditEntry
+	completed []domain.ImpersonationAuditEntry
+}
+
+func (r *recordingResolver) ActiveImpersonation(string) (*domain.ImpersonationSession, error) {
+	return nil, nil
+}
+
+func (r *recordingResolver) RecordImpersonatedRequest(entry *domain.ImpersonationAuditEntry) error {
+	if r.recordErr != nil {
+		return r.recordErr
+	}
+	entry.ID = uint(len(r.recorded) + 1)
+	r.recorded = append(r.recorded, *entry)
+	return nil
+}
+
+func (r *recordingResolver) CompleteImpersonatedRequest(entry *domain.ImpersonationAuditEntry) error {
+	r.completed = append(r.completed, *entry)
+	return nil
+}
+
+func serveAudited(resolver *recordingResolver, method string, handler gin.HandlerFunc) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	session := &domain.ImpersonationSession{ID: 3, ActorSubject: "auth0|global", AdminSubject: "auth0|admin"}
+	router := gin.New()
+	router.Handle(method, "/parking-lots/:id", func(c *gin.Context) {
+		nextAudited(c, resolver, session)
+	}, handler)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, "/parking-lots/7", nil))
+	return w
+}
+
+func TestNextAuditedRecordsBeforeTheHandler(t *testing.T) {
+	resolver := &recordingResolver{}
+	w := serveAudited(resolver, http.MethodDelete, func(c *gin.Context) {
+		assert.Len(t, resolver.recorded, 1, "the entry is written before the handler runs")
+		c.Status(http.StatusNoContent)
+	})
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, domain.ImpersonationAuditEntry{
+		ID: 1, SessionID: 3, ActorSubject: "auth0|global", AdminSubject: "auth0|admin",
+		Method: http.MethodDelete, Route: "/parking-lots/:id", Path: "/parking-lots/7",
+	}, resolver.recorded[0])
+	assert.Len(t, resolver.completed, 1)
+	assert.Equal(t, http.StatusNoContent, resolver.completed[0].Status)
+}
+
+func TestNextAuditedRefusesRequestsThatCannotBeAudited(t *testing.T) {
+	resolver := &recordingResolver{recordErr: errors.New("database is down")}
+	w := serveAudited(resolver, http.MethodPut, func(c *gin.Context) {
+		t.Error("the handler must not run")
+	})
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Empty(t, resolver.completed)
+}
+
+func TestNextAuditedSkipsReadOnlyRequests(t *testing.T) {
+	resolver := &recordingResolver{}
+	w := serveAudited(resolver, http.MethodGet, func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, resolver.recorded)
+}
@@ -0,0 +1,78 @@
+package middlewares
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+// PermissionResolver returns the permissions granted by a set of admin roles.
+type PermissionResolver interface {
+	PermissionsFor(roles []string) ([]string, error)
+}
+
+// staticPermissions resolves permissions from a fixed role to permission mapping.
+type staticPermissions map[string][]string
+
+func (s staticPermissions) PermissionsFor(roles []string) ([]string, error) {
+	var permissions []string
+	for _, role := range roles {
+		permissions = append(permissions, s[role]...)
+	}
+	return permissions, nil
+}
+
+var (
+	resolverMutex sync.RWMutex
+	resolver      PermissionResolver = staticPermissions(domain.DefaultRolePermissions)
+)
+
+// ConfigurePermissionResolver sets where the permissions of admin roles come from. Middlewares
+// built afterwards use it; the default is domain.DefaultRolePermissions.
+func ConfigurePermissionResolver(r PermissionResolver) {
+	resolverMutex.Lock()
+	defer resolverMutex.Unlock()
+	resolver = r
+}
+
+func currentPermissionResolver() PermissionResolver {
+	resolverMutex.RLock()
+	defer resolverMutex.RUnlock()
+	return resolver
+}
+
+// RequirePermission Middleware to allow only admins granted every permission. It must run after
+// AuthMiddleware. The permissions are kept in the context, so handlers acting on a parking lot
+// can also check them against the organization role of the admin.
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !checkPermissions(c, permissions) {
+			return
+		}
+		c.Set(helpers.RequiredPermissionsKey, append(helpers.RequiredPermissions(c), permissions...))
+		c.Next()
+	}
+}
+
+// checkPermissions writes the error response and aborts unless the authenticated admin was
+// granted every permission.
+func checkPermissions(c *gin.Context, permissions []string) bool {
+	principal, ok := helpers.ExtractPrincipal(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		c.Abort()
+		return false
+	}
+
+	for _, permission := range permissions {
+		if !principal.Can(permission) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden: missing permission " + permission})
+			c.Abort()
+			return false
+		}
+	}
+	return true
+}
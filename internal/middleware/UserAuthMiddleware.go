@@ -0,0 +1,117 @@
+package middlewares
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/CamiloLeonP/parking-radar/internal/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+// UserTokenAuthenticator validates driver access tokens and returns the user ID they belong to.
+type UserTokenAuthenticator interface {
+	Authenticate(accessToken string) (uint, error)
+}
+
+// UserAuthMiddleware Middleware to authenticate drivers with the access tokens issued by /users/login.
+func UserAuthMiddleware(authenticator UserTokenAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString, err := extractToken(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+
+		userID, err := authenticator.Authenticate(tokenString)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+			c.Abort()
+			return
+		}
+
+		c.Set(helpers.UserIDKey, userID)
+		c.Next()
+	}
+}
+
+// OptionalUserAuthMiddleware identifies the driver on public routes when an access token is sent.
+// Requests without a token go through anonymously; an invalid token is still rejected.
+func OptionalUserAuthMiddleware(authenticator UserTokenAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+
+		tokenString, err := extractToken(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+
+		userID, err := authenticator.Authenticate(tokenString)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+			c.Abort()
+			return
+		}
+
+		c.Set(helpers.UserIDKey, userID)
+		c.Next()
+	}
+}
+
+// EmailVerificationChecker reports whether a driver verified their e-mail address.
+type EmailVerificationChecker interface {
+	IsEmailVerified(userID uint) (bool, error)
+}
+
+// VerifiedEmailMiddleware only lets drivers with a verified e-mail address through. It must run
+// after UserAuthMiddleware.
+func VerifiedEmailMiddleware(checker EmailVerificationChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := helpers.ExtractUserID(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing authenticated user"})
+			c.Abort()
+			return
+		}
+
+		verified, err := checker.IsEmailVerified(userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check e-mail verification"})
+			c.Abort()
+			return
+		}
+		if !verified {
+			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden: verify your e-mail address first"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// SelfOnlyMiddleware only lets the authenticated driver act on the user whose ID is in the given route parameter.
+func SelfOnlyMiddleware(param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := helpers.ExtractUserID(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing authenticated user"})
+			c.Abort()
+			return
+		}
+
+		targetID, err := strconv.ParseUint(c.Param(param), 10, 32)
+		if err != nil || uint(targetID) != userID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden: you can only access your own account"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
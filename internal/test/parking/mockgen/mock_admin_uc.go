@@ -77,17 +77,3 @@ func (mr *MockIAdminUseCaseMockRecorder) GetParkingLotsByAdmin(adminUUID interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParkingLotsByAdmin", reflect.TypeOf((*MockIAdminUseCase)(nil).GetParkingLotsByAdmin), adminUUID)
 }
-
-// RegisterAdmin mocks base method.
-func (m *MockIAdminUseCase) RegisterAdmin(adminID string) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterAdmin", adminID)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// RegisterAdmin indicates an expected call of RegisterAdmin.
-func (mr *MockIAdminUseCaseMockRecorder) RegisterAdmin(adminID interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterAdmin", reflect.TypeOf((*MockIAdminUseCase)(nil).RegisterAdmin), adminID)
-}
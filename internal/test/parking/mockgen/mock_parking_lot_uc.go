@@ -34,6 +34,20 @@ func (m *MockIParkingLotUseCase) EXPECT() *MockIParkingLotUseCaseMockRecorder {
 	return m.recorder
 }
 
+// AuthorizeParkingLot mocks base method.
+func (m *MockIParkingLotUseCase) AuthorizeParkingLot(parkingLotID uint, adminUUID string, permissions []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeParkingLot", parkingLotID, adminUUID, permissions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthorizeParkingLot indicates an expected call of AuthorizeParkingLot.
+func (mr *MockIParkingLotUseCaseMockRecorder) AuthorizeParkingLot(parkingLotID, adminUUID, permissions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeParkingLot", reflect.TypeOf((*MockIParkingLotUseCase)(nil).AuthorizeParkingLot), parkingLotID, adminUUID, permissions)
+}
+
 // CreateParkingLot mocks base method.
 func (m *MockIParkingLotUseCase) CreateParkingLot(req usecase.CreateParkingLotRequest) (*usecase.ParkingLotResponse, error) {
 	m.ctrl.T.Helper()
@@ -94,18 +108,18 @@ func (mr *MockIParkingLotUseCaseMockRecorder) GetParkingLotWithOwnership(parking
 }
 
 // ListParkingLots mocks base method.
-func (m *MockIParkingLotUseCase) ListParkingLots() ([]usecase.ParkingLotResponse, error) {
+func (m *MockIParkingLotUseCase) ListParkingLots(query usecase.ParkingLotQuery) ([]usecase.ParkingLotResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListParkingLots")
+	ret := m.ctrl.Call(m, "ListParkingLots", query)
 	ret0, _ := ret[0].([]usecase.ParkingLotResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListParkingLots indicates an expected call of ListParkingLots.
-func (mr *MockIParkingLotUseCaseMockRecorder) ListParkingLots() *gomock.Call {
+func (mr *MockIParkingLotUseCaseMockRecorder) ListParkingLots(query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListParkingLots", reflect.TypeOf((*MockIParkingLotUseCase)(nil).ListParkingLots))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListParkingLots", reflect.TypeOf((*MockIParkingLotUseCase)(nil).ListParkingLots), query)
 }
 
 // UpdateParkingLot mocks base method.
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./user_auth_uc.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+
+	usecase "github.com/CamiloLeonP/parking-radar/internal/app/usecase"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIUserAuthUseCase is a mock of IUserAuthUseCase interface.
+type MockIUserAuthUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserAuthUseCaseMockRecorder
+}
+
+// MockIUserAuthUseCaseMockRecorder is the mock recorder for MockIUserAuthUseCase.
+type MockIUserAuthUseCaseMockRecorder struct {
+	mock *MockIUserAuthUseCase
+}
+
+// NewMockIUserAuthUseCase creates a new mock instance.
+func NewMockIUserAuthUseCase(ctrl *gomock.Controller) *MockIUserAuthUseCase {
+	mock := &MockIUserAuthUseCase{ctrl: ctrl}
+	mock.recorder = &MockIUserAuthUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserAuthUseCase) EXPECT() *MockIUserAuthUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockIUserAuthUseCase) Authenticate(accessToken string) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", accessToken)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockIUserAuthUseCaseMockRecorder) Authenticate(accessToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockIUserAuthUseCase)(nil).Authenticate), accessToken)
+}
+
+// Login mocks base method.
+func (m *MockIUserAuthUseCase) Login(username, password string) (*usecase.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", username, password)
+	ret0, _ := ret[0].(*usecase.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockIUserAuthUseCaseMockRecorder) Login(username, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockIUserAuthUseCase)(nil).Login), username, password)
+}
+
+// Logout mocks base method.
+func (m *MockIUserAuthUseCase) Logout(refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockIUserAuthUseCaseMockRecorder) Logout(refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockIUserAuthUseCase)(nil).Logout), refreshToken)
+}
+
+// LogoutAll mocks base method.
+func (m *MockIUserAuthUseCase) LogoutAll(userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutAll", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockIUserAuthUseCaseMockRecorder) LogoutAll(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockIUserAuthUseCase)(nil).LogoutAll), userID)
+}
+
+// Refresh mocks base method.
+func (m *MockIUserAuthUseCase) Refresh(refreshToken string) (*usecase.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", refreshToken)
+	ret0, _ := ret[0].(*usecase.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockIUserAuthUseCaseMockRecorder) Refresh(refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockIUserAuthUseCase)(nil).Refresh), refreshToken)
+}
@@ -92,3 +92,40 @@ func (mr *MockIUserUseCaseMockRecorder) UpdateUser(id, username, email, password
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockIUserUseCase)(nil).UpdateUser), id, username, email, password)
 }
+
+// MockEmailVerificationSender is a mock of EmailVerificationSender interface.
+type MockEmailVerificationSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailVerificationSenderMockRecorder
+}
+
+// MockEmailVerificationSenderMockRecorder is the mock recorder for MockEmailVerificationSender.
+type MockEmailVerificationSenderMockRecorder struct {
+	mock *MockEmailVerificationSender
+}
+
+// NewMockEmailVerificationSender creates a new mock instance.
+func NewMockEmailVerificationSender(ctrl *gomock.Controller) *MockEmailVerificationSender {
+	mock := &MockEmailVerificationSender{ctrl: ctrl}
+	mock.recorder = &MockEmailVerificationSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailVerificationSender) EXPECT() *MockEmailVerificationSenderMockRecorder {
+	return m.recorder
+}
+
+// SendVerification mocks base method.
+func (m *MockEmailVerificationSender) SendVerification(userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendVerification", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendVerification indicates an expected call of SendVerification.
+func (mr *MockEmailVerificationSenderMockRecorder) SendVerification(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerification", reflect.TypeOf((*MockEmailVerificationSender)(nil).SendVerification), userID)
+}
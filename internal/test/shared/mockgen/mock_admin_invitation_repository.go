@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./admin_invitation_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+
+	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIAdminInvitationRepository is a mock of IAdminInvitationRepository interface.
+type MockIAdminInvitationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAdminInvitationRepositoryMockRecorder
+}
+
+// MockIAdminInvitationRepositoryMockRecorder is the mock recorder for MockIAdminInvitationRepository.
+type MockIAdminInvitationRepositoryMockRecorder struct {
+	mock *MockIAdminInvitationRepository
+}
+
+// NewMockIAdminInvitationRepository creates a new mock instance.
+func NewMockIAdminInvitationRepository(ctrl *gomock.Controller) *MockIAdminInvitationRepository {
+	mock := &MockIAdminInvitationRepository{ctrl: ctrl}
+	mock.recorder = &MockIAdminInvitationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAdminInvitationRepository) EXPECT() *MockIAdminInvitationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockIAdminInvitationRepository) Accept(invitation *domain.AdminInvitation, event *domain.AdminInvitationEvent, admin *domain.Admin, membership *domain.OrganizationMembership) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", invitation, event, admin, membership)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockIAdminInvitationRepositoryMockRecorder) Accept(invitation, event, admin, membership interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockIAdminInvitationRepository)(nil).Accept), invitation, event, admin, membership)
+}
+
+// Create mocks base method.
+func (m *MockIAdminInvitationRepository) Create(invitation *domain.AdminInvitation, event *domain.AdminInvitationEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", invitation, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIAdminInvitationRepositoryMockRecorder) Create(invitation, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIAdminInvitationRepository)(nil).Create), invitation, event)
+}
+
+// Delete mocks base method.
+func (m *MockIAdminInvitationRepository) Delete(invitation *domain.AdminInvitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", invitation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIAdminInvitationRepositoryMockRecorder) Delete(invitation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIAdminInvitationRepository)(nil).Delete), invitation)
+}
+
+// FindByHash mocks base method.
+func (m *MockIAdminInvitationRepository) FindByHash(tokenHash string) (*domain.AdminInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByHash", tokenHash)
+	ret0, _ := ret[0].(*domain.AdminInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByHash indicates an expected call of FindByHash.
+func (mr *MockIAdminInvitationRepositoryMockRecorder) FindByHash(tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHash", reflect.TypeOf((*MockIAdminInvitationRepository)(nil).FindByHash), tokenHash)
+}
+
+// FindByID mocks base method.
+func (m *MockIAdminInvitationRepository) FindByID(id uint) (*domain.AdminInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*domain.AdminInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockIAdminInvitationRepositoryMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockIAdminInvitationRepository)(nil).FindByID), id)
+}
+
+// List mocks base method.
+func (m *MockIAdminInvitationRepository) List(organizationID *uint) ([]domain.AdminInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", organizationID)
+	ret0, _ := ret[0].([]domain.AdminInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIAdminInvitationRepositoryMockRecorder) List(organizationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIAdminInvitationRepository)(nil).List), organizationID)
+}
+
+// ListEvents mocks base method.
+func (m *MockIAdminInvitationRepository) ListEvents(invitationID uint) ([]domain.AdminInvitationEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", invitationID)
+	ret0, _ := ret[0].([]domain.AdminInvitationEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockIAdminInvitationRepositoryMockRecorder) ListEvents(invitationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockIAdminInvitationRepository)(nil).ListEvents), invitationID)
+}
+
+// Revoke mocks base method.
+func (m *MockIAdminInvitationRepository) Revoke(invitation *domain.AdminInvitation, event *domain.AdminInvitationEvent) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", invitation, event)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockIAdminInvitationRepositoryMockRecorder) Revoke(invitation, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockIAdminInvitationRepository)(nil).Revoke), invitation, event)
+}
@@ -34,6 +34,34 @@ func (m *MockIAdminRepository) EXPECT() *MockIAdminRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ChangeStatus mocks base method.
+func (m *MockIAdminRepository) ChangeStatus(admin *domain.Admin, event *domain.AdminStatusEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeStatus", admin, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeStatus indicates an expected call of ChangeStatus.
+func (mr *MockIAdminRepositoryMockRecorder) ChangeStatus(admin, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeStatus", reflect.TypeOf((*MockIAdminRepository)(nil).ChangeStatus), admin, event)
+}
+
+// ChangeVerification mocks base method.
+func (m *MockIAdminRepository) ChangeVerification(admin *domain.Admin, event *domain.AdminVerificationEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeVerification", admin, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeVerification indicates an expected call of ChangeVerification.
+func (mr *MockIAdminRepositoryMockRecorder) ChangeVerification(admin, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeVerification", reflect.TypeOf((*MockIAdminRepository)(nil).ChangeVerification), admin, event)
+}
+
 // Create mocks base method.
 func (m *MockIAdminRepository) Create(admin *domain.Admin) error {
 	m.ctrl.T.Helper()
@@ -78,6 +106,82 @@ func (mr *MockIAdminRepositoryMockRecorder) FindByAuth0UUID(auth0UUID interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAuth0UUID", reflect.TypeOf((*MockIAdminRepository)(nil).FindByAuth0UUID), auth0UUID)
 }
 
+// FindByID mocks base method.
+func (m *MockIAdminRepository) FindByID(id uint) (*domain.Admin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*domain.Admin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockIAdminRepositoryMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockIAdminRepository)(nil).FindByID), id)
+}
+
+// List mocks base method.
+func (m *MockIAdminRepository) List() ([]domain.Admin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]domain.Admin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIAdminRepositoryMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIAdminRepository)(nil).List))
+}
+
+// ListStatusEvents mocks base method.
+func (m *MockIAdminRepository) ListStatusEvents(adminID uint) ([]domain.AdminStatusEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStatusEvents", adminID)
+	ret0, _ := ret[0].([]domain.AdminStatusEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStatusEvents indicates an expected call of ListStatusEvents.
+func (mr *MockIAdminRepositoryMockRecorder) ListStatusEvents(adminID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatusEvents", reflect.TypeOf((*MockIAdminRepository)(nil).ListStatusEvents), adminID)
+}
+
+// ListVerificationEvents mocks base method.
+func (m *MockIAdminRepository) ListVerificationEvents(adminID uint) ([]domain.AdminVerificationEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVerificationEvents", adminID)
+	ret0, _ := ret[0].([]domain.AdminVerificationEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVerificationEvents indicates an expected call of ListVerificationEvents.
+func (mr *MockIAdminRepositoryMockRecorder) ListVerificationEvents(adminID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVerificationEvents", reflect.TypeOf((*MockIAdminRepository)(nil).ListVerificationEvents), adminID)
+}
+
+// Search mocks base method.
+func (m *MockIAdminRepository) Search(filter domain.AdminFilter) ([]domain.Admin, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", filter)
+	ret0, _ := ret[0].([]domain.Admin)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockIAdminRepositoryMockRecorder) Search(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockIAdminRepository)(nil).Search), filter)
+}
+
 // Update mocks base method.
 func (m *MockIAdminRepository) Update(admin *domain.Admin) error {
 	m.ctrl.T.Helper()
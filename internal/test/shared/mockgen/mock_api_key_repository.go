@@ -0,0 +1,167 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./api_key_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIAPIKeyRepository is a mock of IAPIKeyRepository interface.
+type MockIAPIKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAPIKeyRepositoryMockRecorder
+}
+
+// MockIAPIKeyRepositoryMockRecorder is the mock recorder for MockIAPIKeyRepository.
+type MockIAPIKeyRepositoryMockRecorder struct {
+	mock *MockIAPIKeyRepository
+}
+
+// NewMockIAPIKeyRepository creates a new mock instance.
+func NewMockIAPIKeyRepository(ctrl *gomock.Controller) *MockIAPIKeyRepository {
+	mock := &MockIAPIKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockIAPIKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAPIKeyRepository) EXPECT() *MockIAPIKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIAPIKeyRepository) Create(key *domain.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIAPIKeyRepositoryMockRecorder) Create(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIAPIKeyRepository)(nil).Create), key)
+}
+
+// FindByHash mocks base method.
+func (m *MockIAPIKeyRepository) FindByHash(keyHash string) (*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByHash", keyHash)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByHash indicates an expected call of FindByHash.
+func (mr *MockIAPIKeyRepositoryMockRecorder) FindByHash(keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHash", reflect.TypeOf((*MockIAPIKeyRepository)(nil).FindByHash), keyHash)
+}
+
+// FindByID mocks base method.
+func (m *MockIAPIKeyRepository) FindByID(id uint) (*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockIAPIKeyRepositoryMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockIAPIKeyRepository)(nil).FindByID), id)
+}
+
+// GetUsage mocks base method.
+func (m *MockIAPIKeyRepository) GetUsage(keyID uint, day time.Time) (*domain.APIKeyUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsage", keyID, day)
+	ret0, _ := ret[0].(*domain.APIKeyUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockIAPIKeyRepositoryMockRecorder) GetUsage(keyID, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockIAPIKeyRepository)(nil).GetUsage), keyID, day)
+}
+
+// IncrementUsage mocks base method.
+func (m *MockIAPIKeyRepository) IncrementUsage(keyID uint, day time.Time, rejected bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementUsage", keyID, day, rejected)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementUsage indicates an expected call of IncrementUsage.
+func (mr *MockIAPIKeyRepositoryMockRecorder) IncrementUsage(keyID, day, rejected interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementUsage", reflect.TypeOf((*MockIAPIKeyRepository)(nil).IncrementUsage), keyID, day, rejected)
+}
+
+// List mocks base method.
+func (m *MockIAPIKeyRepository) List() ([]domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIAPIKeyRepositoryMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIAPIKeyRepository)(nil).List))
+}
+
+// ListUsageSince mocks base method.
+func (m *MockIAPIKeyRepository) ListUsageSince(keyID uint, since time.Time) ([]domain.APIKeyUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsageSince", keyID, since)
+	ret0, _ := ret[0].([]domain.APIKeyUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsageSince indicates an expected call of ListUsageSince.
+func (mr *MockIAPIKeyRepositoryMockRecorder) ListUsageSince(keyID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsageSince", reflect.TypeOf((*MockIAPIKeyRepository)(nil).ListUsageSince), keyID, since)
+}
+
+// Revoke mocks base method.
+func (m *MockIAPIKeyRepository) Revoke(id uint, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockIAPIKeyRepositoryMockRecorder) Revoke(id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockIAPIKeyRepository)(nil).Revoke), id, at)
+}
+
+// TouchLastUsed mocks base method.
+func (m *MockIAPIKeyRepository) TouchLastUsed(id uint, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchLastUsed", id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchLastUsed indicates an expected call of TouchLastUsed.
+func (mr *MockIAPIKeyRepositoryMockRecorder) TouchLastUsed(id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchLastUsed", reflect.TypeOf((*MockIAPIKeyRepository)(nil).TouchLastUsed), id, at)
+}
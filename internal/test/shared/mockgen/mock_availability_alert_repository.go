@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./availability_alert_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+
+	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIAvailabilityAlertRepository is a mock of IAvailabilityAlertRepository interface.
+type MockIAvailabilityAlertRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAvailabilityAlertRepositoryMockRecorder
+}
+
+// MockIAvailabilityAlertRepositoryMockRecorder is the mock recorder for MockIAvailabilityAlertRepository.
+type MockIAvailabilityAlertRepositoryMockRecorder struct {
+	mock *MockIAvailabilityAlertRepository
+}
+
+// NewMockIAvailabilityAlertRepository creates a new mock instance.
+func NewMockIAvailabilityAlertRepository(ctrl *gomock.Controller) *MockIAvailabilityAlertRepository {
+	mock := &MockIAvailabilityAlertRepository{ctrl: ctrl}
+	mock.recorder = &MockIAvailabilityAlertRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAvailabilityAlertRepository) EXPECT() *MockIAvailabilityAlertRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIAvailabilityAlertRepository) Create(alert *domain.AvailabilityAlert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", alert)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIAvailabilityAlertRepositoryMockRecorder) Create(alert interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIAvailabilityAlertRepository)(nil).Create), alert)
+}
+
+// Delete mocks base method.
+func (m *MockIAvailabilityAlertRepository) Delete(userID, alertID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userID, alertID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIAvailabilityAlertRepositoryMockRecorder) Delete(userID, alertID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIAvailabilityAlertRepository)(nil).Delete), userID, alertID)
+}
+
+// ListActiveByParkingLot mocks base method.
+func (m *MockIAvailabilityAlertRepository) ListActiveByParkingLot(parkingLotID uint) ([]domain.AvailabilityAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveByParkingLot", parkingLotID)
+	ret0, _ := ret[0].([]domain.AvailabilityAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveByParkingLot indicates an expected call of ListActiveByParkingLot.
+func (mr *MockIAvailabilityAlertRepositoryMockRecorder) ListActiveByParkingLot(parkingLotID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveByParkingLot", reflect.TypeOf((*MockIAvailabilityAlertRepository)(nil).ListActiveByParkingLot), parkingLotID)
+}
+
+// ListByUser mocks base method.
+func (m *MockIAvailabilityAlertRepository) ListByUser(userID uint) ([]domain.AvailabilityAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", userID)
+	ret0, _ := ret[0].([]domain.AvailabilityAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockIAvailabilityAlertRepositoryMockRecorder) ListByUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockIAvailabilityAlertRepository)(nil).ListByUser), userID)
+}
+
+// Update mocks base method.
+func (m *MockIAvailabilityAlertRepository) Update(alert *domain.AvailabilityAlert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", alert)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIAvailabilityAlertRepositoryMockRecorder) Update(alert interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIAvailabilityAlertRepository)(nil).Update), alert)
+}
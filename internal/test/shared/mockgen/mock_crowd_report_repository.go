@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./crowd_report_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockICrowdReportRepository is a mock of ICrowdReportRepository interface.
+type MockICrowdReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockICrowdReportRepositoryMockRecorder
+}
+
+// MockICrowdReportRepositoryMockRecorder is the mock recorder for MockICrowdReportRepository.
+type MockICrowdReportRepositoryMockRecorder struct {
+	mock *MockICrowdReportRepository
+}
+
+// NewMockICrowdReportRepository creates a new mock instance.
+func NewMockICrowdReportRepository(ctrl *gomock.Controller) *MockICrowdReportRepository {
+	mock := &MockICrowdReportRepository{ctrl: ctrl}
+	mock.recorder = &MockICrowdReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICrowdReportRepository) EXPECT() *MockICrowdReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AdjustReputation mocks base method.
+func (m *MockICrowdReportRepository) AdjustReputation(userID, agreements, disagreements uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustReputation", userID, agreements, disagreements)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdjustReputation indicates an expected call of AdjustReputation.
+func (mr *MockICrowdReportRepositoryMockRecorder) AdjustReputation(userID, agreements, disagreements interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustReputation", reflect.TypeOf((*MockICrowdReportRepository)(nil).AdjustReputation), userID, agreements, disagreements)
+}
+
+// Create mocks base method.
+func (m *MockICrowdReportRepository) Create(report *domain.CrowdReport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", report)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockICrowdReportRepositoryMockRecorder) Create(report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockICrowdReportRepository)(nil).Create), report)
+}
+
+// FindLatestByUser mocks base method.
+func (m *MockICrowdReportRepository) FindLatestByUser(userID, parkingLotID uint) (*domain.CrowdReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestByUser", userID, parkingLotID)
+	ret0, _ := ret[0].(*domain.CrowdReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatestByUser indicates an expected call of FindLatestByUser.
+func (mr *MockICrowdReportRepositoryMockRecorder) FindLatestByUser(userID, parkingLotID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestByUser", reflect.TypeOf((*MockICrowdReportRepository)(nil).FindLatestByUser), userID, parkingLotID)
+}
+
+// GetReputation mocks base method.
+func (m *MockICrowdReportRepository) GetReputation(userID uint) (*domain.ReporterReputation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReputation", userID)
+	ret0, _ := ret[0].(*domain.ReporterReputation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReputation indicates an expected call of GetReputation.
+func (mr *MockICrowdReportRepositoryMockRecorder) GetReputation(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReputation", reflect.TypeOf((*MockICrowdReportRepository)(nil).GetReputation), userID)
+}
+
+// ListByParkingLotSince mocks base method.
+func (m *MockICrowdReportRepository) ListByParkingLotSince(parkingLotID uint, since time.Time) ([]domain.CrowdReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByParkingLotSince", parkingLotID, since)
+	ret0, _ := ret[0].([]domain.CrowdReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByParkingLotSince indicates an expected call of ListByParkingLotSince.
+func (mr *MockICrowdReportRepositoryMockRecorder) ListByParkingLotSince(parkingLotID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByParkingLotSince", reflect.TypeOf((*MockICrowdReportRepository)(nil).ListByParkingLotSince), parkingLotID, since)
+}
+
+// ListByUser mocks base method.
+func (m *MockICrowdReportRepository) ListByUser(userID uint) ([]domain.CrowdReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", userID)
+	ret0, _ := ret[0].([]domain.CrowdReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockICrowdReportRepositoryMockRecorder) ListByUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockICrowdReportRepository)(nil).ListByUser), userID)
+}
+
+// ListGroupedByParkingLotSince mocks base method.
+func (m *MockICrowdReportRepository) ListGroupedByParkingLotSince(since time.Time) (map[uint][]domain.CrowdReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroupedByParkingLotSince", since)
+	ret0, _ := ret[0].(map[uint][]domain.CrowdReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroupedByParkingLotSince indicates an expected call of ListGroupedByParkingLotSince.
+func (mr *MockICrowdReportRepositoryMockRecorder) ListGroupedByParkingLotSince(since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupedByParkingLotSince", reflect.TypeOf((*MockICrowdReportRepository)(nil).ListGroupedByParkingLotSince), since)
+}
@@ -0,0 +1,167 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./esp32_device_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+
+	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIEsp32DeviceRepository is a mock of IEsp32DeviceRepository interface.
+type MockIEsp32DeviceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEsp32DeviceRepositoryMockRecorder
+}
+
+// MockIEsp32DeviceRepositoryMockRecorder is the mock recorder for MockIEsp32DeviceRepository.
+type MockIEsp32DeviceRepositoryMockRecorder struct {
+	mock *MockIEsp32DeviceRepository
+}
+
+// NewMockIEsp32DeviceRepository creates a new mock instance.
+func NewMockIEsp32DeviceRepository(ctrl *gomock.Controller) *MockIEsp32DeviceRepository {
+	mock := &MockIEsp32DeviceRepository{ctrl: ctrl}
+	mock.recorder = &MockIEsp32DeviceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEsp32DeviceRepository) EXPECT() *MockIEsp32DeviceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIEsp32DeviceRepository) Create(device *domain.Esp32Device) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", device)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIEsp32DeviceRepositoryMockRecorder) Create(device interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIEsp32DeviceRepository)(nil).Create), device)
+}
+
+// Delete mocks base method.
+func (m *MockIEsp32DeviceRepository) Delete(id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIEsp32DeviceRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIEsp32DeviceRepository)(nil).Delete), id)
+}
+
+// GetByDeviceIdentifier mocks base method.
+func (m *MockIEsp32DeviceRepository) GetByDeviceIdentifier(identifier string) (*domain.Esp32Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDeviceIdentifier", identifier)
+	ret0, _ := ret[0].(*domain.Esp32Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDeviceIdentifier indicates an expected call of GetByDeviceIdentifier.
+func (mr *MockIEsp32DeviceRepositoryMockRecorder) GetByDeviceIdentifier(identifier interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDeviceIdentifier", reflect.TypeOf((*MockIEsp32DeviceRepository)(nil).GetByDeviceIdentifier), identifier)
+}
+
+// GetByID mocks base method.
+func (m *MockIEsp32DeviceRepository) GetByID(id uint64) (*domain.Esp32Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*domain.Esp32Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockIEsp32DeviceRepositoryMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIEsp32DeviceRepository)(nil).GetByID), id)
+}
+
+// GetByTokenHash mocks base method.
+func (m *MockIEsp32DeviceRepository) GetByTokenHash(hash string) (*domain.Esp32Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTokenHash", hash)
+	ret0, _ := ret[0].(*domain.Esp32Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTokenHash indicates an expected call of GetByTokenHash.
+func (mr *MockIEsp32DeviceRepositoryMockRecorder) GetByTokenHash(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTokenHash", reflect.TypeOf((*MockIEsp32DeviceRepository)(nil).GetByTokenHash), hash)
+}
+
+// ListAll mocks base method.
+func (m *MockIEsp32DeviceRepository) ListAll() ([]domain.Esp32Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll")
+	ret0, _ := ret[0].([]domain.Esp32Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockIEsp32DeviceRepositoryMockRecorder) ListAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockIEsp32DeviceRepository)(nil).ListAll))
+}
+
+// ListByDeviceIdentifier mocks base method.
+func (m *MockIEsp32DeviceRepository) ListByDeviceIdentifier(identifier string) ([]domain.Esp32Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByDeviceIdentifier", identifier)
+	ret0, _ := ret[0].([]domain.Esp32Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByDeviceIdentifier indicates an expected call of ListByDeviceIdentifier.
+func (mr *MockIEsp32DeviceRepositoryMockRecorder) ListByDeviceIdentifier(identifier interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByDeviceIdentifier", reflect.TypeOf((*MockIEsp32DeviceRepository)(nil).ListByDeviceIdentifier), identifier)
+}
+
+// ListForMember mocks base method.
+func (m *MockIEsp32DeviceRepository) ListForMember(adminID uint) ([]domain.Esp32Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListForMember", adminID)
+	ret0, _ := ret[0].([]domain.Esp32Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListForMember indicates an expected call of ListForMember.
+func (mr *MockIEsp32DeviceRepositoryMockRecorder) ListForMember(adminID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForMember", reflect.TypeOf((*MockIEsp32DeviceRepository)(nil).ListForMember), adminID)
+}
+
+// Update mocks base method.
+func (m *MockIEsp32DeviceRepository) Update(device *domain.Esp32Device) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", device)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIEsp32DeviceRepositoryMockRecorder) Update(device interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIEsp32DeviceRepository)(nil).Update), device)
+}
@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./favorite_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+
+	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIFavoriteRepository is a mock of IFavoriteRepository interface.
+type MockIFavoriteRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIFavoriteRepositoryMockRecorder
+}
+
+// MockIFavoriteRepositoryMockRecorder is the mock recorder for MockIFavoriteRepository.
+type MockIFavoriteRepositoryMockRecorder struct {
+	mock *MockIFavoriteRepository
+}
+
+// NewMockIFavoriteRepository creates a new mock instance.
+func NewMockIFavoriteRepository(ctrl *gomock.Controller) *MockIFavoriteRepository {
+	mock := &MockIFavoriteRepository{ctrl: ctrl}
+	mock.recorder = &MockIFavoriteRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIFavoriteRepository) EXPECT() *MockIFavoriteRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIFavoriteRepository) Create(favorite *domain.FavoriteParkingLot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", favorite)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIFavoriteRepositoryMockRecorder) Create(favorite interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIFavoriteRepository)(nil).Create), favorite)
+}
+
+// Delete mocks base method.
+func (m *MockIFavoriteRepository) Delete(userID, parkingLotID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userID, parkingLotID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIFavoriteRepositoryMockRecorder) Delete(userID, parkingLotID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIFavoriteRepository)(nil).Delete), userID, parkingLotID)
+}
+
+// ListByUser mocks base method.
+func (m *MockIFavoriteRepository) ListByUser(userID uint) ([]domain.FavoriteParkingLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", userID)
+	ret0, _ := ret[0].([]domain.FavoriteParkingLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockIFavoriteRepositoryMockRecorder) ListByUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockIFavoriteRepository)(nil).ListByUser), userID)
+}
@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./impersonation_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIImpersonationRepository is a mock of IImpersonationRepository interface.
+type MockIImpersonationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIImpersonationRepositoryMockRecorder
+}
+
+// MockIImpersonationRepositoryMockRecorder is the mock recorder for MockIImpersonationRepository.
+type MockIImpersonationRepositoryMockRecorder struct {
+	mock *MockIImpersonationRepository
+}
+
+// NewMockIImpersonationRepository creates a new mock instance.
+func NewMockIImpersonationRepository(ctrl *gomock.Controller) *MockIImpersonationRepository {
+	mock := &MockIImpersonationRepository{ctrl: ctrl}
+	mock.recorder = &MockIImpersonationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIImpersonationRepository) EXPECT() *MockIImpersonationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIImpersonationRepository) Create(session *domain.ImpersonationSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIImpersonationRepositoryMockRecorder) Create(session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIImpersonationRepository)(nil).Create), session)
+}
+
+// CreateAuditEntry mocks base method.
+func (m *MockIImpersonationRepository) CreateAuditEntry(entry *domain.ImpersonationAuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditEntry", entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAuditEntry indicates an expected call of CreateAuditEntry.
+func (mr *MockIImpersonationRepositoryMockRecorder) CreateAuditEntry(entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditEntry", reflect.TypeOf((*MockIImpersonationRepository)(nil).CreateAuditEntry), entry)
+}
+
+// EndActive mocks base method.
+func (m *MockIImpersonationRepository) EndActive(actorSubject string, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndActive", actorSubject, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EndActive indicates an expected call of EndActive.
+func (mr *MockIImpersonationRepositoryMockRecorder) EndActive(actorSubject, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndActive", reflect.TypeOf((*MockIImpersonationRepository)(nil).EndActive), actorSubject, at)
+}
+
+// FindActive mocks base method.
+func (m *MockIImpersonationRepository) FindActive(actorSubject string, at time.Time) (*domain.ImpersonationSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActive", actorSubject, at)
+	ret0, _ := ret[0].(*domain.ImpersonationSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActive indicates an expected call of FindActive.
+func (mr *MockIImpersonationRepositoryMockRecorder) FindActive(actorSubject, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActive", reflect.TypeOf((*MockIImpersonationRepository)(nil).FindActive), actorSubject, at)
+}
+
+// List mocks base method.
+func (m *MockIImpersonationRepository) List() ([]domain.ImpersonationSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]domain.ImpersonationSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIImpersonationRepositoryMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIImpersonationRepository)(nil).List))
+}
+
+// ListAuditEntries mocks base method.
+func (m *MockIImpersonationRepository) ListAuditEntries(sessionID uint) ([]domain.ImpersonationAuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditEntries", sessionID)
+	ret0, _ := ret[0].([]domain.ImpersonationAuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditEntries indicates an expected call of ListAuditEntries.
+func (mr *MockIImpersonationRepositoryMockRecorder) ListAuditEntries(sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEntries", reflect.TypeOf((*MockIImpersonationRepository)(nil).ListAuditEntries), sessionID)
+}
+
+// UpdateAuditEntryStatus mocks base method.
+func (m *MockIImpersonationRepository) UpdateAuditEntryStatus(entryID uint, status int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAuditEntryStatus", entryID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAuditEntryStatus indicates an expected call of UpdateAuditEntryStatus.
+func (mr *MockIImpersonationRepositoryMockRecorder) UpdateAuditEntryStatus(entryID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuditEntryStatus", reflect.TypeOf((*MockIImpersonationRepository)(nil).UpdateAuditEntryStatus), entryID, status)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./lot_snapshot_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockILotSnapshotRepository is a mock of ILotSnapshotRepository interface.
+type MockILotSnapshotRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockILotSnapshotRepositoryMockRecorder
+}
+
+// MockILotSnapshotRepositoryMockRecorder is the mock recorder for MockILotSnapshotRepository.
+type MockILotSnapshotRepositoryMockRecorder struct {
+	mock *MockILotSnapshotRepository
+}
+
+// NewMockILotSnapshotRepository creates a new mock instance.
+func NewMockILotSnapshotRepository(ctrl *gomock.Controller) *MockILotSnapshotRepository {
+	mock := &MockILotSnapshotRepository{ctrl: ctrl}
+	mock.recorder = &MockILotSnapshotRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILotSnapshotRepository) EXPECT() *MockILotSnapshotRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockILotSnapshotRepository) Create(snapshot *domain.LotSnapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", snapshot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockILotSnapshotRepositoryMockRecorder) Create(snapshot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockILotSnapshotRepository)(nil).Create), snapshot)
+}
+
+// FindLatestBefore mocks base method.
+func (m *MockILotSnapshotRepository) FindLatestBefore(parkingLotID uint, at time.Time) (*domain.LotSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestBefore", parkingLotID, at)
+	ret0, _ := ret[0].(*domain.LotSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatestBefore indicates an expected call of FindLatestBefore.
+func (mr *MockILotSnapshotRepositoryMockRecorder) FindLatestBefore(parkingLotID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestBefore", reflect.TypeOf((*MockILotSnapshotRepository)(nil).FindLatestBefore), parkingLotID, at)
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./occupancy_sample_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIOccupancySampleRepository is a mock of IOccupancySampleRepository interface.
+type MockIOccupancySampleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOccupancySampleRepositoryMockRecorder
+}
+
+// MockIOccupancySampleRepositoryMockRecorder is the mock recorder for MockIOccupancySampleRepository.
+type MockIOccupancySampleRepositoryMockRecorder struct {
+	mock *MockIOccupancySampleRepository
+}
+
+// NewMockIOccupancySampleRepository creates a new mock instance.
+func NewMockIOccupancySampleRepository(ctrl *gomock.Controller) *MockIOccupancySampleRepository {
+	mock := &MockIOccupancySampleRepository{ctrl: ctrl}
+	mock.recorder = &MockIOccupancySampleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOccupancySampleRepository) EXPECT() *MockIOccupancySampleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIOccupancySampleRepository) Create(sample *domain.OccupancySample) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", sample)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIOccupancySampleRepositoryMockRecorder) Create(sample interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIOccupancySampleRepository)(nil).Create), sample)
+}
+
+// ListByParkingLotSince mocks base method.
+func (m *MockIOccupancySampleRepository) ListByParkingLotSince(parkingLotID uint, since time.Time) ([]domain.OccupancySample, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByParkingLotSince", parkingLotID, since)
+	ret0, _ := ret[0].([]domain.OccupancySample)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByParkingLotSince indicates an expected call of ListByParkingLotSince.
+func (mr *MockIOccupancySampleRepositoryMockRecorder) ListByParkingLotSince(parkingLotID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByParkingLotSince", reflect.TypeOf((*MockIOccupancySampleRepository)(nil).ListByParkingLotSince), parkingLotID, since)
+}
+
+// ListByParkingLotsSince mocks base method.
+func (m *MockIOccupancySampleRepository) ListByParkingLotsSince(parkingLotIDs []uint, since time.Time) ([]domain.OccupancySample, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByParkingLotsSince", parkingLotIDs, since)
+	ret0, _ := ret[0].([]domain.OccupancySample)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByParkingLotsSince indicates an expected call of ListByParkingLotsSince.
+func (mr *MockIOccupancySampleRepositoryMockRecorder) ListByParkingLotsSince(parkingLotIDs, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByParkingLotsSince", reflect.TypeOf((*MockIOccupancySampleRepository)(nil).ListByParkingLotsSince), parkingLotIDs, since)
+}
+
+// StreamByParkingLotBetween mocks base method.
+func (m *MockIOccupancySampleRepository) StreamByParkingLotBetween(parkingLotID uint, from, to time.Time, fn func(domain.OccupancySample) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamByParkingLotBetween", parkingLotID, from, to, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamByParkingLotBetween indicates an expected call of StreamByParkingLotBetween.
+func (mr *MockIOccupancySampleRepositoryMockRecorder) StreamByParkingLotBetween(parkingLotID, from, to, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamByParkingLotBetween", reflect.TypeOf((*MockIOccupancySampleRepository)(nil).StreamByParkingLotBetween), parkingLotID, from, to, fn)
+}
+
+// StreamByParkingLotBetweenForMember mocks base method.
+func (m *MockIOccupancySampleRepository) StreamByParkingLotBetweenForMember(parkingLotID, adminID uint, from, to time.Time, fn func(domain.OccupancySample) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamByParkingLotBetweenForMember", parkingLotID, adminID, from, to, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamByParkingLotBetweenForMember indicates an expected call of StreamByParkingLotBetweenForMember.
+func (mr *MockIOccupancySampleRepositoryMockRecorder) StreamByParkingLotBetweenForMember(parkingLotID, adminID, from, to, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamByParkingLotBetweenForMember", reflect.TypeOf((*MockIOccupancySampleRepository)(nil).StreamByParkingLotBetweenForMember), parkingLotID, adminID, from, to, fn)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./operator_document_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+
+	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIOperatorDocumentRepository is a mock of IOperatorDocumentRepository interface.
+type MockIOperatorDocumentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOperatorDocumentRepositoryMockRecorder
+}
+
+// MockIOperatorDocumentRepositoryMockRecorder is the mock recorder for MockIOperatorDocumentRepository.
+type MockIOperatorDocumentRepositoryMockRecorder struct {
+	mock *MockIOperatorDocumentRepository
+}
+
+// NewMockIOperatorDocumentRepository creates a new mock instance.
+func NewMockIOperatorDocumentRepository(ctrl *gomock.Controller) *MockIOperatorDocumentRepository {
+	mock := &MockIOperatorDocumentRepository{ctrl: ctrl}
+	mock.recorder = &MockIOperatorDocumentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOperatorDocumentRepository) EXPECT() *MockIOperatorDocumentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIOperatorDocumentRepository) Create(document *domain.OperatorDocument) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", document)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIOperatorDocumentRepositoryMockRecorder) Create(document interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIOperatorDocumentRepository)(nil).Create), document)
+}
+
+// FindByAdmin mocks base method.
+func (m *MockIOperatorDocumentRepository) FindByAdmin(adminID, documentID uint) (*domain.OperatorDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByAdmin", adminID, documentID)
+	ret0, _ := ret[0].(*domain.OperatorDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByAdmin indicates an expected call of FindByAdmin.
+func (mr *MockIOperatorDocumentRepositoryMockRecorder) FindByAdmin(adminID, documentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAdmin", reflect.TypeOf((*MockIOperatorDocumentRepository)(nil).FindByAdmin), adminID, documentID)
+}
+
+// ListByAdmin mocks base method.
+func (m *MockIOperatorDocumentRepository) ListByAdmin(adminID uint) ([]domain.OperatorDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByAdmin", adminID)
+	ret0, _ := ret[0].([]domain.OperatorDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByAdmin indicates an expected call of ListByAdmin.
+func (mr *MockIOperatorDocumentRepositoryMockRecorder) ListByAdmin(adminID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAdmin", reflect.TypeOf((*MockIOperatorDocumentRepository)(nil).ListByAdmin), adminID)
+}
@@ -0,0 +1,182 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./organization_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+
+	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIOrganizationRepository is a mock of IOrganizationRepository interface.
+type MockIOrganizationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOrganizationRepositoryMockRecorder
+}
+
+// MockIOrganizationRepositoryMockRecorder is the mock recorder for MockIOrganizationRepository.
+type MockIOrganizationRepositoryMockRecorder struct {
+	mock *MockIOrganizationRepository
+}
+
+// NewMockIOrganizationRepository creates a new mock instance.
+func NewMockIOrganizationRepository(ctrl *gomock.Controller) *MockIOrganizationRepository {
+	mock := &MockIOrganizationRepository{ctrl: ctrl}
+	mock.recorder = &MockIOrganizationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOrganizationRepository) EXPECT() *MockIOrganizationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountOwners mocks base method.
+func (m *MockIOrganizationRepository) CountOwners(organizationID uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOwners", organizationID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOwners indicates an expected call of CountOwners.
+func (mr *MockIOrganizationRepositoryMockRecorder) CountOwners(organizationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOwners", reflect.TypeOf((*MockIOrganizationRepository)(nil).CountOwners), organizationID)
+}
+
+// Create mocks base method.
+func (m *MockIOrganizationRepository) Create(organization *domain.Organization, owner *domain.OrganizationMembership) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", organization, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIOrganizationRepositoryMockRecorder) Create(organization, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIOrganizationRepository)(nil).Create), organization, owner)
+}
+
+// DeleteMembership mocks base method.
+func (m *MockIOrganizationRepository) DeleteMembership(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMembership", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMembership indicates an expected call of DeleteMembership.
+func (mr *MockIOrganizationRepositoryMockRecorder) DeleteMembership(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMembership", reflect.TypeOf((*MockIOrganizationRepository)(nil).DeleteMembership), id)
+}
+
+// FindByID mocks base method.
+func (m *MockIOrganizationRepository) FindByID(id uint) (*domain.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*domain.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockIOrganizationRepositoryMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockIOrganizationRepository)(nil).FindByID), id)
+}
+
+// FindMembership mocks base method.
+func (m *MockIOrganizationRepository) FindMembership(organizationID, adminID uint) (*domain.OrganizationMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMembership", organizationID, adminID)
+	ret0, _ := ret[0].(*domain.OrganizationMembership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMembership indicates an expected call of FindMembership.
+func (mr *MockIOrganizationRepositoryMockRecorder) FindMembership(organizationID, adminID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMembership", reflect.TypeOf((*MockIOrganizationRepository)(nil).FindMembership), organizationID, adminID)
+}
+
+// List mocks base method.
+func (m *MockIOrganizationRepository) List() ([]domain.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]domain.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIOrganizationRepositoryMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIOrganizationRepository)(nil).List))
+}
+
+// ListByAdmin mocks base method.
+func (m *MockIOrganizationRepository) ListByAdmin(adminID uint) ([]domain.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByAdmin", adminID)
+	ret0, _ := ret[0].([]domain.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByAdmin indicates an expected call of ListByAdmin.
+func (mr *MockIOrganizationRepositoryMockRecorder) ListByAdmin(adminID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAdmin", reflect.TypeOf((*MockIOrganizationRepository)(nil).ListByAdmin), adminID)
+}
+
+// ListMemberships mocks base method.
+func (m *MockIOrganizationRepository) ListMemberships(organizationID uint) ([]domain.OrganizationMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMemberships", organizationID)
+	ret0, _ := ret[0].([]domain.OrganizationMembership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMemberships indicates an expected call of ListMemberships.
+func (mr *MockIOrganizationRepositoryMockRecorder) ListMemberships(organizationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMemberships", reflect.TypeOf((*MockIOrganizationRepository)(nil).ListMemberships), organizationID)
+}
+
+// ListMembershipsByAdmin mocks base method.
+func (m *MockIOrganizationRepository) ListMembershipsByAdmin(adminID uint) ([]domain.OrganizationMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembershipsByAdmin", adminID)
+	ret0, _ := ret[0].([]domain.OrganizationMembership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembershipsByAdmin indicates an expected call of ListMembershipsByAdmin.
+func (mr *MockIOrganizationRepositoryMockRecorder) ListMembershipsByAdmin(adminID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembershipsByAdmin", reflect.TypeOf((*MockIOrganizationRepository)(nil).ListMembershipsByAdmin), adminID)
+}
+
+// SaveMembership mocks base method.
+func (m *MockIOrganizationRepository) SaveMembership(membership *domain.OrganizationMembership) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMembership", membership)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveMembership indicates an expected call of SaveMembership.
+func (mr *MockIOrganizationRepositoryMockRecorder) SaveMembership(membership interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMembership", reflect.TypeOf((*MockIOrganizationRepository)(nil).SaveMembership), membership)
+}
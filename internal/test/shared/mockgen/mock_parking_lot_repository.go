@@ -62,19 +62,34 @@ func (mr *MockIParkingLotRepositoryMockRecorder) Delete(id interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIParkingLotRepository)(nil).Delete), id)
 }
 
-// FindByAdminID mocks base method.
-func (m *MockIParkingLotRepository) FindByAdminID(adminID uint) ([]domain.ParkingLot, error) {
+// FindByMember mocks base method.
+func (m *MockIParkingLotRepository) FindByMember(adminID uint) ([]domain.ParkingLot, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByAdminID", adminID)
+	ret := m.ctrl.Call(m, "FindByMember", adminID)
 	ret0, _ := ret[0].([]domain.ParkingLot)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindByAdminID indicates an expected call of FindByAdminID.
-func (mr *MockIParkingLotRepositoryMockRecorder) FindByAdminID(adminID interface{}) *gomock.Call {
+// FindByMember indicates an expected call of FindByMember.
+func (mr *MockIParkingLotRepositoryMockRecorder) FindByMember(adminID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAdminID", reflect.TypeOf((*MockIParkingLotRepository)(nil).FindByAdminID), adminID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByMember", reflect.TypeOf((*MockIParkingLotRepository)(nil).FindByMember), adminID)
+}
+
+// FindByOrganizationID mocks base method.
+func (m *MockIParkingLotRepository) FindByOrganizationID(organizationID uint) ([]domain.ParkingLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByOrganizationID", organizationID)
+	ret0, _ := ret[0].([]domain.ParkingLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByOrganizationID indicates an expected call of FindByOrganizationID.
+func (mr *MockIParkingLotRepositoryMockRecorder) FindByOrganizationID(organizationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOrganizationID", reflect.TypeOf((*MockIParkingLotRepository)(nil).FindByOrganizationID), organizationID)
 }
 
 // GetByID mocks base method.
@@ -92,19 +107,35 @@ func (mr *MockIParkingLotRepositoryMockRecorder) GetByID(id interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIParkingLotRepository)(nil).GetByID), id)
 }
 
-// GetByIDWithAdmin mocks base method.
-func (m *MockIParkingLotRepository) GetByIDWithAdmin(parkingLotID, adminID uint) (*domain.ParkingLot, error) {
+// GetByIDForMember mocks base method.
+func (m *MockIParkingLotRepository) GetByIDForMember(parkingLotID, adminID uint) (*domain.ParkingLot, *domain.OrganizationMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForMember", parkingLotID, adminID)
+	ret0, _ := ret[0].(*domain.ParkingLot)
+	ret1, _ := ret[1].(*domain.OrganizationMembership)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByIDForMember indicates an expected call of GetByIDForMember.
+func (mr *MockIParkingLotRepositoryMockRecorder) GetByIDForMember(parkingLotID, adminID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForMember", reflect.TypeOf((*MockIParkingLotRepository)(nil).GetByIDForMember), parkingLotID, adminID)
+}
+
+// GetPublicByID mocks base method.
+func (m *MockIParkingLotRepository) GetPublicByID(id uint) (*domain.ParkingLot, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByIDWithAdmin", parkingLotID, adminID)
+	ret := m.ctrl.Call(m, "GetPublicByID", id)
 	ret0, _ := ret[0].(*domain.ParkingLot)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByIDWithAdmin indicates an expected call of GetByIDWithAdmin.
-func (mr *MockIParkingLotRepositoryMockRecorder) GetByIDWithAdmin(parkingLotID, adminID interface{}) *gomock.Call {
+// GetPublicByID indicates an expected call of GetPublicByID.
+func (mr *MockIParkingLotRepositoryMockRecorder) GetPublicByID(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDWithAdmin", reflect.TypeOf((*MockIParkingLotRepository)(nil).GetByIDWithAdmin), parkingLotID, adminID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicByID", reflect.TypeOf((*MockIParkingLotRepository)(nil).GetPublicByID), id)
 }
 
 // List mocks base method.
@@ -122,6 +153,52 @@ func (mr *MockIParkingLotRepositoryMockRecorder) List() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIParkingLotRepository)(nil).List))
 }
 
+// ListPublic mocks base method.
+func (m *MockIParkingLotRepository) ListPublic() ([]domain.ParkingLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPublic")
+	ret0, _ := ret[0].([]domain.ParkingLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPublic indicates an expected call of ListPublic.
+func (mr *MockIParkingLotRepositoryMockRecorder) ListPublic() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPublic", reflect.TypeOf((*MockIParkingLotRepository)(nil).ListPublic))
+}
+
+// OperatorStatus mocks base method.
+func (m *MockIParkingLotRepository) OperatorStatus(parkingLotID uint) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OperatorStatus", parkingLotID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OperatorStatus indicates an expected call of OperatorStatus.
+func (mr *MockIParkingLotRepositoryMockRecorder) OperatorStatus(parkingLotID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OperatorStatus", reflect.TypeOf((*MockIParkingLotRepository)(nil).OperatorStatus), parkingLotID)
+}
+
+// Search mocks base method.
+func (m *MockIParkingLotRepository) Search(filter domain.ParkingLotFilter) ([]domain.ParkingLot, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", filter)
+	ret0, _ := ret[0].([]domain.ParkingLot)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockIParkingLotRepositoryMockRecorder) Search(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockIParkingLotRepository)(nil).Search), filter)
+}
+
 // Update mocks base method.
 func (m *MockIParkingLotRepository) Update(parkingLot *domain.ParkingLot) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./parking_session_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+
+	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIParkingSessionRepository is a mock of IParkingSessionRepository interface.
+type MockIParkingSessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIParkingSessionRepositoryMockRecorder
+}
+
+// MockIParkingSessionRepositoryMockRecorder is the mock recorder for MockIParkingSessionRepository.
+type MockIParkingSessionRepositoryMockRecorder struct {
+	mock *MockIParkingSessionRepository
+}
+
+// NewMockIParkingSessionRepository creates a new mock instance.
+func NewMockIParkingSessionRepository(ctrl *gomock.Controller) *MockIParkingSessionRepository {
+	mock := &MockIParkingSessionRepository{ctrl: ctrl}
+	mock.recorder = &MockIParkingSessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIParkingSessionRepository) EXPECT() *MockIParkingSessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockIParkingSessionRepository) Claim(session *domain.ParkingSession) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", session)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockIParkingSessionRepositoryMockRecorder) Claim(session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockIParkingSessionRepository)(nil).Claim), session)
+}
+
+// Complete mocks base method.
+func (m *MockIParkingSessionRepository) Complete(session *domain.ParkingSession) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", session)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockIParkingSessionRepositoryMockRecorder) Complete(session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIParkingSessionRepository)(nil).Complete), session)
+}
+
+// Create mocks base method.
+func (m *MockIParkingSessionRepository) Create(session *domain.ParkingSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIParkingSessionRepositoryMockRecorder) Create(session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIParkingSessionRepository)(nil).Create), session)
+}
+
+// FindActiveBySensor mocks base method.
+func (m *MockIParkingSessionRepository) FindActiveBySensor(sensorID uint) (*domain.ParkingSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveBySensor", sensorID)
+	ret0, _ := ret[0].(*domain.ParkingSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveBySensor indicates an expected call of FindActiveBySensor.
+func (mr *MockIParkingSessionRepositoryMockRecorder) FindActiveBySensor(sensorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveBySensor", reflect.TypeOf((*MockIParkingSessionRepository)(nil).FindActiveBySensor), sensorID)
+}
+
+// FindActiveByUser mocks base method.
+func (m *MockIParkingSessionRepository) FindActiveByUser(userID uint) (*domain.ParkingSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveByUser", userID)
+	ret0, _ := ret[0].(*domain.ParkingSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveByUser indicates an expected call of FindActiveByUser.
+func (mr *MockIParkingSessionRepositoryMockRecorder) FindActiveByUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveByUser", reflect.TypeOf((*MockIParkingSessionRepository)(nil).FindActiveByUser), userID)
+}
+
+// GetByID mocks base method.
+func (m *MockIParkingSessionRepository) GetByID(id uint) (*domain.ParkingSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*domain.ParkingSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockIParkingSessionRepositoryMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIParkingSessionRepository)(nil).GetByID), id)
+}
+
+// ListByParkingLot mocks base method.
+func (m *MockIParkingSessionRepository) ListByParkingLot(parkingLotID uint, status string) ([]domain.ParkingSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByParkingLot", parkingLotID, status)
+	ret0, _ := ret[0].([]domain.ParkingSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByParkingLot indicates an expected call of ListByParkingLot.
+func (mr *MockIParkingSessionRepositoryMockRecorder) ListByParkingLot(parkingLotID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByParkingLot", reflect.TypeOf((*MockIParkingSessionRepository)(nil).ListByParkingLot), parkingLotID, status)
+}
+
+// ListByUser mocks base method.
+func (m *MockIParkingSessionRepository) ListByUser(userID uint, status string) ([]domain.ParkingSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", userID, status)
+	ret0, _ := ret[0].([]domain.ParkingSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockIParkingSessionRepositoryMockRecorder) ListByUser(userID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockIParkingSessionRepository)(nil).ListByUser), userID, status)
+}
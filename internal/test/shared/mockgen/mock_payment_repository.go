@@ -0,0 +1,228 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./payment_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIPaymentRepository is a mock of IPaymentRepository interface.
+type MockIPaymentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPaymentRepositoryMockRecorder
+}
+
+// MockIPaymentRepositoryMockRecorder is the mock recorder for MockIPaymentRepository.
+type MockIPaymentRepositoryMockRecorder struct {
+	mock *MockIPaymentRepository
+}
+
+// NewMockIPaymentRepository creates a new mock instance.
+func NewMockIPaymentRepository(ctrl *gomock.Controller) *MockIPaymentRepository {
+	mock := &MockIPaymentRepository{ctrl: ctrl}
+	mock.recorder = &MockIPaymentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPaymentRepository) EXPECT() *MockIPaymentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ApplyEvent mocks base method.
+func (m *MockIPaymentRepository) ApplyEvent(payment *domain.Payment, event *domain.PaymentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyEvent", payment, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyEvent indicates an expected call of ApplyEvent.
+func (mr *MockIPaymentRepositoryMockRecorder) ApplyEvent(payment, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyEvent", reflect.TypeOf((*MockIPaymentRepository)(nil).ApplyEvent), payment, event)
+}
+
+// ClaimEvent mocks base method.
+func (m *MockIPaymentRepository) ClaimEvent(payment *domain.Payment, event *domain.PaymentEvent) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimEvent", payment, event)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimEvent indicates an expected call of ClaimEvent.
+func (mr *MockIPaymentRepositoryMockRecorder) ClaimEvent(payment, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimEvent", reflect.TypeOf((*MockIPaymentRepository)(nil).ClaimEvent), payment, event)
+}
+
+// Create mocks base method.
+func (m *MockIPaymentRepository) Create(payment *domain.Payment, event *domain.PaymentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", payment, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIPaymentRepositoryMockRecorder) Create(payment, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIPaymentRepository)(nil).Create), payment, event)
+}
+
+// FindByIdempotencyKey mocks base method.
+func (m *MockIPaymentRepository) FindByIdempotencyKey(userID uint, key string) (*domain.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIdempotencyKey", userID, key)
+	ret0, _ := ret[0].(*domain.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIdempotencyKey indicates an expected call of FindByIdempotencyKey.
+func (mr *MockIPaymentRepositoryMockRecorder) FindByIdempotencyKey(userID, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdempotencyKey", reflect.TypeOf((*MockIPaymentRepository)(nil).FindByIdempotencyKey), userID, key)
+}
+
+// FindByProviderReference mocks base method.
+func (m *MockIPaymentRepository) FindByProviderReference(provider, reference string) (*domain.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByProviderReference", provider, reference)
+	ret0, _ := ret[0].(*domain.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByProviderReference indicates an expected call of FindByProviderReference.
+func (mr *MockIPaymentRepositoryMockRecorder) FindByProviderReference(provider, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByProviderReference", reflect.TypeOf((*MockIPaymentRepository)(nil).FindByProviderReference), provider, reference)
+}
+
+// FindOpenByParkingSession mocks base method.
+func (m *MockIPaymentRepository) FindOpenByParkingSession(parkingSessionID uint) (*domain.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOpenByParkingSession", parkingSessionID)
+	ret0, _ := ret[0].(*domain.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOpenByParkingSession indicates an expected call of FindOpenByParkingSession.
+func (mr *MockIPaymentRepositoryMockRecorder) FindOpenByParkingSession(parkingSessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOpenByParkingSession", reflect.TypeOf((*MockIPaymentRepository)(nil).FindOpenByParkingSession), parkingSessionID)
+}
+
+// FindOpenByReservation mocks base method.
+func (m *MockIPaymentRepository) FindOpenByReservation(reservationID uint) (*domain.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOpenByReservation", reservationID)
+	ret0, _ := ret[0].(*domain.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOpenByReservation indicates an expected call of FindOpenByReservation.
+func (mr *MockIPaymentRepositoryMockRecorder) FindOpenByReservation(reservationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOpenByReservation", reflect.TypeOf((*MockIPaymentRepository)(nil).FindOpenByReservation), reservationID)
+}
+
+// GetByID mocks base method.
+func (m *MockIPaymentRepository) GetByID(id uint) (*domain.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*domain.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockIPaymentRepositoryMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIPaymentRepository)(nil).GetByID), id)
+}
+
+// HasEvent mocks base method.
+func (m *MockIPaymentRepository) HasEvent(dedupKey string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasEvent", dedupKey)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasEvent indicates an expected call of HasEvent.
+func (mr *MockIPaymentRepositoryMockRecorder) HasEvent(dedupKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasEvent", reflect.TypeOf((*MockIPaymentRepository)(nil).HasEvent), dedupKey)
+}
+
+// ListByUser mocks base method.
+func (m *MockIPaymentRepository) ListByUser(userID uint) ([]domain.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", userID)
+	ret0, _ := ret[0].([]domain.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockIPaymentRepositoryMockRecorder) ListByUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockIPaymentRepository)(nil).ListByUser), userID)
+}
+
+// ListEvents mocks base method.
+func (m *MockIPaymentRepository) ListEvents(paymentID uint) ([]domain.PaymentEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", paymentID)
+	ret0, _ := ret[0].([]domain.PaymentEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockIPaymentRepositoryMockRecorder) ListEvents(paymentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockIPaymentRepository)(nil).ListEvents), paymentID)
+}
+
+// ReleaseEvent mocks base method.
+func (m *MockIPaymentRepository) ReleaseEvent(event *domain.PaymentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseEvent", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseEvent indicates an expected call of ReleaseEvent.
+func (mr *MockIPaymentRepositoryMockRecorder) ReleaseEvent(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseEvent", reflect.TypeOf((*MockIPaymentRepository)(nil).ReleaseEvent), event)
+}
+
+// RevenueByParkingLot mocks base method.
+func (m *MockIPaymentRepository) RevenueByParkingLot(parkingLotID uint, from, to time.Time) (*domain.Revenue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevenueByParkingLot", parkingLotID, from, to)
+	ret0, _ := ret[0].(*domain.Revenue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevenueByParkingLot indicates an expected call of RevenueByParkingLot.
+func (mr *MockIPaymentRepositoryMockRecorder) RevenueByParkingLot(parkingLotID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevenueByParkingLot", reflect.TypeOf((*MockIPaymentRepository)(nil).RevenueByParkingLot), parkingLotID, from, to)
+}
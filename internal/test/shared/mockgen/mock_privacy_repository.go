@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./privacy_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIPrivacyRepository is a mock of IPrivacyRepository interface.
+type MockIPrivacyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPrivacyRepositoryMockRecorder
+}
+
+// MockIPrivacyRepositoryMockRecorder is the mock recorder for MockIPrivacyRepository.
+type MockIPrivacyRepositoryMockRecorder struct {
+	mock *MockIPrivacyRepository
+}
+
+// NewMockIPrivacyRepository creates a new mock instance.
+func NewMockIPrivacyRepository(ctrl *gomock.Controller) *MockIPrivacyRepository {
+	mock := &MockIPrivacyRepository{ctrl: ctrl}
+	mock.recorder = &MockIPrivacyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPrivacyRepository) EXPECT() *MockIPrivacyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AnonymizeAdmin mocks base method.
+func (m *MockIPrivacyRepository) AnonymizeAdmin(adminID uint, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeAdmin", adminID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnonymizeAdmin indicates an expected call of AnonymizeAdmin.
+func (mr *MockIPrivacyRepositoryMockRecorder) AnonymizeAdmin(adminID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeAdmin", reflect.TypeOf((*MockIPrivacyRepository)(nil).AnonymizeAdmin), adminID, at)
+}
+
+// AnonymizeUser mocks base method.
+func (m *MockIPrivacyRepository) AnonymizeUser(userID uint, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUser", userID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnonymizeUser indicates an expected call of AnonymizeUser.
+func (mr *MockIPrivacyRepositoryMockRecorder) AnonymizeUser(userID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUser", reflect.TypeOf((*MockIPrivacyRepository)(nil).AnonymizeUser), userID, at)
+}
@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./refresh_token_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIRefreshTokenRepository is a mock of IRefreshTokenRepository interface.
+type MockIRefreshTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRefreshTokenRepositoryMockRecorder
+}
+
+// MockIRefreshTokenRepositoryMockRecorder is the mock recorder for MockIRefreshTokenRepository.
+type MockIRefreshTokenRepositoryMockRecorder struct {
+	mock *MockIRefreshTokenRepository
+}
+
+// NewMockIRefreshTokenRepository creates a new mock instance.
+func NewMockIRefreshTokenRepository(ctrl *gomock.Controller) *MockIRefreshTokenRepository {
+	mock := &MockIRefreshTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockIRefreshTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRefreshTokenRepository) EXPECT() *MockIRefreshTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIRefreshTokenRepository) Create(token *domain.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIRefreshTokenRepositoryMockRecorder) Create(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIRefreshTokenRepository)(nil).Create), token)
+}
+
+// FindByHash mocks base method.
+func (m *MockIRefreshTokenRepository) FindByHash(tokenHash string) (*domain.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByHash", tokenHash)
+	ret0, _ := ret[0].(*domain.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByHash indicates an expected call of FindByHash.
+func (mr *MockIRefreshTokenRepositoryMockRecorder) FindByHash(tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHash", reflect.TypeOf((*MockIRefreshTokenRepository)(nil).FindByHash), tokenHash)
+}
+
+// HasActiveInFamily mocks base method.
+func (m *MockIRefreshTokenRepository) HasActiveInFamily(familyID string, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasActiveInFamily", familyID, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasActiveInFamily indicates an expected call of HasActiveInFamily.
+func (mr *MockIRefreshTokenRepositoryMockRecorder) HasActiveInFamily(familyID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasActiveInFamily", reflect.TypeOf((*MockIRefreshTokenRepository)(nil).HasActiveInFamily), familyID, at)
+}
+
+// Revoke mocks base method.
+func (m *MockIRefreshTokenRepository) Revoke(id uint, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", id, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockIRefreshTokenRepositoryMockRecorder) Revoke(id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockIRefreshTokenRepository)(nil).Revoke), id, at)
+}
+
+// RevokeAllForUser mocks base method.
+func (m *MockIRefreshTokenRepository) RevokeAllForUser(userID uint, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllForUser", userID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAllForUser indicates an expected call of RevokeAllForUser.
+func (mr *MockIRefreshTokenRepositoryMockRecorder) RevokeAllForUser(userID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllForUser", reflect.TypeOf((*MockIRefreshTokenRepository)(nil).RevokeAllForUser), userID, at)
+}
+
+// RevokeFamily mocks base method.
+func (m *MockIRefreshTokenRepository) RevokeFamily(familyID string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFamily", familyID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeFamily indicates an expected call of RevokeFamily.
+func (mr *MockIRefreshTokenRepositoryMockRecorder) RevokeFamily(familyID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFamily", reflect.TypeOf((*MockIRefreshTokenRepository)(nil).RevokeFamily), familyID, at)
+}
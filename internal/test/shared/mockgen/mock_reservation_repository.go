@@ -0,0 +1,169 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./reservation_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIReservationRepository is a mock of IReservationRepository interface.
+type MockIReservationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIReservationRepositoryMockRecorder
+}
+
+// MockIReservationRepositoryMockRecorder is the mock recorder for MockIReservationRepository.
+type MockIReservationRepositoryMockRecorder struct {
+	mock *MockIReservationRepository
+}
+
+// NewMockIReservationRepository creates a new mock instance.
+func NewMockIReservationRepository(ctrl *gomock.Controller) *MockIReservationRepository {
+	mock := &MockIReservationRepository{ctrl: ctrl}
+	mock.recorder = &MockIReservationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIReservationRepository) EXPECT() *MockIReservationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountActiveGroupedByParkingLot mocks base method.
+func (m *MockIReservationRepository) CountActiveGroupedByParkingLot(now time.Time) (map[uint]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActiveGroupedByParkingLot", now)
+	ret0, _ := ret[0].(map[uint]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActiveGroupedByParkingLot indicates an expected call of CountActiveGroupedByParkingLot.
+func (mr *MockIReservationRepositoryMockRecorder) CountActiveGroupedByParkingLot(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActiveGroupedByParkingLot", reflect.TypeOf((*MockIReservationRepository)(nil).CountActiveGroupedByParkingLot), now)
+}
+
+// CreateHold mocks base method.
+func (m *MockIReservationRepository) CreateHold(reservation *domain.Reservation, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", reservation, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockIReservationRepositoryMockRecorder) CreateHold(reservation, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockIReservationRepository)(nil).CreateHold), reservation, now)
+}
+
+// ExpireHolds mocks base method.
+func (m *MockIReservationRepository) ExpireHolds(now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireHolds", now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireHolds indicates an expected call of ExpireHolds.
+func (mr *MockIReservationRepositoryMockRecorder) ExpireHolds(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireHolds", reflect.TypeOf((*MockIReservationRepository)(nil).ExpireHolds), now)
+}
+
+// FindActiveBySensor mocks base method.
+func (m *MockIReservationRepository) FindActiveBySensor(sensorID uint, now time.Time) (*domain.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveBySensor", sensorID, now)
+	ret0, _ := ret[0].(*domain.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveBySensor indicates an expected call of FindActiveBySensor.
+func (mr *MockIReservationRepositoryMockRecorder) FindActiveBySensor(sensorID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveBySensor", reflect.TypeOf((*MockIReservationRepository)(nil).FindActiveBySensor), sensorID, now)
+}
+
+// GetByID mocks base method.
+func (m *MockIReservationRepository) GetByID(id uint) (*domain.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*domain.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockIReservationRepositoryMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIReservationRepository)(nil).GetByID), id)
+}
+
+// ListActiveByParkingLot mocks base method.
+func (m *MockIReservationRepository) ListActiveByParkingLot(parkingLotID uint, now time.Time) ([]domain.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveByParkingLot", parkingLotID, now)
+	ret0, _ := ret[0].([]domain.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveByParkingLot indicates an expected call of ListActiveByParkingLot.
+func (mr *MockIReservationRepositoryMockRecorder) ListActiveByParkingLot(parkingLotID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveByParkingLot", reflect.TypeOf((*MockIReservationRepository)(nil).ListActiveByParkingLot), parkingLotID, now)
+}
+
+// ListByParkingLot mocks base method.
+func (m *MockIReservationRepository) ListByParkingLot(parkingLotID uint) ([]domain.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByParkingLot", parkingLotID)
+	ret0, _ := ret[0].([]domain.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByParkingLot indicates an expected call of ListByParkingLot.
+func (mr *MockIReservationRepositoryMockRecorder) ListByParkingLot(parkingLotID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByParkingLot", reflect.TypeOf((*MockIReservationRepository)(nil).ListByParkingLot), parkingLotID)
+}
+
+// ListByUser mocks base method.
+func (m *MockIReservationRepository) ListByUser(userID uint) ([]domain.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", userID)
+	ret0, _ := ret[0].([]domain.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockIReservationRepositoryMockRecorder) ListByUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockIReservationRepository)(nil).ListByUser), userID)
+}
+
+// Update mocks base method.
+func (m *MockIReservationRepository) Update(reservation *domain.Reservation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", reservation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIReservationRepositoryMockRecorder) Update(reservation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIReservationRepository)(nil).Update), reservation)
+}
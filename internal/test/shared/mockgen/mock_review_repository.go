@@ -0,0 +1,257 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./review_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIReviewRepository is a mock of IReviewRepository interface.
+type MockIReviewRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIReviewRepositoryMockRecorder
+}
+
+// MockIReviewRepositoryMockRecorder is the mock recorder for MockIReviewRepository.
+type MockIReviewRepositoryMockRecorder struct {
+	mock *MockIReviewRepository
+}
+
+// NewMockIReviewRepository creates a new mock instance.
+func NewMockIReviewRepository(ctrl *gomock.Controller) *MockIReviewRepository {
+	mock := &MockIReviewRepository{ctrl: ctrl}
+	mock.recorder = &MockIReviewRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIReviewRepository) EXPECT() *MockIReviewRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByParkingLotSince mocks base method.
+func (m *MockIReviewRepository) CountByParkingLotSince(parkingLotID uint, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByParkingLotSince", parkingLotID, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByParkingLotSince indicates an expected call of CountByParkingLotSince.
+func (mr *MockIReviewRepositoryMockRecorder) CountByParkingLotSince(parkingLotID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByParkingLotSince", reflect.TypeOf((*MockIReviewRepository)(nil).CountByParkingLotSince), parkingLotID, since)
+}
+
+// CountByUserSince mocks base method.
+func (m *MockIReviewRepository) CountByUserSince(userID uint, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByUserSince", userID, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByUserSince indicates an expected call of CountByUserSince.
+func (mr *MockIReviewRepositoryMockRecorder) CountByUserSince(userID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByUserSince", reflect.TypeOf((*MockIReviewRepository)(nil).CountByUserSince), userID, since)
+}
+
+// CountReportsByUserSince mocks base method.
+func (m *MockIReviewRepository) CountReportsByUserSince(userID uint, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountReportsByUserSince", userID, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountReportsByUserSince indicates an expected call of CountReportsByUserSince.
+func (mr *MockIReviewRepositoryMockRecorder) CountReportsByUserSince(userID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountReportsByUserSince", reflect.TypeOf((*MockIReviewRepository)(nil).CountReportsByUserSince), userID, since)
+}
+
+// Create mocks base method.
+func (m *MockIReviewRepository) Create(review *domain.Review) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", review)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIReviewRepositoryMockRecorder) Create(review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIReviewRepository)(nil).Create), review)
+}
+
+// CreateReport mocks base method.
+func (m *MockIReviewRepository) CreateReport(report *domain.ReviewReport) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReport", report)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReport indicates an expected call of CreateReport.
+func (mr *MockIReviewRepositoryMockRecorder) CreateReport(report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReport", reflect.TypeOf((*MockIReviewRepository)(nil).CreateReport), report)
+}
+
+// Delete mocks base method.
+func (m *MockIReviewRepository) Delete(userID, reviewID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userID, reviewID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIReviewRepositoryMockRecorder) Delete(userID, reviewID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIReviewRepository)(nil).Delete), userID, reviewID)
+}
+
+// FindByUserAndParkingLot mocks base method.
+func (m *MockIReviewRepository) FindByUserAndParkingLot(userID, parkingLotID uint) (*domain.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserAndParkingLot", userID, parkingLotID)
+	ret0, _ := ret[0].(*domain.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserAndParkingLot indicates an expected call of FindByUserAndParkingLot.
+func (mr *MockIReviewRepositoryMockRecorder) FindByUserAndParkingLot(userID, parkingLotID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserAndParkingLot", reflect.TypeOf((*MockIReviewRepository)(nil).FindByUserAndParkingLot), userID, parkingLotID)
+}
+
+// GetByID mocks base method.
+func (m *MockIReviewRepository) GetByID(id uint) (*domain.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*domain.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockIReviewRepositoryMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIReviewRepository)(nil).GetByID), id)
+}
+
+// GetRatingSummary mocks base method.
+func (m *MockIReviewRepository) GetRatingSummary(parkingLotID uint) (domain.RatingSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatingSummary", parkingLotID)
+	ret0, _ := ret[0].(domain.RatingSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRatingSummary indicates an expected call of GetRatingSummary.
+func (mr *MockIReviewRepositoryMockRecorder) GetRatingSummary(parkingLotID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatingSummary", reflect.TypeOf((*MockIReviewRepository)(nil).GetRatingSummary), parkingLotID)
+}
+
+// ListByUser mocks base method.
+func (m *MockIReviewRepository) ListByUser(userID uint) ([]domain.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", userID)
+	ret0, _ := ret[0].([]domain.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockIReviewRepositoryMockRecorder) ListByUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockIReviewRepository)(nil).ListByUser), userID)
+}
+
+// ListForModeration mocks base method.
+func (m *MockIReviewRepository) ListForModeration() ([]domain.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListForModeration")
+	ret0, _ := ret[0].([]domain.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListForModeration indicates an expected call of ListForModeration.
+func (mr *MockIReviewRepositoryMockRecorder) ListForModeration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForModeration", reflect.TypeOf((*MockIReviewRepository)(nil).ListForModeration))
+}
+
+// ListPublishedByParkingLot mocks base method.
+func (m *MockIReviewRepository) ListPublishedByParkingLot(parkingLotID uint) ([]domain.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPublishedByParkingLot", parkingLotID)
+	ret0, _ := ret[0].([]domain.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPublishedByParkingLot indicates an expected call of ListPublishedByParkingLot.
+func (mr *MockIReviewRepositoryMockRecorder) ListPublishedByParkingLot(parkingLotID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPublishedByParkingLot", reflect.TypeOf((*MockIReviewRepository)(nil).ListPublishedByParkingLot), parkingLotID)
+}
+
+// ListRatingSummaries mocks base method.
+func (m *MockIReviewRepository) ListRatingSummaries() (map[uint]domain.RatingSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRatingSummaries")
+	ret0, _ := ret[0].(map[uint]domain.RatingSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRatingSummaries indicates an expected call of ListRatingSummaries.
+func (mr *MockIReviewRepositoryMockRecorder) ListRatingSummaries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRatingSummaries", reflect.TypeOf((*MockIReviewRepository)(nil).ListRatingSummaries))
+}
+
+// Restore mocks base method.
+func (m *MockIReviewRepository) Restore(review *domain.Review) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", review)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockIReviewRepositoryMockRecorder) Restore(review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockIReviewRepository)(nil).Restore), review)
+}
+
+// Update mocks base method.
+func (m *MockIReviewRepository) Update(review *domain.Review) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", review)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIReviewRepositoryMockRecorder) Update(review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIReviewRepository)(nil).Update), review)
+}
@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./role_permission_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+
+	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIRolePermissionRepository is a mock of IRolePermissionRepository interface.
+type MockIRolePermissionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRolePermissionRepositoryMockRecorder
+}
+
+// MockIRolePermissionRepositoryMockRecorder is the mock recorder for MockIRolePermissionRepository.
+type MockIRolePermissionRepositoryMockRecorder struct {
+	mock *MockIRolePermissionRepository
+}
+
+// NewMockIRolePermissionRepository creates a new mock instance.
+func NewMockIRolePermissionRepository(ctrl *gomock.Controller) *MockIRolePermissionRepository {
+	mock := &MockIRolePermissionRepository{ctrl: ctrl}
+	mock.recorder = &MockIRolePermissionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRolePermissionRepository) EXPECT() *MockIRolePermissionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListAll mocks base method.
+func (m *MockIRolePermissionRepository) ListAll() ([]domain.RolePermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll")
+	ret0, _ := ret[0].([]domain.RolePermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockIRolePermissionRepositoryMockRecorder) ListAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockIRolePermissionRepository)(nil).ListAll))
+}
+
+// ReplaceRole mocks base method.
+func (m *MockIRolePermissionRepository) ReplaceRole(role string, permissions []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRole", role, permissions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRole indicates an expected call of ReplaceRole.
+func (mr *MockIRolePermissionRepositoryMockRecorder) ReplaceRole(role, permissions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRole", reflect.TypeOf((*MockIRolePermissionRepository)(nil).ReplaceRole), role, permissions)
+}
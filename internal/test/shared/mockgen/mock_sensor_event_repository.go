@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./sensor_event_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockISensorEventRepository is a mock of ISensorEventRepository interface.
+type MockISensorEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockISensorEventRepositoryMockRecorder
+}
+
+// MockISensorEventRepositoryMockRecorder is the mock recorder for MockISensorEventRepository.
+type MockISensorEventRepositoryMockRecorder struct {
+	mock *MockISensorEventRepository
+}
+
+// NewMockISensorEventRepository creates a new mock instance.
+func NewMockISensorEventRepository(ctrl *gomock.Controller) *MockISensorEventRepository {
+	mock := &MockISensorEventRepository{ctrl: ctrl}
+	mock.recorder = &MockISensorEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISensorEventRepository) EXPECT() *MockISensorEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockISensorEventRepository) Create(event *domain.SensorEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockISensorEventRepositoryMockRecorder) Create(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockISensorEventRepository)(nil).Create), event)
+}
+
+// StreamByParkingLotBetween mocks base method.
+func (m *MockISensorEventRepository) StreamByParkingLotBetween(parkingLotID uint, from, to time.Time, fn func(domain.SensorEvent) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamByParkingLotBetween", parkingLotID, from, to, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamByParkingLotBetween indicates an expected call of StreamByParkingLotBetween.
+func (mr *MockISensorEventRepositoryMockRecorder) StreamByParkingLotBetween(parkingLotID, from, to, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamByParkingLotBetween", reflect.TypeOf((*MockISensorEventRepository)(nil).StreamByParkingLotBetween), parkingLotID, from, to, fn)
+}
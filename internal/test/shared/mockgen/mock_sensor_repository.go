@@ -6,6 +6,7 @@ package mockgen
 
 import (
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
 	gomock "github.com/golang/mock/gomock"
@@ -34,6 +35,21 @@ func (m *MockISensorRepository) EXPECT() *MockISensorRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountGroupedByParkingLot mocks base method.
+func (m *MockISensorRepository) CountGroupedByParkingLot() (map[uint]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountGroupedByParkingLot")
+	ret0, _ := ret[0].(map[uint]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountGroupedByParkingLot indicates an expected call of CountGroupedByParkingLot.
+func (mr *MockISensorRepositoryMockRecorder) CountGroupedByParkingLot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountGroupedByParkingLot", reflect.TypeOf((*MockISensorRepository)(nil).CountGroupedByParkingLot))
+}
+
 // Create mocks base method.
 func (m *MockISensorRepository) Create(sensor *domain.Sensor) error {
 	m.ctrl.T.Helper()
@@ -122,6 +138,51 @@ func (mr *MockISensorRepositoryMockRecorder) ListByParkingLot(parkingLotID inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByParkingLot", reflect.TypeOf((*MockISensorRepository)(nil).ListByParkingLot), parkingLotID)
 }
 
+// ListByParkingLotBetween mocks base method.
+func (m *MockISensorRepository) ListByParkingLotBetween(parkingLotID uint, from, to time.Time) ([]domain.Sensor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByParkingLotBetween", parkingLotID, from, to)
+	ret0, _ := ret[0].([]domain.Sensor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByParkingLotBetween indicates an expected call of ListByParkingLotBetween.
+func (mr *MockISensorRepositoryMockRecorder) ListByParkingLotBetween(parkingLotID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByParkingLotBetween", reflect.TypeOf((*MockISensorRepository)(nil).ListByParkingLotBetween), parkingLotID, from, to)
+}
+
+// ListByParkingLotForMember mocks base method.
+func (m *MockISensorRepository) ListByParkingLotForMember(parkingLotID, adminID uint) ([]domain.Sensor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByParkingLotForMember", parkingLotID, adminID)
+	ret0, _ := ret[0].([]domain.Sensor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByParkingLotForMember indicates an expected call of ListByParkingLotForMember.
+func (mr *MockISensorRepositoryMockRecorder) ListByParkingLotForMember(parkingLotID, adminID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByParkingLotForMember", reflect.TypeOf((*MockISensorRepository)(nil).ListByParkingLotForMember), parkingLotID, adminID)
+}
+
+// ListByParkingLots mocks base method.
+func (m *MockISensorRepository) ListByParkingLots(parkingLotIDs []uint) ([]domain.Sensor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByParkingLots", parkingLotIDs)
+	ret0, _ := ret[0].([]domain.Sensor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByParkingLots indicates an expected call of ListByParkingLots.
+func (mr *MockISensorRepositoryMockRecorder) ListByParkingLots(parkingLotIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByParkingLots", reflect.TypeOf((*MockISensorRepository)(nil).ListByParkingLots), parkingLotIDs)
+}
+
 // ListGroupedByParkingLot mocks base method.
 func (m *MockISensorRepository) ListGroupedByParkingLot() (map[uint]uint, error) {
 	m.ctrl.T.Helper()
@@ -137,6 +198,21 @@ func (mr *MockISensorRepositoryMockRecorder) ListGroupedByParkingLot() *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupedByParkingLot", reflect.TypeOf((*MockISensorRepository)(nil).ListGroupedByParkingLot))
 }
 
+// ListGroupedByParkingLotForSpots mocks base method.
+func (m *MockISensorRepository) ListGroupedByParkingLotForSpots(filter domain.SpotFilter) (map[uint]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroupedByParkingLotForSpots", filter)
+	ret0, _ := ret[0].(map[uint]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroupedByParkingLotForSpots indicates an expected call of ListGroupedByParkingLotForSpots.
+func (mr *MockISensorRepositoryMockRecorder) ListGroupedByParkingLotForSpots(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupedByParkingLotForSpots", reflect.TypeOf((*MockISensorRepository)(nil).ListGroupedByParkingLotForSpots), filter)
+}
+
 // Update mocks base method.
 func (m *MockISensorRepository) Update(sensor *domain.Sensor) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./user_token_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIUserTokenRepository is a mock of IUserTokenRepository interface.
+type MockIUserTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserTokenRepositoryMockRecorder
+}
+
+// MockIUserTokenRepositoryMockRecorder is the mock recorder for MockIUserTokenRepository.
+type MockIUserTokenRepositoryMockRecorder struct {
+	mock *MockIUserTokenRepository
+}
+
+// NewMockIUserTokenRepository creates a new mock instance.
+func NewMockIUserTokenRepository(ctrl *gomock.Controller) *MockIUserTokenRepository {
+	mock := &MockIUserTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockIUserTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserTokenRepository) EXPECT() *MockIUserTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountCreatedSince mocks base method.
+func (m *MockIUserTokenRepository) CountCreatedSince(userID uint, purpose string, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCreatedSince", userID, purpose, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCreatedSince indicates an expected call of CountCreatedSince.
+func (mr *MockIUserTokenRepositoryMockRecorder) CountCreatedSince(userID, purpose, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCreatedSince", reflect.TypeOf((*MockIUserTokenRepository)(nil).CountCreatedSince), userID, purpose, since)
+}
+
+// Create mocks base method.
+func (m *MockIUserTokenRepository) Create(token *domain.UserToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIUserTokenRepositoryMockRecorder) Create(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIUserTokenRepository)(nil).Create), token)
+}
+
+// FindByHash mocks base method.
+func (m *MockIUserTokenRepository) FindByHash(tokenHash string) (*domain.UserToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByHash", tokenHash)
+	ret0, _ := ret[0].(*domain.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByHash indicates an expected call of FindByHash.
+func (mr *MockIUserTokenRepositoryMockRecorder) FindByHash(tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHash", reflect.TypeOf((*MockIUserTokenRepository)(nil).FindByHash), tokenHash)
+}
+
+// InvalidateForUser mocks base method.
+func (m *MockIUserTokenRepository) InvalidateForUser(userID uint, purpose string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateForUser", userID, purpose, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateForUser indicates an expected call of InvalidateForUser.
+func (mr *MockIUserTokenRepositoryMockRecorder) InvalidateForUser(userID, purpose, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateForUser", reflect.TypeOf((*MockIUserTokenRepository)(nil).InvalidateForUser), userID, purpose, at)
+}
+
+// MarkUsed mocks base method.
+func (m *MockIUserTokenRepository) MarkUsed(id uint, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", id, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockIUserTokenRepositoryMockRecorder) MarkUsed(id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockIUserTokenRepository)(nil).MarkUsed), id, at)
+}
@@ -0,0 +1,166 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./vehicle_repository.go
+
+// Package mockgen is a generated GoMock package.
+package mockgen
+
+import (
+	reflect "reflect"
+
+	domain "github.com/CamiloLeonP/parking-radar/internal/app/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIVehicleRepository is a mock of IVehicleRepository interface.
+type MockIVehicleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIVehicleRepositoryMockRecorder
+}
+
+// MockIVehicleRepositoryMockRecorder is the mock recorder for MockIVehicleRepository.
+type MockIVehicleRepositoryMockRecorder struct {
+	mock *MockIVehicleRepository
+}
+
+// NewMockIVehicleRepository creates a new mock instance.
+func NewMockIVehicleRepository(ctrl *gomock.Controller) *MockIVehicleRepository {
+	mock := &MockIVehicleRepository{ctrl: ctrl}
+	mock.recorder = &MockIVehicleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIVehicleRepository) EXPECT() *MockIVehicleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIVehicleRepository) Create(vehicle *domain.Vehicle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", vehicle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIVehicleRepositoryMockRecorder) Create(vehicle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIVehicleRepository)(nil).Create), vehicle)
+}
+
+// Delete mocks base method.
+func (m *MockIVehicleRepository) Delete(userID, vehicleID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userID, vehicleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIVehicleRepositoryMockRecorder) Delete(userID, vehicleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIVehicleRepository)(nil).Delete), userID, vehicleID)
+}
+
+// FindByUserAndPlate mocks base method.
+func (m *MockIVehicleRepository) FindByUserAndPlate(userID uint, plate string) (*domain.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserAndPlate", userID, plate)
+	ret0, _ := ret[0].(*domain.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserAndPlate indicates an expected call of FindByUserAndPlate.
+func (mr *MockIVehicleRepositoryMockRecorder) FindByUserAndPlate(userID, plate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserAndPlate", reflect.TypeOf((*MockIVehicleRepository)(nil).FindByUserAndPlate), userID, plate)
+}
+
+// FindDefault mocks base method.
+func (m *MockIVehicleRepository) FindDefault(userID uint) (*domain.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDefault", userID)
+	ret0, _ := ret[0].(*domain.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDefault indicates an expected call of FindDefault.
+func (mr *MockIVehicleRepositoryMockRecorder) FindDefault(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDefault", reflect.TypeOf((*MockIVehicleRepository)(nil).FindDefault), userID)
+}
+
+// GetByID mocks base method.
+func (m *MockIVehicleRepository) GetByID(userID, vehicleID uint) (*domain.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", userID, vehicleID)
+	ret0, _ := ret[0].(*domain.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockIVehicleRepositoryMockRecorder) GetByID(userID, vehicleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIVehicleRepository)(nil).GetByID), userID, vehicleID)
+}
+
+// ListByPlate mocks base method.
+func (m *MockIVehicleRepository) ListByPlate(plate string) ([]domain.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByPlate", plate)
+	ret0, _ := ret[0].([]domain.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByPlate indicates an expected call of ListByPlate.
+func (mr *MockIVehicleRepositoryMockRecorder) ListByPlate(plate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByPlate", reflect.TypeOf((*MockIVehicleRepository)(nil).ListByPlate), plate)
+}
+
+// ListByUser mocks base method.
+func (m *MockIVehicleRepository) ListByUser(userID uint) ([]domain.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", userID)
+	ret0, _ := ret[0].([]domain.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockIVehicleRepositoryMockRecorder) ListByUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockIVehicleRepository)(nil).ListByUser), userID)
+}
+
+// SetDefault mocks base method.
+func (m *MockIVehicleRepository) SetDefault(userID, vehicleID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDefault", userID, vehicleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDefault indicates an expected call of SetDefault.
+func (mr *MockIVehicleRepositoryMockRecorder) SetDefault(userID, vehicleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDefault", reflect.TypeOf((*MockIVehicleRepository)(nil).SetDefault), userID, vehicleID)
+}
+
+// Update mocks base method.
+func (m *MockIVehicleRepository) Update(vehicle *domain.Vehicle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", vehicle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIVehicleRepositoryMockRecorder) Update(vehicle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIVehicleRepository)(nil).Update), vehicle)
+}